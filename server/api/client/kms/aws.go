@@ -0,0 +1,73 @@
+package kms
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// AWSConfig はAWS KMSの接続設定
+type AWSConfig struct {
+	Endpoint string // 例: https://kms.ap-northeast-1.amazonaws.com
+	Region   string
+	// KeyID は暗号化に使うKMSキーのID・ARN・エイリアス (例: alias/pii)
+	KeyID     string
+	AccessKey string
+	SecretKey string
+}
+
+// AWS はAWS KMSのEncrypt・Decrypt APIでデータ鍵を暗号化するプロバイダ
+// KMSキーの自動ローテーションを有効にしても、過去の鍵で暗号化したものはKMSが復号する
+type AWS struct {
+	keyID  string
+	client *kms.Client
+}
+
+func NewAWS(cfg AWSConfig) *AWS {
+	opts := kms.Options{
+		Region: cfg.Region,
+		Credentials: aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: cfg.AccessKey, SecretAccessKey: cfg.SecretKey, Source: "AWSConfig"}, nil
+		}),
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	if cfg.Endpoint != "" {
+		opts.BaseEndpoint = aws.String(cfg.Endpoint)
+	}
+
+	return &AWS{
+		keyID:  cfg.KeyID,
+		client: kms.New(opts),
+	}
+}
+
+func (k *AWS) KeyID() string {
+	return k.keyID
+}
+
+func (k *AWS) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	out, err := k.client.Encrypt(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(k.keyID),
+		Plaintext: plaintext,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.CiphertextBlob, nil
+}
+
+// Decrypt は暗号文に含まれるKMSキーで復号する。KeyIDを変更した後も以前のキーで暗号化したものを復号できる
+func (k *AWS) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	out, err := k.client.Decrypt(ctx, &kms.DecryptInput{
+		CiphertextBlob: ciphertext,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Plaintext, nil
+}
@@ -0,0 +1,98 @@
+package kms_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/kms"
+)
+
+// kmsServer はキーIDを前置するだけのKMS互換サーバー
+type kmsServer struct {
+	t *testing.T
+}
+
+func (s *kmsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/ap-northeast-1/kms/aws4_request") {
+		s.t.Errorf("Authorization = %q", auth)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var in struct {
+		KeyId          string
+		Plaintext      []byte
+		CiphertextBlob []byte
+	}
+	body, _ := io.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &in); err != nil {
+		s.t.Errorf("request body %q: %v", body, err)
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	switch r.Header.Get("X-Amz-Target") {
+	case "TrentService.Encrypt":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"KeyId":          in.KeyId,
+			"CiphertextBlob": append([]byte(in.KeyId+":"), in.Plaintext...),
+		})
+	case "TrentService.Decrypt":
+		keyID, plaintext, ok := bytes.Cut(in.CiphertextBlob, []byte(":"))
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"__type":"InvalidCiphertextException","message":"invalid ciphertext"}`)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"KeyId":     string(keyID),
+			"Plaintext": plaintext,
+		})
+	default:
+		s.t.Errorf("X-Amz-Target = %q", r.Header.Get("X-Amz-Target"))
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestAWSEncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	ts := httptest.NewServer(&kmsServer{t: t})
+	t.Cleanup(ts.Close)
+
+	cfg := kms.AWSConfig{
+		Endpoint:  ts.URL,
+		Region:    "ap-northeast-1",
+		KeyID:     "alias/pii",
+		AccessKey: "AKID",
+		SecretKey: "SECRET",
+	}
+	k := kms.NewAWS(cfg)
+
+	ciphertext, err := k.Encrypt(ctx, []byte("data key"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if want := "alias/pii:data key"; string(ciphertext) != want {
+		t.Fatalf("Encrypt() = %q, want %q", ciphertext, want)
+	}
+
+	// キーIDを変えても、以前のキーで暗号化したものは復号できる
+	cfg.KeyID = "alias/pii-next"
+	plaintext, err := kms.NewAWS(cfg).Decrypt(ctx, ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if string(plaintext) != "data key" {
+		t.Errorf("Decrypt() = %q, want %q", plaintext, "data key")
+	}
+
+	if _, err := k.Decrypt(ctx, []byte("broken")); err == nil || !strings.Contains(err.Error(), "InvalidCiphertextException") {
+		t.Errorf("Decrypt() of an invalid ciphertext error = %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package kms
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const keySize = 32
+
+// Keyfile はローカルのファイルに置いたマスター鍵を使う開発用のプロバイダ
+// ファイルには1行に1つ「鍵ID:Base64で符号化した32バイトの鍵」を書き、最後の行の鍵で暗号化する
+// 古い鍵の行を残しておけば、その鍵で暗号化したデータ鍵も復号できる
+type Keyfile struct {
+	keys    map[string][]byte
+	current string
+}
+
+// NewKeyfile はpathの鍵ファイルを読み込む。createがtrueでファイルがない場合は鍵を生成して作成する
+func NewKeyfile(path string, create bool) (*Keyfile, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) && create {
+		if err := generateKeyfile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	k := &Keyfile{keys: map[string][]byte{}}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		id, encoded, ok := strings.Cut(text, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("kms: %s:%d: expected <id>:<base64 key>", path, line)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != keySize {
+			return nil, fmt.Errorf("kms: %s:%d: key must be %d bytes encoded in base64", path, line, keySize)
+		}
+		k.keys[id] = key
+		k.current = id
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if k.current == "" {
+		return nil, fmt.Errorf("kms: %s: no keys", path)
+	}
+
+	return k, nil
+}
+
+func generateKeyfile(path string) error {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	line := time.Now().Format("20060102150405") + ":" + base64.StdEncoding.EncodeToString(key) + "\n"
+
+	return os.WriteFile(path, []byte(line), 0o600)
+}
+
+func (k *Keyfile) KeyID() string {
+	return k.current
+}
+
+// Encrypt は「鍵ID:」に続けてnonceとAES-GCMの暗号文を返す
+func (k *Keyfile) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	aead, err := newGCM(k.keys[k.current])
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	out := append([]byte(k.current+":"), nonce...)
+
+	return aead.Seal(out, nonce, plaintext, []byte(k.current)), nil
+}
+
+func (k *Keyfile) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	i := bytes.IndexByte(ciphertext, ':')
+	if i < 0 {
+		return nil, ErrUnknownKey
+	}
+	id := string(ciphertext[:i])
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	body := ciphertext[i+1:]
+	if len(body) < aead.NonceSize() {
+		return nil, errors.New("kms: ciphertext too short")
+	}
+
+	return aead.Open(nil, body[:aead.NonceSize()], body[aead.NonceSize():], []byte(id))
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
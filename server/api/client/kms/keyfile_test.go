@@ -0,0 +1,83 @@
+package kms_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/kms"
+)
+
+func keyLine(t *testing.T, id string) string {
+	t.Helper()
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+
+	return id + ":" + base64.StdEncoding.EncodeToString(key) + "\n"
+}
+
+func TestKeyfileRotation(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "master.key")
+
+	k, err := kms.NewKeyfile(path, true)
+	if err != nil {
+		t.Fatalf("NewKeyfile() error = %v", err)
+	}
+	old := k.KeyID()
+	ciphertext, err := k.Encrypt(ctx, []byte("data key"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	// 新しい鍵の行を追加すると、以降はその鍵で暗号化し、古い鍵の暗号文も復号できる
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(keyLine(t, "next")); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	rotated, err := kms.NewKeyfile(path, false)
+	if err != nil {
+		t.Fatalf("NewKeyfile() error = %v", err)
+	}
+	if rotated.KeyID() != "next" {
+		t.Errorf("KeyID() = %q, want %q", rotated.KeyID(), "next")
+	}
+	plaintext, err := rotated.Decrypt(ctx, ciphertext)
+	if err != nil || string(plaintext) != "data key" {
+		t.Errorf("Decrypt() with key %q = %q, %v", old, plaintext, err)
+	}
+
+	tests := []struct {
+		name       string
+		ciphertext []byte
+		wantErr    error
+	}{
+		{name: "unknown key", ciphertext: []byte("missing:0123456789abcdef"), wantErr: kms.ErrUnknownKey},
+		{name: "no key id", ciphertext: []byte("0123456789abcdef"), wantErr: kms.ErrUnknownKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := rotated.Decrypt(ctx, tt.ciphertext); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Decrypt() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	tampered := append([]byte{}, ciphertext...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := rotated.Decrypt(ctx, tampered); err == nil {
+		t.Error("Decrypt() of a tampered ciphertext succeeded")
+	}
+}
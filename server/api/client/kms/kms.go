@@ -0,0 +1,20 @@
+// Package kms は個人情報の暗号化に使うデータ鍵を包むマスター鍵の抽象化
+package kms
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnknownKey は暗号文を包んだマスター鍵が見つからない場合のエラー
+var ErrUnknownKey = errors.New("kms: unknown master key")
+
+// Provider はマスター鍵でデータ鍵を暗号化・復号する。マスター鍵そのものは外に出さない
+type Provider interface {
+	// KeyID は暗号化に使う現在のマスター鍵の識別子を返す
+	KeyID() string
+	// Encrypt は現在のマスター鍵でデータ鍵を暗号化する
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	// Decrypt はEncryptで暗号化したデータ鍵を復号する。過去のマスター鍵で暗号化したものも復号できる
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
@@ -0,0 +1,23 @@
+package payment
+
+import "context"
+
+// Disabled は決済代行会社と契約していない環境で使う実装
+// クレジットカード・QRコード決済とその返金をすべて受け付けない
+type Disabled struct{}
+
+func NewDisabled() *Disabled {
+	return &Disabled{}
+}
+
+func (d *Disabled) Charge(ctx context.Context, req ChargeRequest) (string, error) {
+	return "", ErrUnavailable
+}
+
+func (d *Disabled) Refund(ctx context.Context, req RefundRequest) (string, error) {
+	return "", ErrUnavailable
+}
+
+func (d *Disabled) FindCharge(ctx context.Context, idempotencyKey string) (string, error) {
+	return "", ErrUnknownCharge
+}
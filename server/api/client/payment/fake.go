@@ -0,0 +1,89 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// FakeDeclineToken はFakeで決済を承認しないトークン
+const FakeDeclineToken = "tok_declined"
+
+// Fake は決済代行会社に接続せずに決済を承認する開発・テスト用の実装
+// 取引はメモリに保持するため、再起動前の取引の返金は残額を検証せずに承認し、冪等キーでも見つからない
+type Fake struct {
+	mu      sync.Mutex
+	seq     int
+	charges map[string]*fakeCharge
+	// keys は冪等キーごとの決済・返金の参照番号
+	keys map[string]string
+}
+
+type fakeCharge struct {
+	amount   int
+	refunded int
+}
+
+func NewFake() *Fake {
+	return &Fake{charges: map[string]*fakeCharge{}, keys: map[string]string{}}
+}
+
+func (f *Fake) Charge(ctx context.Context, req ChargeRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if reference, ok := f.keys["charge:"+req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		return reference, nil
+	}
+	if req.Amount <= 0 || req.Token == "" || req.Token == FakeDeclineToken {
+		return "", ErrDeclined
+	}
+
+	f.seq++
+	reference := fmt.Sprintf("fake_ch_%06d", f.seq)
+	f.charges[reference] = &fakeCharge{amount: req.Amount}
+	if req.IdempotencyKey != "" {
+		f.keys["charge:"+req.IdempotencyKey] = reference
+	}
+
+	return reference, nil
+}
+
+func (f *Fake) Refund(ctx context.Context, req RefundRequest) (string, error) {
+	if req.Reference == "" {
+		return "", ErrUnknownCharge
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if reference, ok := f.keys["refund:"+req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		return reference, nil
+	}
+	if charge, ok := f.charges[req.Reference]; ok {
+		if req.Amount > charge.amount-charge.refunded {
+			return "", ErrRefundExceedsCharge
+		}
+		charge.refunded += req.Amount
+	}
+
+	f.seq++
+	reference := fmt.Sprintf("fake_re_%06d", f.seq)
+	if req.IdempotencyKey != "" {
+		f.keys["refund:"+req.IdempotencyKey] = reference
+	}
+
+	return reference, nil
+}
+
+func (f *Fake) FindCharge(ctx context.Context, idempotencyKey string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	reference, ok := f.keys["charge:"+idempotencyKey]
+	if !ok {
+		return "", ErrUnknownCharge
+	}
+
+	return reference, nil
+}
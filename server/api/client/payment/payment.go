@@ -0,0 +1,56 @@
+// Package payment はクレジットカード・QRコード決済を処理する決済代行会社の抽象化
+package payment
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrDeclined は決済代行会社が決済を承認しなかった場合のエラー
+	ErrDeclined = errors.New("payment: declined")
+	// ErrUnknownCharge は返金する取引が決済代行会社に存在しない場合のエラー
+	ErrUnknownCharge = errors.New("payment: unknown charge")
+	// ErrRefundExceedsCharge は返金額が取引の返金可能な残額を超える場合のエラー
+	ErrRefundExceedsCharge = errors.New("payment: refund exceeds charge")
+	// ErrUnavailable は決済代行会社による決済が使えない設定の場合のエラー
+	ErrUnavailable = errors.New("payment: gateway payments are not available")
+)
+
+type ChargeRequest struct {
+	// 決済手段 (CREDIT_CARD, QR)
+	Method string
+	Amount int
+	// カード情報を置き換えたトークン、または読み取ったQRコード
+	Token string
+	// 決済代行会社の管理画面に表示する説明
+	Description string
+	// 冪等キー。同じキーで再度依頼しても二重に決済せず、最初の決済の結果を返す
+	IdempotencyKey string
+}
+
+type RefundRequest struct {
+	// 返金する取引の参照番号
+	Reference string
+	Amount    int
+	// 冪等キー。同じキーで再度依頼しても二重に返金せず、最初の返金の結果を返す
+	IdempotencyKey string
+}
+
+type Gateway interface {
+	// Charge は決済を実行し、取引の参照番号を返す
+	Charge(ctx context.Context, req ChargeRequest) (string, error)
+	// Refund は取引の全部または一部を返金し、返金の参照番号を返す
+	Refund(ctx context.Context, req RefundRequest) (string, error)
+	// FindCharge は冪等キーを指定した決済の参照番号を返す。決済されていない場合はErrUnknownChargeを返す
+	FindCharge(ctx context.Context, idempotencyKey string) (string, error)
+}
+
+// Rejected は決済代行会社が依頼を受け付けなかったことが確かなエラーかを返す
+// タイムアウトなどそれ以外のエラーでは、決済代行会社が依頼を処理したかどうか分からない
+func Rejected(err error) bool {
+	return errors.Is(err, ErrDeclined) ||
+		errors.Is(err, ErrUnknownCharge) ||
+		errors.Is(err, ErrRefundExceedsCharge) ||
+		errors.Is(err, ErrUnavailable)
+}
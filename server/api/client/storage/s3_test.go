@@ -0,0 +1,175 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/storage"
+)
+
+// s3Server はパス形式のリクエストを受け付けるメモリ上のS3互換サーバー
+type s3Server struct {
+	t       *testing.T
+	mu      sync.Mutex
+	objects map[string]s3Object
+}
+
+type s3Object struct {
+	body        []byte
+	contentType string
+}
+
+func (s *s3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/ap-northeast-1/s3/aws4_request") {
+		s.t.Errorf("%s %s: Authorization = %q", r.Method, r.URL.Path, auth)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[r.URL.Path]
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		s.objects[r.URL.Path] = s3Object{body: body, contentType: r.Header.Get("Content-Type")}
+	case http.MethodGet:
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		_, _ = w.Write(obj.body)
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestS3(t *testing.T) (*storage.S3, *s3Server) {
+	srv := &s3Server{t: t, objects: map[string]s3Object{}}
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+
+	return storage.NewS3(storage.S3Config{
+		Endpoint:  ts.URL,
+		Region:    "ap-northeast-1",
+		Bucket:    "stock-images",
+		AccessKey: "AKID",
+		SecretKey: "SECRET",
+		PathStyle: true,
+	}), srv
+}
+
+func TestS3PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	s, srv := newTestS3(t)
+
+	tests := []struct {
+		name string
+		key  string
+		path string
+	}{
+		{name: "plain key", key: "stocks/1/a.png", path: "/stock-images/stocks/1/a.png"},
+		{name: "key with spaces and multibyte characters", key: "stocks/1/画像 1.png", path: "/stock-images/stocks/1/画像 1.png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := s.Put(ctx, tt.key, []byte("png"), "image/png"); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+			if obj, ok := srv.objects[tt.path]; !ok || string(obj.body) != "png" || obj.contentType != "image/png" {
+				t.Fatalf("stored object at %q = %+v, %v", tt.path, obj, ok)
+			}
+
+			body, err := s.Get(ctx, tt.key)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			got, _ := io.ReadAll(body)
+			body.Close()
+			if string(got) != "png" {
+				t.Errorf("Get() = %q, want %q", got, "png")
+			}
+
+			if err := s.Delete(ctx, tt.key); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err := s.Get(ctx, tt.key); !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("Get() after Delete() error = %v, want %v", err, storage.ErrNotFound)
+			}
+			if err := s.Delete(ctx, tt.key); err != nil {
+				t.Errorf("Delete() of a missing object error = %v", err)
+			}
+		})
+	}
+}
+
+func TestS3SignedURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      storage.S3Config
+		wantHost string
+		wantPath string
+	}{
+		{
+			name: "path style with public endpoint",
+			cfg: storage.S3Config{
+				Endpoint:       "http://minio:9000",
+				PublicEndpoint: "http://localhost:9000",
+				PathStyle:      true,
+			},
+			wantHost: "localhost:9000",
+			wantPath: "/stock-images/stocks/1/a.png",
+		},
+		{
+			name:     "virtual hosted style",
+			cfg:      storage.S3Config{Endpoint: "https://s3.ap-northeast-1.amazonaws.com"},
+			wantHost: "stock-images.s3.ap-northeast-1.amazonaws.com",
+			wantPath: "/stocks/1/a.png",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Region = "ap-northeast-1"
+			tt.cfg.Bucket = "stock-images"
+			tt.cfg.AccessKey = "AKID"
+			tt.cfg.SecretKey = "SECRET"
+
+			signed, err := storage.NewS3(tt.cfg).SignedURL(context.Background(), "stocks/1/a.png", 5*time.Minute)
+			if err != nil {
+				t.Fatalf("SignedURL() error = %v", err)
+			}
+			u, err := url.Parse(signed)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if u.Host != tt.wantHost || u.Path != tt.wantPath {
+				t.Errorf("SignedURL() = %s, want host %q and path %q", signed, tt.wantHost, tt.wantPath)
+			}
+			q := u.Query()
+			if got := q.Get("X-Amz-Expires"); got != "300" {
+				t.Errorf("X-Amz-Expires = %q, want %q", got, "300")
+			}
+			if got := q.Get("X-Amz-Credential"); !strings.HasPrefix(got, "AKID/") || !strings.HasSuffix(got, "/ap-northeast-1/s3/aws4_request") {
+				t.Errorf("X-Amz-Credential = %q", got)
+			}
+			if q.Get("X-Amz-Signature") == "" {
+				t.Error("X-Amz-Signature is empty")
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Package storage は画像などのファイルを保存するストレージの抽象化
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	// ErrNotFound はオブジェクトが存在しない場合のエラー
+	ErrNotFound = errors.New("storage: object not found")
+	// ErrInvalidSignature は署名付きURLの検証に失敗した場合のエラー
+	ErrInvalidSignature = errors.New("storage: invalid or expired signature")
+)
+
+type Storage interface {
+	// Put はkeyにオブジェクトを保存する
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	// Get はkeyのオブジェクトを取得する
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete はkeyのオブジェクトを削除する。存在しない場合もエラーにしない
+	Delete(ctx context.Context, key string) error
+	// SignedURL は有効期限付きで閲覧できるURLを返す
+	SignedURL(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+// Verifier はAPI自身が署名付きURLを配信するストレージが実装する
+type Verifier interface {
+	Verify(key string, expires int64, signature string) error
+}
@@ -0,0 +1,169 @@
+// address は日本の住所の正規化と分割を行う
+package address
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Address は都道府県・市区町村・町域以降・建物名に分けた住所
+type Address struct {
+	// 郵便番号。ハイフン付きの7桁 (例: 100-0005)
+	PostalCode string `json:"postal_code"`
+	Prefecture string `json:"prefecture"`
+	// 市区町村。郡名・政令指定都市の区名を含む
+	City string `json:"city"`
+	// 町域と番地
+	Street   string `json:"street"`
+	Building string `json:"building"`
+}
+
+// Prefectures は都道府県名の一覧 (全国地方公共団体コード順)
+var Prefectures = []string{
+	"北海道", "青森県", "岩手県", "宮城県", "秋田県", "山形県", "福島県",
+	"茨城県", "栃木県", "群馬県", "埼玉県", "千葉県", "東京都", "神奈川県",
+	"新潟県", "富山県", "石川県", "福井県", "山梨県", "長野県", "岐阜県",
+	"静岡県", "愛知県", "三重県", "滋賀県", "京都府", "大阪府", "兵庫県",
+	"奈良県", "和歌山県", "鳥取県", "島根県", "岡山県", "広島県", "山口県",
+	"徳島県", "香川県", "愛媛県", "高知県", "福岡県", "佐賀県", "長崎県",
+	"熊本県", "大分県", "宮崎県", "鹿児島県", "沖縄県",
+}
+
+var (
+	postalCodePattern = regexp.MustCompile(`^〒?\s*([0-9]{3})-?([0-9]{4})(?:\s+|$)`)
+	hyphenPattern     = regexp.MustCompile(`([0-9])[‐‑–—―−ーｰ－]`)
+	spacePattern      = regexp.MustCompile(`\s+`)
+	// cityPattern は辞書に市区町村がない場合に使う。「郡＋町村」「市＋区」「市区町村」の順に試す
+	cityPattern = regexp.MustCompile(`^(.+?郡.+?[町村]|.+?市.+?区|.+?[市区町村])`)
+	// streetPattern は番地までを町域以降、残りを建物名とみなす。番地の直後が条・丁目などの場合は続きとして扱う
+	streetPattern = regexp.MustCompile(`^(.*?[0-9]+(?:(?:-|丁目|番地|番|号|の)[0-9]+)*(?:丁目|番地|番|号)?)\s*((?:[^0-9条丁番号の\-].*)?)$`)
+)
+
+// IsPrefecture は都道府県名として正しいかを返す
+func IsPrefecture(name string) bool {
+	for _, p := range Prefectures {
+		if p == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NormalizePostalCode は全角数字・ハイフンの有無・〒記号を許容して7桁の郵便番号を返す
+func NormalizePostalCode(s string) (string, bool) {
+	s = strings.TrimSpace(norm.NFKC.String(s))
+	s = strings.TrimPrefix(s, "〒")
+	s = strings.TrimSpace(hyphenPattern.ReplaceAllString(s, "$1-"))
+
+	m := postalCodePattern.FindStringSubmatch(s)
+	if m == nil || len(m[0]) != len(s) {
+		return "", false
+	}
+
+	return m[1] + m[2], true
+}
+
+// FormatPostalCode は7桁の郵便番号をハイフン付きにする
+func FormatPostalCode(code string) string {
+	if len(code) != 7 {
+		return code
+	}
+
+	return code[:3] + "-" + code[3:]
+}
+
+// Format は郵便番号を除いた1行の住所にする
+func Format(a Address) string {
+	s := a.Prefecture + a.City + a.Street
+	if a.Building != "" {
+		s += " " + a.Building
+	}
+
+	return s
+}
+
+// Parse は1行の住所を分割する。都道府県と市区町村を判別できない場合は郵便番号以外を空で返す
+//
+// postalCode は住所に郵便番号が含まれない場合に、都道府県の補完に使う
+func Parse(s, postalCode string) Address {
+	s = normalize(s)
+
+	var a Address
+	if m := postalCodePattern.FindStringSubmatch(s); m != nil {
+		a.PostalCode = FormatPostalCode(m[1] + m[2])
+		s = s[len(m[0]):]
+	} else if code, ok := NormalizePostalCode(postalCode); ok {
+		a.PostalCode = FormatPostalCode(code)
+	}
+
+	rest := s
+	for _, p := range Prefectures {
+		if strings.HasPrefix(s, p) {
+			a.Prefecture = p
+			rest = strings.TrimSpace(s[len(p):])
+			break
+		}
+	}
+
+	if a.Prefecture == "" {
+		// 都道府県が省略されている場合は郵便番号の市区町村と一致するときのみ補完する
+		for _, e := range Lookup(strings.ReplaceAll(a.PostalCode, "-", "")) {
+			if strings.HasPrefix(rest, e.City) {
+				a.Prefecture = e.Prefecture
+				break
+			}
+		}
+		if a.Prefecture == "" {
+			return Address{PostalCode: a.PostalCode}
+		}
+	}
+
+	a.City = matchCity(a.Prefecture, rest)
+	if a.City == "" {
+		return Address{PostalCode: a.PostalCode}
+	}
+	rest = strings.TrimSpace(rest[len(a.City):])
+
+	a.Street, a.Building = splitBuilding(rest)
+
+	return a
+}
+
+// normalize は全角英数字と空白を半角にし、数字に続く長音・ダッシュ類をハイフンにそろえる
+func normalize(s string) string {
+	s = norm.NFKC.String(s)
+	s = hyphenPattern.ReplaceAllString(s, "$1-")
+	s = spacePattern.ReplaceAllString(s, " ")
+
+	return strings.TrimSpace(s)
+}
+
+// matchCity は辞書の市区町村のうち最長の前方一致を返し、辞書にない場合は表記から推定する
+func matchCity(prefecture, s string) string {
+	var city string
+	for _, c := range citiesOf(prefecture) {
+		if len(c) > len(city) && strings.HasPrefix(s, c) {
+			city = c
+		}
+	}
+	if city != "" {
+		return city
+	}
+
+	return cityPattern.FindString(s)
+}
+
+// splitBuilding は番地があれば番地の直後で、なければ最初の空白で建物名を分ける
+func splitBuilding(s string) (string, string) {
+	if m := streetPattern.FindStringSubmatch(s); m != nil {
+		return strings.TrimSpace(m[1]), strings.TrimSpace(m[2])
+	}
+	if street, building, ok := strings.Cut(s, " "); ok {
+		return street, strings.TrimSpace(building)
+	}
+
+	return s, ""
+}
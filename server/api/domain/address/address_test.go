@@ -0,0 +1,184 @@
+package address
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizePostalCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		want   string
+		wantOK bool
+	}{
+		{name: "with hyphen", s: "100-0005", want: "1000005", wantOK: true},
+		{name: "without hyphen", s: "1000005", want: "1000005", wantOK: true},
+		{name: "full width with postal mark", s: "〒１００－０００５", want: "1000005", wantOK: true},
+		{name: "long vowel mark as hyphen", s: "100ー0005", want: "1000005", wantOK: true},
+		{name: "six digits", s: "100-000"},
+		{name: "space as separator", s: "100 0005"},
+		{name: "trailing text", s: "100-0005 東京都"},
+		{name: "letters", s: "abc-defg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NormalizePostalCode(tt.s)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizePostalCode(%q) = (%q, %v), want (%q, %v)", tt.s, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          string
+		postalCode string
+		want       Address
+	}{
+		{
+			name: "postal code, street and building",
+			s:    "〒100-0005 東京都千代田区丸の内1-1-1 丸の内ビル5F",
+			want: Address{PostalCode: "100-0005", Prefecture: "東京都", City: "千代田区", Street: "丸の内1-1-1", Building: "丸の内ビル5F"},
+		},
+		{
+			name: "full width with chome and ideographic space",
+			s:    "東京都千代田区丸の内１丁目１番１号　パレスビル",
+			want: Address{Prefecture: "東京都", City: "千代田区", Street: "丸の内1丁目1番1号", Building: "パレスビル"},
+		},
+		{
+			name: "long vowel marks as hyphens",
+			s:    "東京都新宿区新宿3ー1ー1",
+			want: Address{Prefecture: "東京都", City: "新宿区", Street: "新宿3-1-1"},
+		},
+		{
+			name: "postal code without hyphen and no street number",
+			s:    "1000005 東京都千代田区丸の内",
+			want: Address{PostalCode: "100-0005", Prefecture: "東京都", City: "千代田区", Street: "丸の内"},
+		},
+		{
+			name: "designated city ward from dictionary",
+			s:    "大阪府大阪市北区梅田1-1-3 大阪駅前第3ビル",
+			want: Address{Prefecture: "大阪府", City: "大阪市北区", Street: "梅田1-1-3", Building: "大阪駅前第3ビル"},
+		},
+		{
+			name: "city not in dictionary with jo",
+			s:    "北海道旭川市4条通8丁目",
+			want: Address{Prefecture: "北海道", City: "旭川市", Street: "4条通8丁目"},
+		},
+		{
+			name: "district and town not in dictionary",
+			s:    "長野県北佐久郡軽井沢町大字軽井沢1323",
+			want: Address{Prefecture: "長野県", City: "北佐久郡軽井沢町", Street: "大字軽井沢1323"},
+		},
+		{
+			name: "building without street number",
+			s:    "東京都渋谷区渋谷 ヒカリエ",
+			want: Address{Prefecture: "東京都", City: "渋谷区", Street: "渋谷", Building: "ヒカリエ"},
+		},
+		{
+			name:       "prefecture completed from postal code",
+			s:          "千代田区丸の内1-1-1",
+			postalCode: "1000005",
+			want:       Address{PostalCode: "100-0005", Prefecture: "東京都", City: "千代田区", Street: "丸の内1-1-1"},
+		},
+		{
+			name:       "prefecture not completed when city differs from postal code",
+			s:          "中央区銀座1-1",
+			postalCode: "100-0005",
+			want:       Address{PostalCode: "100-0005"},
+		},
+		{
+			name: "prefecture omitted without postal code",
+			s:    "千代田区丸の内1-1-1",
+			want: Address{},
+		},
+		{
+			name: "not a japanese address",
+			s:    "1600 Amphitheatre Parkway",
+			want: Address{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.s, tt.postalCode); got != tt.want {
+				t.Errorf("Parse(%q, %q) = %+v, want %+v", tt.s, tt.postalCode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseKenAll(t *testing.T) {
+	const data = `"13101","100  ","1000000","ﾄｳｷｮｳﾄ","ﾁﾖﾀﾞｸ","ｲｶﾆｹｲｻｲｶﾞﾅｲﾊﾞｱｲ","東京都","千代田区","以下に掲載がない場合",0,0,0,0,0,0
+"13101","100  ","1000005","ﾄｳｷｮｳﾄ","ﾁﾖﾀﾞｸ","ﾏﾙﾉｳﾁ(ﾂｷﾞﾉﾋﾞﾙｦﾉｿﾞｸ)","東京都","千代田区","丸の内（次のビルを除く）",0,0,1,0,0,0
+"13101","100  ","1000005","ﾄｳｷｮｳﾄ","ﾁﾖﾀﾞｸ","ﾏﾙﾉｳﾁ(ﾂｷﾞﾉﾋﾞﾙｦﾉｿﾞｸ)","東京都","千代田区","丸の内（次のビルを除く）",0,0,1,0,0,0
+"01101","060  ","0600042","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｵｵﾄﾞｵﾘﾆｼ","北海道","札幌市中央区","大通西（１～１９丁目、２０丁目",0,0,0,0,0,0
+"01101","060  ","0600042","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｵｵﾄﾞｵﾘﾆｼ","北海道","札幌市中央区","１番地）",0,0,0,0,0,0
+"01101","060  ","0600042","ﾎｯｶｲﾄﾞｳ","ｻｯﾎﾟﾛｼﾁｭｳｵｳｸ","ｵｵﾄﾞｵﾘﾆｼ","北海道","札幌市中央区","大通西",0,0,0,0,0,0
+"08220","30534","3050000","ｲﾊﾞﾗｷｹﾝ","ﾂｸﾊﾞｼ","ﾂｸﾊﾞｼﾉﾂｷﾞﾆﾊﾞﾝﾁｶﾞｸﾙﾊﾞｱｲ","茨城県","つくば市","つくば市の次に番地がくる場合",0,0,0,0,0,0
+"20321","38903","3890300","ﾅｶﾞﾉｹﾝ","ｷﾀｻｸｸﾞﾝﾐﾖﾀﾏﾁ","ﾐﾖﾀﾏﾁｲﾁｴﾝ","長野県","北佐久郡御代田町","北佐久郡御代田町一円",0,0,0,0,0,0
+"13101","100  ","1000001"
+`
+
+	d, err := parseKenAll(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("parseKenAll() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		code string
+		want []Entry
+	}{
+		{
+			name: "default town is empty",
+			code: "1000000",
+			want: []Entry{{PostalCode: "1000000", Prefecture: "東京都", City: "千代田区"}},
+		},
+		{
+			name: "parenthesized note is removed and duplicates are merged",
+			code: "1000005",
+			want: []Entry{{PostalCode: "1000005", Prefecture: "東京都", City: "千代田区", Town: "丸の内"}},
+		},
+		{
+			name: "continued rows are skipped",
+			code: "0600042",
+			want: []Entry{{PostalCode: "0600042", Prefecture: "北海道", City: "札幌市中央区", Town: "大通西"}},
+		},
+		{
+			name: "street number follows city",
+			code: "3050000",
+			want: []Entry{{PostalCode: "3050000", Prefecture: "茨城県", City: "つくば市"}},
+		},
+		{
+			name: "whole city",
+			code: "3890300",
+			want: []Entry{{PostalCode: "3890300", Prefecture: "長野県", City: "北佐久郡御代田町"}},
+		},
+		{
+			name: "short row is ignored",
+			code: "1000001",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.entries[tt.code]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("entries[%q] = %+v, want %+v", tt.code, got, tt.want)
+			}
+		})
+	}
+
+	wantCities := map[string][]string{
+		"東京都": {"千代田区"},
+		"北海道": {"札幌市中央区"},
+		"茨城県": {"つくば市"},
+		"長野県": {"北佐久郡御代田町"},
+	}
+	if !reflect.DeepEqual(d.cities, wantCities) {
+		t.Errorf("cities = %v, want %v", d.cities, wantCities)
+	}
+}
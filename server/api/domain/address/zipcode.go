@@ -0,0 +1,138 @@
+package address
+
+import (
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"encoding/csv"
+	"io"
+	"strings"
+	"sync"
+)
+
+// zipcodeData は日本郵便の郵便番号データ (utf_ken_all.csv) をgzip圧縮したもの
+//
+// リポジトリには一部の郵便番号のみを含む。`make zipcode-update` で全国の最新のデータに更新する
+//
+//go:embed data/utf_ken_all.csv.gz
+var zipcodeData []byte
+
+// Entry は郵便番号に対応する地域
+type Entry struct {
+	PostalCode string `json:"postal_code"`
+	Prefecture string `json:"prefecture"`
+	City       string `json:"city"`
+	// 町域。郵便番号が市区町村全体に対応する場合は空
+	Town string `json:"town"`
+}
+
+type dictionary struct {
+	entries map[string][]Entry
+	cities  map[string][]string
+}
+
+var (
+	dictOnce sync.Once
+	dict     *dictionary
+	dictErr  error
+)
+
+// Lookup は7桁の郵便番号に対応する地域を返す。該当がない場合は空
+func Lookup(code string) []Entry {
+	d, err := load()
+	if err != nil {
+		return nil
+	}
+
+	return d.entries[code]
+}
+
+func citiesOf(prefecture string) []string {
+	d, err := load()
+	if err != nil {
+		return nil
+	}
+
+	return d.cities[prefecture]
+}
+
+func load() (*dictionary, error) {
+	dictOnce.Do(func() {
+		zr, err := gzip.NewReader(bytes.NewReader(zipcodeData))
+		if err != nil {
+			dictErr = err
+			return
+		}
+		defer zr.Close()
+
+		dict, dictErr = parseKenAll(zr)
+	})
+
+	return dict, dictErr
+}
+
+// parseKenAll は日本郵便の郵便番号データを読み込む
+//
+// 列は 3: 郵便番号, 7: 都道府県名, 8: 市区町村名, 9: 町域名。
+// 町域名の括弧書き (対象の丁目・ビルの階など) は除き、長い町域名が複数行に分かれている場合は先頭行のみ使う
+func parseKenAll(r io.Reader) (*dictionary, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	d := &dictionary{entries: map[string][]Entry{}, cities: map[string][]string{}}
+	seenCity := map[string]bool{}
+	// 括弧書きが次の行に続いている郵便番号
+	var continued string
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 9 {
+			continue
+		}
+
+		code, prefecture, city, town := record[2], record[6], record[7], record[8]
+		if continued == code {
+			if strings.Contains(town, "）") {
+				continued = ""
+			}
+			continue
+		}
+		continued = ""
+		if i := strings.Index(town, "（"); i >= 0 {
+			if !strings.Contains(town, "）") {
+				continued = code
+			}
+			town = town[:i]
+		}
+		if town == "以下に掲載がない場合" || strings.HasSuffix(town, "の次に番地がくる場合") || town == city+"一円" {
+			town = ""
+		}
+
+		e := Entry{PostalCode: code, Prefecture: prefecture, City: city, Town: town}
+		if !containsEntry(d.entries[code], e) {
+			d.entries[code] = append(d.entries[code], e)
+		}
+		if !seenCity[prefecture+city] {
+			seenCity[prefecture+city] = true
+			d.cities[prefecture] = append(d.cities[prefecture], city)
+		}
+	}
+
+	return d, nil
+}
+
+func containsEntry(entries []Entry, e Entry) bool {
+	for _, x := range entries {
+		if x == e {
+			return true
+		}
+	}
+
+	return false
+}
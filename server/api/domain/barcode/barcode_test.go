@@ -0,0 +1,63 @@
+package barcode_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/barcode"
+)
+
+func TestIsJAN(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{name: "JAN-13", code: "4569951116179", want: true},
+		{name: "JAN-13 with check digit 4", code: "4901234567894", want: true},
+		{name: "EAN-8", code: "45123450", want: true},
+		{name: "EAN-8 with check digit 0", code: "12345670", want: true},
+		{name: "wrong check digit", code: "4569951116178", want: false},
+		{name: "wrong EAN-8 check digit", code: "45123451", want: false},
+		{name: "12 digits", code: "456995111617", want: false},
+		{name: "14 digits", code: "45699511161790", want: false},
+		{name: "letter in body", code: "45699511161A9", want: false},
+		{name: "letter as check digit", code: "456995111617X", want: false},
+		{name: "full width digits", code: "４５６９９５１１", want: false},
+		{name: "empty", code: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := barcode.IsJAN(tt.code); got != tt.want {
+				t.Errorf("IsJAN(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCode128Modules(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{name: "ascii", content: "SN-0001"},
+		{name: "digits", content: "4569951116179"},
+		{name: "non ascii", content: "シリアル1", wantErr: barcode.ErrUnencodable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modules, err := barcode.Code128Modules(tt.content)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Code128Modules(%q) error = %v, want %v", tt.content, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			// バーコードは黒バーで始まり黒バーで終わる
+			if len(modules) == 0 || !modules[0] || !modules[len(modules)-1] {
+				t.Errorf("Code128Modules(%q) does not start and end with a bar", tt.content)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package dedupe は顧客の重複判定に使う正規化と類似度
+package dedupe
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizePhoneNumber は電話番号を数字のみの国内表記にそろえる
+// 全角数字、ハイフン・括弧・空白の有無、+81の国番号表記の違いを吸収する
+func NormalizePhoneNumber(s string) string {
+	s = norm.NFKC.String(strings.TrimSpace(s))
+
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+
+	if strings.HasPrefix(s, "+81") {
+		digits = "0" + strings.TrimPrefix(digits, "81")
+	}
+
+	return digits
+}
+
+// NormalizeEmail はメールアドレスの前後の空白を除き、小文字にそろえる
+func NormalizeEmail(s string) string {
+	return strings.ToLower(strings.TrimSpace(norm.NFKC.String(s)))
+}
+
+// NormalizeName は氏名を比較用にそろえる
+// 全角・半角の違いと空白を除き、カタカナはひらがなに、英字は小文字にする
+func NormalizeName(s string) string {
+	s = norm.NFKC.String(s)
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case r >= 'ァ' && r <= 'ヶ':
+			b.WriteRune(r - ('ァ' - 'ぁ'))
+		default:
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	return b.String()
+}
+
+// NameSimilarity は正規化済みの氏名の類似度を0から1で返す
+// 編集距離を長い方の文字数で割った値を1から引く
+func NameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 0
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
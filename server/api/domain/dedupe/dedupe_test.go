@@ -0,0 +1,98 @@
+package dedupe_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/dedupe"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "hyphens", s: "090-1234-5678", want: "09012345678"},
+		{name: "full width with parentheses", s: "０９０（１２３４）５６７８", want: "09012345678"},
+		{name: "japanese country code", s: "+81 90-1234-5678", want: "09012345678"},
+		{name: "spaces around", s: " 03 1234 5678 ", want: "0312345678"},
+		{name: "other country code is kept", s: "+1 212 555 0123", want: "12125550123"},
+		{name: "empty", s: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dedupe.NormalizePhoneNumber(tt.s); got != tt.want {
+				t.Errorf("NormalizePhoneNumber(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "upper case and spaces", s: " Taro@Example.COM ", want: "taro@example.com"},
+		{name: "full width", s: "ｔａｒｏ＠ｅｘａｍｐｌｅ．ｃｏｍ", want: "taro@example.com"},
+		{name: "already normalized", s: "taro@example.com", want: "taro@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dedupe.NormalizeEmail(tt.s); got != tt.want {
+				t.Errorf("NormalizeEmail(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "ideographic space", s: "山田　太郎", want: "山田太郎"},
+		{name: "katakana to hiragana", s: "ヤマダ タロウ", want: "やまだたろう"},
+		{name: "half width katakana", s: "ﾔﾏﾀﾞ ﾀﾛｳ", want: "やまだたろう"},
+		{name: "hiragana is kept", s: "やまだ たろう", want: "やまだたろう"},
+		{name: "latin letters", s: "John SMITH", want: "johnsmith"},
+		{name: "full width latin letters", s: "ＪＯＨＮ", want: "john"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dedupe.NormalizeName(tt.s); got != tt.want {
+				t.Errorf("NormalizeName(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{name: "same", a: "やまだたろう", b: "やまだたろう", want: 1},
+		{name: "one character missing", a: "やまだたろう", b: "やまだたろ", want: 1 - 1.0/6},
+		{name: "one character replaced", a: "山田太郎", b: "山田次郎", want: 0.75},
+		{name: "completely different", a: "やまだ", b: "たなか", want: 0},
+		{name: "one side empty", a: "abc", b: "", want: 0},
+		{name: "both empty", a: "", b: "", want: 0},
+		{name: "prefix of longer name", a: "やまだ", b: "やまだたろう", want: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dedupe.NameSimilarity(tt.a, tt.b)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("NameSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if reverse := dedupe.NameSimilarity(tt.b, tt.a); reverse != got {
+				t.Errorf("NameSimilarity(%q, %q) = %v, want %v", tt.b, tt.a, reverse, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package model
+
+// AddressCandidate は郵便番号から引いた住所の候補
+type AddressCandidate struct {
+	PostalCode string `json:"postal_code" example:"100-0005"`
+	Prefecture string `json:"prefecture" example:"東京都"`
+	City       string `json:"city" example:"千代田区"`
+	// 町域。郵便番号が市区町村全体に対応する場合は空
+	Town string `json:"town" example:"丸の内"`
+}
+
+// AddressNormalization は既存の住所を分割した結果の件数
+type AddressNormalization struct {
+	CustomersParsed int `json:"customers_parsed"`
+	// 都道府県・市区町村を判別できず、分割しなかった件数
+	CustomersUnparsed int `json:"customers_unparsed"`
+	StoresParsed      int `json:"stores_parsed"`
+	StoresUnparsed    int `json:"stores_unparsed"`
+}
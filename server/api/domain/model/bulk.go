@@ -0,0 +1,43 @@
+package model
+
+type BulkItemStatus string
+
+const (
+	BulkItemCreated BulkItemStatus = "created" // 作成済み
+	BulkItemFailed  BulkItemStatus = "failed"  // 検証・登録に失敗
+	BulkItemSkipped BulkItemStatus = "skipped" // 他の行の失敗により取り消し・未実行
+)
+
+// BulkItemResult は一括作成の1件ごとの結果。Indexはリクエストの配列の添字
+type BulkItemResult struct {
+	Index  int            `json:"index"`
+	Status BulkItemStatus `json:"status"`
+	ID     *int           `json:"id,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// BulkResult は一括作成の結果
+type BulkResult struct {
+	Atomic  bool              `json:"atomic"`
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Skipped int               `json:"skipped"`
+	Items   []*BulkItemResult `json:"items"`
+}
+
+// Tally は件ごとの状態から件数を集計する
+func (r *BulkResult) Tally() *BulkResult {
+	r.Created, r.Failed, r.Skipped = 0, 0, 0
+	for _, item := range r.Items {
+		switch item.Status {
+		case BulkItemCreated:
+			r.Created++
+		case BulkItemFailed:
+			r.Failed++
+		case BulkItemSkipped:
+			r.Skipped++
+		}
+	}
+
+	return r
+}
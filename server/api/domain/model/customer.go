@@ -1,14 +1,43 @@
 package model
 
+import "time"
+
 type Customer struct {
 	SoftDeleteTimestamp
 
-	ID          string `json:"id" gorm:"primaryKey;type:uuid;size:255;default:uuid_generate_v4()"`
-	Name        string `json:"name"`
-	Email       string `json:"email"`
-	PhoneNumber string `json:"phone_number"`
-	Address     string `json:"address"`
-	TenantID    string `json:"tenant_id"`
+	ID   string `json:"id" gorm:"primaryKey;type:uuid;size:255;default:uuid_generate_v4()"`
+	Name string `json:"name"`
+	// メールアドレス・電話番号・住所 (番地・建物名を含む) はDBに暗号化して保存する
+	Email string `json:"email" gorm:"serializer:encrypted"`
+	// E.164形式 (例: +819012345678)
+	PhoneNumber string `json:"phone_number" example:"+819012345678" gorm:"serializer:encrypted"`
+	// 表示用の電話番号。日本の番号は国内表記 (例: 090-1234-5678)
+	PhoneNumberDisplay string `json:"phone_number_display" example:"090-1234-5678" gorm:"serializer:encrypted"`
+	// 電話番号の国 (ISO 3166-1 alpha-2)。国番号から特定できない場合は空
+	PhoneCountry string `json:"phone_country" example:"JP"`
+	Address      string `json:"address" gorm:"serializer:encrypted"`
+	PostalCode   string `json:"postal_code"`
+	Prefecture   string `json:"prefecture"`
+	City         string `json:"city"`
+	Street       string `json:"street" gorm:"serializer:encrypted"`
+	Building     string `json:"building" gorm:"serializer:encrypted"`
+	TenantID     string `json:"tenant_id"`
+	// 暗号化したメールアドレス・電話番号を完全一致で検索するためのブラインドインデックス
+	EmailIndex       *string `json:"-" gorm:"column:email_bidx"`
+	PhoneNumberIndex *string `json:"-" gorm:"column:phone_number_bidx"`
+	// RFM分析のスコア (1〜5、5が最良)。テナント内の順位から算出し、発注のない顧客は空
+	RecencyScore   *int `json:"recency_score"`
+	FrequencyScore *int `json:"frequency_score"`
+	MonetaryScore  *int `json:"monetary_score"`
+	// スコア算出時点の最終購入からの日数・発注件数・累計購入額
+	RecencyDays *int       `json:"recency_days"`
+	Frequency   int        `json:"frequency"`
+	Monetary    int        `json:"monetary"`
+	RFMScoredAt *time.Time `json:"rfm_scored_at" gorm:"column:rfm_scored_at"`
+	// ポイント残高
+	PointBalance int `json:"point_balance"`
+	// 個人情報を匿名化した日時。匿名化した顧客は削除済みとして扱う
+	AnonymizedAt *time.Time `json:"anonymized_at"`
 	// リレーション (hasMany)
 	Orders []*Order `json:"orders" gorm:"foreignKey:CustomerID"`
 }
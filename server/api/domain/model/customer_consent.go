@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+type ConsentPurpose string
+
+const (
+	ConsentMarketingEmail ConsentPurpose = "marketing_email" // メールでの販促
+	ConsentMarketingSMS   ConsentPurpose = "marketing_sms"   // SMSでの販促
+	ConsentMarketingPost  ConsentPurpose = "marketing_post"  // ダイレクトメールの郵送
+	ConsentThirdParty     ConsentPurpose = "third_party"     // 第三者への提供
+)
+
+// ConsentSourceAnonymization は匿名化に伴って同意を撤回した記録の取得経路
+const ConsentSourceAnonymization = "anonymization"
+
+// CustomerConsent は顧客の利用目的ごとの同意・撤回の記録。記録は変更せず、同じ目的の最新の記録が現在の状態
+type CustomerConsent struct {
+	Timestamp
+
+	ID         int            `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID   string         `json:"tenant_id"`
+	CustomerID string         `json:"customer_id"`
+	Purpose    ConsentPurpose `json:"purpose" example:"marketing_email"`
+	Granted    bool           `json:"granted"`
+	// 同意を取得した経路 (例: 店頭、Webフォーム)
+	Source     string    `json:"source" example:"店頭"`
+	Note       string    `json:"note"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// CustomerConsents は顧客の同意の現在の状態と履歴
+type CustomerConsents struct {
+	// 目的ごとの最新の記録
+	Current []*CustomerConsent `json:"current"`
+	// 全ての記録 (新しい順)
+	History []*CustomerConsent `json:"history"`
+}
+
+// CustomerPersonalData は本人からの開示請求に応じて出力する、顧客に紐づく全ての情報
+type CustomerPersonalData struct {
+	ExportedAt time.Time `json:"exported_at"`
+	// 発注を含む顧客情報。削除済みの顧客も対象
+	Customer *Customer          `json:"customer"`
+	Consents []*CustomerConsent `json:"consents"`
+	Merges   []*CustomerMerge   `json:"merges"`
+	// この顧客に統合された顧客 (統合元) の情報
+	MergedCustomers []*Customer `json:"merged_customers"`
+}
+
+// CustomerAnonymization は顧客の匿名化の結果
+type CustomerAnonymization struct {
+	CustomerID string `json:"customer_id"`
+	// 匿名化した顧客。統合元の顧客を含む
+	AnonymizedCustomerIDs []string `json:"anonymized_customer_ids"`
+	// 会計のために保持した発注の件数
+	RetainedOrders int       `json:"retained_orders"`
+	AnonymizedAt   time.Time `json:"anonymized_at"`
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+type DuplicateReason string
+
+const (
+	DuplicatePhoneNumber DuplicateReason = "phone_number" // 電話番号が一致
+	DuplicateEmail       DuplicateReason = "email"        // メールアドレスが一致
+	DuplicateName        DuplicateReason = "name"         // 氏名が類似
+)
+
+// CustomerDuplicate は重複の可能性がある顧客の組
+type CustomerDuplicate struct {
+	Customer       *Customer         `json:"customer"`
+	Candidate      *Customer         `json:"candidate"`
+	Reasons        []DuplicateReason `json:"reasons"`
+	NameSimilarity float64           `json:"name_similarity"`
+}
+
+// CustomerMerge は顧客の統合履歴。統合元1件ごとに記録する
+type CustomerMerge struct {
+	Timestamp
+
+	ID               int              `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID         string           `json:"tenant_id"`
+	TargetCustomerID string           `json:"target_customer_id"`
+	SourceCustomerID string           `json:"source_customer_id"`
+	Source           CustomerSnapshot `json:"source" gorm:"type:jsonb"`
+	OrderIDs         IntList          `json:"order_ids" gorm:"type:jsonb"`
+	Note             string           `json:"note"`
+	MergedAt         time.Time        `json:"merged_at"`
+}
+
+// CustomerSnapshot は統合時点の統合元の顧客情報
+// メールアドレス・電話番号・住所は顧客と同じくDBに暗号化して保存する
+type CustomerSnapshot struct {
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	PhoneNumber string `json:"phone_number"`
+	Address     string `json:"address"`
+}
+
+func (s CustomerSnapshot) Value() (driver.Value, error) {
+	b, err := json.Marshal(s)
+
+	return string(b), err
+}
+
+func (s *CustomerSnapshot) Scan(src interface{}) error {
+	return scanJSON(src, s)
+}
+
+type IntList []int
+
+func (l IntList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+
+	return string(b), err
+}
+
+func (l *IntList) Scan(src interface{}) error {
+	return scanJSON(src, l)
+}
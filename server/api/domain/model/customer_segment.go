@@ -0,0 +1,52 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+)
+
+// CustomerSegment は保存した顧客の絞り込み条件
+type CustomerSegment struct {
+	Timestamp
+
+	ID          int           `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID    string        `json:"tenant_id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Filter      SegmentFilter `json:"filter" gorm:"type:jsonb"`
+}
+
+// SegmentFilter は顧客の絞り込み条件。指定した条件をすべて満たす顧客が対象
+type SegmentFilter struct {
+	// RFMスコアの範囲 (1〜5)
+	MinRecencyScore   *int `json:"min_recency_score,omitempty" example:"1"`
+	MaxRecencyScore   *int `json:"max_recency_score,omitempty" example:"2"`
+	MinFrequencyScore *int `json:"min_frequency_score,omitempty"`
+	MaxFrequencyScore *int `json:"max_frequency_score,omitempty"`
+	MinMonetaryScore  *int `json:"min_monetary_score,omitempty" example:"4"`
+	MaxMonetaryScore  *int `json:"max_monetary_score,omitempty"`
+	// 最終購入からの日数の範囲
+	MinRecencyDays *int `json:"min_recency_days,omitempty" example:"180"`
+	MaxRecencyDays *int `json:"max_recency_days,omitempty"`
+	// 発注件数・累計購入額の下限
+	MinFrequency *int `json:"min_frequency,omitempty"`
+	MinMonetary  *int `json:"min_monetary,omitempty" example:"100000"`
+	// いずれかの都道府県に住む顧客
+	Prefectures []string `json:"prefectures,omitempty"`
+}
+
+func (f SegmentFilter) Value() (driver.Value, error) {
+	b, err := json.Marshal(f)
+
+	return string(b), err
+}
+
+func (f *SegmentFilter) Scan(src interface{}) error {
+	return scanJSON(src, f)
+}
+
+// SegmentCount は顧客セグメントに該当する顧客の件数
+type SegmentCount struct {
+	SegmentID int   `json:"segment_id"`
+	Count     int64 `json:"count"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// CustomerSummary は顧客の購入実績の集計。キャンセルされた発注は含めない
+type CustomerSummary struct {
+	CustomerID string `json:"customer_id"`
+	// 累計購入額。値引き・ポイント利用・返品で返した額を差し引く
+	LifetimeSpend int `json:"lifetime_spend"`
+	OrderCount    int `json:"order_count"`
+	Units         int `json:"units"`
+	// 1回の発注あたりの平均購入額 (円未満は四捨五入)
+	AverageBasket   int        `json:"average_basket"`
+	FirstPurchaseAt *time.Time `json:"first_purchase_at"`
+	LastPurchaseAt  *time.Time `json:"last_purchase_at"`
+	// 購入額の多い分類。分類が未設定の在庫は含めない
+	FavoriteCategories []*CategorySpend `json:"favorite_categories" gorm:"-"`
+}
+
+// CategorySpend は分類ごとの購入実績
+type CategorySpend struct {
+	Category   string `json:"category"`
+	Spend      int    `json:"spend"`
+	OrderCount int    `json:"order_count"`
+	Units      int    `json:"units"`
+}
+
+// CustomerOrderHistory は顧客の発注履歴の1ページ分
+type CustomerOrderHistory struct {
+	// 発注の総件数 (キャンセルを含む)
+	Total  int64    `json:"total"`
+	Limit  int      `json:"limit"`
+	Offset int      `json:"offset"`
+	Orders []*Order `json:"orders"`
+}
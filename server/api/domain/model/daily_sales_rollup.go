@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// DailySalesRollup はテナント・店舗・在庫・日・ステータスごとの売上の日次集計
+// 発注の登録・更新時に差分で更新し、売上集計はこのテーブルから行う
+// 日付はREPORT_TIMEZONEでの発注日
+type DailySalesRollup struct {
+	Timestamp
+
+	TenantID  string      `json:"tenant_id" gorm:"primaryKey"`
+	StoreID   string      `json:"store_id" gorm:"primaryKey"`
+	StockID   int         `json:"stock_id" gorm:"primaryKey"`
+	SalesDate time.Time   `json:"sales_date" gorm:"primaryKey;type:date"`
+	Status    OrderStatus `json:"status" gorm:"primaryKey"`
+	// 値引き・ポイント利用・完了した返品で返した額を差し引いた売上金額
+	Revenue    int `json:"revenue"`
+	OrderCount int `json:"order_count"`
+	Units      int `json:"units"`
+}
+
+// DailySalesDiscrepancy は日次集計と発注からの集計が一致しない行
+type DailySalesDiscrepancy struct {
+	TenantID           string      `json:"tenant_id"`
+	StoreID            string      `json:"store_id"`
+	StockID            int         `json:"stock_id"`
+	SalesDate          time.Time   `json:"sales_date"`
+	Status             OrderStatus `json:"status"`
+	ExpectedRevenue    int         `json:"expected_revenue"`
+	ExpectedOrderCount int         `json:"expected_order_count"`
+	ExpectedUnits      int         `json:"expected_units"`
+	ActualRevenue      int         `json:"actual_revenue"`
+	ActualOrderCount   int         `json:"actual_order_count"`
+	ActualUnits        int         `json:"actual_units"`
+}
@@ -0,0 +1,30 @@
+package model
+
+type DataKeyPurpose string
+
+const (
+	DataKeyEncryption DataKeyPurpose = "encryption"  // 個人情報の暗号化
+	DataKeyBlindIndex DataKeyPurpose = "blind_index" // 暗号化した列を検索するためのブラインドインデックス
+)
+
+// DataKey は列の暗号化に使うデータ鍵。鍵そのものはマスター鍵で暗号化して保存する
+// 有効な鍵は用途ごとに1つで、無効にした鍵も暗号化済みの値の復号に使うため削除しない
+type DataKey struct {
+	Timestamp
+
+	ID          int            `json:"id" gorm:"primaryKey;autoIncrement"`
+	Purpose     DataKeyPurpose `json:"purpose"`
+	WrappedKey  []byte         `json:"-"`
+	MasterKeyID string         `json:"master_key_id"`
+	Active      bool           `json:"active"`
+}
+
+// KeyRotation はデータ鍵のローテーションと再暗号化の結果
+type KeyRotation struct {
+	// 暗号化に使う有効なデータ鍵
+	DataKeyID int `json:"data_key_id"`
+	// 現在のマスター鍵で包み直したデータ鍵の数
+	Rewrapped int64 `json:"rewrapped"`
+	// 有効なデータ鍵で暗号化し直した顧客と統合履歴の数
+	Reencrypted int `json:"reencrypted"`
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type ImportTarget string
+
+const (
+	ImportStocks    ImportTarget = "stocks"    // 在庫
+	ImportCustomers ImportTarget = "customers" // 顧客
+)
+
+type ImportJobStatus string
+
+const (
+	ImportPending   ImportJobStatus = "PENDING"   // 処理待ち
+	ImportRunning   ImportJobStatus = "RUNNING"   // 処理中
+	ImportCompleted ImportJobStatus = "COMPLETED" // 完了
+	ImportFailed    ImportJobStatus = "FAILED"    // 失敗
+)
+
+// ImportJob はCSV・Excelファイルの取り込み
+// ドライランの場合は検証のみ行い、登録・更新はしない
+type ImportJob struct {
+	Timestamp
+
+	ID            int             `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID      string          `json:"tenant_id"`
+	StoreID       string          `json:"store_id"`
+	Target        ImportTarget    `json:"target"`
+	Status        ImportJobStatus `json:"status"`
+	DryRun        bool            `json:"dry_run"`
+	FileName      string          `json:"file_name"`
+	FileKey       string          `json:"-"`
+	Encoding      string          `json:"encoding"`
+	Mapping       ImportMapping   `json:"mapping" gorm:"type:jsonb"`
+	MatchBy       string          `json:"match_by"`
+	DefaultUserID *string         `json:"default_user_id"`
+	TotalRows     int             `json:"total_rows"`
+	ProcessedRows int             `json:"processed_rows"`
+	CreatedRows   int             `json:"created_rows"`
+	UpdatedRows   int             `json:"updated_rows"`
+	FailedRows    int             `json:"failed_rows"`
+	Errors        ImportRowErrors `json:"errors" gorm:"type:jsonb"`
+	Error         string          `json:"error"`
+	StartedAt     *time.Time      `json:"started_at"`
+	FinishedAt    *time.Time      `json:"finished_at"`
+}
+
+// ImportMapping はファイルの列見出しから取り込み先の項目名への対応
+type ImportMapping map[string]string
+
+func (m ImportMapping) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(m)
+
+	return string(b), err
+}
+
+func (m *ImportMapping) Scan(src interface{}) error {
+	return scanJSON(src, m)
+}
+
+// ImportRowError は取り込みに失敗した行と項目。行番号は見出し行を1行目とする
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportRowErrors []ImportRowError
+
+func (e ImportRowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(e)
+
+	return string(b), err
+}
+
+func (e *ImportRowErrors) Scan(src interface{}) error {
+	return scanJSON(src, e)
+}
+
+func scanJSON(src interface{}, v interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(s, v)
+	case string:
+		return json.Unmarshal([]byte(s), v)
+	default:
+		return fmt.Errorf("unsupported type for jsonb: %T", src)
+	}
+}
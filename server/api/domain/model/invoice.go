@@ -0,0 +1,113 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+type InvoiceType string
+
+const (
+	InvoiceTypeInvoice    InvoiceType = "INVOICE"     // 適格請求書
+	InvoiceTypeCreditNote InvoiceType = "CREDIT_NOTE" // 適格返還請求書 (発行済みの請求書の取消)
+)
+
+// NumberPrefix は請求書番号の接頭辞を返す
+func (t InvoiceType) NumberPrefix() string {
+	if t == InvoiceTypeCreditNote {
+		return "CN-"
+	}
+
+	return "INV-"
+}
+
+// InvoiceIssuer はテナントの適格請求書発行事業者としての情報
+type InvoiceIssuer struct {
+	Timestamp
+
+	TenantID string `json:"tenant_id" gorm:"primaryKey;type:uuid"`
+	// 適格請求書発行事業者の登録番号 (T+13桁)
+	RegistrationNumber string `json:"registration_number" example:"T1234567890123"`
+	Name               string `json:"name" example:"株式会社バイセル"`
+	PostalCode         string `json:"postal_code" example:"1600023"`
+	Address            string `json:"address" example:"東京都新宿区西新宿6-8-1"`
+	PhoneNumber        string `json:"phone_number" example:"03-1234-5678"`
+}
+
+// Invoice は発行済みの請求書・返還請求書。記載内容は発行時点のもので、発行後は変更しない
+// 訂正は元の請求書を取り消す返還請求書を発行し、請求書を発行し直して行う
+type Invoice struct {
+	Timestamp
+
+	ID       int         `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID string      `json:"tenant_id"`
+	OrderID  int         `json:"order_id"`
+	Type     InvoiceType `json:"type" example:"INVOICE"`
+	// テナント・種別ごとの連番 (例: INV-00000001)
+	Number string `json:"number" example:"INV-00000001"`
+	// 返還請求書が取り消す請求書
+	OriginalInvoiceID *int      `json:"original_invoice_id"`
+	Reason            string    `json:"reason"`
+	IssuedAt          time.Time `json:"issued_at"`
+	// 取引年月日 (発注日)
+	TransactionDate time.Time `json:"transaction_date" gorm:"type:date"`
+	// 発行者
+	RegistrationNumber string `json:"registration_number" example:"T1234567890123"`
+	IssuerName         string `json:"issuer_name"`
+	IssuerPostalCode   string `json:"issuer_postal_code"`
+	IssuerAddress      string `json:"issuer_address"`
+	IssuerPhoneNumber  string `json:"issuer_phone_number"`
+	// 宛名 (顧客名)
+	RecipientName string        `json:"recipient_name"`
+	Lines         InvoiceLines  `json:"lines" gorm:"type:jsonb"`
+	Taxes         TaxBreakdowns `json:"taxes" gorm:"type:jsonb"`
+	// 税込の合計金額と消費税額の合計。返還請求書では負の値
+	Total int `json:"total"`
+	Tax   int `json:"tax"`
+}
+
+// InvoiceLine は請求書の明細。金額は税込で、値引きは負の金額の明細とする
+type InvoiceLine struct {
+	Description string `json:"description" example:"LOUIS VUITTON M41524 ブラウン モノグラム ハンドバッグ"`
+	Quantity    int    `json:"quantity" example:"1"`
+	// 単価は数量で割り切れる場合のみ記載する
+	UnitPrice *int `json:"unit_price" example:"110000"`
+	Amount    int  `json:"amount" example:"110000"`
+	TaxRate   int  `json:"tax_rate" example:"10"`
+}
+
+// Reduced は軽減税率の対象の明細かを返す
+func (l *InvoiceLine) Reduced() bool {
+	return l.TaxRate == ReducedTaxRate
+}
+
+type InvoiceLines []*InvoiceLine
+
+func (l InvoiceLines) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(l)
+
+	return string(b), err
+}
+
+func (l *InvoiceLines) Scan(src interface{}) error {
+	return scanJSON(src, l)
+}
+
+type TaxBreakdowns []*TaxBreakdown
+
+func (t TaxBreakdowns) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(t)
+
+	return string(b), err
+}
+
+func (t *TaxBreakdowns) Scan(src interface{}) error {
+	return scanJSON(src, t)
+}
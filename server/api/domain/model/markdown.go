@@ -0,0 +1,63 @@
+package model
+
+import "time"
+
+// MarkdownRule は在庫の経過日数に応じた自動値下げの段階
+// 在庫の登録からの経過日数が最も大きい段階を基準価格に対して適用する
+type MarkdownRule struct {
+	Timestamp
+
+	ID       int    `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID string `json:"tenant_id"`
+	// 在庫の登録からの経過日数
+	AgeDays int `json:"age_days" example:"60"`
+	// 基準価格からの値下げ率 (%)
+	DiscountPercent int `json:"discount_percent" example:"10"`
+}
+
+// MarkdownRuleSet はテナントの自動値下げの段階と原価の下限の設定
+type MarkdownRuleSet struct {
+	TenantID    string          `json:"tenant_id"`
+	FloorAtCost bool            `json:"floor_at_cost" example:"true"`
+	Rules       []*MarkdownRule `json:"rules"`
+}
+
+// MarkdownCandidate は自動値下げの対象となり得る在庫
+type MarkdownCandidate struct {
+	StockID   int
+	StoreID   string
+	Name      string
+	Price     int
+	CreatedAt time.Time
+	// 自動値下げ以外で最後に設定した販売価格。価格の履歴がない場合は現在の価格
+	BasePrice int
+	// 適用済みの自動値下げの段階のうち最も大きい経過日数
+	MarkdownAgeDays *int
+}
+
+// MarkdownItem は自動値下げで変更する在庫の販売価格
+type MarkdownItem struct {
+	StockID         int    `json:"stock_id"`
+	StoreID         string `json:"store_id"`
+	Name            string `json:"name" example:"ヴィンテージ ショルダーバッグ"`
+	AgeDays         int    `json:"age_days" example:"63"`
+	RuleAgeDays     int    `json:"rule_age_days" example:"60"`
+	DiscountPercent int    `json:"discount_percent" example:"10"`
+	BasePrice       int    `json:"base_price" example:"100000"`
+	CurrentPrice    int    `json:"current_price" example:"100000"`
+	NewPrice        int    `json:"new_price" example:"90000"`
+	// 評価方法に従った取得原価の単価。取得原価が不明な場合はnull
+	UnitCost *int `json:"unit_cost" example:"70000"`
+	// 原価の下限で値下げ額を抑えたか
+	FloorApplied bool `json:"floor_applied" example:"false"`
+}
+
+// MarkdownPlan は自動値下げの試算結果
+type MarkdownPlan struct {
+	TenantID    string          `json:"tenant_id"`
+	EvaluatedAt time.Time       `json:"evaluated_at"`
+	FloorAtCost bool            `json:"floor_at_cost" example:"true"`
+	Items       []*MarkdownItem `json:"items"`
+	// 原価の下限を適用する設定で取得原価が不明なため値下げしない在庫
+	CostUnknownStockIDs []int `json:"cost_unknown_stock_ids"`
+}
@@ -3,11 +3,22 @@ package model
 type Order struct {
 	Timestamp
 
-	ID           int         `json:"id" gorm:"primaryKey;autoIncrement"`
-	TotalAmount  int         `json:"total_amount"`
+	ID          int `json:"id" gorm:"primaryKey;autoIncrement"`
+	TotalAmount int `json:"total_amount"`
+	// キャンペーンによる値引き額の合計。TotalAmountは値引き前の金額
+	DiscountAmount int `json:"discount_amount"`
+	// 値引きに利用したポイント (1ポイント1円)
+	PointsUsed   int         `json:"points_used"`
 	Quantity     int         `json:"quantity"`
 	DeliveryDate string      `json:"delivery_date"`
 	Status       OrderStatus `json:"status"`
 	StockID      int         `json:"stock_id"`
 	CustomerID   string      `json:"customer_id"`
+	// リレーション (hasMany)
+	Discounts []*OrderDiscount `json:"discounts" gorm:"foreignKey:OrderID"`
+}
+
+// AmountDue は発注で支払いが必要な金額を返す
+func (o *Order) AmountDue() int {
+	return o.TotalAmount - o.DiscountAmount - o.PointsUsed
 }
@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+type ReturnStatus string
+
+const (
+	ReturnRequested ReturnStatus = "REQUESTED" // 受付済み
+	ReturnInspected ReturnStatus = "INSPECTED" // 検品済み
+	ReturnCompleted ReturnStatus = "COMPLETED" // 完了
+	ReturnRejected  ReturnStatus = "REJECTED"  // 返品不可
+)
+
+// ReturnCondition は検品した返品の状態ランク
+type ReturnCondition string
+
+const (
+	ConditionNew  ReturnCondition = "NEW"  // 新品・未使用
+	ConditionS    ReturnCondition = "S"    // 未使用に近い
+	ConditionA    ReturnCondition = "A"    // 目立った傷や汚れなし
+	ConditionB    ReturnCondition = "B"    // やや傷や汚れあり
+	ConditionC    ReturnCondition = "C"    // 傷や汚れあり
+	ConditionJunk ReturnCondition = "JUNK" // ジャンク (再販不可)
+)
+
+type ReturnResolution string
+
+const (
+	ResolutionRefund      ReturnResolution = "REFUND"       // 支払った決済手段で返金
+	ResolutionExchange    ReturnResolution = "EXCHANGE"     // 別の発注で交換品を渡す
+	ResolutionStoreCredit ReturnResolution = "STORE_CREDIT" // ポイントで返還
+)
+
+// OrderReturn は納品済みの発注の返品。受付・検品を経て、返金・交換・ポイント返還のいずれかで完了する
+type OrderReturn struct {
+	Timestamp
+
+	ID       int    `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID string `json:"tenant_id"`
+	OrderID  int    `json:"order_id"`
+	// 返品された在庫 (発注の在庫)
+	StockID  int          `json:"stock_id"`
+	Quantity int          `json:"quantity" example:"1"`
+	Reason   string       `json:"reason" example:"サイズが合わない"`
+	Status   ReturnStatus `json:"status" example:"REQUESTED"`
+	// 検品した状態ランク。検品前は空
+	Condition *ReturnCondition `json:"condition" example:"B"`
+	// 検品・対応の記録
+	Note       string            `json:"note"`
+	Resolution *ReturnResolution `json:"resolution" example:"REFUND"`
+	// 戻し入れた在庫。状態ランクに応じて元とは別の在庫に戻せる。戻し入れない場合は空
+	RestockStockID *int `json:"restock_stock_id"`
+	// 返金額、またはポイントで返還した額
+	Amount int `json:"amount" example:"8000"`
+	// 交換品の発注
+	ExchangeOrderID *int       `json:"exchange_order_id"`
+	InspectedAt     *time.Time `json:"inspected_at"`
+	// 完了、または返品不可とした日時
+	ClosedAt *time.Time `json:"closed_at"`
+}
@@ -0,0 +1,169 @@
+package model
+
+import "time"
+
+type PaymentMethod string
+
+const (
+	PaymentCash         PaymentMethod = "CASH"          // 現金
+	PaymentCreditCard   PaymentMethod = "CREDIT_CARD"   // クレジットカード
+	PaymentQR           PaymentMethod = "QR"            // QRコード決済
+	PaymentBankTransfer PaymentMethod = "BANK_TRANSFER" // 銀行振込
+	PaymentPoints       PaymentMethod = "POINTS"        // ポイント (1ポイント1円)
+)
+
+// ViaGateway は決済代行会社を通す決済手段かを返す
+func (m PaymentMethod) ViaGateway() bool {
+	return m == PaymentCreditCard || m == PaymentQR
+}
+
+type PaymentStatus string
+
+const (
+	PaymentUnpaid        PaymentStatus = "UNPAID"         // 未払い
+	PaymentPartiallyPaid PaymentStatus = "PARTIALLY_PAID" // 一部入金
+	PaymentPaid          PaymentStatus = "PAID"           // 支払い済み
+	PaymentRefunded      PaymentStatus = "REFUNDED"       // 全額返金済み
+)
+
+type ChargeStatus string
+
+const (
+	ChargePending   ChargeStatus = "PENDING"   // 決済代行会社の結果待ち
+	ChargeCompleted ChargeStatus = "COMPLETED" // 支払い済み
+	ChargeFailed    ChargeStatus = "FAILED"    // 決済代行会社が決済を承認しなかった
+)
+
+type RefundCause string
+
+const (
+	RefundCancellation RefundCause = "CANCELLATION" // 発注のキャンセル
+	RefundReturn       RefundCause = "RETURN"       // 返品
+	RefundOther        RefundCause = "OTHER"        // その他の調整
+)
+
+type RefundStatus string
+
+const (
+	RefundPending   RefundStatus = "PENDING"   // 決済代行会社の結果待ち
+	RefundCompleted RefundStatus = "COMPLETED" // 返金済み
+	RefundFailed    RefundStatus = "FAILED"    // 決済代行会社が返金を受け付けなかった
+)
+
+// Payment は発注に対する支払い。1つの発注を複数の決済手段で分けて支払える
+type Payment struct {
+	Timestamp
+
+	ID       int           `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID string        `json:"tenant_id"`
+	OrderID  int           `json:"order_id"`
+	Method   PaymentMethod `json:"method" example:"CASH"`
+	Amount   int           `json:"amount" example:"8000"`
+	// 現金で預かった金額。釣り銭は預かり金額と支払額の差
+	Tendered *int `json:"tendered" example:"10000"`
+	// 決済代行会社の取引の参照番号、または振込の照合番号など
+	Reference string `json:"reference" example:"fake_ch_000001"`
+	Note      string `json:"note"`
+	// 決済代行会社を通す支払いは記録を確定してから決済するため、結果が分かるまでPENDINGになる
+	Status ChargeStatus `json:"status" example:"COMPLETED"`
+	PaidAt time.Time    `json:"paid_at"`
+}
+
+// Effective は支払額に数える支払いかを返す。結果待ちの支払いは二重に支払わないよう数え、失敗した支払いは数えない
+func (p *Payment) Effective() bool {
+	return p.Status != ChargeFailed
+}
+
+// Refund は支払いの全部または一部の返金
+type Refund struct {
+	Timestamp
+
+	ID        int         `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID  string      `json:"tenant_id"`
+	OrderID   int         `json:"order_id"`
+	PaymentID int         `json:"payment_id"`
+	Amount    int         `json:"amount" example:"8000"`
+	Cause     RefundCause `json:"cause" example:"CANCELLATION"`
+	Reason    string      `json:"reason"`
+	// 返品による返金の場合の返品ID
+	ReturnID *int `json:"return_id"`
+	// 決済代行会社の返金の参照番号など
+	Reference string `json:"reference" example:"fake_re_000002"`
+	// 決済代行会社を通す返金は記録を確定してから依頼するため、結果が分かるまでPENDINGになる
+	Status     RefundStatus `json:"status" example:"COMPLETED"`
+	RefundedAt time.Time    `json:"refunded_at"`
+}
+
+// Effective は返金額に数える返金かを返す。結果待ちの返金は二重に返金しないよう数え、失敗した返金は数えない
+func (r *Refund) Effective() bool {
+	return r.Status != RefundFailed
+}
+
+// OrderPayments は発注の支払い状況
+type OrderPayments struct {
+	OrderID int `json:"order_id"`
+	// 支払いが必要な金額。値引き額と値引きに利用したポイント、返品で返した額を差し引いた金額
+	AmountDue int `json:"amount_due" example:"8000"`
+	// 完了した返品で返金、またはポイントで返還した額
+	Returned int `json:"returned" example:"0"`
+	// 支払額と返金額。決済代行会社の結果待ちのものを含み、失敗したものは含まない
+	Paid     int `json:"paid" example:"8000"`
+	Refunded int `json:"refunded" example:"0"`
+	// 未払いの残額。返品によらない返金の分は未払いに戻る
+	Balance  int           `json:"balance" example:"0"`
+	Status   PaymentStatus `json:"status" example:"PAID"`
+	Payments []*Payment    `json:"payments"`
+	Refunds  []*Refund     `json:"refunds"`
+}
+
+// NewOrderPayments は支払いと返金、完了した返品で返した額から発注の支払い状況を求める
+func NewOrderPayments(order *Order, payments []*Payment, refunds []*Refund, returned int) *OrderPayments {
+	p := &OrderPayments{
+		OrderID:   order.ID,
+		AmountDue: order.AmountDue() - returned,
+		Returned:  returned,
+		Payments:  payments,
+		Refunds:   refunds,
+	}
+	for _, payment := range payments {
+		if payment.Effective() {
+			p.Paid += payment.Amount
+		}
+	}
+	for _, refund := range refunds {
+		if refund.Effective() {
+			p.Refunded += refund.Amount
+		}
+	}
+
+	net := p.Paid - p.Refunded
+	p.Balance = max(p.AmountDue-net, 0)
+	switch {
+	case p.Refunded > 0 && net <= 0:
+		p.Status = PaymentRefunded
+	case net >= p.AmountDue:
+		p.Status = PaymentPaid
+	case net <= 0:
+		p.Status = PaymentUnpaid
+	default:
+		p.Status = PaymentPartiallyPaid
+	}
+
+	return p
+}
+
+// RefundableAmount は支払いのうち返金していない金額を返す。支払い済みでない支払いは返金できない
+func (p *Payment) RefundableAmount(refunds []*Refund) int {
+	if p.Status != ChargeCompleted {
+		return 0
+	}
+
+	refundable := p.Amount
+	for _, refund := range refunds {
+		if refund.PaymentID == p.ID && refund.Effective() {
+			refundable -= refund.Amount
+		}
+	}
+
+	return refundable
+}
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+type PointTransactionType string
+
+const (
+	PointEarn          PointTransactionType = "EARN"           // 発注の納品による付与
+	PointRedeem        PointTransactionType = "REDEEM"         // 発注の値引きに利用
+	PointExpire        PointTransactionType = "EXPIRE"         // 有効期限切れによる失効
+	PointEarnReversal  PointTransactionType = "EARN_REVERSAL"  // 納品の取り消し・返品による付与の取り消し
+	PointRedeemRefund  PointTransactionType = "REDEEM_REFUND"  // 発注のキャンセルによる利用の取り消し
+	PointPayment       PointTransactionType = "PAYMENT"        // 発注の支払いに利用
+	PointPaymentRefund PointTransactionType = "PAYMENT_REFUND" // 支払いの返金による返還
+	PointReturnCredit  PointTransactionType = "RETURN_CREDIT"  // 返品のポイントでの返還
+)
+
+// PointTransaction はポイントの増減の記録。増加の記録は未使用の残りを持ち、減少時に有効期限の近いものから消し込む
+type PointTransaction struct {
+	Timestamp
+
+	ID         int                  `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID   string               `json:"tenant_id"`
+	CustomerID string               `json:"customer_id"`
+	OrderID    *int                 `json:"order_id"`
+	Type       PointTransactionType `json:"type" example:"EARN"`
+	// 増減したポイント (減少は負)
+	Points int `json:"points" example:"100"`
+	// 増加したポイントのうち未使用・未失効の残り
+	Remaining int `json:"remaining" example:"100"`
+	// 増加したポイントの有効期限。空の場合は無期限
+	ExpiresAt *time.Time `json:"expires_at"`
+	// 記録後の残高
+	Balance    int       `json:"balance" example:"1200"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Note       string    `json:"note"`
+}
+
+// PointBalance は顧客のポイント残高と失効予定
+type PointBalance struct {
+	CustomerID string         `json:"customer_id"`
+	Balance    int            `json:"balance" example:"1200"`
+	Expiring   []*PointExpiry `json:"expiring"`
+}
+
+// PointExpiry は同じ日時に失効するポイントの合計
+type PointExpiry struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Points    int       `json:"points" example:"100"`
+}
+
+// PointHistory は顧客のポイント履歴の1ページ分
+type PointHistory struct {
+	Total        int64               `json:"total"`
+	Limit        int                 `json:"limit"`
+	Offset       int                 `json:"offset"`
+	Transactions []*PointTransaction `json:"transactions"`
+}
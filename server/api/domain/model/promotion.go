@@ -0,0 +1,92 @@
+package model
+
+import "time"
+
+type DiscountType string
+
+const (
+	DiscountPercent DiscountType = "PERCENT" // 定率 (%)
+	DiscountFixed   DiscountType = "FIXED"   // 定額 (円)
+)
+
+// Promotion は値引きのキャンペーン。対象・期間・金額の条件をすべて満たす発注明細に適用する
+// クーポンが不要なキャンペーンは条件を満たす発注に自動で適用し、複数該当する場合は値引き額が最も大きいものを適用する
+// クーポンのキャンペーンは自動で適用したキャンペーンと併用できる
+type Promotion struct {
+	Timestamp
+
+	ID           int          `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID     string       `json:"tenant_id"`
+	Name         string       `json:"name" example:"秋のブランドセール"`
+	Description  string       `json:"description"`
+	DiscountType DiscountType `json:"discount_type" example:"PERCENT"`
+	// 定率の場合は%、定額の場合は円
+	DiscountValue int `json:"discount_value" example:"10"`
+	// 対象の在庫・分類・店舗。未指定の条件では絞り込まない
+	StockID  *int    `json:"stock_id"`
+	Category *string `json:"category" example:"バッグ"`
+	StoreID  *string `json:"store_id"`
+	// 適用期間。未指定の場合は期限を設けない
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+	// 適用に必要な発注金額の下限
+	MinSpend int `json:"min_spend" example:"10000"`
+	// 顧客1人あたりの利用回数の上限。0の場合は無制限
+	UsageLimitPerCustomer int `json:"usage_limit_per_customer" example:"1"`
+	// 全体の利用回数の上限。0の場合は無制限
+	UsageLimit     int  `json:"usage_limit" example:"0"`
+	RequiresCoupon bool `json:"requires_coupon"`
+	Active         bool `json:"active"`
+}
+
+// Discount は発注金額に対する値引き額を返す。値引き額は発注金額を超えない
+func (p *Promotion) Discount(amount int) int {
+	var discount int
+	switch p.DiscountType {
+	case DiscountPercent:
+		discount = amount * p.DiscountValue / 100
+	case DiscountFixed:
+		discount = p.DiscountValue
+	}
+
+	return min(discount, amount)
+}
+
+// Limited は利用回数の上限があるかを返す
+func (p *Promotion) Limited() bool {
+	return p.UsageLimit > 0 || p.UsageLimitPerCustomer > 0
+}
+
+// Coupon はキャンペーンを適用するためのコード。コードは大文字で保存し、大文字小文字を区別せずに照合する
+type Coupon struct {
+	Timestamp
+
+	ID          int    `json:"id" gorm:"primaryKey;autoIncrement"`
+	TenantID    string `json:"tenant_id"`
+	PromotionID int    `json:"promotion_id"`
+	Code        string `json:"code" example:"AUTUMN2025"`
+	// このコードの利用回数の上限。0の場合は無制限
+	UsageLimit int  `json:"usage_limit" example:"100"`
+	Active     bool `json:"active"`
+}
+
+// OrderDiscount は発注明細に適用した値引き。適用時のキャンペーン名と値引き額を残す
+type OrderDiscount struct {
+	Timestamp
+
+	ID          int    `json:"id" gorm:"primaryKey;autoIncrement"`
+	OrderID     int    `json:"order_id"`
+	StockID     int    `json:"stock_id"`
+	PromotionID int    `json:"promotion_id"`
+	CouponID    *int   `json:"coupon_id"`
+	Name        string `json:"name" example:"秋のブランドセール"`
+	Amount      int    `json:"amount" example:"1000"`
+}
+
+// PromotionTarget は値引きの判定に使う発注明細の在庫の情報
+type PromotionTarget struct {
+	TenantID string
+	StoreID  string
+	StockID  int
+	Category *string
+}
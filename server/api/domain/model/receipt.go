@@ -0,0 +1,32 @@
+package model
+
+const (
+	PaperWidth58 = 58 // 58mm幅のレシート用紙
+	PaperWidth80 = 80 // 80mm幅のレシート用紙
+)
+
+// ReceiptTemplate はテナントごとのレシートの印字設定
+type ReceiptTemplate struct {
+	Timestamp
+
+	TenantID string `json:"tenant_id" gorm:"primaryKey;type:uuid"`
+	// 既定の用紙幅 (mm)
+	PaperWidth int `json:"paper_width" example:"80"`
+	// 店舗名の下・レシートの末尾に印字する文言。改行で複数行にできる
+	Header string `json:"header" example:"いつもご利用ありがとうございます"`
+	Footer string `json:"footer" example:"返品・交換はレシートをお持ちのうえ14日以内にお申し付けください"`
+	// 店舗の住所・電話番号を印字するか
+	ShowStoreAddress bool `json:"show_store_address" example:"true"`
+	// 発注番号のバーコードを印字するか
+	ShowBarcode bool `json:"show_barcode" example:"true"`
+}
+
+// DefaultReceiptTemplate は印字設定が未登録のテナントに適用する既定値
+func DefaultReceiptTemplate(tenantID string) *ReceiptTemplate {
+	return &ReceiptTemplate{
+		TenantID:         tenantID,
+		PaperWidth:       PaperWidth80,
+		ShowStoreAddress: true,
+		ShowBarcode:      true,
+	}
+}
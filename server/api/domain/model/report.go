@@ -0,0 +1,96 @@
+package model
+
+import "time"
+
+// InventoryValuationReport は基準日時点の棚卸資産評価額
+type InventoryValuationReport struct {
+	TenantID            string            `json:"tenant_id"`
+	AsOf                time.Time         `json:"as_of"`
+	Method              ValuationMethod   `json:"method"`
+	Quantity            int               `json:"quantity"`
+	Value               int               `json:"value"`
+	UnknownCostQuantity int               `json:"unknown_cost_quantity"`
+	Stores              []*StoreValuation `json:"stores"`
+}
+
+type StoreValuation struct {
+	StoreID             string            `json:"store_id"`
+	StoreName           string            `json:"store_name"`
+	Quantity            int               `json:"quantity"`
+	Value               int               `json:"value"`
+	UnknownCostQuantity int               `json:"unknown_cost_quantity"`
+	Stocks              []*StockValuation `json:"stocks"`
+}
+
+type StockValuation struct {
+	StockID             int    `json:"stock_id"`
+	Name                string `json:"name"`
+	Quantity            int    `json:"quantity"`
+	UnitCost            int    `json:"unit_cost"`
+	Value               int    `json:"value"`
+	UnknownCostQuantity int    `json:"unknown_cost_quantity"`
+}
+
+type SalesPeriod string
+
+const (
+	PeriodDay   SalesPeriod = "day"   // 日別
+	PeriodWeek  SalesPeriod = "week"  // 週別（月曜始まり）
+	PeriodMonth SalesPeriod = "month" // 月別
+)
+
+type SalesDimension string
+
+const (
+	DimensionStore  SalesDimension = "store"  // 店舗別
+	DimensionUser   SalesDimension = "user"   // 担当者別
+	DimensionStock  SalesDimension = "stock"  // 在庫別
+	DimensionStatus SalesDimension = "status" // ステータス別
+)
+
+// SalesReportQuery は売上集計の条件
+// From・Toは発注日時の範囲で、Toは含まない
+type SalesReportQuery struct {
+	TenantID string
+	From     *time.Time
+	To       *time.Time
+	TimeZone string
+	Period   *SalesPeriod
+	GroupBy  []SalesDimension
+	Statuses []OrderStatus
+	StoreID  *string
+}
+
+// SalesSummary は売上・発注件数・数量・平均発注額の集計値
+type SalesSummary struct {
+	// 売上金額。値引き・ポイント利用・返品で返した額を差し引く
+	Revenue           int `json:"revenue"`
+	OrderCount        int `json:"order_count"`
+	Units             int `json:"units"`
+	AverageOrderValue int `json:"average_order_value"`
+}
+
+// SalesReportRow は集計軸ごとの1行。指定されていない集計軸は省略する
+type SalesReportRow struct {
+	PeriodStart *string      `json:"period_start,omitempty"`
+	StoreID     *string      `json:"store_id,omitempty"`
+	StoreName   *string      `json:"store_name,omitempty"`
+	UserID      *string      `json:"user_id,omitempty"`
+	UserName    *string      `json:"user_name,omitempty"`
+	StockID     *int         `json:"stock_id,omitempty"`
+	StockName   *string      `json:"stock_name,omitempty"`
+	Status      *OrderStatus `json:"status,omitempty"`
+	SalesSummary
+}
+
+type SalesReport struct {
+	TenantID string            `json:"tenant_id"`
+	From     *time.Time        `json:"from"`
+	To       *time.Time        `json:"to"`
+	TimeZone string            `json:"time_zone"`
+	Period   *SalesPeriod      `json:"period"`
+	GroupBy  []SalesDimension  `json:"group_by"`
+	Statuses []OrderStatus     `json:"statuses"`
+	Total    SalesSummary      `json:"total"`
+	Rows     []*SalesReportRow `json:"rows"`
+}
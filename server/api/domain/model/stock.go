@@ -3,12 +3,39 @@ package model
 type Stock struct {
 	Timestamp
 
-	ID       int    `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name     string `json:"name"`
-	Quantity int    `json:"quantity"`
-	Price    int    `json:"price"`
-	StoreID  string `json:"store_id"`
-	UserID   string `json:"user_id"`
+	ID           int     `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name         string  `json:"name"`
+	Quantity     int     `json:"quantity"`
+	Price        int     `json:"price"`
+	Barcode      *string `json:"barcode"`
+	JAN          *string `json:"jan" gorm:"column:jan"`
+	SerialNumber *string `json:"serial_number"`
+	// 分類 (例: バッグ、時計)。顧客の購入傾向の集計に使う
+	Category *string `json:"category"`
+	// 消費税率 (%)。販売価格は税込で、食品など軽減税率の対象は8
+	TaxRate int    `json:"tax_rate" example:"10" gorm:"default:10"`
+	StoreID string `json:"store_id"`
+	UserID  string `json:"user_id"`
 	// リレーション (hasMany)
-	Orders []Order `json:"orders" gorm:"foreignKey:StockID"`
+	Orders []Order       `json:"orders" gorm:"foreignKey:StockID"`
+	Images []*StockImage `json:"images" gorm:"foreignKey:StockID"`
+}
+
+// StockOwner は在庫が属するテナントと店舗
+type StockOwner struct {
+	TenantID string
+	StoreID  string
+	StockID  int
+}
+
+// Code はラベル印字に使う識別コードを返す
+// バーコード、JAN、シリアル番号の順に設定されているものを優先する
+func (s *Stock) Code() (string, bool) {
+	for _, code := range []*string{s.Barcode, s.JAN, s.SerialNumber} {
+		if code != nil && *code != "" {
+			return *code, true
+		}
+	}
+
+	return "", false
 }
@@ -0,0 +1,18 @@
+package model
+
+type StockImage struct {
+	Timestamp
+
+	ID           int    `json:"id" gorm:"primaryKey;autoIncrement"`
+	StockID      int    `json:"stock_id"`
+	Position     int    `json:"position"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	StorageKey   string `json:"-"`
+	ThumbnailKey string `json:"-"`
+	// 署名付きURL (DBには保存しない)
+	URL          string `json:"url" gorm:"-"`
+	ThumbnailURL string `json:"thumbnail_url" gorm:"-"`
+}
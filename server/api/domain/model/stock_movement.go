@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+type StockMovementType string
+
+const (
+	MovementReceipt    StockMovementType = "RECEIPT"    // 入庫(仕入)
+	MovementSale       StockMovementType = "SALE"       // 販売・販売取消
+	MovementAdjustment StockMovementType = "ADJUSTMENT" // 数量調整
+	MovementStocktake  StockMovementType = "STOCKTAKE"  // 棚卸差異
+	MovementReturn     StockMovementType = "RETURN"     // 返品の戻し入れ
+)
+
+// StockMovement は在庫数量の増減履歴
+// 在庫数量(stocks.quantity)の変更は必ずこの履歴を伴う
+type StockMovement struct {
+	Timestamp
+
+	ID       int               `json:"id" gorm:"primaryKey;autoIncrement"`
+	StockID  int               `json:"stock_id"`
+	Type     StockMovementType `json:"type"`
+	Quantity int               `json:"quantity"`  // 増加は正、減少は負
+	UnitCost *int              `json:"unit_cost"` // 入庫時の取得原価(1点あたり)
+	OrderID  *int              `json:"order_id"`
+	Reason   string            `json:"reason"`
+	// 発生日時。評価額の基準日判定に使う
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// StockLedgerEntry は評価額の計算用に在庫の情報を付加した入出庫履歴
+type StockLedgerEntry struct {
+	StockMovement
+	StoreID   string
+	StockName string
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type PriceChangeStatus string
+
+const (
+	PriceChangeScheduled PriceChangeStatus = "SCHEDULED" // 適用待ち
+	PriceChangeApplied   PriceChangeStatus = "APPLIED"   // 適用済み
+	PriceChangeCancelled PriceChangeStatus = "CANCELLED" // 取消
+)
+
+// StockPriceChange は在庫の販売価格の変更
+// 即時の変更は適用済みとして記録し、予約した変更は有効日時を過ぎるとワーカーが適用する
+type StockPriceChange struct {
+	Timestamp
+
+	ID      int `json:"id" gorm:"primaryKey;autoIncrement"`
+	StockID int `json:"stock_id"`
+	Price   int `json:"price" example:"90000"`
+	// 変更前の価格。適用時に記録する
+	PreviousPrice *int              `json:"previous_price" example:"100000"`
+	EffectiveFrom time.Time         `json:"effective_from"`
+	Status        PriceChangeStatus `json:"status" example:"APPLIED"`
+	AppliedAt     *time.Time        `json:"applied_at"`
+	Reason        string            `json:"reason" example:"週明けの値下げ"`
+	// 自動値下げによる変更の場合、適用した段階の経過日数
+	MarkdownAgeDays *int `json:"markdown_age_days" example:"60"`
+}
+
+// StockPriceHistory は在庫の販売価格の推移
+type StockPriceHistory struct {
+	StockID      int `json:"stock_id"`
+	CurrentPrice int `json:"current_price" example:"90000"`
+	// 適用済みの変更を有効日時の古い順に並べたもの
+	Changes []*StockPriceChange `json:"changes"`
+	// 適用待ちの変更を有効日時の近い順に並べたもの
+	Scheduled []*StockPriceChange `json:"scheduled"`
+}
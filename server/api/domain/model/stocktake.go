@@ -0,0 +1,114 @@
+package model
+
+import "time"
+
+type StocktakeStatus string
+
+const (
+	StocktakeCounting  StocktakeStatus = "COUNTING"  // 実数カウント中
+	StocktakeReviewing StocktakeStatus = "REVIEWING" // 差異確認中
+	StocktakeCompleted StocktakeStatus = "COMPLETED" // 完了
+	StocktakeCancelled StocktakeStatus = "CANCELLED" // 中止
+)
+
+type StocktakeReasonCode string
+
+const (
+	ReasonDamage   StocktakeReasonCode = "DAMAGE"   // 破損
+	ReasonTheft    StocktakeReasonCode = "THEFT"    // 盗難
+	ReasonLost     StocktakeReasonCode = "LOST"     // 紛失
+	ReasonFound    StocktakeReasonCode = "FOUND"    // 発見
+	ReasonMiscount StocktakeReasonCode = "MISCOUNT" // 計上誤り
+	ReasonOther    StocktakeReasonCode = "OTHER"    // その他
+)
+
+// Stocktake は店舗単位の棚卸
+// 開始時点の在庫数量をスナップショットとして保持し、実数との差異を確認する
+type Stocktake struct {
+	Timestamp
+
+	ID          int             `json:"id" gorm:"primaryKey;autoIncrement"`
+	StoreID     string          `json:"store_id"`
+	Status      StocktakeStatus `json:"status"`
+	Note        string          `json:"note"`
+	SnapshotAt  time.Time       `json:"snapshot_at"`
+	ClosedAt    *time.Time      `json:"closed_at"`
+	CompletedAt *time.Time      `json:"completed_at"`
+}
+
+// StocktakeItem は棚卸対象の在庫1件分の帳簿数量と実数
+type StocktakeItem struct {
+	Timestamp
+
+	ID              int                  `json:"id" gorm:"primaryKey;autoIncrement"`
+	StocktakeID     int                  `json:"stocktake_id"`
+	StockID         int                  `json:"stock_id"`
+	Name            string               `json:"name"`
+	Price           int                  `json:"price"`
+	SystemQuantity  int                  `json:"system_quantity"`
+	CountedQuantity *int                 `json:"counted_quantity"`
+	ReasonCode      *StocktakeReasonCode `json:"reason_code"`
+	Note            string               `json:"note"`
+	ApprovedAt      *time.Time           `json:"approved_at"`
+	// 承認時に在庫数量に反映した調整量。スナップショット以降の入出庫を考慮するため、実数と帳簿数量の差と異なる場合がある
+	AdjustedQuantity *int `json:"adjusted_quantity"`
+	MovementID       *int `json:"movement_id"`
+}
+
+// Variance は差異を返す。承認済みの場合は在庫数量に反映した調整量、未承認の場合は実数と帳簿数量の差。未カウントの場合はnil
+func (i *StocktakeItem) Variance() *int {
+	if i.CountedQuantity == nil {
+		return nil
+	}
+	if i.ApprovedAt != nil && i.AdjustedQuantity != nil {
+		v := *i.AdjustedQuantity
+
+		return &v
+	}
+	v := *i.CountedQuantity - i.SystemQuantity
+
+	return &v
+}
+
+// StocktakeCount は端末ごとのカウント結果
+// 同じ在庫を複数の端末で数えた場合は合計を実数とする
+type StocktakeCount struct {
+	Timestamp
+
+	ID          int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	StocktakeID int       `json:"stocktake_id"`
+	StockID     int       `json:"stock_id"`
+	DeviceID    string    `json:"device_id"`
+	Quantity    int       `json:"quantity"`
+	CountedAt   time.Time `json:"counted_at"`
+}
+
+// StocktakeVariance は差異一覧の1行
+type StocktakeVariance struct {
+	StocktakeItem
+	Variance      *int `json:"variance"`
+	VarianceValue *int `json:"variance_value"`
+}
+
+// StocktakeReport は棚卸結果の報告書
+type StocktakeReport struct {
+	Stocktake        *Stocktake              `json:"stocktake"`
+	TotalItems       int                     `json:"total_items"`
+	CountedItems     int                     `json:"counted_items"`
+	UncountedItems   int                     `json:"uncounted_items"`
+	DiscrepancyItems int                     `json:"discrepancy_items"`
+	ApprovedItems    int                     `json:"approved_items"`
+	SystemQuantity   int                     `json:"system_quantity"`
+	CountedQuantity  int                     `json:"counted_quantity"`
+	VarianceQuantity int                     `json:"variance_quantity"`
+	VarianceValue    int                     `json:"variance_value"`
+	ByReason         []*StocktakeReasonTotal `json:"by_reason"`
+	Items            []*StocktakeVariance    `json:"items"`
+}
+
+type StocktakeReasonTotal struct {
+	ReasonCode StocktakeReasonCode `json:"reason_code"`
+	Items      int                 `json:"items"`
+	Quantity   int                 `json:"quantity"`
+	Value      int                 `json:"value"`
+}
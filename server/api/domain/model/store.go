@@ -7,6 +7,10 @@ type Store struct {
 	Name        string `json:"name"`
 	ZipCode     string `json:"zip_code"`
 	Address     string `json:"address"`
+	Prefecture  string `json:"prefecture"`
+	City        string `json:"city"`
+	Street      string `json:"street"`
+	Building    string `json:"building"`
 	PhoneNumber string `json:"phone_number"`
 	TenantID    string `json:"tenant_id"`
 	// リレーション (hasMany)
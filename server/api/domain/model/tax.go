@@ -0,0 +1,40 @@
+package model
+
+import "sort"
+
+const (
+	StandardTaxRate = 10 // 標準税率 (%)
+	ReducedTaxRate  = 8  // 軽減税率 (%)
+)
+
+// TaxBreakdown は税率ごとの対象金額 (税込) と消費税額
+type TaxBreakdown struct {
+	Rate    int  `json:"rate" example:"10"`
+	Reduced bool `json:"reduced" example:"false"`
+	Amount  int  `json:"amount" example:"110000"`
+	Tax     int  `json:"tax" example:"10000"`
+}
+
+// IncludedTax は税込金額に含まれる消費税額を1円未満切り捨てで求める
+func IncludedTax(amount, rate int) int {
+	return amount * rate / (100 + rate)
+}
+
+// NewTaxBreakdowns は税率ごとの税込金額から消費税額を求め、税率の高い順に並べる
+// 端数処理は明細ごとではなく税率ごとに1回だけ行う
+func NewTaxBreakdowns(amounts map[int]int) TaxBreakdowns {
+	taxes := make(TaxBreakdowns, 0, len(amounts))
+	for rate, amount := range amounts {
+		taxes = append(taxes, &TaxBreakdown{
+			Rate:    rate,
+			Reduced: rate == ReducedTaxRate,
+			Amount:  amount,
+			Tax:     IncludedTax(amount, rate),
+		})
+	}
+	sort.Slice(taxes, func(i, j int) bool {
+		return taxes[i].Rate > taxes[j].Rate
+	})
+
+	return taxes
+}
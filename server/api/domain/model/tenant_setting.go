@@ -0,0 +1,32 @@
+package model
+
+type ValuationMethod string
+
+const (
+	ValuationMovingAverage ValuationMethod = "MOVING_AVERAGE" // 移動平均法
+	ValuationFIFO          ValuationMethod = "FIFO"           // 先入先出法
+)
+
+// TenantSetting はテナント単位の業務設定
+type TenantSetting struct {
+	Timestamp
+
+	TenantID        string          `json:"tenant_id" gorm:"primaryKey;type:uuid"`
+	ValuationMethod ValuationMethod `json:"valuation_method"`
+	// 納品済みの発注で何円ごとに1ポイント付与するか。0の場合は付与しない
+	PointEarnUnit int `json:"point_earn_unit" example:"100"`
+	// 付与したポイントの有効期限 (月数)。0の場合は無期限
+	PointExpiryMonths int `json:"point_expiry_months" example:"12"`
+	// 自動値下げで販売価格を取得原価より下げないか
+	MarkdownFloorAtCost bool `json:"markdown_floor_at_cost" example:"true"`
+}
+
+// DefaultTenantSetting は設定が未登録のテナントに適用する既定値
+func DefaultTenantSetting(tenantID string) *TenantSetting {
+	return &TenantSetting{
+		TenantID:            tenantID,
+		ValuationMethod:     ValuationMovingAverage,
+		PointExpiryMonths:   12,
+		MarkdownFloorAtCost: true,
+	}
+}
@@ -0,0 +1,227 @@
+// phone は電話番号をE.164形式と表示用の形式にそろえる
+package phone
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultCountry は国が指定されない場合の国
+const DefaultCountry = "JP"
+
+// Number は正規化した電話番号
+type Number struct {
+	// E.164形式 (例: +819012345678)
+	E164 string
+	// 表示用。日本の番号は市外局番から始まるハイフン区切り、その他の国は国番号付き
+	Display string
+	// ISO 3166-1 alpha-2の国コード。国番号から国を特定できない場合は空
+	Country string
+}
+
+type country struct {
+	// ISO 3166-1 alpha-2の国コード
+	iso  string
+	code string
+	// 国内の番号の先頭に付けるトランクプレフィックス0を使うか
+	trunk bool
+	// トランクプレフィックスを除いた国内の番号の桁数
+	min, max int
+}
+
+// countries は国内表記での入力を受け付ける国。国番号から国を推定する際は先に書いた国を優先する
+var countries = []country{
+	{iso: "JP", code: "81", trunk: true, min: 9, max: 10},
+	{iso: "US", code: "1", min: 10, max: 10},
+	{iso: "CA", code: "1", min: 10, max: 10},
+	{iso: "GB", code: "44", trunk: true, min: 9, max: 10},
+	{iso: "FR", code: "33", trunk: true, min: 9, max: 9},
+	{iso: "DE", code: "49", trunk: true, min: 6, max: 13},
+	{iso: "AU", code: "61", trunk: true, min: 9, max: 9},
+	{iso: "CN", code: "86", trunk: true, min: 9, max: 11},
+	{iso: "KR", code: "82", trunk: true, min: 8, max: 10},
+	{iso: "TW", code: "886", trunk: true, min: 8, max: 9},
+	{iso: "HK", code: "852", min: 8, max: 8},
+	{iso: "SG", code: "65", min: 8, max: 8},
+	{iso: "TH", code: "66", trunk: true, min: 8, max: 9},
+	{iso: "VN", code: "84", trunk: true, min: 9, max: 10},
+	{iso: "PH", code: "63", trunk: true, min: 9, max: 10},
+	{iso: "ID", code: "62", trunk: true, min: 8, max: 12},
+	{iso: "MY", code: "60", trunk: true, min: 8, max: 10},
+	{iso: "IN", code: "91", trunk: true, min: 10, max: 10},
+}
+
+func findCountry(iso string) (country, bool) {
+	for _, c := range countries {
+		if c.iso == iso {
+			return c, true
+		}
+	}
+
+	return country{}, false
+}
+
+// IsSupportedCountry は国内表記での入力を受け付ける国かを返す
+func IsSupportedCountry(iso string) bool {
+	_, ok := findCountry(iso)
+
+	return ok
+}
+
+// Parse は電話番号を正規化する
+//
+// +から始まる番号は国際表記として扱い、それ以外は countryCode の国内表記として扱う (空の場合は日本)。
+// 全角数字と、ハイフン・括弧・ピリオド・空白による区切りを許容する
+func Parse(s, countryCode string) (Number, bool) {
+	s = strings.TrimSpace(norm.NFKC.String(s))
+	international := strings.HasPrefix(s, "+")
+	if international {
+		s = s[1:]
+	}
+
+	var digits strings.Builder
+	var groups []string
+	var group strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+			group.WriteRune(r)
+		case r == '-' || r == ' ' || r == '(' || r == ')' || r == '.':
+			if group.Len() > 0 {
+				groups = append(groups, group.String())
+				group.Reset()
+			}
+		default:
+			return Number{}, false
+		}
+	}
+	if group.Len() > 0 {
+		groups = append(groups, group.String())
+	}
+
+	if international {
+		return parseInternational(digits.String(), countryCode, groups)
+	}
+
+	if countryCode == "" {
+		countryCode = DefaultCountry
+	}
+	c, ok := findCountry(countryCode)
+	if !ok {
+		return Number{}, false
+	}
+
+	national := digits.String()
+	if countryCode == "JP" && (!strings.HasPrefix(national, "0") || strings.HasPrefix(national, "00")) {
+		return Number{}, false
+	}
+	if c.trunk {
+		national = strings.TrimPrefix(national, "0")
+	} else if c.code == "1" && len(national) == 11 {
+		national = strings.TrimPrefix(national, "1")
+	}
+
+	return newNumber(c, national, groups)
+}
+
+func parseInternational(digits, countryCode string, groups []string) (Number, bool) {
+	if countryCode != "" {
+		c, ok := findCountry(countryCode)
+		if !ok || !strings.HasPrefix(digits, c.code) {
+			return Number{}, false
+		}
+
+		return newNumber(c, nationalOf(c, digits), groups)
+	}
+
+	for _, c := range countries {
+		if strings.HasPrefix(digits, c.code) {
+			return newNumber(c, nationalOf(c, digits), groups)
+		}
+	}
+
+	// 国内表記に対応していない国は E.164 の桁数のみ検証する
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return Number{}, false
+	}
+
+	return Number{E164: "+" + digits, Display: "+" + strings.Join(groups, " ")}, true
+}
+
+// nationalOf は国際表記の番号から国番号と、誤って付けたトランクプレフィックスを除く
+func nationalOf(c country, digits string) string {
+	national := strings.TrimPrefix(digits, c.code)
+	if c.trunk {
+		national = strings.TrimPrefix(national, "0")
+	}
+
+	return national
+}
+
+func newNumber(c country, national string, groups []string) (Number, bool) {
+	if len(national) < c.min || len(national) > c.max || national[0] == '0' {
+		return Number{}, false
+	}
+
+	n := Number{E164: "+" + c.code + national, Country: c.iso}
+	if c.iso == "JP" {
+		n.Display = displayJP("0"+national, groups)
+	} else {
+		n.Display = displayInternational(c, national, groups)
+	}
+
+	return n, true
+}
+
+// displayInternational は入力の区切りを残して国番号付きの表記にする
+func displayInternational(c country, national string, groups []string) string {
+	joined := strings.Join(groups, "")
+	switch {
+	case len(groups) > 1 && joined == c.code+national && groups[0] == c.code:
+		return "+" + strings.Join(groups, " ")
+	case len(groups) > 1 && (joined == national || joined == "0"+national):
+		groups = append([]string{}, groups...)
+		groups[0] = strings.TrimPrefix(groups[0], "0")
+		if groups[0] == "" {
+			groups = groups[1:]
+		}
+
+		return "+" + c.code + " " + strings.Join(groups, " ")
+	default:
+		return "+" + c.code + " " + national
+	}
+}
+
+// displayJP は入力がハイフン等で区切られていればその区切りを使い、なければ番号の種類から区切る
+func displayJP(national string, groups []string) string {
+	if len(groups) > 1 && strings.Join(groups, "") == national {
+		return strings.Join(groups, "-")
+	}
+
+	var sizes []int
+	switch {
+	case len(national) == 11 && (strings.HasPrefix(national, "070") || strings.HasPrefix(national, "080") ||
+		strings.HasPrefix(national, "090") || strings.HasPrefix(national, "050") || strings.HasPrefix(national, "020")):
+		sizes = []int{3, 4, 4}
+	case len(national) == 10 && (strings.HasPrefix(national, "0120") || strings.HasPrefix(national, "0570")):
+		sizes = []int{4, 3, 3}
+	case len(national) == 11 && strings.HasPrefix(national, "0800"):
+		sizes = []int{4, 3, 4}
+	case len(national) == 10 && (strings.HasPrefix(national, "03") || strings.HasPrefix(national, "06")):
+		sizes = []int{2, 4, 4}
+	case len(national) == 10:
+		sizes = []int{3, 3, 4}
+	default:
+		return national
+	}
+
+	parts := make([]string, 0, len(sizes))
+	for _, size := range sizes {
+		parts = append(parts, national[:size])
+		national = national[size:]
+	}
+
+	return strings.Join(parts, "-")
+}
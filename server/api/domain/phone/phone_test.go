@@ -0,0 +1,94 @@
+package phone_test
+
+import (
+	"testing"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/phone"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		country string
+		want    phone.Number
+		wantOK  bool
+	}{
+		{
+			name: "mobile with hyphens", s: "090-1234-5678",
+			want: phone.Number{E164: "+819012345678", Display: "090-1234-5678", Country: "JP"}, wantOK: true,
+		},
+		{
+			name: "mobile without separators", s: "09012345678",
+			want: phone.Number{E164: "+819012345678", Display: "090-1234-5678", Country: "JP"}, wantOK: true,
+		},
+		{
+			name: "full width with parentheses", s: "０３（１２３４）５６７８",
+			want: phone.Number{E164: "+81312345678", Display: "03-1234-5678", Country: "JP"}, wantOK: true,
+		},
+		{
+			name: "tokyo landline", s: "0312345678",
+			want: phone.Number{E164: "+81312345678", Display: "03-1234-5678", Country: "JP"}, wantOK: true,
+		},
+		{
+			name: "toll free", s: "0120123456",
+			want: phone.Number{E164: "+81120123456", Display: "0120-123-456", Country: "JP"}, wantOK: true,
+		},
+		{
+			name: "three digit area code", s: "0451234567",
+			want: phone.Number{E164: "+81451234567", Display: "045-123-4567", Country: "JP"}, wantOK: true,
+		},
+		{
+			name: "japanese number in international format", s: "+81 90 1234 5678",
+			want: phone.Number{E164: "+819012345678", Display: "090-1234-5678", Country: "JP"}, wantOK: true,
+		},
+		{
+			name: "international format with trunk prefix", s: "+81 090-1234-5678",
+			want: phone.Number{E164: "+819012345678", Display: "090-1234-5678", Country: "JP"}, wantOK: true,
+		},
+		{
+			name: "us national format", s: "(212) 555-0123", country: "US",
+			want: phone.Number{E164: "+12125550123", Display: "+1 212 555 0123", Country: "US"}, wantOK: true,
+		},
+		{
+			name: "us national format with country code", s: "1-212-555-0123", country: "US",
+			want: phone.Number{E164: "+12125550123", Display: "+1 212 555 0123", Country: "US"}, wantOK: true,
+		},
+		{
+			name: "shared country code prefers first country", s: "+1 212 555 0123",
+			want: phone.Number{E164: "+12125550123", Display: "+1 212 555 0123", Country: "US"}, wantOK: true,
+		},
+		{
+			name: "shared country code with explicit country", s: "+1 212 555 0123", country: "CA",
+			want: phone.Number{E164: "+12125550123", Display: "+1 212 555 0123", Country: "CA"}, wantOK: true,
+		},
+		{
+			name: "uk national format", s: "020 7946 0958", country: "GB",
+			want: phone.Number{E164: "+442079460958", Display: "+44 20 7946 0958", Country: "GB"}, wantOK: true,
+		},
+		{
+			name: "unsupported country in international format", s: "+971 50 123 4567",
+			want: phone.Number{E164: "+971501234567", Display: "+971 50 123 4567"}, wantOK: true,
+		},
+		{name: "japanese number without trunk prefix", s: "12345678"},
+		{name: "international prefix in national format", s: "0012345678"},
+		{name: "letters", s: "090-1234-567a"},
+		{name: "too short", s: "090123"},
+		{name: "too long", s: "090123456789"},
+		{name: "country code does not match country", s: "+81 90 1234 5678", country: "US"},
+		{name: "unsupported country", s: "090-1234-5678", country: "XX"},
+		{name: "international number starting with 0", s: "+0123456789"},
+		{name: "empty", s: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := phone.Parse(tt.s, tt.country)
+			if ok != tt.wantOK {
+				t.Fatalf("Parse(%q, %q) ok = %v, want %v", tt.s, tt.country, ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q, %q) = %+v, want %+v", tt.s, tt.country, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package valuation は在庫の入出庫履歴から棚卸資産の評価額を計算する
+package valuation
+
+import (
+	"math"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+)
+
+// Calculator は1在庫分の入出庫履歴を発生順に受け取り、評価額を計算する
+//
+// 取得原価が不明な入庫(移行時の期首残高など)は原価不明数量として別に集計する
+// 出庫は原価不明分から先に払い出す
+type Calculator struct {
+	method model.ValuationMethod
+
+	// 移動平均法
+	knownQuantity int
+	averageCost   float64
+	// 先入先出法
+	layers []layer
+
+	unknownQuantity int
+	lastCost        *float64
+}
+
+type layer struct {
+	quantity int
+	cost     float64
+}
+
+type Result struct {
+	Quantity            int `json:"quantity"`
+	Value               int `json:"value"`
+	UnitCost            int `json:"unit_cost"`
+	UnknownCostQuantity int `json:"unknown_cost_quantity"`
+}
+
+func NewCalculator(method model.ValuationMethod) *Calculator {
+	return &Calculator{method: method}
+}
+
+// Add は入出庫を1件反映する。発生順に呼び出す必要がある
+func (c *Calculator) Add(m *model.StockMovement) {
+	if m.Quantity > 0 {
+		c.receive(m.Quantity, m.UnitCost)
+	} else if m.Quantity < 0 {
+		c.issue(-m.Quantity)
+	}
+}
+
+func (c *Calculator) receive(quantity int, unitCost *int) {
+	var cost *float64
+	if unitCost != nil {
+		v := float64(*unitCost)
+		cost = &v
+		c.lastCost = cost
+	} else {
+		// 返品・数量調整など原価を伴わない入庫は現在の単価で受け入れる
+		cost = c.currentCost()
+	}
+
+	if cost == nil {
+		c.unknownQuantity += quantity
+
+		return
+	}
+
+	switch c.method {
+	case model.ValuationFIFO:
+		c.layers = append(c.layers, layer{quantity: quantity, cost: *cost})
+	default:
+		total := float64(c.knownQuantity)*c.averageCost + float64(quantity)*(*cost)
+		c.knownQuantity += quantity
+		if c.knownQuantity != 0 {
+			c.averageCost = total / float64(c.knownQuantity)
+		}
+	}
+}
+
+func (c *Calculator) issue(quantity int) {
+	fromUnknown := min(quantity, max(c.unknownQuantity, 0))
+	c.unknownQuantity -= fromUnknown
+	quantity -= fromUnknown
+	if quantity == 0 {
+		return
+	}
+
+	switch c.method {
+	case model.ValuationFIFO:
+		for quantity > 0 && len(c.layers) > 0 {
+			take := min(quantity, c.layers[0].quantity)
+			c.layers[0].quantity -= take
+			quantity -= take
+			if c.layers[0].quantity == 0 {
+				c.layers = c.layers[1:]
+			}
+		}
+		if quantity > 0 {
+			// 入庫を超える出庫はマイナス在庫として直近の単価で保持する
+			cost := 0.0
+			if c.lastCost != nil {
+				cost = *c.lastCost
+			}
+			c.layers = append(c.layers, layer{quantity: -quantity, cost: cost})
+		}
+	default:
+		if c.knownQuantity == 0 && c.lastCost != nil {
+			c.averageCost = *c.lastCost
+		}
+		c.knownQuantity -= quantity
+	}
+}
+
+func (c *Calculator) currentCost() *float64 {
+	if c.method != model.ValuationFIFO && c.knownQuantity > 0 {
+		avg := c.averageCost
+
+		return &avg
+	}
+
+	return c.lastCost
+}
+
+func (c *Calculator) Result() Result {
+	quantity := c.unknownQuantity
+	value := 0.0
+
+	switch c.method {
+	case model.ValuationFIFO:
+		for _, l := range c.layers {
+			quantity += l.quantity
+			value += float64(l.quantity) * l.cost
+		}
+	default:
+		quantity += c.knownQuantity
+		value = float64(c.knownQuantity) * c.averageCost
+	}
+
+	r := Result{
+		Quantity:            quantity,
+		Value:               int(math.Round(value)),
+		UnknownCostQuantity: c.unknownQuantity,
+	}
+	if known := quantity - c.unknownQuantity; known != 0 {
+		r.UnitCost = int(math.Round(value / float64(known)))
+	}
+
+	return r
+}
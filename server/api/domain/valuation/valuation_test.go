@@ -0,0 +1,105 @@
+package valuation_test
+
+import (
+	"testing"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/valuation"
+)
+
+// movement は入出庫1件。costがnilの場合は取得原価を伴わない
+type movement struct {
+	quantity int
+	cost     *int
+}
+
+func cost(v int) *int {
+	return &v
+}
+
+func TestCalculator(t *testing.T) {
+	tests := []struct {
+		name      string
+		movements []movement
+		want      map[model.ValuationMethod]valuation.Result
+	}{
+		{
+			name:      "no movements",
+			movements: nil,
+			want: map[model.ValuationMethod]valuation.Result{
+				model.ValuationFIFO:          {},
+				model.ValuationMovingAverage: {},
+			},
+		},
+		{
+			name:      "issue from oldest layer or average",
+			movements: []movement{{10, cost(100)}, {10, cost(200)}, {-15, nil}},
+			want: map[model.ValuationMethod]valuation.Result{
+				model.ValuationFIFO:          {Quantity: 5, Value: 1000, UnitCost: 200},
+				model.ValuationMovingAverage: {Quantity: 5, Value: 750, UnitCost: 150},
+			},
+		},
+		{
+			name:      "unknown cost is issued first",
+			movements: []movement{{5, nil}, {10, cost(100)}, {-7, nil}},
+			want: map[model.ValuationMethod]valuation.Result{
+				model.ValuationFIFO:          {Quantity: 8, Value: 800, UnitCost: 100},
+				model.ValuationMovingAverage: {Quantity: 8, Value: 800, UnitCost: 100},
+			},
+		},
+		{
+			name:      "only unknown cost",
+			movements: []movement{{4, nil}},
+			want: map[model.ValuationMethod]valuation.Result{
+				model.ValuationFIFO:          {Quantity: 4, UnknownCostQuantity: 4},
+				model.ValuationMovingAverage: {Quantity: 4, UnknownCostQuantity: 4},
+			},
+		},
+		{
+			name:      "return after selling out uses last cost",
+			movements: []movement{{10, cost(100)}, {-10, nil}, {2, nil}},
+			want: map[model.ValuationMethod]valuation.Result{
+				model.ValuationFIFO:          {Quantity: 2, Value: 200, UnitCost: 100},
+				model.ValuationMovingAverage: {Quantity: 2, Value: 200, UnitCost: 100},
+			},
+		},
+		{
+			name:      "return is received at last cost or current average",
+			movements: []movement{{2, cost(100)}, {2, cost(300)}, {-1, nil}, {1, nil}},
+			want: map[model.ValuationMethod]valuation.Result{
+				model.ValuationFIFO:          {Quantity: 4, Value: 1000, UnitCost: 250},
+				model.ValuationMovingAverage: {Quantity: 4, Value: 800, UnitCost: 200},
+			},
+		},
+		{
+			name:      "negative stock keeps last cost",
+			movements: []movement{{5, cost(100)}, {-8, nil}},
+			want: map[model.ValuationMethod]valuation.Result{
+				model.ValuationFIFO:          {Quantity: -3, Value: -300, UnitCost: 100},
+				model.ValuationMovingAverage: {Quantity: -3, Value: -300, UnitCost: 100},
+			},
+		},
+		{
+			name:      "value is rounded to yen",
+			movements: []movement{{3, cost(100)}, {1, cost(101)}},
+			want: map[model.ValuationMethod]valuation.Result{
+				model.ValuationFIFO:          {Quantity: 4, Value: 401, UnitCost: 100},
+				model.ValuationMovingAverage: {Quantity: 4, Value: 401, UnitCost: 100},
+			},
+		},
+	}
+	for _, tt := range tests {
+		for method, want := range tt.want {
+			t.Run(tt.name+"/"+string(method), func(t *testing.T) {
+				c := valuation.NewCalculator(method)
+				for _, m := range tt.movements {
+					c.Add(&model.StockMovement{Quantity: m.quantity, UnitCost: m.cost})
+				}
+
+				if got := c.Result(); got != want {
+					t.Errorf("Result() = %+v, want %+v", got, want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// LookupAddress godoc
+//
+//	@Summary		郵便番号からの住所の検索
+//	@Description	郵便番号に対応する都道府県・市区町村・町域を取得する。入力フォームの自動補完用
+//	@Description	1つの郵便番号に複数の町域が対応する場合は全件を返す
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			zip	query		string	true	"郵便番号（ハイフンの有無は問わない）"
+//	@Success		200	{object}	[]model.AddressCandidate
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/addresses/lookup [get]
+func (h *Handler) LookupAddress(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.LookupAddressRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	candidates, err := h.Usecase.LookupAddress(ctx, req.Zip)
+	if errors.Is(err, usecase.ErrInvalidPostalCode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrUnknownPostalCode) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, candidates)
+}
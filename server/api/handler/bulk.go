@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// errNullBulkItem は一括作成の配列にnullが含まれる場合のエラー
+var errNullBulkItem = errors.New("item must not be null")
+
+// validateBulkItem は一括作成の1件を検証する
+func validateBulkItem(c echo.Context, item interface{}) error {
+	if v := reflect.ValueOf(item); v.Kind() == reflect.Pointer && v.IsNil() {
+		return errNullBulkItem
+	}
+
+	return c.Validate(item)
+}
+
+// bulkResponse は一括作成の1件ごとの結果を返す
+// 全件一括で失敗した場合も、どの行が原因かわかるよう結果を返す
+func bulkResponse(c echo.Context, result *model.BulkResult, err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrTooManyBulkItems):
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err).
+			WithInternal(err)
+	case errors.Is(err, usecase.ErrInvalidBulkItems),
+		errors.Is(err, gorm.ErrForeignKeyViolated),
+		errors.Is(err, gorm.ErrRecordNotFound),
+		errors.Is(err, usecase.ErrPointsExceedAmount),
+		errors.Is(err, usecase.ErrInvalidCoupon):
+		return c.JSON(http.StatusBadRequest, result)
+	case errors.Is(err, gorm.ErrDuplicatedKey),
+		errors.Is(err, usecase.ErrInsufficientPoints),
+		errors.Is(err, usecase.ErrPromotionNotApplicable):
+		return c.JSON(http.StatusConflict, result)
+	case err != nil:
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	case result.Failed > 0:
+		return c.JSON(http.StatusMultiStatus, result)
+	default:
+		return c.JSON(http.StatusCreated, result)
+	}
+}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
 	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
 	"github.com/labstack/echo/v4"
 )
@@ -12,13 +15,15 @@ import (
 //
 //	@Summary		顧客一覧の取得
 //	@Description	顧客一覧の取得
-//	@Produce		json
+//	@Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 //	@Security		ApiKeyAuth
-//	@Param			limit	query		int		false	"取得件数"	minimum(0)	example(10)
-//	@Param			offset	query		int		false	"取得開始位置"	minimum(0)	example(0)
-//	@Success		200	{object}	[]model.Customer
-//	@Failure		400	{object}	error
-//	@Failure		500	{object}	error
+//	@Param			limit		query		int		false	"取得件数"								minimum(0)	example(10)
+//	@Param			offset		query		int		false	"取得開始位置"							minimum(0)	example(0)
+//	@Param			format		query		string	false	"出力形式（指定時は件数の指定がなければ全件をファイルで出力）"	Enums(csv, xlsx)
+//	@Param			encoding	query		string	false	"CSVの文字コード（既定はBOM付きUTF-8）"			Enums(utf-8, shift_jis)
+//	@Success		200			{object}	[]model.Customer
+//	@Failure		400			{object}	error
+//	@Failure		500			{object}	error
 //	@Router			/customers [get]
 func (h *Handler) GetCustomers(c echo.Context) error {
 	ctx := h.GetCtx(c)
@@ -34,11 +39,24 @@ func (h *Handler) GetCustomers(c echo.Context) error {
 			WithInternal(err)
 	}
 
-	customers, err := h.Usecase.GetCustomers(ctx, usecaseRequest.GetCustomersRequest{
+	input := usecaseRequest.GetCustomersRequest{
 		TenantID: c.Get("tenant_id").(string),
 		Limit:    req.Limit,
 		Offset:   req.Offset,
-	})
+	}
+
+	if req.Format != nil {
+		if err := h.export(c, req.ExportRequest, "customers", func(w sheet.Writer) error {
+			return h.Usecase.ExportCustomers(ctx, input, w)
+		}); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err).
+				WithInternal(err)
+		}
+
+		return nil
+	}
+
+	customers, err := h.Usecase.GetCustomers(ctx, input)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -85,6 +103,8 @@ func (h *Handler) GetCustomer(c echo.Context) error {
 //
 //	@Summary		顧客の作成
 //	@Description	顧客の作成
+//	@Description	都道府県を指定した場合は分割した住所から address を作り、指定しない場合は address を分割して保存する
+//	@Description	郵便番号は郵便番号データにある場合、住所と一致する必要がある
 //	@Accept			json
 //	@Produce		json
 //	@Security		ApiKeyAuth
@@ -108,12 +128,22 @@ func (h *Handler) CreateCustomer(c echo.Context) error {
 	}
 
 	customerID, err := h.Usecase.CreateCustomer(ctx, usecaseRequest.CreateCustomerRequest{
-		TenantID:    req.TenantID,
-		Name:        req.Name,
-		Email:       req.Email,
-		PhoneNumber: req.PhoneNumber,
-		Address:     req.Address,
+		TenantID:     req.TenantID,
+		Name:         req.Name,
+		Email:        req.Email,
+		PhoneNumber:  req.PhoneNumber,
+		PhoneCountry: req.PhoneCountry,
+		Address:      req.Address,
+		PostalCode:   req.PostalCode,
+		Prefecture:   req.Prefecture,
+		City:         req.City,
+		Street:       req.Street,
+		Building:     req.Building,
 	})
+	if isCustomerInputError(err) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -126,11 +156,12 @@ func (h *Handler) CreateCustomer(c echo.Context) error {
 //
 //	@Summary		顧客の更新
 //	@Description	顧客の更新
+//	@Description	住所の扱いは顧客の作成と同じ
 //	@Accept			json
 //	@Produce		json
 //	@Security		ApiKeyAuth
-//	@Param			id		path		string						true	"顧客ID"		format(uuid)
-//	@Param			req		body		request.UpdateCustomerRequest	true	"更新条件"
+//	@Param			id	path		string							true	"顧客ID"	format(uuid)
+//	@Param			req	body		request.UpdateCustomerRequest	true	"更新条件"
 //	@Success		200	{object}	model.Customer
 //	@Failure		400	{object}	error
 //	@Failure		500	{object}	error
@@ -150,13 +181,23 @@ func (h *Handler) UpdateCustomer(c echo.Context) error {
 	}
 
 	customer, err := h.Usecase.UpdateCustomer(ctx, usecaseRequest.UpdateCustomerRequest{
-		ID:          req.ID,
-		TenantID:    req.TenantID,
-		Name:        req.Name,
-		Email:       req.Email,
-		PhoneNumber: req.PhoneNumber,
-		Address:     req.Address,
+		ID:           req.ID,
+		TenantID:     req.TenantID,
+		Name:         req.Name,
+		Email:        req.Email,
+		PhoneNumber:  req.PhoneNumber,
+		PhoneCountry: req.PhoneCountry,
+		Address:      req.Address,
+		PostalCode:   req.PostalCode,
+		Prefecture:   req.Prefecture,
+		City:         req.City,
+		Street:       req.Street,
+		Building:     req.Building,
 	})
+	if isCustomerInputError(err) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -198,3 +239,11 @@ func (h *Handler) DeleteCustomer(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// isCustomerInputError は電話番号・住所の入力に誤りがある場合のエラーかを返す
+func isCustomerInputError(err error) bool {
+	return errors.Is(err, usecase.ErrInvalidPhoneNumber) ||
+		errors.Is(err, usecase.ErrInvalidPostalCode) ||
+		errors.Is(err, usecase.ErrPostalCodeMismatch) ||
+		errors.Is(err, usecase.ErrInvalidPrefecture)
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetCustomerConsents godoc
+//
+//	@Summary		顧客の同意状況の取得
+//	@Description	利用目的ごとの最新の同意状況と、同意・撤回の全ての記録を取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"顧客ID"
+//	@Success		200	{object}	model.CustomerConsents
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customers/{id}/consents [get]
+func (h *Handler) GetCustomerConsents(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetCustomerConsentsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	consents, err := h.Usecase.GetCustomerConsents(ctx, c.Get("tenant_id").(string), req.CustomerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, consents)
+}
+
+// RecordCustomerConsent godoc
+//
+//	@Summary		顧客の同意・撤回の記録
+//	@Description	利用目的ごとの同意・撤回を記録する。記録は追記のみで、同じ目的の最新の記録が現在の同意状況になる
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string									true	"顧客ID"
+//	@Param			req	body		request.RecordCustomerConsentRequest	true	"同意・撤回の内容"
+//	@Success		201	{object}	model.CustomerConsent
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customers/{id}/consents [post]
+func (h *Handler) RecordCustomerConsent(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.RecordCustomerConsentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	consent, err := h.Usecase.RecordCustomerConsent(ctx, usecaseRequest.RecordCustomerConsentRequest{
+		TenantID:   c.Get("tenant_id").(string),
+		CustomerID: req.CustomerID,
+		Purpose:    model.ConsentPurpose(req.Purpose),
+		Granted:    *req.Granted,
+		Source:     req.Source,
+		Note:       req.Note,
+		RecordedAt: req.RecordedAt,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrCustomerAnonymized) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, consent)
+}
+
+// GetCustomerPersonalData godoc
+//
+//	@Summary		顧客の保有個人データの開示
+//	@Description	本人からの開示請求に応じて、顧客情報・発注・同意の記録・統合履歴など顧客に紐づく全ての情報を出力する
+//	@Description	削除済みの顧客や、統合により削除された顧客の情報も含む
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"顧客ID"
+//	@Success		200	{object}	model.CustomerPersonalData
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customers/{id}/personal-data [get]
+func (h *Handler) GetCustomerPersonalData(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetCustomerPersonalDataRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	data, err := h.Usecase.GetCustomerPersonalData(ctx, c.Get("tenant_id").(string), req.CustomerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// AnonymizeCustomer godoc
+//
+//	@Summary		顧客の個人情報の匿名化
+//	@Description	本人からの削除請求に応じて、氏名・連絡先・住所を消去して顧客を削除済みにする。元に戻すことはできない
+//	@Description	発注は会計のために残す。統合された顧客と統合履歴の顧客情報も消去し、有効な同意は撤回として記録する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"顧客ID"
+//	@Success		200	{object}	model.CustomerAnonymization
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customers/{id}/anonymize [post]
+func (h *Handler) AnonymizeCustomer(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.AnonymizeCustomerRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	result, err := h.Usecase.AnonymizeCustomer(ctx, c.Get("tenant_id").(string), req.CustomerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrCustomerAnonymized) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
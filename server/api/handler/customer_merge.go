@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetCustomerDuplicates godoc
+//
+//	@Summary		重複顧客の候補の取得
+//	@Description	重複の可能性がある顧客の組を取得する
+//	@Description	電話番号（ハイフン・全角・+81の表記ゆれを除く）とメールアドレス（大文字・小文字を区別しない）は一致、氏名は類似度で判定する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			customer_id			query		string	false	"指定した顧客の重複候補のみを取得"	format(uuid)
+//	@Param			min_name_similarity	query		number	false	"氏名の類似度の下限（既定は0.8）"	minimum(0)	maximum(1)
+//	@Success		200					{object}	[]model.CustomerDuplicate
+//	@Failure		400					{object}	error
+//	@Failure		404					{object}	error
+//	@Failure		500					{object}	error
+//	@Router			/customers/duplicates [get]
+func (h *Handler) GetCustomerDuplicates(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetCustomerDuplicatesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	duplicates, err := h.Usecase.GetCustomerDuplicates(ctx, usecaseRequest.GetCustomerDuplicatesRequest{
+		TenantID:          c.Get("tenant_id").(string),
+		CustomerID:        req.CustomerID,
+		MinNameSimilarity: req.MinNameSimilarity,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, duplicates)
+}
+
+// MergeCustomers godoc
+//
+//	@Summary		顧客の統合
+//	@Description	統合元の顧客の発注を指定した顧客に付け替え、統合元の顧客を削除する
+//	@Description	統合元の顧客情報と付け替えた発注は統合履歴として残る
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string							true	"統合先の顧客ID"	format(uuid)
+//	@Param			req	body		request.MergeCustomersRequest	true	"統合元"
+//	@Success		200	{object}	[]model.CustomerMerge
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customers/{id}/merge [post]
+func (h *Handler) MergeCustomers(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.MergeCustomersRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	merges, err := h.Usecase.MergeCustomers(ctx, usecaseRequest.MergeCustomersRequest{
+		TenantID:  c.Get("tenant_id").(string),
+		TargetID:  req.CustomerID,
+		SourceIDs: req.SourceIDs,
+		Note:      req.Note,
+	})
+	if errors.Is(err, usecase.ErrMergeSameCustomer) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, merges)
+}
+
+// GetCustomerMerges godoc
+//
+//	@Summary		顧客の統合履歴の取得
+//	@Description	顧客が統合先または統合元になった履歴を新しい順に取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"顧客ID"	format(uuid)
+//	@Success		200	{object}	[]model.CustomerMerge
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customers/{id}/merges [get]
+func (h *Handler) GetCustomerMerges(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetCustomerMergesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	merges, err := h.Usecase.GetCustomerMerges(ctx, c.Get("tenant_id").(string), req.CustomerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, merges)
+}
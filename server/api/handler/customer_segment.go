@@ -0,0 +1,314 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetCustomerSegments godoc
+//
+//	@Summary		顧客セグメント一覧の取得
+//	@Description	保存した顧客の絞り込み条件を名前順に取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	[]model.CustomerSegment
+//	@Failure		500	{object}	error
+//	@Router			/customer-segments [get]
+func (h *Handler) GetCustomerSegments(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	segments, err := h.Usecase.GetCustomerSegments(ctx, c.Get("tenant_id").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, segments)
+}
+
+// GetCustomerSegment godoc
+//
+//	@Summary		顧客セグメントの取得
+//	@Description	顧客セグメントの取得
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"顧客セグメントID"
+//	@Success		200	{object}	model.CustomerSegment
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customer-segments/{id} [get]
+func (h *Handler) GetCustomerSegment(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetCustomerSegmentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	segment, err := h.Usecase.GetCustomerSegment(ctx, c.Get("tenant_id").(string), req.SegmentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, segment)
+}
+
+// CreateCustomerSegment godoc
+//
+//	@Summary		顧客セグメントの作成
+//	@Description	顧客の絞り込み条件を名前を付けて保存する。RFMスコアは定期的に算出し直される
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			req	body		request.CreateCustomerSegmentRequest	true	"作成条件"
+//	@Success		201	{object}	model.CustomerSegment
+//	@Failure		400	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customer-segments [post]
+func (h *Handler) CreateCustomerSegment(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.CreateCustomerSegmentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	segment, err := h.Usecase.CreateCustomerSegment(ctx, usecaseRequest.CreateCustomerSegmentRequest{
+		TenantID:    c.Get("tenant_id").(string),
+		Name:        req.Name,
+		Description: req.Description,
+		Filter:      model.SegmentFilter(req.Filter),
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, segment)
+}
+
+// UpdateCustomerSegment godoc
+//
+//	@Summary		顧客セグメントの更新
+//	@Description	顧客セグメントの更新
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int										true	"顧客セグメントID"
+//	@Param			req	body		request.UpdateCustomerSegmentRequest	true	"更新条件"
+//	@Success		200	{object}	model.CustomerSegment
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customer-segments/{id} [put]
+func (h *Handler) UpdateCustomerSegment(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.UpdateCustomerSegmentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	segment, err := h.Usecase.UpdateCustomerSegment(ctx, usecaseRequest.UpdateCustomerSegmentRequest{
+		TenantID:    c.Get("tenant_id").(string),
+		SegmentID:   req.SegmentID,
+		Name:        req.Name,
+		Description: req.Description,
+		Filter:      model.SegmentFilter(req.Filter),
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, segment)
+}
+
+// DeleteCustomerSegment godoc
+//
+//	@Summary		顧客セグメントの削除
+//	@Description	顧客セグメントの削除
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"顧客セグメントID"
+//	@Success		204	{string}	string
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customer-segments/{id} [delete]
+func (h *Handler) DeleteCustomerSegment(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.DeleteCustomerSegmentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	err := h.Usecase.DeleteCustomerSegment(ctx, c.Get("tenant_id").(string), req.SegmentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CountCustomerSegment godoc
+//
+//	@Summary		顧客セグメントの件数の取得
+//	@Description	顧客セグメントに該当する削除されていない顧客の件数を取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"顧客セグメントID"
+//	@Success		200	{object}	model.SegmentCount
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customer-segments/{id}/count [get]
+func (h *Handler) CountCustomerSegment(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetCustomerSegmentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	count, err := h.Usecase.CountCustomerSegment(ctx, c.Get("tenant_id").(string), req.SegmentID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, count)
+}
+
+// GetSegmentCustomers godoc
+//
+//	@Summary		顧客セグメントの顧客一覧の取得
+//	@Description	顧客セグメントに該当する削除されていない顧客を累計購入額の多い順に取得する
+//	@Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Security		ApiKeyAuth
+//	@Param			id			path		int		true	"顧客セグメントID"
+//	@Param			limit		query		int		false	"取得件数"								minimum(0)	example(10)
+//	@Param			offset		query		int		false	"取得開始位置"							minimum(0)	example(0)
+//	@Param			format		query		string	false	"出力形式（指定時は件数の指定がなければ全件をファイルで出力）"	Enums(csv, xlsx)
+//	@Param			encoding	query		string	false	"CSVの文字コード（既定はBOM付きUTF-8）"			Enums(utf-8, shift_jis)
+//	@Success		200			{object}	[]model.Customer
+//	@Failure		400			{object}	error
+//	@Failure		404			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/customer-segments/{id}/customers [get]
+func (h *Handler) GetSegmentCustomers(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetSegmentCustomersRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	input := usecaseRequest.GetSegmentCustomersRequest{
+		TenantID:  c.Get("tenant_id").(string),
+		SegmentID: req.SegmentID,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	}
+
+	if req.Format != nil {
+		err := h.export(c, req.ExportRequest, "segment_customers", func(w sheet.Writer) error {
+			return h.Usecase.ExportSegmentCustomers(ctx, input, w)
+		})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err).
+				WithInternal(err)
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err).
+				WithInternal(err)
+		}
+
+		return nil
+	}
+
+	customers, err := h.Usecase.GetSegmentCustomers(ctx, input)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, customers)
+}
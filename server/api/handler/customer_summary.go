@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetCustomerSummary godoc
+//
+//	@Summary		顧客の購入実績の取得
+//	@Description	累計購入額・発注件数・初回と最終の購入日時・平均購入額・よく購入する分類を取得する
+//	@Description	キャンセルされた発注は含めない
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"顧客ID"	format(uuid)
+//	@Success		200	{object}	model.CustomerSummary
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customers/{id}/summary [get]
+func (h *Handler) GetCustomerSummary(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetCustomerSummaryRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	summary, err := h.Usecase.GetCustomerSummary(ctx, c.Get("tenant_id").(string), req.CustomerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// GetCustomerOrders godoc
+//
+//	@Summary		顧客の発注履歴の取得
+//	@Description	顧客の発注を新しい順に取得する。件数の既定は20件、上限は100件
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string	true	"顧客ID"		format(uuid)
+//	@Param			limit	query		int		false	"取得件数"		minimum(1)	maximum(100)
+//	@Param			offset	query		int		false	"取得開始位置"	minimum(0)
+//	@Success		200		{object}	model.CustomerOrderHistory
+//	@Failure		400		{object}	error
+//	@Failure		404		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/customers/{id}/orders [get]
+func (h *Handler) GetCustomerOrders(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetCustomerOrdersRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	history, err := h.Usecase.GetCustomerOrders(ctx, usecaseRequest.GetCustomerOrdersRequest{
+		TenantID:   c.Get("tenant_id").(string),
+		CustomerID: req.CustomerID,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
+	"github.com/labstack/echo/v4"
+)
+
+// exportBufferSize はレスポンスを送り始めるまでにバッファする大きさ
+// 出力開始前に起きたエラーは通常のエラーレスポンスとして返せる
+const exportBufferSize = 64 * 1024
+
+// export は一覧をCSV・xlsxファイルとしてレスポンスに逐次書き出す
+func (h *Handler) export(c echo.Context, req request.ExportRequest, name string, write func(w sheet.Writer) error) error {
+	format := sheet.Format(*req.Format)
+	encoding := sheet.EncodingUTF8
+	if req.Encoding != nil {
+		encoding = sheet.Encoding(*req.Encoding)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, format.ContentType(encoding))
+	res.Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="%s_%s.%s"`, name, time.Now().Format("20060102150405"), format))
+
+	buf := bufio.NewWriterSize(res, exportBufferSize)
+	w, err := sheet.New(buf, format, encoding, name)
+	if err == nil {
+		err = write(w)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		// 送信済みの場合はステータスを変更できないため、エラーはログにのみ残る
+		if !res.Committed {
+			res.Header().Del(echo.HeaderContentType)
+			res.Header().Del(echo.HeaderContentDisposition)
+		}
+		return err
+	}
+
+	return buf.Flush()
+}
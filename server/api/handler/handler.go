@@ -25,6 +25,15 @@ func (h *Handler) AssignRoutes(e *echo.Echo) {
 	{
 		g.GET("/health", h.GetHealth)
 		g.GET("/swagger/*", echoSwagger.WrapHandler)
+		g.GET("/media/*", h.GetMedia)
+
+		/* tenant setting */
+		g.GET("/settings", h.GetTenantSetting)
+		g.PUT("/settings", h.UpdateTenantSetting)
+		g.GET("/settings/invoice", h.GetInvoiceIssuer)
+		g.PUT("/settings/invoice", h.UpdateInvoiceIssuer)
+		g.GET("/settings/receipt", h.GetReceiptTemplate)
+		g.PUT("/settings/receipt", h.UpdateReceiptTemplate)
 
 		/* user */
 		ug := g.Group("/users")
@@ -40,23 +49,88 @@ func (h *Handler) AssignRoutes(e *echo.Echo) {
 		sg := g.Group("/stocks")
 		{
 			sg.GET("", h.GetStocks)
+			sg.GET("/lookup", h.LookupStock)
 			sg.GET("/:id", h.GetStock)
+			sg.GET("/:id/barcode", h.GetStockBarcode)
+			sg.GET("/:id/movements", h.GetStockMovements)
+			sg.POST("/:id/receipts", h.ReceiveStock)
+			sg.GET("/:id/prices", h.GetStockPriceHistory)
+			sg.POST("/:id/price-changes", h.SchedulePriceChange)
+			sg.DELETE("/:id/price-changes/:change_id", h.CancelPriceChange)
+			sg.GET("/:id/images", h.GetStockImages)
+			sg.POST("/:id/images", h.UploadStockImage)
+			sg.PUT("/:id/images/order", h.ReorderStockImages)
+			sg.DELETE("/:id/images/:image_id", h.DeleteStockImage)
 			sg.POST("", h.CreateStock)
 			sg.POST("/bulk", h.CreateBulkStock)
+			sg.POST("/import", h.ImportStocks)
+			sg.POST("/labels", h.GenerateStockLabels)
 			sg.PUT("/:id", h.UpdateStock)
 			sg.DELETE("/:id", h.DeleteStock)
 		}
 
+		/* stocktake */
+		tg := g.Group("/stocktakes")
+		{
+			tg.GET("", h.GetStocktakes)
+			tg.GET("/:id", h.GetStocktake)
+			tg.GET("/:id/variances", h.GetStocktakeVariances)
+			tg.GET("/:id/report", h.GetStocktakeReport)
+			tg.POST("", h.StartStocktake)
+			tg.POST("/:id/counts", h.SubmitStocktakeCounts)
+			tg.POST("/:id/close", h.CloseStocktake)
+			tg.POST("/:id/approve", h.ApproveStocktakeItems)
+			tg.POST("/:id/complete", h.CompleteStocktake)
+			tg.POST("/:id/cancel", h.CancelStocktake)
+		}
+
 		/* customer */
 		cg := g.Group("/customers")
 		{
 			cg.GET("", h.GetCustomers)
+			cg.GET("/duplicates", h.GetCustomerDuplicates)
 			cg.GET("/:id", h.GetCustomer)
+			cg.GET("/:id/summary", h.GetCustomerSummary)
+			cg.GET("/:id/orders", h.GetCustomerOrders)
+			cg.GET("/:id/merges", h.GetCustomerMerges)
+			cg.POST("/:id/merge", h.MergeCustomers)
+			cg.GET("/:id/consents", h.GetCustomerConsents)
+			cg.POST("/:id/consents", h.RecordCustomerConsent)
+			cg.GET("/:id/personal-data", h.GetCustomerPersonalData)
+			cg.POST("/:id/anonymize", h.AnonymizeCustomer)
+			cg.GET("/:id/points", h.GetPointBalance)
+			cg.GET("/:id/points/history", h.GetPointHistory)
 			cg.POST("", h.CreateCustomer)
+			cg.POST("/import", h.ImportCustomers)
 			cg.PUT("/:id", h.UpdateCustomer)
 			cg.DELETE("/:id", h.DeleteCustomer)
 		}
 
+		/* customer segment */
+		csg := g.Group("/customer-segments")
+		{
+			csg.GET("", h.GetCustomerSegments)
+			csg.GET("/:id", h.GetCustomerSegment)
+			csg.GET("/:id/count", h.CountCustomerSegment)
+			csg.GET("/:id/customers", h.GetSegmentCustomers)
+			csg.POST("", h.CreateCustomerSegment)
+			csg.PUT("/:id", h.UpdateCustomerSegment)
+			csg.DELETE("/:id", h.DeleteCustomerSegment)
+		}
+
+		/* address */
+		ag := g.Group("/addresses")
+		{
+			ag.GET("/lookup", h.LookupAddress)
+		}
+
+		/* import job */
+		ig := g.Group("/import-jobs")
+		{
+			ig.GET("", h.GetImportJobs)
+			ig.GET("/:id", h.GetImportJob)
+		}
+
 		/* order */
 		og := g.Group("/orders")
 		{
@@ -65,6 +139,61 @@ func (h *Handler) AssignRoutes(e *echo.Echo) {
 			og.POST("", h.CreateOrder)
 			og.POST("/bulk", h.CreateBulkOrder)
 			og.PUT("/:id", h.UpdateOrder)
+			og.GET("/:id/payments", h.GetOrderPayments)
+			og.POST("/:id/payments", h.CreatePayment)
+			og.POST("/:id/refunds", h.CreateRefund)
+			og.GET("/:id/returns", h.GetReturnsOfOrder)
+			og.POST("/:id/returns", h.CreateOrderReturn)
+			og.GET("/:id/invoice.pdf", h.GetOrderInvoicePDF)
+			og.GET("/:id/invoices", h.GetOrderInvoices)
+			og.GET("/:id/receipt", h.GetOrderReceipt)
+			og.GET("/:id/receipt/preview", h.GetOrderReceiptPreview)
+		}
+
+		/* invoice */
+		ivg := g.Group("/invoices")
+		{
+			ivg.GET("/:id", h.GetInvoice)
+			ivg.GET("/:id/pdf", h.GetInvoicePDF)
+			ivg.POST("/:id/credit-note", h.CreateCreditNote)
+		}
+
+		/* order return */
+		rtg := g.Group("/returns")
+		{
+			rtg.GET("", h.GetOrderReturns)
+			rtg.GET("/:id", h.GetOrderReturn)
+			rtg.POST("/:id/inspect", h.InspectOrderReturn)
+			rtg.POST("/:id/reject", h.RejectOrderReturn)
+			rtg.POST("/:id/complete", h.CompleteOrderReturn)
+		}
+
+		/* promotion */
+		pg := g.Group("/promotions")
+		{
+			pg.GET("", h.GetPromotions)
+			pg.GET("/:id", h.GetPromotion)
+			pg.GET("/:id/coupons", h.GetCoupons)
+			pg.POST("", h.CreatePromotion)
+			pg.POST("/:id/coupons", h.CreateCoupon)
+			pg.PUT("/:id", h.UpdatePromotion)
+			pg.DELETE("/:id", h.DeletePromotion)
+			pg.DELETE("/:id/coupons/:coupon_id", h.DeleteCoupon)
+		}
+
+		/* markdown */
+		mg := g.Group("/markdown-rules")
+		{
+			mg.GET("", h.GetMarkdownRules)
+			mg.GET("/preview", h.PreviewMarkdowns)
+			mg.PUT("", h.UpdateMarkdownRules)
+		}
+
+		/* report */
+		rg := g.Group("/reports")
+		{
+			rg.GET("/inventory-valuation", h.GetInventoryValuation)
+			rg.GET("/sales", h.GetSalesReport)
 		}
 	}
 }
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// ImportStocks godoc
+//
+//	@Summary		在庫の取り込み
+//	@Description	CSV・Excelファイルから在庫を登録する。バーコードが一致する在庫は更新する
+//	@Description	列の対応付けを省略した場合は、項目名または在庫一覧の出力と同じ見出しの列を使う
+//	@Description	小さいファイルはその場で取り込んで結果を返し、大きいファイルは202を返してバックグラウンドで取り込む
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			file			formData	file	true	"取り込むファイル（CSVまたはxlsx）"
+//	@Param			dry_run			formData	bool	false	"検証のみ行い、登録・更新しない"
+//	@Param			encoding		formData	string	false	"CSVの文字コード（BOM付きの場合はUTF-8）"	Enums(utf-8, shift_jis)
+//	@Param			mapping			formData	string	false	"列見出しから項目名への対応（JSON）"		example({"商品名":"name"})
+//	@Param			default_user_id	formData	string	false	"担当者の列がない場合に設定する従業員ID"	format(uuid)
+//	@Success		200				{object}	model.ImportJob
+//	@Success		202				{object}	model.ImportJob
+//	@Failure		400				{object}	error
+//	@Failure		413				{object}	error
+//	@Failure		500				{object}	error
+//	@Router			/stocks/import [post]
+func (h *Handler) ImportStocks(c echo.Context) error {
+	var req request.ImportStocksRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	return h.importFile(c, req.ImportRequest, func(input usecaseRequest.ImportRequest) (*model.ImportJob, error) {
+		input.DefaultUserID = req.DefaultUserID
+		return h.Usecase.ImportStocks(h.GetCtx(c), input)
+	})
+}
+
+// ImportCustomers godoc
+//
+//	@Summary		顧客の取り込み
+//	@Description	CSV・Excelファイルから顧客を登録する。メールアドレスまたは電話番号が一致する顧客は更新する
+//	@Description	列の対応付けを省略した場合は、項目名または顧客一覧の出力と同じ見出しの列を使う
+//	@Description	小さいファイルはその場で取り込んで結果を返し、大きいファイルは202を返してバックグラウンドで取り込む
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			file		formData	file	true	"取り込むファイル（CSVまたはxlsx）"
+//	@Param			dry_run		formData	bool	false	"検証のみ行い、登録・更新しない"
+//	@Param			encoding	formData	string	false	"CSVの文字コード（BOM付きの場合はUTF-8）"	Enums(utf-8, shift_jis)
+//	@Param			mapping		formData	string	false	"列見出しから項目名への対応（JSON）"		example({"氏名":"name"})
+//	@Param			match_by	formData	string	false	"既存の顧客と照合する項目（既定はemail）"		Enums(email, phone_number)
+//	@Success		200			{object}	model.ImportJob
+//	@Success		202			{object}	model.ImportJob
+//	@Failure		400			{object}	error
+//	@Failure		413			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/customers/import [post]
+func (h *Handler) ImportCustomers(c echo.Context) error {
+	var req request.ImportCustomersRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	return h.importFile(c, req.ImportRequest, func(input usecaseRequest.ImportRequest) (*model.ImportJob, error) {
+		input.MatchBy = req.MatchBy
+		return h.Usecase.ImportCustomers(h.GetCtx(c), input)
+	})
+}
+
+// GetImportJobs godoc
+//
+//	@Summary		取り込み一覧の取得
+//	@Description	テナントの取り込みを新しい順に取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			limit	query		int	false	"取得件数"		minimum(0)	example(10)
+//	@Param			offset	query		int	false	"取得開始位置"	minimum(0)	example(0)
+//	@Success		200		{object}	[]model.ImportJob
+//	@Failure		400		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/import-jobs [get]
+func (h *Handler) GetImportJobs(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetImportJobsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	jobs, err := h.Usecase.GetImportJobs(ctx, usecaseRequest.GetImportJobsRequest{
+		TenantID: c.Get("tenant_id").(string),
+		Limit:    req.Limit,
+		Offset:   req.Offset,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, jobs)
+}
+
+// GetImportJob godoc
+//
+//	@Summary		取り込みの取得
+//	@Description	取り込みの状態・進捗・行ごとのエラーを取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"取り込みID"	minimum(1)
+//	@Success		200	{object}	model.ImportJob
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/import-jobs/{id} [get]
+func (h *Handler) GetImportJob(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetImportJobRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	job, err := h.Usecase.GetImportJob(ctx, c.Get("tenant_id").(string), req.JobID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// importFile はアップロードされたファイルを取り込みに渡す
+// その場で取り込んだ場合は200、バックグラウンドに回した場合は202と進捗の取得先を返す
+func (h *Handler) importFile(c echo.Context, req request.ImportRequest, start func(usecaseRequest.ImportRequest) (*model.ImportJob, error)) error {
+	var mapping model.ImportMapping
+	if req.Mapping != "" {
+		if err := json.Unmarshal([]byte(req.Mapping), &mapping); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err).
+				WithInternal(err)
+		}
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+	defer file.Close()
+
+	job, err := start(usecaseRequest.ImportRequest{
+		TenantID: c.Get("tenant_id").(string),
+		StoreID:  c.Get("store_id").(string),
+		FileName: fileHeader.Filename,
+		Size:     fileHeader.Size,
+		Body:     file,
+		Encoding: req.Encoding,
+		Mapping:  mapping,
+		DryRun:   req.DryRun,
+	})
+	if errors.Is(err, usecase.ErrImportTooLarge) {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrInvalidImportFile) || errors.Is(err, usecase.ErrInvalidImportMapping) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if job.Status == model.ImportPending {
+		c.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/v1/import-jobs/%d", job.ID))
+		return c.JSON(http.StatusAccepted, job)
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
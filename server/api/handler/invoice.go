@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetInvoiceIssuer godoc
+//
+//	@Summary		請求書の発行者情報の取得
+//	@Description	適格請求書に記載する登録番号・名称・所在地を取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	model.InvoiceIssuer
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/settings/invoice [get]
+func (h *Handler) GetInvoiceIssuer(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	issuer, err := h.Usecase.GetInvoiceIssuer(ctx, c.Get("tenant_id").(string))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, issuer)
+}
+
+// UpdateInvoiceIssuer godoc
+//
+//	@Summary		請求書の発行者情報の登録
+//	@Description	適格請求書に記載する登録番号・名称・所在地を登録する。発行済みの請求書の記載内容は変わらない
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			req	body		request.UpdateInvoiceIssuerRequest	true	"登録内容"
+//	@Success		200	{object}	model.InvoiceIssuer
+//	@Failure		400	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/settings/invoice [put]
+func (h *Handler) UpdateInvoiceIssuer(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.UpdateInvoiceIssuerRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	issuer, err := h.Usecase.UpdateInvoiceIssuer(ctx, usecaseRequest.UpdateInvoiceIssuerRequest{
+		TenantID:           c.Get("tenant_id").(string),
+		RegistrationNumber: req.RegistrationNumber,
+		Name:               req.Name,
+		PostalCode:         req.PostalCode,
+		Address:            req.Address,
+		PhoneNumber:        req.PhoneNumber,
+	})
+	if errors.Is(err, usecase.ErrInvalidPostalCode) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, issuer)
+}
+
+// GetOrderInvoicePDF godoc
+//
+//	@Summary		発注の請求書PDFの取得
+//	@Description	発注の適格請求書をPDFで取得する。登録番号・税率ごとの対象金額と消費税額を記載する
+//	@Description	初回の取得時にその時点の発注の内容で請求書番号を採番して発行し、以降は同じ請求書を返す
+//	@Description	記載内容を訂正する場合は返還請求書で取り消すと、次回の取得時に発行し直す
+//	@Produce		application/pdf
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"発注ID"	minimum(1)
+//	@Success		200	{file}		binary
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Failure		503	{object}	error
+//	@Router			/orders/{id}/invoice.pdf [get]
+func (h *Handler) GetOrderInvoicePDF(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetOrderInvoiceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	pdf, err := h.Usecase.GetOrderInvoicePDF(ctx, c.Get("tenant_id").(string), req.OrderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrInvoiceIssuerNotSet) || errors.Is(err, usecase.ErrOrderCancelled) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrFontUnavailable) {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`inline; filename="invoice_%d.pdf"`, req.OrderID))
+
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
+
+// GetOrderInvoices godoc
+//
+//	@Summary		発注の請求書一覧の取得
+//	@Description	発注の請求書・返還請求書を発行順に取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"発注ID"	minimum(1)
+//	@Success		200	{object}	[]model.Invoice
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/orders/{id}/invoices [get]
+func (h *Handler) GetOrderInvoices(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetOrderInvoiceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	invoices, err := h.Usecase.GetOrderInvoices(ctx, c.Get("tenant_id").(string), req.OrderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, invoices)
+}
+
+// GetInvoice godoc
+//
+//	@Summary		請求書の取得
+//	@Description	発行済みの請求書・返還請求書を取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"請求書ID"	minimum(1)
+//	@Success		200	{object}	model.Invoice
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/invoices/{id} [get]
+func (h *Handler) GetInvoice(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetInvoiceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	invoice, err := h.Usecase.GetInvoice(ctx, c.Get("tenant_id").(string), req.InvoiceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, invoice)
+}
+
+// GetInvoicePDF godoc
+//
+//	@Summary		請求書PDFの取得
+//	@Description	発行済みの請求書・返還請求書を発行時点の記載内容でPDFにする
+//	@Produce		application/pdf
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"請求書ID"	minimum(1)
+//	@Success		200	{file}		binary
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Failure		503	{object}	error
+//	@Router			/invoices/{id}/pdf [get]
+func (h *Handler) GetInvoicePDF(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetInvoiceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	pdf, err := h.Usecase.GetInvoicePDF(ctx, c.Get("tenant_id").(string), req.InvoiceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrFontUnavailable) {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`inline; filename="invoice_%d.pdf"`, req.InvoiceID))
+
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
+
+// CreateCreditNote godoc
+//
+//	@Summary		返還請求書の発行
+//	@Description	発行済みの請求書を取り消す返還請求書を発行する。取り消せるのは発注の有効な請求書のみ
+//	@Description	取り消した後に発注の請求書PDFを取得すると、その時点の発注の内容で請求書を発行し直す
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int								true	"請求書ID"	minimum(1)
+//	@Param			req	body		request.CreateCreditNoteRequest	true	"発行内容"
+//	@Success		201	{object}	model.Invoice
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/invoices/{id}/credit-note [post]
+func (h *Handler) CreateCreditNote(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.CreateCreditNoteRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	creditNote, err := h.Usecase.CreateCreditNote(ctx, usecaseRequest.CreateCreditNoteRequest{
+		TenantID:  c.Get("tenant_id").(string),
+		InvoiceID: req.InvoiceID,
+		Reason:    req.Reason,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrInvoiceCredited) ||
+		errors.Is(err, usecase.ErrInvoiceIssuerNotSet) ||
+		errors.Is(err, gorm.ErrDuplicatedKey) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, creditNote)
+}
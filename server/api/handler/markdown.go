@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+)
+
+// GetMarkdownRules godoc
+//
+//	@Summary		自動値下げの段階の取得
+//	@Description	在庫の経過日数に応じた自動値下げの段階を経過日数の順に取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	model.MarkdownRuleSet
+//	@Failure		500	{object}	error
+//	@Router			/markdown-rules [get]
+func (h *Handler) GetMarkdownRules(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	rules, err := h.Usecase.GetMarkdownRules(ctx, c.Get("tenant_id").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+// UpdateMarkdownRules godoc
+//
+//	@Summary		自動値下げの段階の更新
+//	@Description	自動値下げの段階を指定した内容で置き換える。空の配列を指定すると自動値下げを行わない
+//	@Description	在庫の登録からの経過日数が到達した最も大きい段階の値下げ率を、自動値下げ以外で最後に設定した販売価格に対して適用する
+//	@Description	経過日数が大きい段階ほど値下げ率を大きくする必要がある。値下げは日次で実行する
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			req	body		request.UpdateMarkdownRulesRequest	true	"自動値下げの段階"
+//	@Success		200	{object}	model.MarkdownRuleSet
+//	@Failure		400	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/markdown-rules [put]
+func (h *Handler) UpdateMarkdownRules(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.UpdateMarkdownRulesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	input := usecaseRequest.UpdateMarkdownRulesRequest{
+		TenantID:    c.Get("tenant_id").(string),
+		FloorAtCost: req.FloorAtCost,
+		Rules:       make([]usecaseRequest.MarkdownRuleInput, 0, len(req.Rules)),
+	}
+	for _, r := range req.Rules {
+		input.Rules = append(input.Rules, usecaseRequest.MarkdownRuleInput{
+			AgeDays:         r.AgeDays,
+			DiscountPercent: r.DiscountPercent,
+		})
+	}
+
+	rules, err := h.Usecase.UpdateMarkdownRules(ctx, input)
+	if errors.Is(err, usecase.ErrInvalidMarkdownRules) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+// PreviewMarkdowns godoc
+//
+//	@Summary		自動値下げの試算
+//	@Description	現在の段階で自動値下げを実行した場合に変更される販売価格を試算する。販売価格は変更しない
+//	@Description	原価を下限とする設定で取得原価が不明な在庫は値下げせず、在庫IDのみを返す
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	model.MarkdownPlan
+//	@Failure		500	{object}	error
+//	@Router			/markdown-rules/preview [get]
+func (h *Handler) PreviewMarkdowns(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	plan, err := h.Usecase.PreviewMarkdowns(ctx, c.Get("tenant_id").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, plan)
+}
@@ -1,24 +1,30 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
 	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 // GetOrders godoc
 //
 //	@Summary		発注一覧の取得
 //	@Description	発注一覧の取得
-//	@Produce		json
+//	@Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 //	@Security		ApiKeyAuth
-//	@Param			limit	query		int		false	"取得件数"	minimum(0)	example(10)
-//	@Param			offset	query		int		false	"取得開始位置"	minimum(0)	example(0)
-//	@Success		200	{object}	[]model.Order
-//	@Failure		400	{object}	error
-//	@Failure		500	{object}	error
+//	@Param			limit		query		int		false	"取得件数"								minimum(0)	example(10)
+//	@Param			offset		query		int		false	"取得開始位置"							minimum(0)	example(0)
+//	@Param			format		query		string	false	"出力形式（指定時は件数の指定がなければ全件をファイルで出力）"	Enums(csv, xlsx)
+//	@Param			encoding	query		string	false	"CSVの文字コード（既定はBOM付きUTF-8）"			Enums(utf-8, shift_jis)
+//	@Success		200			{object}	[]model.Order
+//	@Failure		400			{object}	error
+//	@Failure		500			{object}	error
 //	@Router			/orders [get]
 func (h *Handler) GetOrders(c echo.Context) error {
 	ctx := h.GetCtx(c)
@@ -34,11 +40,24 @@ func (h *Handler) GetOrders(c echo.Context) error {
 			WithInternal(err)
 	}
 
-	orders, err := h.Usecase.GetOrders(ctx, usecaseRequest.GetOrdersRequest{
+	input := usecaseRequest.GetOrdersRequest{
 		TenantID: c.Get("tenant_id").(string),
 		Limit:    req.Limit,
 		Offset:   req.Offset,
-	})
+	}
+
+	if req.Format != nil {
+		if err := h.export(c, req.ExportRequest, "orders", func(w sheet.Writer) error {
+			return h.Usecase.ExportOrders(ctx, input, w)
+		}); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err).
+				WithInternal(err)
+		}
+
+		return nil
+	}
+
+	orders, err := h.Usecase.GetOrders(ctx, input)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -91,6 +110,8 @@ func (h *Handler) GetOrder(c echo.Context) error {
 //	@Param			req	body		request.CreateOrderRequest	true	"作成条件"
 //	@Success		201	{object}	int
 //	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
 //	@Failure		500	{object}	error
 //	@Router			/orders [post]
 func (h *Handler) CreateOrder(c echo.Context) error {
@@ -108,13 +129,28 @@ func (h *Handler) CreateOrder(c echo.Context) error {
 	}
 
 	orderID, err := h.Usecase.CreateOrder(ctx, usecaseRequest.CreateOrderRequest{
+		TenantID:     c.Get("tenant_id").(string),
 		TotalAmount:  req.TotalAmount,
+		PointsUsed:   req.PointsUsed,
 		Quantity:     req.Quantity,
 		DeliveryDate: req.DeliveryDate,
 		Status:       req.Status,
 		StockID:      req.StockID,
 		CustomerID:   req.CustomerID,
+		CouponCode:   req.CouponCode,
 	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrPointsExceedAmount) || errors.Is(err, usecase.ErrInvalidCoupon) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrInsufficientPoints) || errors.Is(err, usecase.ErrPromotionNotApplicable) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -126,14 +162,15 @@ func (h *Handler) CreateOrder(c echo.Context) error {
 // UpdateOrder godoc
 //
 //	@Summary		発注の更新
-//	@Description	発注の更新
+//	@Description	発注を更新する。値引きを適用した発注の金額は変更できない
 //	@Accept			json
 //	@Produce		json
 //	@Security		ApiKeyAuth
-//	@Param			id		path		int						true	"発注ID"		minimum(1)
-//	@Param			req		body		request.UpdateOrderRequest	true	"更新条件"
+//	@Param			id	path		int							true	"発注ID"	minimum(1)
+//	@Param			req	body		request.UpdateOrderRequest	true	"更新条件"
 //	@Success		200	{object}	model.Order
 //	@Failure		400	{object}	error
+//	@Failure		409	{object}	error
 //	@Failure		500	{object}	error
 //	@Router			/orders/{id} [put]
 func (h *Handler) UpdateOrder(c echo.Context) error {
@@ -158,6 +195,19 @@ func (h *Handler) UpdateOrder(c echo.Context) error {
 		DeliveryDate: req.DeliveryDate,
 		Status:       req.Status,
 	})
+	if errors.Is(err, usecase.ErrPointsExceedAmount) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrOrderHasDiscounts) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrInsufficientPoints) ||
+		errors.Is(err, usecase.ErrOrderHasReturns) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -170,23 +220,52 @@ func (h *Handler) UpdateOrder(c echo.Context) error {
 //
 //	@Summary		発注の一括作成
 //	@Description	発注の一括作成
+//	@Description	atomicを指定すると1件ごとの結果（添字・状態・作成したID・エラー）を返す
+//	@Description	atomic=trueは全件を1つのトランザクションで作成し、1件でも失敗すれば全件を取り消す
+//	@Description	atomic=falseは作成できた行を確定し、一部が失敗した場合は207を返す
 //	@Accept			json
 //	@Produce		json
 //	@Security		ApiKeyAuth
-//	@Param			req	body		request.CreateBulkOrderRequest	true	"作成条件"
-//	@Success		201	{object}	[]int
-//	@Failure		400	{object}	error
-//	@Failure		500	{object}	error
+//	@Param			atomic	query		bool							false	"1件ごとの結果を返す。trueは全件成功した場合のみ作成する"
+//	@Param			req		body		request.CreateBulkOrderRequest	true	"作成条件"
+//	@Success		201		{object}	[]int							"atomic未指定の場合"
+//	@Success		207		{object}	model.BulkResult				"atomic=falseで一部の行が失敗した場合"
+//	@Failure		400		{object}	model.BulkResult
+//	@Failure		404		{object}	error
+//	@Failure		409		{object}	model.BulkResult
+//	@Failure		413		{object}	error
+//	@Failure		500		{object}	error
 //	@Router			/orders/bulk [post]
 func (h *Handler) CreateBulkOrder(c echo.Context) error {
 	ctx := h.GetCtx(c)
 
 	var req request.CreateBulkOrderRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
 	if err := c.Bind(&req.Orders); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
 	}
 
+	if req.Atomic != nil {
+		input := usecaseRequest.CreateBulkOrderItemsRequest{
+			Orders: make([]usecaseRequest.CreateOrderRequest, len(req.Orders)),
+			Errors: make([]error, len(req.Orders)),
+			Atomic: *req.Atomic,
+		}
+		for i, order := range req.Orders {
+			if input.Errors[i] = validateBulkItem(c, order); input.Errors[i] == nil {
+				input.Orders[i] = convertCreateOrderRequest(c.Get("tenant_id").(string), order)
+			}
+		}
+
+		result, err := h.Usecase.CreateBulkOrderItems(ctx, input)
+
+		return bulkResponse(c, result, err)
+	}
+
 	if err := c.Validate(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err).
 			WithInternal(err)
@@ -194,17 +273,26 @@ func (h *Handler) CreateBulkOrder(c echo.Context) error {
 
 	var orders []usecaseRequest.CreateOrderRequest
 	for _, order := range req.Orders {
-		orders = append(orders, usecaseRequest.CreateOrderRequest{
-			TotalAmount:  order.TotalAmount,
-			Quantity:     order.Quantity,
-			DeliveryDate: order.DeliveryDate,
-			Status:       order.Status,
-			StockID:      order.StockID,
-			CustomerID:   order.CustomerID,
-		})
+		orders = append(orders, convertCreateOrderRequest(c.Get("tenant_id").(string), order))
 	}
 
 	orderIDs, err := h.Usecase.CreateBulkOrder(ctx, orders)
+	if errors.Is(err, usecase.ErrTooManyBulkItems) {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrPointsExceedAmount) || errors.Is(err, usecase.ErrInvalidCoupon) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrInsufficientPoints) || errors.Is(err, usecase.ErrPromotionNotApplicable) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -212,3 +300,17 @@ func (h *Handler) CreateBulkOrder(c echo.Context) error {
 
 	return c.JSON(http.StatusCreated, orderIDs)
 }
+
+func convertCreateOrderRequest(tenantID string, order *request.CreateOrderRequest) usecaseRequest.CreateOrderRequest {
+	return usecaseRequest.CreateOrderRequest{
+		TenantID:     tenantID,
+		TotalAmount:  order.TotalAmount,
+		PointsUsed:   order.PointsUsed,
+		Quantity:     order.Quantity,
+		DeliveryDate: order.DeliveryDate,
+		Status:       order.Status,
+		StockID:      order.StockID,
+		CustomerID:   order.CustomerID,
+		CouponCode:   order.CouponCode,
+	}
+}
@@ -0,0 +1,363 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetOrderReturns godoc
+//
+//	@Summary		返品一覧の取得
+//	@Description	返品を受付の新しい順に取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			status	query		string	false	"状態"		Enums(REQUESTED, INSPECTED, COMPLETED, REJECTED)
+//	@Param			limit	query		int		false	"取得件数"		minimum(0)	example(10)
+//	@Param			offset	query		int		false	"取得開始位置"	minimum(0)	example(0)
+//	@Success		200		{object}	[]model.OrderReturn
+//	@Failure		400		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/returns [get]
+func (h *Handler) GetOrderReturns(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetOrderReturnsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	returns, err := h.Usecase.GetOrderReturns(ctx, usecaseRequest.GetOrderReturnsRequest{
+		TenantID: c.Get("tenant_id").(string),
+		Status:   req.Status,
+		Limit:    req.Limit,
+		Offset:   req.Offset,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, returns)
+}
+
+// GetOrderReturn godoc
+//
+//	@Summary		返品の取得
+//	@Description	返品の取得
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"返品ID"	minimum(1)
+//	@Success		200	{object}	model.OrderReturn
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/returns/{id} [get]
+func (h *Handler) GetOrderReturn(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetOrderReturnRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	ret, err := h.Usecase.GetOrderReturn(ctx, c.Get("tenant_id").(string), req.ReturnID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, ret)
+}
+
+// GetReturnsOfOrder godoc
+//
+//	@Summary		発注の返品一覧の取得
+//	@Description	発注の返品を受付の新しい順に取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"発注ID"	minimum(1)
+//	@Success		200	{object}	[]model.OrderReturn
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/orders/{id}/returns [get]
+func (h *Handler) GetReturnsOfOrder(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetReturnsOfOrderRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	tenantID := c.Get("tenant_id").(string)
+	if _, err := h.Usecase.GetOrder(ctx, tenantID, req.OrderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err).
+				WithInternal(err)
+		}
+
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	returns, err := h.Usecase.GetOrderReturns(ctx, usecaseRequest.GetOrderReturnsRequest{
+		TenantID: tenantID,
+		OrderID:  &req.OrderID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, returns)
+}
+
+// CreateOrderReturn godoc
+//
+//	@Summary		返品の受付
+//	@Description	納品済みの発注の返品を受け付ける。返品不可としたものを除き、発注の数量を超えて返品できない
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int									true	"発注ID"	minimum(1)
+//	@Param			req	body		request.CreateOrderReturnRequest	true	"返品"
+//	@Success		201	{object}	model.OrderReturn
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/orders/{id}/returns [post]
+func (h *Handler) CreateOrderReturn(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.CreateOrderReturnRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	ret, err := h.Usecase.CreateOrderReturn(ctx, usecaseRequest.CreateOrderReturnRequest{
+		TenantID: c.Get("tenant_id").(string),
+		OrderID:  req.OrderID,
+		Quantity: req.Quantity,
+		Reason:   req.Reason,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrOrderNotDelivered) || errors.Is(err, usecase.ErrReturnExceedsQuantity) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, ret)
+}
+
+// InspectOrderReturn godoc
+//
+//	@Summary		返品の検品
+//	@Description	返品の状態ランクを記録する。完了前であれば検品し直せる
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int									true	"返品ID"	minimum(1)
+//	@Param			req	body		request.InspectOrderReturnRequest	true	"検品結果"
+//	@Success		200	{object}	model.OrderReturn
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/returns/{id}/inspect [post]
+func (h *Handler) InspectOrderReturn(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.InspectOrderReturnRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	ret, err := h.Usecase.InspectOrderReturn(ctx, usecaseRequest.InspectOrderReturnRequest{
+		TenantID:  c.Get("tenant_id").(string),
+		ReturnID:  req.ReturnID,
+		Condition: req.Condition,
+		Note:      req.Note,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrReturnStatus) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, ret)
+}
+
+// RejectOrderReturn godoc
+//
+//	@Summary		返品の不可
+//	@Description	完了前の返品を返品不可とする。返品不可とした数量は再び返品を受け付けられる
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int									true	"返品ID"	minimum(1)
+//	@Param			req	body		request.RejectOrderReturnRequest	true	"理由"
+//	@Success		200	{object}	model.OrderReturn
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/returns/{id}/reject [post]
+func (h *Handler) RejectOrderReturn(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.RejectOrderReturnRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	ret, err := h.Usecase.RejectOrderReturn(ctx, usecaseRequest.RejectOrderReturnRequest{
+		TenantID: c.Get("tenant_id").(string),
+		ReturnID: req.ReturnID,
+		Note:     req.Note,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrReturnStatus) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, ret)
+}
+
+// CompleteOrderReturn godoc
+//
+//	@Summary		返品の完了
+//	@Description	検品済みの返品を在庫に戻し入れ、返金・交換・ポイント返還のいずれかで完了する
+//	@Description	戻し入れは返品の入出庫として記録し、状態ランクに応じて元とは別の在庫に戻せる
+//	@Description	返金は発注の新しい支払いから順に、支払った決済手段で返金する。交換の場合は交換品の発注を別に作成して指定する
+//	@Description	決済代行会社が返金を受け付けなかった場合は、返品を完了したまま502を返し、返金をFAILEDとして残す
+//	@Description	納品で付与したポイントは、返品した数量の按分額に相当する分を取り消す。売上集計は発注の内容を集計するため返品では変わらない
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int									true	"返品ID"	minimum(1)
+//	@Param			req	body		request.CompleteOrderReturnRequest	true	"対応"
+//	@Success		200	{object}	model.OrderReturn
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Failure		502	{object}	error
+//	@Router			/returns/{id}/complete [post]
+func (h *Handler) CompleteOrderReturn(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.CompleteOrderReturnRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	ret, err := h.Usecase.CompleteOrderReturn(ctx, usecaseRequest.CompleteOrderReturnRequest{
+		TenantID:        c.Get("tenant_id").(string),
+		ReturnID:        req.ReturnID,
+		Resolution:      req.Resolution,
+		Restock:         req.Restock,
+		RestockStockID:  req.RestockStockID,
+		UnitCost:        req.UnitCost,
+		Amount:          req.Amount,
+		ExchangeOrderID: req.ExchangeOrderID,
+		Note:            req.Note,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrExchangeOrderRequired) || errors.Is(err, usecase.ErrInvalidExchangeOrder) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrReturnStatus) || errors.Is(err, usecase.ErrRefundExceedsPayment) ||
+		errors.Is(err, usecase.ErrReturnAmountExceeded) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrRefundFailed) {
+		return echo.NewHTTPError(http.StatusBadGateway, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, ret)
+}
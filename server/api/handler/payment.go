@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetOrderPayments godoc
+//
+//	@Summary		発注の支払い状況の取得
+//	@Description	発注の支払いと返金を日時の順に取得する
+//	@Description	支払いが必要な金額は値引き額と値引きに利用したポイント、完了した返品で返金・ポイント返還した額を差し引いた金額
+//	@Description	返品によらない返金の分は未払いに戻る
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"発注ID"	minimum(1)
+//	@Success		200	{object}	model.OrderPayments
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/orders/{id}/payments [get]
+func (h *Handler) GetOrderPayments(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetOrderPaymentsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	payments, err := h.Usecase.GetOrderPayments(ctx, c.Get("tenant_id").(string), req.OrderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, payments)
+}
+
+// CreatePayment godoc
+//
+//	@Summary		発注の支払いの記録
+//	@Description	発注に支払いを記録する。複数の決済手段に分けて支払う場合は決済手段ごとに記録する
+//	@Description	クレジットカード・QRコード決済は決済代行会社で決済し、ポイントは顧客の残高から差し引く
+//	@Description	未払いの残額を超える支払いは受け付けない。現金の釣り銭は預かり金額で記録する
+//	@Description	決済代行会社を使わない設定の場合、クレジットカード・QRコード決済は400を返す
+//	@Description	クレジットカード・QRコード決済はPENDINGで記録してから決済し、承認されなかった場合は402を返して支払いをFAILEDとして残す
+//	@Description	決済代行会社の結果が分からない場合は202を返す。支払いはPENDINGのまま残り、後から結果を確かめて反映する
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int								true	"発注ID"	minimum(1)
+//	@Param			req	body		request.CreatePaymentRequest	true	"支払い"
+//	@Success		201	{object}	model.Payment
+//	@Success		202	{object}	model.Payment
+//	@Failure		400	{object}	error
+//	@Failure		402	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/orders/{id}/payments [post]
+func (h *Handler) CreatePayment(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.CreatePaymentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	payment, err := h.Usecase.CreatePayment(ctx, usecaseRequest.CreatePaymentRequest{
+		TenantID:  c.Get("tenant_id").(string),
+		OrderID:   req.OrderID,
+		Method:    req.Method,
+		Amount:    req.Amount,
+		Tendered:  req.Tendered,
+		Token:     req.Token,
+		Reference: req.Reference,
+		Note:      req.Note,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrPaymentTokenRequired) ||
+		errors.Is(err, usecase.ErrPaymentUnavailable) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrPaymentDeclined) {
+		return echo.NewHTTPError(http.StatusPaymentRequired, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrOrderCancelled) ||
+		errors.Is(err, usecase.ErrPaymentExceedsBalance) ||
+		errors.Is(err, usecase.ErrInsufficientPoints) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if payment.Status == model.ChargePending {
+		return c.JSON(http.StatusAccepted, payment)
+	}
+
+	return c.JSON(http.StatusCreated, payment)
+}
+
+// CreateRefund godoc
+//
+//	@Summary		発注の支払いの返金
+//	@Description	支払いの全部または一部を支払った決済手段で返金する。ポイントで支払った分は顧客の残高に戻す
+//	@Description	キャンセルによる返金はキャンセルした発注に限る
+//	@Description	クレジットカード・QRコード決済の返金は記録してから決済代行会社に依頼し、受け付けられなかった場合は502を返して返金をFAILEDとして残す
+//	@Description	決済代行会社の結果が分からない場合は202を返す。返金はPENDINGのまま残り、後から同じ依頼をし直して結果を反映する
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int							true	"発注ID"	minimum(1)
+//	@Param			req	body		request.CreateRefundRequest	true	"返金"
+//	@Success		201	{object}	model.Refund
+//	@Success		202	{object}	model.Refund
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Failure		502	{object}	error
+//	@Router			/orders/{id}/refunds [post]
+func (h *Handler) CreateRefund(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.CreateRefundRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	refund, err := h.Usecase.CreateRefund(ctx, usecaseRequest.CreateRefundRequest{
+		TenantID:  c.Get("tenant_id").(string),
+		OrderID:   req.OrderID,
+		PaymentID: req.PaymentID,
+		Amount:    req.Amount,
+		Cause:     req.Cause,
+		Reason:    req.Reason,
+		Reference: req.Reference,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrRefundNotAllowed) || errors.Is(err, usecase.ErrRefundExceedsPayment) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrRefundFailed) {
+		return echo.NewHTTPError(http.StatusBadGateway, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if refund.Status == model.RefundPending {
+		return c.JSON(http.StatusAccepted, refund)
+	}
+
+	return c.JSON(http.StatusCreated, refund)
+}
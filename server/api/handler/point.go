@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetPointBalance godoc
+//
+//	@Summary		顧客のポイント残高の取得
+//	@Description	ポイント残高と、失効予定のポイントを失効日時の近い順に取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"顧客ID"	format(uuid)
+//	@Success		200	{object}	model.PointBalance
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/customers/{id}/points [get]
+func (h *Handler) GetPointBalance(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetPointBalanceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	balance, err := h.Usecase.GetPointBalance(ctx, c.Get("tenant_id").(string), req.CustomerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, balance)
+}
+
+// GetPointHistory godoc
+//
+//	@Summary		顧客のポイント履歴の取得
+//	@Description	ポイントの付与・利用・失効・取り消しを新しい順に取得する。件数の既定は20件、上限は100件
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string	true	"顧客ID"		format(uuid)
+//	@Param			limit	query		int		false	"取得件数"		minimum(1)	maximum(100)
+//	@Param			offset	query		int		false	"取得開始位置"	minimum(0)
+//	@Success		200		{object}	model.PointHistory
+//	@Failure		400		{object}	error
+//	@Failure		404		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/customers/{id}/points/history [get]
+func (h *Handler) GetPointHistory(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetPointHistoryRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	history, err := h.Usecase.GetPointHistory(ctx, usecaseRequest.GetPointHistoryRequest{
+		TenantID:   c.Get("tenant_id").(string),
+		CustomerID: req.CustomerID,
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
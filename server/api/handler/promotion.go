@@ -0,0 +1,364 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetPromotions godoc
+//
+//	@Summary		キャンペーン一覧の取得
+//	@Description	キャンペーンを新しい順に取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	[]model.Promotion
+//	@Failure		500	{object}	error
+//	@Router			/promotions [get]
+func (h *Handler) GetPromotions(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	promotions, err := h.Usecase.GetPromotions(ctx, c.Get("tenant_id").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, promotions)
+}
+
+// GetPromotion godoc
+//
+//	@Summary		キャンペーンの取得
+//	@Description	キャンペーンの取得
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"キャンペーンID"
+//	@Success		200	{object}	model.Promotion
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/promotions/{id} [get]
+func (h *Handler) GetPromotion(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetPromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	promotion, err := h.Usecase.GetPromotion(ctx, c.Get("tenant_id").(string), req.PromotionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, promotion)
+}
+
+// CreatePromotion godoc
+//
+//	@Summary		キャンペーンの作成
+//	@Description	値引きのキャンペーンを作成する。値引きは定率 (%) または定額 (円)
+//	@Description	クーポンが不要なキャンペーンは条件を満たす発注に自動で適用し、複数該当する場合は値引き額が最も大きいものを適用する
+//	@Description	クーポンのキャンペーンは発注の作成時にクーポンコードを指定した場合に適用し、自動で適用するキャンペーンと併用できる
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			req	body		request.CreatePromotionRequest	true	"作成条件"
+//	@Success		201	{object}	model.Promotion
+//	@Failure		400	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/promotions [post]
+func (h *Handler) CreatePromotion(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.CreatePromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	promotion, err := h.Usecase.CreatePromotion(ctx, usecaseRequest.CreatePromotionRequest{
+		PromotionInput: convertPromotionRequest(req.PromotionRequest),
+		TenantID:       c.Get("tenant_id").(string),
+	})
+	if errors.Is(err, usecase.ErrInvalidPromotion) || errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, promotion)
+}
+
+// UpdatePromotion godoc
+//
+//	@Summary		キャンペーンの更新
+//	@Description	キャンペーンの更新。適用済みの発注の値引きは変更しない
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int								true	"キャンペーンID"
+//	@Param			req	body		request.UpdatePromotionRequest	true	"更新条件"
+//	@Success		200	{object}	model.Promotion
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/promotions/{id} [put]
+func (h *Handler) UpdatePromotion(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.UpdatePromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	promotion, err := h.Usecase.UpdatePromotion(ctx, usecaseRequest.UpdatePromotionRequest{
+		PromotionInput: convertPromotionRequest(req.PromotionRequest),
+		TenantID:       c.Get("tenant_id").(string),
+		PromotionID:    req.PromotionID,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrInvalidPromotion) || errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, promotion)
+}
+
+// DeletePromotion godoc
+//
+//	@Summary		キャンペーンの削除
+//	@Description	キャンペーンとクーポンを削除する。発注に適用済みのキャンペーンは削除できないため、無効にする
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"キャンペーンID"
+//	@Success		204	{string}	string
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/promotions/{id} [delete]
+func (h *Handler) DeletePromotion(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.DeletePromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	err := h.Usecase.DeletePromotion(ctx, c.Get("tenant_id").(string), req.PromotionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetCoupons godoc
+//
+//	@Summary		クーポン一覧の取得
+//	@Description	キャンペーンのクーポンをコード順に取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"キャンペーンID"
+//	@Success		200	{object}	[]model.Coupon
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/promotions/{id}/coupons [get]
+func (h *Handler) GetCoupons(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetPromotionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	coupons, err := h.Usecase.GetCoupons(ctx, c.Get("tenant_id").(string), req.PromotionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, coupons)
+}
+
+// CreateCoupon godoc
+//
+//	@Summary		クーポンの作成
+//	@Description	キャンペーンを適用するクーポンコードを作成する。コードは大文字小文字を区別せず、テナント内で重複できない
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int							true	"キャンペーンID"
+//	@Param			req	body		request.CreateCouponRequest	true	"作成条件"
+//	@Success		201	{object}	model.Coupon
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/promotions/{id}/coupons [post]
+func (h *Handler) CreateCoupon(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.CreateCouponRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	coupon, err := h.Usecase.CreateCoupon(ctx, usecaseRequest.CreateCouponRequest{
+		TenantID:    c.Get("tenant_id").(string),
+		PromotionID: req.PromotionID,
+		Code:        req.Code,
+		UsageLimit:  req.UsageLimit,
+		Active:      req.Active == nil || *req.Active,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, coupon)
+}
+
+// DeleteCoupon godoc
+//
+//	@Summary		クーポンの削除
+//	@Description	クーポンを削除する。発注に適用済みのクーポンは削除できない
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id			path		int	true	"キャンペーンID"
+//	@Param			coupon_id	path		int	true	"クーポンID"
+//	@Success		204			{string}	string
+//	@Failure		400			{object}	error
+//	@Failure		404			{object}	error
+//	@Failure		409			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/promotions/{id}/coupons/{coupon_id} [delete]
+func (h *Handler) DeleteCoupon(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.DeleteCouponRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	err := h.Usecase.DeleteCoupon(ctx, c.Get("tenant_id").(string), req.PromotionID, req.CouponID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func convertPromotionRequest(req request.PromotionRequest) usecaseRequest.PromotionInput {
+	return usecaseRequest.PromotionInput{
+		Name:                  req.Name,
+		Description:           req.Description,
+		DiscountType:          req.DiscountType,
+		DiscountValue:         req.DiscountValue,
+		StockID:               req.StockID,
+		Category:              req.Category,
+		StoreID:               req.StoreID,
+		StartsAt:              req.StartsAt,
+		EndsAt:                req.EndsAt,
+		MinSpend:              req.MinSpend,
+		UsageLimitPerCustomer: req.UsageLimitPerCustomer,
+		UsageLimit:            req.UsageLimit,
+		RequiresCoupon:        req.RequiresCoupon,
+		Active:                req.Active == nil || *req.Active,
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/receipt"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetReceiptTemplate godoc
+//
+//	@Summary		レシートの印字設定の取得
+//	@Description	レシートの用紙幅・ヘッダー・フッターの文言と、店舗住所・バーコードを印字するかを取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	model.ReceiptTemplate
+//	@Failure		500	{object}	error
+//	@Router			/settings/receipt [get]
+func (h *Handler) GetReceiptTemplate(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	tmpl, err := h.Usecase.GetReceiptTemplate(ctx, c.Get("tenant_id").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, tmpl)
+}
+
+// UpdateReceiptTemplate godoc
+//
+//	@Summary		レシートの印字設定の更新
+//	@Description	レシートの印字設定を更新する。ヘッダー・フッターは改行で複数行にできる
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			req	body		request.UpdateReceiptTemplateRequest	true	"更新条件"
+//	@Success		200	{object}	model.ReceiptTemplate
+//	@Failure		400	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/settings/receipt [put]
+func (h *Handler) UpdateReceiptTemplate(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.UpdateReceiptTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	tmpl, err := h.Usecase.UpdateReceiptTemplate(ctx, usecaseRequest.UpdateReceiptTemplateRequest{
+		TenantID:         c.Get("tenant_id").(string),
+		PaperWidth:       req.PaperWidth,
+		Header:           req.Header,
+		Footer:           req.Footer,
+		ShowStoreAddress: req.ShowStoreAddress,
+		ShowBarcode:      req.ShowBarcode,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, tmpl)
+}
+
+// GetOrderReceipt godoc
+//
+//	@Summary		発注のレシートの取得
+//	@Description	発注のレシートをレシートプリンタ向けのESC/POSのコマンド列 (Shift_JIS) で取得する
+//	@Description	店舗名・明細・税率ごとの内訳・支払い・発注番号のバーコードを、テナントの印字設定に従って印字する
+//	@Produce		application/octet-stream
+//	@Security		ApiKeyAuth
+//	@Param			id			path		int	true	"発注ID"						minimum(1)
+//	@Param			paper_width	query		int	false	"用紙幅 (mm)。未指定の場合は印字設定の用紙幅"	Enums(58, 80)
+//	@Success		200			{file}		binary
+//	@Failure		400			{object}	error
+//	@Failure		404			{object}	error
+//	@Failure		409			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/orders/{id}/receipt [get]
+func (h *Handler) GetOrderReceipt(c echo.Context) error {
+	data, err := h.getOrderReceipt(c, receipt.FormatESCPOS)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="receipt_%s.bin"`, c.Param("id")))
+
+	return c.Blob(http.StatusOK, echo.MIMEOctetStream, data)
+}
+
+// GetOrderReceiptPreview godoc
+//
+//	@Summary		発注のレシートのプレビュー
+//	@Description	発注のレシートをプレーンテキストで取得する。拡大文字は通常の大きさ、バーコードは発注番号で表示する
+//	@Produce		plain
+//	@Security		ApiKeyAuth
+//	@Param			id			path		int	true	"発注ID"						minimum(1)
+//	@Param			paper_width	query		int	false	"用紙幅 (mm)。未指定の場合は印字設定の用紙幅"	Enums(58, 80)
+//	@Success		200			{string}	string
+//	@Failure		400			{object}	error
+//	@Failure		404			{object}	error
+//	@Failure		409			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/orders/{id}/receipt/preview [get]
+func (h *Handler) GetOrderReceiptPreview(c echo.Context) error {
+	data, err := h.getOrderReceipt(c, receipt.FormatText)
+	if err != nil {
+		return err
+	}
+
+	return c.Blob(http.StatusOK, echo.MIMETextPlainCharsetUTF8, data)
+}
+
+func (h *Handler) getOrderReceipt(c echo.Context, format receipt.Format) ([]byte, error) {
+	ctx := h.GetCtx(c)
+
+	var req request.GetOrderReceiptRequest
+	if err := c.Bind(&req); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	data, err := h.Usecase.GetOrderReceipt(ctx, usecaseRequest.GetOrderReceiptRequest{
+		TenantID:   c.Get("tenant_id").(string),
+		OrderID:    req.OrderID,
+		PaperWidth: req.PaperWidth,
+		Format:     format,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrOrderCancelled) {
+		return nil, echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+)
+
+// GetInventoryValuation godoc
+//
+//	@Summary		棚卸資産評価額の取得
+//	@Description	基準日の終了時点における在庫の評価額を店舗別・テナント合計で取得する
+//	@Description	評価方法はテナント設定に従い、methodを指定した場合はその方法で試算する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			as_of	query		string	false	"基準日(省略時は現在)"	format(date)	example(2025-09-30)
+//	@Param			method	query		string	false	"評価方法"			Enums(MOVING_AVERAGE, FIFO)
+//	@Success		200		{object}	model.InventoryValuationReport
+//	@Failure		400		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/reports/inventory-valuation [get]
+func (h *Handler) GetInventoryValuation(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetInventoryValuationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	asOf, err := endOfDay(req.AsOf)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	report, err := h.Usecase.GetInventoryValuation(ctx, usecaseRequest.GetInventoryValuationRequest{
+		TenantID: c.Get("tenant_id").(string),
+		AsOf:     asOf,
+		Method:   req.Method,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GetSalesReport godoc
+//
+//	@Summary		売上集計の取得
+//	@Description	売上金額・発注件数・数量・平均発注額を集計する。期間は発注日時で絞り込む
+//	@Description	period と group_by を指定すると、期間・店舗・担当者・在庫・ステータスごとの内訳を返す
+//	@Description	status を指定しない場合、キャンセルされた発注は集計に含めない
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			from		query		string		false	"開始日"			format(date)	example(2025-10-01)
+//	@Param			to			query		string		false	"終了日（当日を含む）"	format(date)	example(2025-10-31)
+//	@Param			period		query		string		false	"期間の単位"			Enums(day, week, month)
+//	@Param			group_by	query		[]string	false	"集計軸"			collectionFormat(multi)	Enums(store, user, stock, status)
+//	@Param			status		query		[]string	false	"ステータス"			collectionFormat(multi)	Enums(PENDING, SHIPPED, DELIVERED, CANCELLED)
+//	@Param			store_id	query		string		false	"店舗ID"			format(uuid)
+//	@Success		200			{object}	model.SalesReport
+//	@Failure		400			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/reports/sales [get]
+func (h *Handler) GetSalesReport(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetSalesReportRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	if req.From != "" && req.To != "" && req.From > req.To {
+		err := errors.New("from must be on or before to")
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	report, err := h.Usecase.GetSalesReport(ctx, usecaseRequest.GetSalesReportRequest{
+		TenantID: c.Get("tenant_id").(string),
+		From:     req.From,
+		To:       req.To,
+		Period:   req.Period,
+		GroupBy:  req.GroupBy,
+		Statuses: req.Statuses,
+		StoreID:  req.StoreID,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// endOfDay は日付文字列をその日の終了時刻に変換する。空文字の場合はnilを返す
+func endOfDay(date string) (*time.Time, error) {
+	if date == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	return &t, nil
+}
@@ -0,0 +1,5 @@
+package request
+
+type LookupAddressRequest struct {
+	Zip string `query:"zip" validate:"required,jp_postal_code" example:"100-0005"`
+}
@@ -1,6 +1,8 @@
 package request
 
 type GetCustomersRequest struct {
+	ExportRequest
+
 	Limit  *int `query:"limit" validate:"omitempty,numeric,gte=0" example:"10" minimum:"0"`
 	Offset *int `query:"offset" validate:"omitempty,numeric,gte=0" example:"0" minimum:"0"`
 }
@@ -10,22 +12,65 @@ type GetCustomerRequest struct {
 }
 
 type CreateCustomerRequest struct {
-	TenantID    string `json:"tenant_id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
-	Name        string `json:"name" validate:"required,min=1,max=255" example:"田中 太郎"`
-	Email       string `json:"email" validate:"required,email" example:"taro_tanaka@example.com"`
-	PhoneNumber string `json:"phone_number" validate:"required,jp_phone_number" example:"09012345678"`
-	Address     string `json:"address" validate:"required,min=1,max=255" example:"東京都千代田区丸の内1-1-1"`
+	TenantID string `json:"tenant_id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+	Name     string `json:"name" validate:"required,min=1,max=255" example:"田中 太郎"`
+	Email    string `json:"email" validate:"required,email" example:"taro_tanaka@example.com"`
+	// +から始まる国際表記、または phone_country の国内表記。E.164形式で保存する
+	PhoneNumber string `json:"phone_number" validate:"required,phone_number=PhoneCountry" example:"090-1234-5678"`
+	// 国内表記の電話番号の国 (ISO 3166-1 alpha-2)。未指定の場合は日本
+	PhoneCountry string `json:"phone_country" validate:"omitempty,phone_country" example:"JP"`
+	// 都道府県を指定しない場合は必須。都道府県を指定した場合は分割した住所から作る
+	Address    string `json:"address" validate:"required_without=Prefecture,max=255" example:"東京都千代田区丸の内1-1-1"`
+	PostalCode string `json:"postal_code" validate:"omitempty,jp_postal_code" example:"100-0005"`
+	Prefecture string `json:"prefecture" validate:"omitempty,max=4" example:"東京都"`
+	City       string `json:"city" validate:"required_with=Prefecture,max=255" example:"千代田区"`
+	Street     string `json:"street" validate:"required_with=Prefecture,max=255" example:"丸の内1-1-1"`
+	Building   string `json:"building" validate:"max=255" example:"丸の内ビル5F"`
 }
 
 type UpdateCustomerRequest struct {
-	ID          string `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000" swaggerignore:"true"`
-	TenantID    string `json:"tenant_id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
-	Name        string `json:"name" validate:"required,min=1,max=255" example:"田中 太郎"`
-	Email       string `json:"email" validate:"required,email" example:"taro_tanaka@example.com"`
-	PhoneNumber string `json:"phone_number" validate:"required,jp_phone_number" example:"09012345678"`
-	Address     string `json:"address" validate:"required,min=1,max=255" example:"東京都千代田区丸の内1-1-1"`
+	ID       string `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000" swaggerignore:"true"`
+	TenantID string `json:"tenant_id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+	Name     string `json:"name" validate:"required,min=1,max=255" example:"田中 太郎"`
+	Email    string `json:"email" validate:"required,email" example:"taro_tanaka@example.com"`
+	// +から始まる国際表記、または phone_country の国内表記。E.164形式で保存する
+	PhoneNumber string `json:"phone_number" validate:"required,phone_number=PhoneCountry" example:"090-1234-5678"`
+	// 国内表記の電話番号の国 (ISO 3166-1 alpha-2)。未指定の場合は日本
+	PhoneCountry string `json:"phone_country" validate:"omitempty,phone_country" example:"JP"`
+	// 都道府県を指定しない場合は必須。都道府県を指定した場合は分割した住所から作る
+	Address    string `json:"address" validate:"required_without=Prefecture,max=255" example:"東京都千代田区丸の内1-1-1"`
+	PostalCode string `json:"postal_code" validate:"omitempty,jp_postal_code" example:"100-0005"`
+	Prefecture string `json:"prefecture" validate:"omitempty,max=4" example:"東京都"`
+	City       string `json:"city" validate:"required_with=Prefecture,max=255" example:"千代田区"`
+	Street     string `json:"street" validate:"required_with=Prefecture,max=255" example:"丸の内1-1-1"`
+	Building   string `json:"building" validate:"max=255" example:"丸の内ビル5F"`
 }
 
 type DeleteCustomerRequest struct {
 	CustomerID string `param:"id" validate:"required" example:"00000000-0000-0000-0000-000000000000"`
 }
+
+type GetCustomerDuplicatesRequest struct {
+	CustomerID        *string  `query:"customer_id" validate:"omitempty,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+	MinNameSimilarity *float64 `query:"min_name_similarity" validate:"omitempty,gt=0,lte=1" example:"0.8" minimum:"0" maximum:"1"`
+}
+
+type MergeCustomersRequest struct {
+	CustomerID string   `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000" swaggerignore:"true"`
+	SourceIDs  []string `json:"source_ids" validate:"required,min=1,max=100,dive,uuid4" example:"00000000-0000-0000-0000-000000000001"`
+	Note       string   `json:"note" validate:"max=255" example:"電話番号の表記ゆれによる重複登録"`
+}
+
+type GetCustomerMergesRequest struct {
+	CustomerID string `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+type GetCustomerSummaryRequest struct {
+	CustomerID string `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+type GetCustomerOrdersRequest struct {
+	CustomerID string `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+	Limit      *int   `query:"limit" validate:"omitempty,numeric,gte=1,lte=100" example:"20" minimum:"1" maximum:"100"`
+	Offset     *int   `query:"offset" validate:"omitempty,numeric,gte=0" example:"0" minimum:"0"`
+}
@@ -0,0 +1,26 @@
+package request
+
+import "time"
+
+type GetCustomerConsentsRequest struct {
+	CustomerID string `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+type RecordCustomerConsentRequest struct {
+	CustomerID string `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000" swaggerignore:"true"`
+	Purpose    string `json:"purpose" validate:"required,oneof=marketing_email marketing_sms marketing_post third_party" example:"marketing_email" enums:"marketing_email,marketing_sms,marketing_post,third_party"`
+	// trueで同意、falseで撤回
+	Granted *bool  `json:"granted" validate:"required" example:"true"`
+	Source  string `json:"source" validate:"max=255" example:"店頭"`
+	Note    string `json:"note" validate:"max=1000" example:"会員登録時の申込書で同意"`
+	// 同意・撤回の日時。未指定の場合は記録した日時
+	RecordedAt *time.Time `json:"recorded_at" example:"2025-10-31T10:00:00+09:00"`
+}
+
+type GetCustomerPersonalDataRequest struct {
+	CustomerID string `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+type AnonymizeCustomerRequest struct {
+	CustomerID string `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+}
@@ -0,0 +1,45 @@
+package request
+
+// SegmentFilterRequest は顧客セグメントの絞り込み条件。指定した条件をすべて満たす顧客が対象
+type SegmentFilterRequest struct {
+	MinRecencyScore   *int     `json:"min_recency_score" validate:"omitempty,gte=1,lte=5" example:"1" minimum:"1" maximum:"5"`
+	MaxRecencyScore   *int     `json:"max_recency_score" validate:"omitempty,gte=1,lte=5" example:"2" minimum:"1" maximum:"5"`
+	MinFrequencyScore *int     `json:"min_frequency_score" validate:"omitempty,gte=1,lte=5" minimum:"1" maximum:"5"`
+	MaxFrequencyScore *int     `json:"max_frequency_score" validate:"omitempty,gte=1,lte=5" minimum:"1" maximum:"5"`
+	MinMonetaryScore  *int     `json:"min_monetary_score" validate:"omitempty,gte=1,lte=5" example:"4" minimum:"1" maximum:"5"`
+	MaxMonetaryScore  *int     `json:"max_monetary_score" validate:"omitempty,gte=1,lte=5" minimum:"1" maximum:"5"`
+	MinRecencyDays    *int     `json:"min_recency_days" validate:"omitempty,gte=0" example:"180" minimum:"0"`
+	MaxRecencyDays    *int     `json:"max_recency_days" validate:"omitempty,gte=0" minimum:"0"`
+	MinFrequency      *int     `json:"min_frequency" validate:"omitempty,gte=0" minimum:"0"`
+	MinMonetary       *int     `json:"min_monetary" validate:"omitempty,gte=0" example:"100000" minimum:"0"`
+	Prefectures       []string `json:"prefectures" validate:"omitempty,max=47,dive,min=1,max=4" example:"東京都"`
+}
+
+type GetCustomerSegmentRequest struct {
+	SegmentID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type CreateCustomerSegmentRequest struct {
+	Name        string               `json:"name" validate:"required,min=1,max=255" example:"休眠中の優良顧客"`
+	Description string               `json:"description" validate:"max=1000" example:"半年以上購入がなく累計購入額が上位の顧客"`
+	Filter      SegmentFilterRequest `json:"filter"`
+}
+
+type UpdateCustomerSegmentRequest struct {
+	SegmentID   int                  `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	Name        string               `json:"name" validate:"required,min=1,max=255" example:"休眠中の優良顧客"`
+	Description string               `json:"description" validate:"max=1000" example:"半年以上購入がなく累計購入額が上位の顧客"`
+	Filter      SegmentFilterRequest `json:"filter"`
+}
+
+type DeleteCustomerSegmentRequest struct {
+	SegmentID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type GetSegmentCustomersRequest struct {
+	ExportRequest
+
+	SegmentID int  `param:"id" validate:"required,numeric,gt=0" example:"1"`
+	Limit     *int `query:"limit" validate:"omitempty,numeric,gte=0" example:"10" minimum:"0"`
+	Offset    *int `query:"offset" validate:"omitempty,numeric,gte=0" example:"0" minimum:"0"`
+}
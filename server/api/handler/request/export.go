@@ -0,0 +1,8 @@
+package request
+
+// ExportRequest は一覧をファイルとして出力する場合の指定
+// formatを指定しない場合は通常どおりJSONで返す
+type ExportRequest struct {
+	Format   *string `query:"format" validate:"omitempty,oneof=csv xlsx" example:"csv" enums:"csv,xlsx"`
+	Encoding *string `query:"encoding" validate:"omitempty,oneof=utf-8 shift_jis" example:"utf-8" enums:"utf-8,shift_jis"`
+}
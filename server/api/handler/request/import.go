@@ -0,0 +1,29 @@
+package request
+
+type ImportRequest struct {
+	DryRun   bool   `form:"dry_run" example:"true"`
+	Encoding string `form:"encoding" validate:"omitempty,oneof=utf-8 shift_jis" example:"shift_jis"`
+	// 列見出しから項目名への対応（JSON）
+	Mapping string `form:"mapping" validate:"omitempty,json" example:"{\"商品名\":\"name\",\"売価\":\"price\"}"`
+}
+
+type ImportStocksRequest struct {
+	ImportRequest
+
+	DefaultUserID *string `form:"default_user_id" validate:"omitempty,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+type ImportCustomersRequest struct {
+	ImportRequest
+
+	MatchBy string `form:"match_by" validate:"omitempty,oneof=email phone_number" example:"email"`
+}
+
+type GetImportJobsRequest struct {
+	Limit  *int `query:"limit" validate:"omitempty,numeric,gte=0" example:"10" minimum:"0"`
+	Offset *int `query:"offset" validate:"omitempty,numeric,gte=0" example:"0" minimum:"0"`
+}
+
+type GetImportJobRequest struct {
+	JobID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
@@ -0,0 +1,23 @@
+package request
+
+type UpdateInvoiceIssuerRequest struct {
+	// 適格請求書発行事業者の登録番号 (T+13桁)
+	RegistrationNumber string `json:"registration_number" validate:"required,registration_number" example:"T1234567890123"`
+	Name               string `json:"name" validate:"required,max=255" example:"株式会社バイセル"`
+	PostalCode         string `json:"postal_code" validate:"omitempty,jp_postal_code" example:"160-0023"`
+	Address            string `json:"address" validate:"max=255" example:"東京都新宿区西新宿6-8-1"`
+	PhoneNumber        string `json:"phone_number" validate:"max=20" example:"03-1234-5678"`
+}
+
+type GetOrderInvoiceRequest struct {
+	OrderID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type GetInvoiceRequest struct {
+	InvoiceID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type CreateCreditNoteRequest struct {
+	InvoiceID int    `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	Reason    string `json:"reason" validate:"required,max=1000" example:"宛名の誤り"`
+}
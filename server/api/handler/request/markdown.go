@@ -0,0 +1,12 @@
+package request
+
+type MarkdownRuleRequest struct {
+	AgeDays         int `json:"age_days" validate:"required,numeric,gt=0,lte=3650" example:"60" minimum:"1" maximum:"3650"`
+	DiscountPercent int `json:"discount_percent" validate:"required,numeric,gt=0,lt=100" example:"10" minimum:"1" maximum:"99"`
+}
+
+type UpdateMarkdownRulesRequest struct {
+	// 未指定の場合は変更しない
+	FloorAtCost *bool                  `json:"floor_at_cost" example:"true"`
+	Rules       []*MarkdownRuleRequest `json:"rules" validate:"max=20,dive,required"`
+}
@@ -1,6 +1,8 @@
 package request
 
 type GetOrdersRequest struct {
+	ExportRequest
+
 	Limit  *int `query:"limit" validate:"omitempty,numeric,gte=0" example:"10" minimum:"0"`
 	Offset *int `query:"offset" validate:"omitempty,numeric,gte=0" example:"0" minimum:"0"`
 }
@@ -10,15 +12,18 @@ type GetOrderRequest struct {
 }
 
 type CreateOrderRequest struct {
-	TotalAmount  int    `json:"total_amount" validate:"numeric,gte=0" example:"100000" minimum:"0"`
-	Quantity     int    `json:"quantity" validate:"numeric,gte=0" example:"1" minimum:"0"`
-	DeliveryDate string `json:"delivery_date" validate:"required,datetime=2006-01-02,future_date" example:"2022-01-01"`
-	Status       string `json:"status" validate:"oneof=PENDING SHIPPED DELIVERED CANCELLED" example:"PENDING" enum:"PENDING,SHIPPED,DELIVERED,CANCELLED"` // nolint:lll
-	StockID      int    `json:"stock_id" validate:"required,numeric,gt=0" example:"1"`
-	CustomerID   string `json:"customer_id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+	TotalAmount  int     `json:"total_amount" validate:"numeric,gte=0" example:"100000" minimum:"0"`
+	PointsUsed   int     `json:"points_used" validate:"numeric,gte=0,ltefield=TotalAmount" example:"0" minimum:"0"`
+	Quantity     int     `json:"quantity" validate:"numeric,gte=0" example:"1" minimum:"0"`
+	DeliveryDate string  `json:"delivery_date" validate:"required,datetime=2006-01-02,future_date" example:"2022-01-01"`
+	Status       string  `json:"status" validate:"oneof=PENDING SHIPPED DELIVERED CANCELLED" example:"PENDING" enum:"PENDING,SHIPPED,DELIVERED,CANCELLED"` // nolint:lll
+	StockID      int     `json:"stock_id" validate:"required,numeric,gt=0" example:"1"`
+	CustomerID   string  `json:"customer_id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+	CouponCode   *string `json:"coupon_code" validate:"omitempty,alphanum,max=32" example:"AUTUMN2025"`
 }
 
 type CreateBulkOrderRequest struct {
+	Atomic *bool                 `query:"atomic" swaggerignore:"true"`
 	Orders []*CreateOrderRequest `json:"orders" validate:"required,dive"`
 }
 
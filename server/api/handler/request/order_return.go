@@ -0,0 +1,49 @@
+package request
+
+type GetOrderReturnsRequest struct {
+	Status *string `query:"status" validate:"omitempty,oneof=REQUESTED INSPECTED COMPLETED REJECTED" example:"REQUESTED" enums:"REQUESTED,INSPECTED,COMPLETED,REJECTED"`
+	Limit  *int    `query:"limit" validate:"omitempty,numeric,gte=0" example:"10" minimum:"0"`
+	Offset *int    `query:"offset" validate:"omitempty,numeric,gte=0" example:"0" minimum:"0"`
+}
+
+type GetOrderReturnRequest struct {
+	ReturnID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type GetReturnsOfOrderRequest struct {
+	OrderID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type CreateOrderReturnRequest struct {
+	OrderID  int    `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	Quantity int    `json:"quantity" validate:"required,numeric,gt=0" example:"1" minimum:"1"`
+	Reason   string `json:"reason" validate:"required,max=1000" example:"サイズが合わない"`
+}
+
+type InspectOrderReturnRequest struct {
+	ReturnID  int    `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	Condition string `json:"condition" validate:"required,oneof=NEW S A B C JUNK" example:"B" enums:"NEW,S,A,B,C,JUNK"`
+	Note      string `json:"note" validate:"max=1000" example:"持ち手に擦れあり"`
+}
+
+type RejectOrderReturnRequest struct {
+	ReturnID int    `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	Note     string `json:"note" validate:"max=1000" example:"返品期限切れ"`
+}
+
+type CompleteOrderReturnRequest struct {
+	ReturnID   int    `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	Resolution string `json:"resolution" validate:"required,oneof=REFUND EXCHANGE STORE_CREDIT" example:"REFUND" enums:"REFUND,EXCHANGE,STORE_CREDIT"`
+	// 在庫に戻し入れるか。未指定の場合はジャンク以外なら戻し入れる
+	Restock *bool `json:"restock" example:"true"`
+	// 戻し入れる在庫。未指定の場合は返品された在庫に戻し入れる
+	RestockStockID *int `json:"restock_stock_id" validate:"omitempty,numeric,gt=0" example:"2"`
+	// 戻し入れる返品の取得原価 (1点あたり)。未指定の場合は取得原価不明として扱う
+	UnitCost *int `json:"unit_cost" validate:"omitempty,numeric,gte=0" example:"50000" minimum:"0"`
+	// 返金額、またはポイントで返還する額。未指定の場合は発注の支払いが必要な金額を数量で按分した額
+	// 按分した額と、発注の支払いが必要な金額から完了した返品で返した額を差し引いた額を超えられない
+	Amount *int `json:"amount" validate:"omitempty,numeric,gte=0" example:"8000" minimum:"0"`
+	// 交換品の発注。交換の場合は必須で、返品の受付後に作成した同じ顧客の発注のうち、他の返品の交換品でないものに限る
+	ExchangeOrderID *int   `json:"exchange_order_id" validate:"omitempty,numeric,gt=0" example:"3"`
+	Note            string `json:"note" validate:"max=1000" example:""`
+}
@@ -0,0 +1,28 @@
+package request
+
+type GetOrderPaymentsRequest struct {
+	OrderID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type CreatePaymentRequest struct {
+	OrderID int    `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	Method  string `json:"method" validate:"required,oneof=CASH CREDIT_CARD QR BANK_TRANSFER POINTS" example:"CASH" enums:"CASH,CREDIT_CARD,QR,BANK_TRANSFER,POINTS"`
+	Amount  int    `json:"amount" validate:"required,numeric,gt=0" example:"8000" minimum:"1"`
+	// 現金で預かった金額。現金の場合のみ指定できる
+	Tendered *int `json:"tendered" validate:"omitempty,excluded_unless=Method CASH,gtefield=Amount" example:"10000"`
+	// クレジットカード・QRコード決済で使うトークン
+	Token string `json:"token" validate:"max=255" example:"tok_visa"`
+	// 振込の照合番号など。クレジットカード・QRコード決済では決済代行会社の参照番号で置き換える
+	Reference string `json:"reference" validate:"max=255" example:""`
+	Note      string `json:"note" validate:"max=1000" example:""`
+}
+
+type CreateRefundRequest struct {
+	OrderID   int `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	PaymentID int `json:"payment_id" validate:"required,numeric,gt=0" example:"1"`
+	// 未指定の場合は支払いのうち返金していない全額
+	Amount    *int   `json:"amount" validate:"omitempty,numeric,gt=0" example:"8000" minimum:"1"`
+	Cause     string `json:"cause" validate:"required,oneof=CANCELLATION RETURN OTHER" example:"CANCELLATION" enums:"CANCELLATION,RETURN,OTHER"`
+	Reason    string `json:"reason" validate:"max=1000" example:"お客様都合のキャンセル"`
+	Reference string `json:"reference" validate:"max=255" example:""`
+}
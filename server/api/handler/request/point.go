@@ -0,0 +1,11 @@
+package request
+
+type GetPointBalanceRequest struct {
+	CustomerID string `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+}
+
+type GetPointHistoryRequest struct {
+	CustomerID string `param:"id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+	Limit      *int   `query:"limit" validate:"omitempty,numeric,gte=1,lte=100" example:"20" minimum:"1" maximum:"100"`
+	Offset     *int   `query:"offset" validate:"omitempty,numeric,gte=0" example:"0" minimum:"0"`
+}
@@ -0,0 +1,53 @@
+package request
+
+import "time"
+
+// PromotionRequest はキャンペーンの作成・更新で共通の項目。対象の条件は指定したものをすべて満たす発注明細に適用する
+type PromotionRequest struct {
+	Name                  string     `json:"name" validate:"required,min=1,max=255" example:"秋のブランドセール"`
+	Description           string     `json:"description" validate:"max=1000" example:"対象のバッグが10%オフ"`
+	DiscountType          string     `json:"discount_type" validate:"required,oneof=PERCENT FIXED" example:"PERCENT" enums:"PERCENT,FIXED"`
+	DiscountValue         int        `json:"discount_value" validate:"required,numeric,gt=0" example:"10" minimum:"1"`
+	StockID               *int       `json:"stock_id" validate:"omitempty,numeric,gt=0" example:"1"`
+	Category              *string    `json:"category" validate:"omitempty,min=1,max=255" example:"バッグ"`
+	StoreID               *string    `json:"store_id" validate:"omitempty,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+	StartsAt              *time.Time `json:"starts_at" example:"2025-11-01T00:00:00+09:00"`
+	EndsAt                *time.Time `json:"ends_at" example:"2025-12-01T00:00:00+09:00"`
+	MinSpend              int        `json:"min_spend" validate:"numeric,gte=0" example:"10000" minimum:"0"`
+	UsageLimitPerCustomer int        `json:"usage_limit_per_customer" validate:"numeric,gte=0" example:"1" minimum:"0"`
+	UsageLimit            int        `json:"usage_limit" validate:"numeric,gte=0" example:"0" minimum:"0"`
+	RequiresCoupon        bool       `json:"requires_coupon" example:"false"`
+	// 未指定の場合は有効
+	Active *bool `json:"active" example:"true"`
+}
+
+type GetPromotionRequest struct {
+	PromotionID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type CreatePromotionRequest struct {
+	PromotionRequest
+}
+
+type UpdatePromotionRequest struct {
+	PromotionRequest
+
+	PromotionID int `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+}
+
+type DeletePromotionRequest struct {
+	PromotionID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type CreateCouponRequest struct {
+	PromotionID int    `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	Code        string `json:"code" validate:"required,alphanum,min=4,max=32" example:"AUTUMN2025"`
+	UsageLimit  int    `json:"usage_limit" validate:"numeric,gte=0" example:"100" minimum:"0"`
+	// 未指定の場合は有効
+	Active *bool `json:"active" example:"true"`
+}
+
+type DeleteCouponRequest struct {
+	PromotionID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+	CouponID    int `param:"coupon_id" validate:"required,numeric,gt=0" example:"1"`
+}
@@ -0,0 +1,16 @@
+package request
+
+type UpdateReceiptTemplateRequest struct {
+	// 未指定の項目は変更しない
+	PaperWidth       *int    `json:"paper_width" validate:"omitempty,oneof=58 80" example:"80" enums:"58,80"`
+	Header           *string `json:"header" validate:"omitempty,max=500" example:"いつもご利用ありがとうございます"`
+	Footer           *string `json:"footer" validate:"omitempty,max=500" example:"返品・交換はレシートをお持ちのうえ14日以内にお申し付けください"`
+	ShowStoreAddress *bool   `json:"show_store_address" example:"true"`
+	ShowBarcode      *bool   `json:"show_barcode" example:"true"`
+}
+
+type GetOrderReceiptRequest struct {
+	OrderID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+	// 用紙幅 (mm)。未指定の場合は印字設定の用紙幅
+	PaperWidth *int `query:"paper_width" validate:"omitempty,oneof=58 80" example:"58" enums:"58,80"`
+}
@@ -0,0 +1,15 @@
+package request
+
+type GetInventoryValuationRequest struct {
+	AsOf   string  `query:"as_of" validate:"omitempty,datetime=2006-01-02" example:"2025-09-30"`
+	Method *string `query:"method" validate:"omitempty,oneof=MOVING_AVERAGE FIFO" example:"FIFO" enums:"MOVING_AVERAGE,FIFO"`
+}
+
+type GetSalesReportRequest struct {
+	From     string   `query:"from" validate:"omitempty,datetime=2006-01-02" example:"2025-10-01"`
+	To       string   `query:"to" validate:"omitempty,datetime=2006-01-02" example:"2025-10-31"`
+	Period   *string  `query:"period" validate:"omitempty,oneof=day week month" example:"day" enums:"day,week,month"`
+	GroupBy  []string `query:"group_by" validate:"max=4,dive,oneof=store user stock status" example:"store" enums:"store,user,stock,status"`
+	Statuses []string `query:"status" validate:"dive,oneof=PENDING SHIPPED DELIVERED CANCELLED" example:"DELIVERED" enums:"PENDING,SHIPPED,DELIVERED,CANCELLED"` // nolint:lll
+	StoreID  *string  `query:"store_id" validate:"omitempty,uuid" example:"00000000-0000-0000-0000-000000000000"`
+}
@@ -1,6 +1,8 @@
 package request
 
 type GetStocksRequest struct {
+	ExportRequest
+
 	Limit  *int `query:"limit" validate:"omitempty,numeric,gte=0" example:"10" minimum:"0"`
 	Offset *int `query:"offset" validate:"omitempty,numeric,gte=0" example:"0" minimum:"0"`
 }
@@ -15,9 +17,19 @@ type CreateStockRequest struct {
 	Price    int    `json:"price" validate:"required,numeric,gte=0" example:"100000" minimum:"0"`
 	StoreID  string `json:"store_id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
 	UserID   string `json:"user_id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+	// 識別コード
+	Barcode      *string `json:"barcode" validate:"omitempty,min=1,max=128,printascii" example:"BS-000001"`
+	JAN          *string `json:"jan" validate:"omitempty,jan" example:"4901234567894"`
+	SerialNumber *string `json:"serial_number" validate:"omitempty,min=1,max=255,printascii" example:"SN12345678"`
+	Category     *string `json:"category" validate:"omitempty,min=1,max=255" example:"バッグ"`
+	// 消費税率 (%)。未指定の場合は標準税率
+	TaxRate *int `json:"tax_rate" validate:"omitempty,oneof=8 10" example:"10" enums:"8,10"`
+	// 取得原価(1点あたり)
+	UnitCost *int `json:"unit_cost" validate:"omitempty,gte=0" example:"80000" minimum:"0"`
 }
 
 type CreateBulkStockRequest struct {
+	Atomic *bool                 `query:"atomic" swaggerignore:"true"`
 	Stocks []*CreateStockRequest `json:"stocks" validate:"required,dive"`
 }
 
@@ -28,8 +40,53 @@ type UpdateStockRequest struct {
 	Price    int    `json:"price" validate:"required,numeric,gte=0" example:"100000" minimum:"0"`
 	StoreID  string `json:"store_id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
 	UserID   string `json:"user_id" validate:"required,uuid4" example:"00000000-0000-0000-0000-000000000000"`
+	// 識別コード
+	Barcode      *string `json:"barcode" validate:"omitempty,min=1,max=128,printascii" example:"BS-000001"`
+	JAN          *string `json:"jan" validate:"omitempty,jan" example:"4901234567894"`
+	SerialNumber *string `json:"serial_number" validate:"omitempty,min=1,max=255,printascii" example:"SN12345678"`
+	Category     *string `json:"category" validate:"omitempty,min=1,max=255" example:"バッグ"`
+	// 消費税率 (%)。未指定の場合は変更しない
+	TaxRate *int `json:"tax_rate" validate:"omitempty,oneof=8 10" example:"10" enums:"8,10"`
 }
 
 type DeleteStockRequest struct {
 	StockID string `param:"id" validate:"required,numeric,gt=0" example:"1"`
 }
+
+type LookupStockRequest struct {
+	Code string `query:"code" validate:"required,min=1,max=255" example:"4901234567894"`
+}
+
+type GetStockBarcodeRequest struct {
+	StockID     string `param:"id" validate:"required,numeric,gt=0" example:"1"`
+	ModuleWidth int    `query:"module_width" validate:"omitempty,gte=1,lte=10" example:"2" minimum:"1" maximum:"10"`
+	Height      int    `query:"height" validate:"omitempty,gte=10,lte=1000" example:"80" minimum:"10" maximum:"1000"`
+}
+
+type GenerateStockLabelsRequest struct {
+	StockIDs []int                `json:"stock_ids" validate:"required,min=1,max=500,dive,gt=0" example:"1,2,3"`
+	Template LabelTemplateRequest `json:"template" validate:"required"`
+}
+
+type LabelTemplateRequest struct {
+	WidthMM  float64  `json:"width_mm" validate:"required,gte=20,lte=210" example:"40" minimum:"20" maximum:"210"`
+	HeightMM float64  `json:"height_mm" validate:"required,gte=10,lte=297" example:"30" minimum:"10" maximum:"297"`
+	Fields   []string `json:"fields" validate:"required,min=1,dive,oneof=name price code serial_number stock_id" example:"name,price" enums:"name,price,code,serial_number,stock_id"` // nolint:lll
+	Barcode  bool     `json:"barcode" example:"true"`
+}
+
+type GetStockMovementsRequest struct {
+	ExportRequest
+
+	StockID string `param:"id" validate:"required,numeric,gt=0" example:"1"`
+	Limit   *int   `query:"limit" validate:"omitempty,numeric,gte=0" example:"10" minimum:"0"`
+	Offset  *int   `query:"offset" validate:"omitempty,numeric,gte=0" example:"0" minimum:"0"`
+}
+
+type ReceiveStockRequest struct {
+	StockID    string `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	Quantity   int    `json:"quantity" validate:"required,gt=0" example:"1" minimum:"1"`
+	UnitCost   int    `json:"unit_cost" validate:"gte=0" example:"80000" minimum:"0"`
+	ReceivedAt string `json:"received_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-10-01T10:00:00+09:00"`
+	Note       string `json:"note" validate:"max=255" example:"買取"`
+}
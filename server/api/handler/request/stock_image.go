@@ -0,0 +1,24 @@
+package request
+
+type GetStockImagesRequest struct {
+	StockID string `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type UploadStockImageRequest struct {
+	StockID string `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type ReorderStockImagesRequest struct {
+	StockID  string `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	ImageIDs []int  `json:"image_ids" validate:"required,min=1,dive,gt=0" example:"3,1,2"`
+}
+
+type DeleteStockImageRequest struct {
+	StockID string `param:"id" validate:"required,numeric,gt=0" example:"1"`
+	ImageID int    `param:"image_id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type GetMediaRequest struct {
+	Expires   int64  `query:"expires" validate:"required,gt=0" example:"1700000000"`
+	Signature string `query:"signature" validate:"required,hexadecimal" example:"0a1b2c"`
+}
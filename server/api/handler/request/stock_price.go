@@ -0,0 +1,19 @@
+package request
+
+type GetStockPriceHistoryRequest struct {
+	StockID string `param:"id" validate:"required,numeric,gt=0" example:"1"`
+	From    string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-10-01T00:00:00+09:00"`
+	To      string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-11-01T00:00:00+09:00"`
+}
+
+type SchedulePriceChangeRequest struct {
+	StockID       string `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	Price         int    `json:"price" validate:"numeric,gte=0" example:"90000" minimum:"0"`
+	EffectiveFrom string `json:"effective_from" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2025-11-03T09:00:00+09:00"`
+	Reason        string `json:"reason" validate:"max=255" example:"週明けの値下げ"`
+}
+
+type CancelPriceChangeRequest struct {
+	StockID  string `param:"id" validate:"required,numeric,gt=0" example:"1"`
+	ChangeID int    `param:"change_id" validate:"required,numeric,gt=0" example:"1"`
+}
@@ -0,0 +1,44 @@
+package request
+
+type GetStocktakesRequest struct {
+	ExportRequest
+
+	Limit  *int `query:"limit" validate:"omitempty,numeric,gte=0" example:"10" minimum:"0"`
+	Offset *int `query:"offset" validate:"omitempty,numeric,gte=0" example:"0" minimum:"0"`
+}
+
+type GetStocktakeRequest struct {
+	StocktakeID int `param:"id" validate:"required,numeric,gt=0" example:"1"`
+}
+
+type StartStocktakeRequest struct {
+	Note string `json:"note" validate:"max=255" example:"2025年10月 月末棚卸"`
+}
+
+type SubmitStocktakeCountsRequest struct {
+	StocktakeID int                     `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	DeviceID    string                  `json:"device_id" validate:"required,max=255" example:"handy-01"`
+	Counts      []StocktakeCountRequest `json:"counts" validate:"required,min=1,max=10000,dive"`
+}
+
+type StocktakeCountRequest struct {
+	StockID  *int    `json:"stock_id" validate:"required_without=Code,omitempty,gt=0" example:"1"`
+	Code     *string `json:"code" validate:"required_without=StockID,omitempty,max=255" example:"4901234567894"`
+	Quantity int     `json:"quantity" validate:"gte=0" example:"3" minimum:"0"`
+}
+
+type GetStocktakeVariancesRequest struct {
+	StocktakeID     int  `param:"id" validate:"required,numeric,gt=0" example:"1"`
+	DiscrepancyOnly bool `query:"discrepancy_only" example:"true"`
+}
+
+type ApproveStocktakeItemsRequest struct {
+	StocktakeID int                           `param:"id" validate:"required,numeric,gt=0" example:"1" swaggerignore:"true"`
+	Items       []ApproveStocktakeItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type ApproveStocktakeItemRequest struct {
+	StockID    int    `json:"stock_id" validate:"required,gt=0" example:"1"`
+	ReasonCode string `json:"reason_code" validate:"required,oneof=DAMAGE THEFT LOST FOUND MISCOUNT OTHER" example:"DAMAGE" enums:"DAMAGE,THEFT,LOST,FOUND,MISCOUNT,OTHER"` // nolint:lll
+	Note       string `json:"note" validate:"max=255" example:"落下による破損"`
+}
@@ -0,0 +1,8 @@
+package request
+
+type UpdateTenantSettingRequest struct {
+	ValuationMethod string `json:"valuation_method" validate:"required,oneof=MOVING_AVERAGE FIFO" example:"MOVING_AVERAGE" enums:"MOVING_AVERAGE,FIFO"`
+	// 未指定の場合は変更しない
+	PointEarnUnit     *int `json:"point_earn_unit" validate:"omitempty,numeric,gte=0" example:"100" minimum:"0"`
+	PointExpiryMonths *int `json:"point_expiry_months" validate:"omitempty,numeric,gte=0,lte=120" example:"12" minimum:"0" maximum:"120"`
+}
@@ -1,6 +1,8 @@
 package request
 
 type GetUsersRequest struct {
+	ExportRequest
+
 	Limit  *int `query:"limit" validate:"omitempty,numeric,gte=0" example:"10" minimum:"0"`
 	Offset *int `query:"offset" validate:"omitempty,numeric,gte=0" example:"0" minimum:"0"`
 }
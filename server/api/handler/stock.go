@@ -1,24 +1,33 @@
 package handler
 
 import (
+	"bytes"
+	"errors"
+	"image/png"
 	"net/http"
 
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/label"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
 	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 // GetStocks godoc
 //
 //	@Summary		在庫一覧の取得
 //	@Description	在庫一覧の取得
-//	@Produce		json
+//	@Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 //	@Security		ApiKeyAuth
-//	@Param			limit	query		int		false	"取得件数"	minimum(0)	example(10)
-//	@Param			offset	query		int		false	"取得開始位置"	minimum(0)	example(0)
-//	@Success		200	{object}	[]model.Stock
-//	@Failure		400	{object}	error
-//	@Failure		500	{object}	error
+//	@Param			limit		query		int		false	"取得件数"								minimum(0)	example(10)
+//	@Param			offset		query		int		false	"取得開始位置"							minimum(0)	example(0)
+//	@Param			format		query		string	false	"出力形式（指定時は件数の指定がなければ全件をファイルで出力）"	Enums(csv, xlsx)
+//	@Param			encoding	query		string	false	"CSVの文字コード（既定はBOM付きUTF-8）"			Enums(utf-8, shift_jis)
+//	@Success		200			{object}	[]model.Stock
+//	@Failure		400			{object}	error
+//	@Failure		500			{object}	error
 //	@Router			/stocks [get]
 func (h *Handler) GetStocks(c echo.Context) error {
 	ctx := h.GetCtx(c)
@@ -34,11 +43,24 @@ func (h *Handler) GetStocks(c echo.Context) error {
 			WithInternal(err)
 	}
 
-	stocks, err := h.Usecase.GetStocks(ctx, usecaseRequest.GetStocksRequest{
+	input := usecaseRequest.GetStocksRequest{
 		StoreID: c.Get("store_id").(string),
 		Limit:   req.Limit,
 		Offset:  req.Offset,
-	})
+	}
+
+	if req.Format != nil {
+		if err := h.export(c, req.ExportRequest, "stocks", func(w sheet.Writer) error {
+			return h.Usecase.ExportStocks(ctx, input, w)
+		}); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err).
+				WithInternal(err)
+		}
+
+		return nil
+	}
+
+	stocks, err := h.Usecase.GetStocks(ctx, input)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -91,6 +113,7 @@ func (h *Handler) GetStock(c echo.Context) error {
 //	@Param			req	body		request.CreateStockRequest	true	"在庫情報"
 //	@Success		201	{object}	int
 //	@Failure		400	{object}	error
+//	@Failure		409	{object}	error
 //	@Failure		500	{object}	error
 //	@Router			/stocks [post]
 func (h *Handler) CreateStock(c echo.Context) error {
@@ -113,7 +136,18 @@ func (h *Handler) CreateStock(c echo.Context) error {
 		Price:    req.Price,
 		StoreID:  req.StoreID,
 		UserID:   req.UserID,
+		// 識別コード
+		Barcode:      req.Barcode,
+		JAN:          req.JAN,
+		SerialNumber: req.SerialNumber,
+		Category:     req.Category,
+		TaxRate:      req.TaxRate,
+		UnitCost:     req.UnitCost,
 	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -126,23 +160,51 @@ func (h *Handler) CreateStock(c echo.Context) error {
 //
 //	@Summary		在庫の一括作成
 //	@Description	在庫の一括作成
+//	@Description	atomicを指定すると1件ごとの結果（添字・状態・作成したID・エラー）を返す
+//	@Description	atomic=trueは全件を1つのトランザクションで作成し、1件でも失敗すれば全件を取り消す
+//	@Description	atomic=falseは作成できた行を確定し、一部が失敗した場合は207を返す
 //	@Accept			json
 //	@Produce		json
 //	@Security		ApiKeyAuth
-//	@Param			req	body		request.CreateBulkStockRequest	true	"在庫情報"
-//	@Success		201	{object}	[]int
-//	@Failure		400	{object}	error
-//	@Failure		500	{object}	error
+//	@Param			atomic	query		bool							false	"1件ごとの結果を返す。trueは全件成功した場合のみ作成する"
+//	@Param			req		body		request.CreateBulkStockRequest	true	"在庫情報"
+//	@Success		201		{object}	[]int							"atomic未指定の場合"
+//	@Success		207		{object}	model.BulkResult				"atomic=falseで一部の行が失敗した場合"
+//	@Failure		400		{object}	model.BulkResult
+//	@Failure		409		{object}	model.BulkResult
+//	@Failure		413		{object}	error
+//	@Failure		500		{object}	error
 //	@Router			/stocks/bulk [post]
 func (h *Handler) CreateBulkStock(c echo.Context) error {
 	ctx := h.GetCtx(c)
 
 	var req request.CreateBulkStockRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
 	if err := c.Bind(&req.Stocks); err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
 	}
 
+	if req.Atomic != nil {
+		input := usecaseRequest.CreateBulkStockItemsRequest{
+			Stocks: make([]usecaseRequest.CreateStockRequest, len(req.Stocks)),
+			Errors: make([]error, len(req.Stocks)),
+			Atomic: *req.Atomic,
+		}
+		for i, stock := range req.Stocks {
+			if input.Errors[i] = validateBulkItem(c, stock); input.Errors[i] == nil {
+				input.Stocks[i] = convertCreateStockRequest(stock)
+			}
+		}
+
+		result, err := h.Usecase.CreateBulkStockItems(ctx, input)
+
+		return bulkResponse(c, result, err)
+	}
+
 	if err := c.Validate(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err).
 			WithInternal(err)
@@ -150,16 +212,18 @@ func (h *Handler) CreateBulkStock(c echo.Context) error {
 
 	var stocks []usecaseRequest.CreateStockRequest
 	for _, stock := range req.Stocks {
-		stocks = append(stocks, usecaseRequest.CreateStockRequest{
-			Name:     stock.Name,
-			Quantity: stock.Quantity,
-			Price:    stock.Price,
-			StoreID:  stock.StoreID,
-			UserID:   stock.UserID,
-		})
+		stocks = append(stocks, convertCreateStockRequest(stock))
 	}
 
 	stockIDs, err := h.Usecase.CreateBulkStock(ctx, stocks)
+	if errors.Is(err, usecase.ErrTooManyBulkItems) {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -175,10 +239,11 @@ func (h *Handler) CreateBulkStock(c echo.Context) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		ApiKeyAuth
-//	@Param			id		path		int						true	"在庫ID"		minimum(1)
-//	@Param			req		body		request.UpdateStockRequest	true	"在庫情報"
+//	@Param			id	path		int							true	"在庫ID"	minimum(1)
+//	@Param			req	body		request.UpdateStockRequest	true	"在庫情報"
 //	@Success		200	{object}	model.Stock
 //	@Failure		400	{object}	error
+//	@Failure		409	{object}	error
 //	@Failure		500	{object}	error
 //	@Router			/stocks/{id} [put]
 func (h *Handler) UpdateStock(c echo.Context) error {
@@ -202,7 +267,17 @@ func (h *Handler) UpdateStock(c echo.Context) error {
 		Price:    req.Price,
 		StoreID:  req.StoreID,
 		UserID:   req.UserID,
+		// 識別コード
+		Barcode:      req.Barcode,
+		JAN:          req.JAN,
+		SerialNumber: req.SerialNumber,
+		Category:     req.Category,
+		TaxRate:      req.TaxRate,
 	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -244,3 +319,186 @@ func (h *Handler) DeleteStock(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// LookupStock godoc
+//
+//	@Summary		識別コードによる在庫の検索
+//	@Description	バーコード、JAN、シリアル番号のいずれかに一致する在庫を取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			code	query		string	true	"バーコード / JAN / シリアル番号"	example(4901234567894)
+//	@Success		200		{object}	model.Stock
+//	@Failure		400		{object}	error
+//	@Failure		404		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/stocks/lookup [get]
+func (h *Handler) LookupStock(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.LookupStockRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	stock, err := h.Usecase.LookupStock(ctx, c.Get("store_id").(string), req.Code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, stock)
+}
+
+// GetStockBarcode godoc
+//
+//	@Summary		在庫のバーコード画像の取得
+//	@Description	ラベル印刷用のCode128バーコード画像(PNG)を取得する
+//	@Description	バーコード、JAN、シリアル番号の順に登録済みのコードを使用する
+//	@Produce		png
+//	@Security		ApiKeyAuth
+//	@Param			id				path		int	true	"在庫ID"			minimum(1)
+//	@Param			module_width	query		int	false	"1モジュールの幅(px)"	minimum(1)	maximum(10)		example(2)
+//	@Param			height			query		int	false	"高さ(px)"		minimum(10)	maximum(1000)	example(80)
+//	@Success		200				{file}		binary
+//	@Failure		400				{object}	error
+//	@Failure		404				{object}	error
+//	@Failure		500				{object}	error
+//	@Router			/stocks/{id}/barcode [get]
+func (h *Handler) GetStockBarcode(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetStockBarcodeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	img, err := h.Usecase.GetStockBarcode(ctx, usecaseRequest.GetStockBarcodeRequest{
+		StoreID:     c.Get("store_id").(string),
+		StockID:     req.StockID,
+		ModuleWidth: req.ModuleWidth,
+		Height:      req.Height,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, usecase.ErrStockCodeNotSet) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrStockCodeNotEncodable) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.Blob(http.StatusOK, "image/png", buf.Bytes())
+}
+
+// GenerateStockLabels godoc
+//
+//	@Summary		在庫ラベルPDFの生成
+//	@Description	指定した在庫の値札・ラベルを在庫数量1単位につき1枚のPDFとして生成する
+//	@Description	1ページが1ラベルで、ページサイズはテンプレートのラベルサイズになる
+//	@Accept			json
+//	@Produce		application/pdf
+//	@Security		ApiKeyAuth
+//	@Param			req	body		request.GenerateStockLabelsRequest	true	"出力条件"
+//	@Success		200	{file}		binary
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Failure		503	{object}	error
+//	@Router			/stocks/labels [post]
+func (h *Handler) GenerateStockLabels(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GenerateStockLabelsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	fields := make([]label.Field, 0, len(req.Template.Fields))
+	for _, field := range req.Template.Fields {
+		fields = append(fields, label.Field(field))
+	}
+
+	pdf, err := h.Usecase.GenerateStockLabels(ctx, usecaseRequest.GenerateStockLabelsRequest{
+		StoreID:  c.Get("store_id").(string),
+		StockIDs: req.StockIDs,
+		Template: label.Template{
+			WidthMM:  req.Template.WidthMM,
+			HeightMM: req.Template.HeightMM,
+			Fields:   fields,
+			Barcode:  req.Template.Barcode,
+		},
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrNoLabels) || errors.Is(err, usecase.ErrTooManyLabels) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrStockCodeNotEncodable) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrFontUnavailable) {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="labels.pdf"`)
+
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
+
+func convertCreateStockRequest(stock *request.CreateStockRequest) usecaseRequest.CreateStockRequest {
+	return usecaseRequest.CreateStockRequest{
+		Name:     stock.Name,
+		Quantity: stock.Quantity,
+		Price:    stock.Price,
+		StoreID:  stock.StoreID,
+		UserID:   stock.UserID,
+		// 識別コード
+		Barcode:      stock.Barcode,
+		JAN:          stock.JAN,
+		SerialNumber: stock.SerialNumber,
+		Category:     stock.Category,
+		TaxRate:      stock.TaxRate,
+		UnitCost:     stock.UnitCost,
+	}
+}
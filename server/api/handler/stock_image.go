@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/storage"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetStockImages godoc
+//
+//	@Summary		在庫画像一覧の取得
+//	@Description	在庫画像を表示順に取得する。URLは有効期限付きの署名付きURL
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"在庫ID"	minimum(1)
+//	@Success		200	{object}	[]model.StockImage
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/stocks/{id}/images [get]
+func (h *Handler) GetStockImages(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetStockImagesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	images, err := h.Usecase.GetStockImages(ctx, c.Get("store_id").(string), req.StockID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, images)
+}
+
+// UploadStockImage godoc
+//
+//	@Summary		在庫画像のアップロード
+//	@Description	在庫画像をアップロードし、サムネイルを生成する
+//	@Description	対応形式はJPEG/PNG/GIF/WebPで、形式はファイルの内容から判定する
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		int		true	"在庫ID"	minimum(1)
+//	@Param			image	formData	file	true	"画像ファイル"
+//	@Success		201		{object}	model.StockImage
+//	@Failure		400		{object}	error
+//	@Failure		404		{object}	error
+//	@Failure		413		{object}	error
+//	@Failure		415		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/stocks/{id}/images [post]
+func (h *Handler) UploadStockImage(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.UploadStockImageRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+	defer file.Close()
+
+	image, err := h.Usecase.UploadStockImage(ctx, usecaseRequest.UploadStockImageRequest{
+		StoreID: c.Get("store_id").(string),
+		StockID: req.StockID,
+		Size:    fileHeader.Size,
+		Body:    file,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrImageTooLarge) {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrUnsupportedImageType) {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, image)
+}
+
+// ReorderStockImages godoc
+//
+//	@Summary		在庫画像の並び替え
+//	@Description	登録済みの画像IDをすべて表示順に指定する
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int									true	"在庫ID"	minimum(1)
+//	@Param			req	body		request.ReorderStockImagesRequest	true	"表示順"
+//	@Success		200	{object}	[]model.StockImage
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/stocks/{id}/images/order [put]
+func (h *Handler) ReorderStockImages(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.ReorderStockImagesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	images, err := h.Usecase.ReorderStockImages(ctx, usecaseRequest.ReorderStockImagesRequest{
+		StoreID:  c.Get("store_id").(string),
+		StockID:  req.StockID,
+		ImageIDs: req.ImageIDs,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrInvalidImageOrder) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, images)
+}
+
+// DeleteStockImage godoc
+//
+//	@Summary		在庫画像の削除
+//	@Description	在庫画像の削除
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id			path		int	true	"在庫ID"	minimum(1)
+//	@Param			image_id	path		int	true	"画像ID"	minimum(1)
+//	@Success		204			{string}	string
+//	@Failure		400			{object}	error
+//	@Failure		404			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/stocks/{id}/images/{image_id} [delete]
+func (h *Handler) DeleteStockImage(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.DeleteStockImageRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	err := h.Usecase.DeleteStockImage(ctx, c.Get("store_id").(string), req.StockID, req.ImageID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetMedia は署名付きURLで保存済みのファイルを配信する
+// ファイルシステムストレージ利用時のみ有効で、認証は署名で代替する
+func (h *Handler) GetMedia(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetMediaRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	body, contentType, err := h.Usecase.GetMedia(ctx, c.Param("*"), req.Expires, req.Signature)
+	if errors.Is(err, storage.ErrInvalidSignature) {
+		return echo.NewHTTPError(http.StatusForbidden, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, storage.ErrNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+	defer body.Close()
+
+	return c.Stream(http.StatusOK, contentType, body)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetStockMovements godoc
+//
+//	@Summary		在庫の入出庫履歴の取得
+//	@Description	入庫・販売・数量調整などの履歴を新しい順に取得する
+//	@Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Security		ApiKeyAuth
+//	@Param			id			path		int		true	"在庫ID"								minimum(1)
+//	@Param			limit		query		int		false	"取得件数"								minimum(0)	example(10)
+//	@Param			offset		query		int		false	"取得開始位置"							minimum(0)	example(0)
+//	@Param			format		query		string	false	"出力形式（指定時は件数の指定がなければ全件をファイルで出力）"	Enums(csv, xlsx)
+//	@Param			encoding	query		string	false	"CSVの文字コード（既定はBOM付きUTF-8）"			Enums(utf-8, shift_jis)
+//	@Success		200			{object}	[]model.StockMovement
+//	@Failure		400			{object}	error
+//	@Failure		404			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/stocks/{id}/movements [get]
+func (h *Handler) GetStockMovements(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetStockMovementsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	input := usecaseRequest.GetStockMovementsRequest{
+		StoreID: c.Get("store_id").(string),
+		StockID: req.StockID,
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+	}
+
+	if req.Format != nil {
+		if err := h.export(c, req.ExportRequest, "stock_movements", func(w sheet.Writer) error {
+			return h.Usecase.ExportStockMovements(ctx, input, w)
+		}); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err).
+					WithInternal(err)
+			}
+			return echo.NewHTTPError(http.StatusInternalServerError, err).
+				WithInternal(err)
+		}
+
+		return nil
+	}
+
+	movements, err := h.Usecase.GetStockMovements(ctx, input)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, movements)
+}
+
+// ReceiveStock godoc
+//
+//	@Summary		在庫の入庫
+//	@Description	取得原価を付けて在庫を入庫し、在庫数量を増やす
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int							true	"在庫ID"	minimum(1)
+//	@Param			req	body		request.ReceiveStockRequest	true	"入庫情報"
+//	@Success		201	{object}	model.StockMovement
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/stocks/{id}/receipts [post]
+func (h *Handler) ReceiveStock(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.ReceiveStockRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	var receivedAt *time.Time
+	if req.ReceivedAt != "" {
+		t, err := time.Parse(time.RFC3339, req.ReceivedAt)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err).
+				WithInternal(err)
+		}
+		receivedAt = &t
+	}
+
+	movement, err := h.Usecase.ReceiveStock(ctx, usecaseRequest.ReceiveStockRequest{
+		StoreID:    c.Get("store_id").(string),
+		StockID:    req.StockID,
+		Quantity:   req.Quantity,
+		UnitCost:   req.UnitCost,
+		ReceivedAt: receivedAt,
+		Note:       req.Note,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, movement)
+}
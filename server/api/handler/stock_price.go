@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetStockPriceHistory godoc
+//
+//	@Summary		在庫の販売価格の推移の取得
+//	@Description	適用済みの価格の変更を有効日時の古い順に、適用待ちの変更を有効日時の近い順に取得する
+//	@Description	期間は適用済みの変更の有効日時で絞り込む
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		int		true	"在庫ID"							minimum(1)
+//	@Param			from	query		string	false	"期間の開始日時 (RFC3339)"				example(2025-10-01T00:00:00+09:00)
+//	@Param			to		query		string	false	"期間の終了日時 (RFC3339、この日時を含まない)"	example(2025-11-01T00:00:00+09:00)
+//	@Success		200		{object}	model.StockPriceHistory
+//	@Failure		400		{object}	error
+//	@Failure		404		{object}	error
+//	@Failure		500		{object}	error
+//	@Router			/stocks/{id}/prices [get]
+func (h *Handler) GetStockPriceHistory(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetStockPriceHistoryRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	from, err := parseOptionalTime(req.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	to, err := parseOptionalTime(req.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	history, err := h.Usecase.GetStockPriceHistory(ctx, usecaseRequest.GetStockPriceHistoryRequest{
+		StoreID: c.Get("store_id").(string),
+		StockID: req.StockID,
+		From:    from,
+		To:      to,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
+
+// SchedulePriceChange godoc
+//
+//	@Summary		在庫の販売価格の変更の予約
+//	@Description	有効日時に販売価格を変更する。有効日時を過ぎるとワーカーが適用する
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int									true	"在庫ID"	minimum(1)
+//	@Param			req	body		request.SchedulePriceChangeRequest	true	"予約内容"
+//	@Success		201	{object}	model.StockPriceChange
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/stocks/{id}/price-changes [post]
+func (h *Handler) SchedulePriceChange(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.SchedulePriceChangeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	effectiveFrom, err := time.Parse(time.RFC3339, req.EffectiveFrom)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	change, err := h.Usecase.SchedulePriceChange(ctx, usecaseRequest.SchedulePriceChangeRequest{
+		StoreID:       c.Get("store_id").(string),
+		StockID:       req.StockID,
+		Price:         req.Price,
+		EffectiveFrom: effectiveFrom,
+		Reason:        req.Reason,
+	})
+	if errors.Is(err, usecase.ErrPriceChangeNotInFuture) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, change)
+}
+
+// CancelPriceChange godoc
+//
+//	@Summary		予約した販売価格の変更の取り消し
+//	@Description	適用待ちの価格の変更を取り消す。適用済みの変更は取り消せない
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id			path		int	true	"在庫ID"		minimum(1)
+//	@Param			change_id	path		int	true	"価格の変更ID"	minimum(1)
+//	@Success		200			{object}	model.StockPriceChange
+//	@Failure		400			{object}	error
+//	@Failure		404			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/stocks/{id}/price-changes/{change_id} [delete]
+func (h *Handler) CancelPriceChange(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.CancelPriceChangeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	change, err := h.Usecase.CancelPriceChange(ctx, c.Get("store_id").(string), req.StockID, req.ChangeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, change)
+}
+
+// parseOptionalTime はRFC3339の日時を解釈する。空の場合はnilを返す
+func parseOptionalTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,446 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// GetStocktakes godoc
+//
+//	@Summary		棚卸一覧の取得
+//	@Description	店舗の棚卸を新しい順に取得する
+//	@Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Security		ApiKeyAuth
+//	@Param			limit		query		int		false	"取得件数"								minimum(0)	example(10)
+//	@Param			offset		query		int		false	"取得開始位置"							minimum(0)	example(0)
+//	@Param			format		query		string	false	"出力形式（指定時は件数の指定がなければ全件をファイルで出力）"	Enums(csv, xlsx)
+//	@Param			encoding	query		string	false	"CSVの文字コード（既定はBOM付きUTF-8）"			Enums(utf-8, shift_jis)
+//	@Success		200			{object}	[]model.Stocktake
+//	@Failure		400			{object}	error
+//	@Failure		500			{object}	error
+//	@Router			/stocktakes [get]
+func (h *Handler) GetStocktakes(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetStocktakesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	input := usecaseRequest.GetStocktakesRequest{
+		StoreID: c.Get("store_id").(string),
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+	}
+
+	if req.Format != nil {
+		if err := h.export(c, req.ExportRequest, "stocktakes", func(w sheet.Writer) error {
+			return h.Usecase.ExportStocktakes(ctx, input, w)
+		}); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err).
+				WithInternal(err)
+		}
+
+		return nil
+	}
+
+	stocktakes, err := h.Usecase.GetStocktakes(ctx, input)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, stocktakes)
+}
+
+// GetStocktake godoc
+//
+//	@Summary	棚卸の取得
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		int	true	"棚卸ID"	minimum(1)
+//	@Success	200	{object}	model.Stocktake
+//	@Failure	400	{object}	error
+//	@Failure	404	{object}	error
+//	@Failure	500	{object}	error
+//	@Router		/stocktakes/{id} [get]
+func (h *Handler) GetStocktake(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetStocktakeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	stocktake, err := h.Usecase.GetStocktake(ctx, c.Get("store_id").(string), req.StocktakeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, stocktake)
+}
+
+// StartStocktake godoc
+//
+//	@Summary		棚卸の開始
+//	@Description	店舗の全在庫の現在数量をスナップショットとして保存し、カウントを開始する
+//	@Description	進行中の棚卸がある店舗では開始できない
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			req	body		request.StartStocktakeRequest	true	"棚卸情報"
+//	@Success		201	{object}	model.Stocktake
+//	@Failure		400	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/stocktakes [post]
+func (h *Handler) StartStocktake(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.StartStocktakeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	stocktake, err := h.Usecase.StartStocktake(ctx, usecaseRequest.StartStocktakeRequest{
+		StoreID: c.Get("store_id").(string),
+		Note:    req.Note,
+	})
+	if errors.Is(err, usecase.ErrStocktakeInProgress) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusCreated, stocktake)
+}
+
+// SubmitStocktakeCounts godoc
+//
+//	@Summary		棚卸の実数の登録
+//	@Description	端末ごとのカウント結果を登録する。在庫は在庫IDまたは識別コードで指定する
+//	@Description	同じ端末から再送された在庫は上書きし、複数端末のカウントは合計して実数とする
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int										true	"棚卸ID"	minimum(1)
+//	@Param			req	body		request.SubmitStocktakeCountsRequest	true	"カウント結果"
+//	@Success		200	{object}	[]model.StocktakeVariance
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/stocktakes/{id}/counts [post]
+func (h *Handler) SubmitStocktakeCounts(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.SubmitStocktakeCountsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	counts := make([]usecaseRequest.StocktakeCount, 0, len(req.Counts))
+	for _, count := range req.Counts {
+		counts = append(counts, usecaseRequest.StocktakeCount{
+			StockID:  count.StockID,
+			Code:     count.Code,
+			Quantity: count.Quantity,
+		})
+	}
+
+	variances, err := h.Usecase.SubmitStocktakeCounts(ctx, usecaseRequest.SubmitStocktakeCountsRequest{
+		StoreID:     c.Get("store_id").(string),
+		StocktakeID: req.StocktakeID,
+		DeviceID:    req.DeviceID,
+		Counts:      counts,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrStockNotInStocktake) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrStocktakeStatus) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, variances)
+}
+
+// GetStocktakeVariances godoc
+//
+//	@Summary		棚卸の差異一覧の取得
+//	@Description	帳簿数量と実数の差異、販売価格で換算した差異金額を取得する
+//	@Description	discrepancy_only を指定すると差異のある在庫と未カウントの在庫のみを返す
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id					path		int		true	"棚卸ID"	minimum(1)
+//	@Param			discrepancy_only	query		bool	false	"差異のみ"
+//	@Success		200					{object}	[]model.StocktakeVariance
+//	@Failure		400					{object}	error
+//	@Failure		404					{object}	error
+//	@Failure		500					{object}	error
+//	@Router			/stocktakes/{id}/variances [get]
+func (h *Handler) GetStocktakeVariances(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetStocktakeVariancesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	variances, err := h.Usecase.GetStocktakeVariances(ctx, usecaseRequest.GetStocktakeVariancesRequest{
+		StoreID:         c.Get("store_id").(string),
+		StocktakeID:     req.StocktakeID,
+		DiscrepancyOnly: req.DiscrepancyOnly,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, variances)
+}
+
+// CloseStocktake godoc
+//
+//	@Summary		棚卸のカウント締め切り
+//	@Description	カウントを締め切り、差異確認に進める
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"棚卸ID"	minimum(1)
+//	@Success		200	{object}	model.Stocktake
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/stocktakes/{id}/close [post]
+func (h *Handler) CloseStocktake(c echo.Context) error {
+	return h.changeStocktakeStatus(c, h.Usecase.CloseStocktake)
+}
+
+// ApproveStocktakeItems godoc
+//
+//	@Summary		棚卸の差異の承認
+//	@Description	差異を理由コード付きで承認し、在庫数量を実数に合わせる
+//	@Description	帳簿数量にスナップショット以降の入出庫を加えた数量と実数との差を調整として記録する
+//	@Description	調整は入出庫履歴に STOCKTAKE として記録される
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int										true	"棚卸ID"	minimum(1)
+//	@Param			req	body		request.ApproveStocktakeItemsRequest	true	"承認内容"
+//	@Success		200	{object}	[]model.StocktakeVariance
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/stocktakes/{id}/approve [post]
+func (h *Handler) ApproveStocktakeItems(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.ApproveStocktakeItemsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	items := make([]usecaseRequest.ApproveStocktakeItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, usecaseRequest.ApproveStocktakeItem{
+			StockID:    item.StockID,
+			ReasonCode: model.StocktakeReasonCode(item.ReasonCode),
+			Note:       item.Note,
+		})
+	}
+
+	variances, err := h.Usecase.ApproveStocktakeItems(ctx, usecaseRequest.ApproveStocktakeItemsRequest{
+		StoreID:     c.Get("store_id").(string),
+		StocktakeID: req.StocktakeID,
+		Items:       items,
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrStockNotInStocktake) || errors.Is(err, usecase.ErrStocktakeItemNotCounted) {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrStocktakeStatus) || errors.Is(err, usecase.ErrInsufficientStock) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, variances)
+}
+
+// CompleteStocktake godoc
+//
+//	@Summary	棚卸の完了
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		int	true	"棚卸ID"	minimum(1)
+//	@Success	200	{object}	model.Stocktake
+//	@Failure	400	{object}	error
+//	@Failure	404	{object}	error
+//	@Failure	409	{object}	error
+//	@Failure	500	{object}	error
+//	@Router		/stocktakes/{id}/complete [post]
+func (h *Handler) CompleteStocktake(c echo.Context) error {
+	return h.changeStocktakeStatus(c, h.Usecase.CompleteStocktake)
+}
+
+// CancelStocktake godoc
+//
+//	@Summary		棚卸の中止
+//	@Description	承認済みの差異による在庫数量の調整は取り消されない
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"棚卸ID"	minimum(1)
+//	@Success		200	{object}	model.Stocktake
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		409	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/stocktakes/{id}/cancel [post]
+func (h *Handler) CancelStocktake(c echo.Context) error {
+	return h.changeStocktakeStatus(c, h.Usecase.CancelStocktake)
+}
+
+// GetStocktakeReport godoc
+//
+//	@Summary		棚卸報告書の取得
+//	@Description	カウント状況、差異数量・金額の合計、理由コード別の集計を取得する
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		int	true	"棚卸ID"	minimum(1)
+//	@Success		200	{object}	model.StocktakeReport
+//	@Failure		400	{object}	error
+//	@Failure		404	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/stocktakes/{id}/report [get]
+func (h *Handler) GetStocktakeReport(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetStocktakeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	report, err := h.Usecase.GetStocktakeReport(ctx, c.Get("store_id").(string), req.StocktakeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+func (h *Handler) changeStocktakeStatus(c echo.Context, change func(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error)) error {
+	ctx := h.GetCtx(c)
+
+	var req request.GetStocktakeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	stocktake, err := change(ctx, c.Get("store_id").(string), req.StocktakeID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err).
+			WithInternal(err)
+	}
+	if errors.Is(err, usecase.ErrStocktakeStatus) {
+		return echo.NewHTTPError(http.StatusConflict, err).
+			WithInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, stocktake)
+}
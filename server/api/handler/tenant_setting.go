@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/labstack/echo/v4"
+)
+
+// GetTenantSetting godoc
+//
+//	@Summary		テナント設定の取得
+//	@Description	テナント設定の取得
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	model.TenantSetting
+//	@Failure		500	{object}	error
+//	@Router			/settings [get]
+func (h *Handler) GetTenantSetting(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	setting, err := h.Usecase.GetTenantSetting(ctx, c.Get("tenant_id").(string))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, setting)
+}
+
+// UpdateTenantSetting godoc
+//
+//	@Summary		テナント設定の更新
+//	@Description	テナント設定の更新
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			req	body		request.UpdateTenantSettingRequest	true	"更新条件"
+//	@Success		200	{object}	model.TenantSetting
+//	@Failure		400	{object}	error
+//	@Failure		500	{object}	error
+//	@Router			/settings [put]
+func (h *Handler) UpdateTenantSetting(c echo.Context) error {
+	ctx := h.GetCtx(c)
+
+	var req request.UpdateTenantSettingRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err).
+			WithInternal(err)
+	}
+
+	setting, err := h.Usecase.UpdateTenantSetting(ctx, usecaseRequest.UpdateTenantSettingRequest{
+		TenantID:          c.Get("tenant_id").(string),
+		ValuationMethod:   req.ValuationMethod,
+		PointEarnUnit:     req.PointEarnUnit,
+		PointExpiryMonths: req.PointExpiryMonths,
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err).
+			WithInternal(err)
+	}
+
+	return c.JSON(http.StatusOK, setting)
+}
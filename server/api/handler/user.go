@@ -5,6 +5,7 @@ import (
 
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
 	usecaseRequest "github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
 	"github.com/labstack/echo/v4"
 )
@@ -54,13 +55,15 @@ func convertUsersForDisplay(users []*model.User) []*model.User {
 //
 //	@Summary		従業員一覧の取得
 //	@Description	従業員一覧の取得
-//	@Produce		json
+//	@Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 //	@Security		ApiKeyAuth
-//	@Param			limit	query		int		false	"取得件数"	minimum(0)	example(10)
-//	@Param			offset	query		int		false	"取得開始位置"	minimum(0)	example(0)
-//	@Success		200	{object}	[]model.User
-//	@Failure		400	{object}	error
-//	@Failure		500	{object}	error
+//	@Param			limit		query		int		false	"取得件数"								minimum(0)	example(10)
+//	@Param			offset		query		int		false	"取得開始位置"							minimum(0)	example(0)
+//	@Param			format		query		string	false	"出力形式（指定時は件数の指定がなければ全件をファイルで出力）"	Enums(csv, xlsx)
+//	@Param			encoding	query		string	false	"CSVの文字コード（既定はBOM付きUTF-8）"			Enums(utf-8, shift_jis)
+//	@Success		200			{object}	[]model.User
+//	@Failure		400			{object}	error
+//	@Failure		500			{object}	error
 //	@Router			/users [get]
 func (h *Handler) GetUsers(c echo.Context) error {
 	ctx := h.GetCtx(c)
@@ -76,11 +79,24 @@ func (h *Handler) GetUsers(c echo.Context) error {
 			WithInternal(err)
 	}
 
-	users, err := h.Usecase.GetUsers(ctx, usecaseRequest.GetUsersRequest{
+	input := usecaseRequest.GetUsersRequest{
 		TenantID: c.Get("tenant_id").(string),
 		Limit:    req.Limit,
 		Offset:   req.Offset,
-	})
+	}
+
+	if req.Format != nil {
+		if err := h.export(c, req.ExportRequest, "users", func(w sheet.Writer) error {
+			return h.Usecase.ExportUsers(ctx, input, w)
+		}); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err).
+				WithInternal(err)
+		}
+
+		return nil
+	}
+
+	users, err := h.Usecase.GetUsers(ctx, input)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err).
 			WithInternal(err)
@@ -172,8 +188,8 @@ func (h *Handler) CreateUser(c echo.Context) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		ApiKeyAuth
-//	@Param			id		path		string					true	"従業員ID"		format(uuid)
-//	@Param			req		body		request.UpdateUserRequest	true	"更新条件"
+//	@Param			id	path		string						true	"従業員ID"	format(uuid)
+//	@Param			req	body		request.UpdateUserRequest	true	"更新条件"
 //	@Success		200	{object}	model.User
 //	@Failure		400	{object}	error
 //	@Failure		500	{object}	error
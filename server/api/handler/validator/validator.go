@@ -1,13 +1,20 @@
 package validator
 
 import (
+	"reflect"
 	"regexp"
 	"time"
 
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/address"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/barcode"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/phone"
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 )
 
+// registrationNumberPattern は適格請求書発行事業者の登録番号 (T+13桁の数字)
+var registrationNumberPattern = regexp.MustCompile(`^T[0-9]{13}$`)
+
 type CustomValidator struct {
 	validator *validator.Validate
 }
@@ -22,12 +29,24 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 	if err := cv.validator.RegisterValidation("len10", is10CharecterUnder); err != nil {
 		return err
 	}
-	if err := cv.validator.RegisterValidation("jp_phone_number", isJPPhoneNumber); err != nil {
+	if err := cv.validator.RegisterValidation("phone_number", isPhoneNumber); err != nil {
+		return err
+	}
+	if err := cv.validator.RegisterValidation("phone_country", isPhoneCountry); err != nil {
 		return err
 	}
 	if err := cv.validator.RegisterValidation("future_date", isFutureDate); err != nil {
 		return err
 	}
+	if err := cv.validator.RegisterValidation("jan", isJAN); err != nil {
+		return err
+	}
+	if err := cv.validator.RegisterValidation("jp_postal_code", isJPPostalCode); err != nil {
+		return err
+	}
+	if err := cv.validator.RegisterValidation("registration_number", isRegistrationNumber); err != nil {
+		return err
+	}
 
 	return cv.validator.Struct(i)
 }
@@ -36,15 +55,22 @@ func is10CharecterUnder(fl validator.FieldLevel) bool {
 	return fl.Field().Len() >= 10
 }
 
-func isJPPhoneNumber(fl validator.FieldLevel) bool {
-	phoneRegex := `^(0[1-9]\d{0,3}-\d{1,4}-\d{4}|` +
-		`0[1-9]\d{0,3}\d{1,4}\d{4}|` +
-		`0[5789]0-\d{4}-\d{4}|` +
-		`0[5789]0\d{4}\d{4}|` +
-		`110|119|0120-\d{3}-\d{3})$`
-	r := regexp.MustCompile(phoneRegex)
+// isPhoneNumber は電話番号を検証する。パラメータに国コードを持つ項目名を指定すると、その国の国内表記を受け付ける
+func isPhoneNumber(fl validator.FieldLevel) bool {
+	var country string
+	if param := fl.Param(); param != "" {
+		if f := reflect.Indirect(fl.Parent()).FieldByName(param); f.IsValid() && f.Kind() == reflect.String {
+			country = f.String()
+		}
+	}
+
+	_, ok := phone.Parse(fl.Field().String(), country)
+
+	return ok
+}
 
-	return r.MatchString(fl.Field().String())
+func isPhoneCountry(fl validator.FieldLevel) bool {
+	return phone.IsSupportedCountry(fl.Field().String())
 }
 
 func isFutureDate(fl validator.FieldLevel) bool {
@@ -55,3 +81,17 @@ func isFutureDate(fl validator.FieldLevel) bool {
 
 	return date.After(time.Now())
 }
+
+func isJAN(fl validator.FieldLevel) bool {
+	return barcode.IsJAN(fl.Field().String())
+}
+
+func isJPPostalCode(fl validator.FieldLevel) bool {
+	_, ok := address.NormalizePostalCode(fl.Field().String())
+
+	return ok
+}
+
+func isRegistrationNumber(fl validator.FieldLevel) bool {
+	return registrationNumberPattern.MatchString(fl.Field().String())
+}
@@ -15,6 +15,11 @@ func Complex() echo.MiddlewareFunc {
 				return next(c)
 			}
 
+			// 署名付きURLによるファイル配信は署名で検証する
+			if strings.HasPrefix(c.Request().URL.Path, "/v1/media/") {
+				return next(c)
+			}
+
 			token := c.Request().Header.Get("Authorization")
 			if token == "" {
 				return echo.ErrUnauthorized
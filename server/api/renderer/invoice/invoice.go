@@ -0,0 +1,228 @@
+// Package invoice は適格請求書・適格返還請求書をPDFで描画する
+package invoice
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/address"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/pdf"
+)
+
+const (
+	marginMM    = 20.0
+	bodySize    = 9.0
+	rowHeight   = 18.0
+	reducedMark = "※"
+)
+
+// Render は請求書1通をA4縦のPDFで書き出す。返還請求書の場合はoriginalに取り消す請求書を渡す
+func Render(w io.Writer, font *pdf.Font, inv *model.Invoice, original *model.Invoice) error {
+	doc := pdf.New(font)
+	doc.Title = title(inv) + " " + inv.Number
+
+	r := &pageWriter{doc: doc}
+	r.newPage()
+	r.header(inv, original)
+	r.lines(inv)
+	r.taxes(inv)
+
+	_, err := doc.WriteTo(w)
+
+	return err
+}
+
+func title(inv *model.Invoice) string {
+	if inv.Type == model.InvoiceTypeCreditNote {
+		return "返還請求書"
+	}
+
+	return "請求書"
+}
+
+// pageWriter は明細が1ページに収まらない場合に改ページしながら描画する
+type pageWriter struct {
+	doc  *pdf.Document
+	page *pdf.Page
+	y    float64
+}
+
+func (r *pageWriter) newPage() {
+	r.page = r.doc.AddPage(pdf.A4Width, pdf.A4Height)
+	r.y = pdf.MM(marginMM)
+}
+
+func (r *pageWriter) left() float64 {
+	return pdf.MM(marginMM)
+}
+
+func (r *pageWriter) right() float64 {
+	return pdf.A4Width - pdf.MM(marginMM)
+}
+
+// ensure は残りの高さが足りなければ改ページする
+func (r *pageWriter) ensure(height float64) {
+	if r.y+height > pdf.A4Height-pdf.MM(marginMM) {
+		r.newPage()
+	}
+}
+
+func (r *pageWriter) header(inv *model.Invoice, original *model.Invoice) {
+	p := r.page
+	left, right := r.left(), r.right()
+
+	r.y += 20
+	p.TextCenter(pdf.A4Width/2, r.y, 20, title(inv))
+	r.y += 30
+
+	// 左に宛名、右に発行者
+	top := r.y
+	p.Text(left, r.y, 14, r.page.Truncate(inv.RecipientName+" 様", 14, 250))
+	p.Line(left, r.y+4, left+250, r.y+4, 0.8)
+
+	info := []string{
+		"請求書番号 " + inv.Number,
+		"発行日 " + inv.IssuedAt.Format("2006年1月2日"),
+		"取引年月日 " + inv.TransactionDate.Format("2006年1月2日"),
+	}
+	if original != nil {
+		info = append(info, "対象の請求書 "+original.Number)
+	}
+	y := top
+	for _, s := range info {
+		p.TextRight(right, y, bodySize, s)
+		y += bodySize * 1.5
+	}
+
+	y += bodySize
+	issuer := []string{inv.IssuerName}
+	if inv.IssuerPostalCode != "" {
+		issuer = append(issuer, "〒"+address.FormatPostalCode(inv.IssuerPostalCode))
+	}
+	if inv.IssuerAddress != "" {
+		issuer = append(issuer, inv.IssuerAddress)
+	}
+	if inv.IssuerPhoneNumber != "" {
+		issuer = append(issuer, "TEL "+inv.IssuerPhoneNumber)
+	}
+	issuer = append(issuer, "登録番号 "+inv.RegistrationNumber)
+	for _, s := range issuer {
+		p.TextRight(right, y, bodySize, r.page.Truncate(s, bodySize, 220))
+		y += bodySize * 1.5
+	}
+
+	// 宛名の下に請求金額
+	r.y += 36
+	label := "ご請求金額"
+	if inv.Type == model.InvoiceTypeCreditNote {
+		label = "返還金額"
+	}
+	p.Text(left, r.y, 11, label)
+	p.Text(left+80, r.y, 16, "￥"+renderer.Comma(abs(inv.Total))+" -")
+	p.Text(left+80, r.y+14, bodySize, fmt.Sprintf("(税込 うち消費税 ￥%s)", renderer.Comma(abs(inv.Tax))))
+	p.Line(left, r.y+20, left+250, r.y+20, 0.8)
+
+	if inv.Type == model.InvoiceTypeCreditNote && inv.Reason != "" {
+		r.y += 36
+		for _, line := range r.page.Wrap("理由: "+inv.Reason, bodySize, 250, 3) {
+			p.Text(left, r.y, bodySize, line)
+			r.y += bodySize * 1.5
+		}
+	}
+
+	r.y = max(r.y, y) + 24
+}
+
+// 明細表の列 (右端のx座標)
+func (r *pageWriter) columns() (quantity, unitPrice, amount, rate float64) {
+	right := r.right()
+
+	return right - 190, right - 120, right - 40, right
+}
+
+func (r *pageWriter) tableHeader() {
+	p := r.page
+	left, right := r.left(), r.right()
+	quantity, unitPrice, amount, rate := r.columns()
+
+	p.FillRect(left, r.y, right-left, 0.8)
+	r.y += rowHeight - 5
+	p.Text(left+4, r.y, bodySize, "品名")
+	p.TextRight(quantity, r.y, bodySize, "数量")
+	p.TextRight(unitPrice, r.y, bodySize, "単価")
+	p.TextRight(amount, r.y, bodySize, "金額(税込)")
+	p.TextRight(rate, r.y, bodySize, "税率")
+	r.y += 5
+	p.Line(left, r.y, right, r.y, 0.5)
+}
+
+func (r *pageWriter) lines(inv *model.Invoice) {
+	r.tableHeader()
+
+	left, right := r.left(), r.right()
+	quantity, unitPrice, amount, rate := r.columns()
+	reduced := false
+	for _, line := range inv.Lines {
+		if r.y+rowHeight > pdf.A4Height-pdf.MM(marginMM) {
+			r.newPage()
+			r.tableHeader()
+		}
+		p := r.page
+		r.y += rowHeight - 5
+
+		description := line.Description
+		if line.Reduced() {
+			description += " " + reducedMark
+			reduced = true
+		}
+		p.Text(left+4, r.y, bodySize, r.page.Truncate(description, bodySize, quantity-left-50))
+		if line.Quantity != 0 {
+			p.TextRight(quantity, r.y, bodySize, renderer.Comma(line.Quantity))
+		}
+		if line.UnitPrice != nil {
+			p.TextRight(unitPrice, r.y, bodySize, renderer.Comma(*line.UnitPrice))
+		}
+		p.TextRight(amount, r.y, bodySize, renderer.Comma(line.Amount))
+		p.TextRight(rate, r.y, bodySize, fmt.Sprintf("%d%%", line.TaxRate))
+		r.y += 5
+		p.Line(left, r.y, right, r.y, 0.3)
+	}
+
+	if reduced {
+		r.y += bodySize * 1.5
+		r.page.Text(left, r.y, bodySize-1, reducedMark+"は軽減税率対象")
+	}
+	r.y += 16
+}
+
+// taxes は税率ごとの対象金額と消費税額を記載する
+func (r *pageWriter) taxes(inv *model.Invoice) {
+	r.ensure(rowHeight * float64(len(inv.Taxes)+2))
+
+	p := r.page
+	right := r.right()
+	label := right - 200
+	for _, tax := range inv.Taxes {
+		r.y += rowHeight - 5
+		p.Text(label, r.y, bodySize, fmt.Sprintf("%d%%対象", tax.Rate))
+		p.TextRight(right, r.y, bodySize, fmt.Sprintf("￥%s (消費税 ￥%s)", renderer.Comma(tax.Amount), renderer.Comma(tax.Tax)))
+		r.y += 5
+		p.Line(label, r.y, right, r.y, 0.3)
+	}
+
+	r.y += rowHeight - 5
+	p.Text(label, r.y, bodySize+1, "合計")
+	p.TextRight(right, r.y, bodySize+1, fmt.Sprintf("￥%s (消費税 ￥%s)", renderer.Comma(inv.Total), renderer.Comma(inv.Tax)))
+	r.y += 5
+	p.Line(label, r.y, right, r.y, 0.8)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
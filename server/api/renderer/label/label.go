@@ -0,0 +1,128 @@
+// Package label は在庫の値札・ラベルをPDFで描画する
+package label
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/barcode"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/pdf"
+)
+
+type Field string
+
+const (
+	FieldName         Field = "name"          // 商品名
+	FieldPrice        Field = "price"         // 販売価格
+	FieldCode         Field = "code"          // 識別コード(バーコード/JAN/シリアル番号)
+	FieldSerialNumber Field = "serial_number" // シリアル番号
+	FieldStockID      Field = "stock_id"      // 在庫ID
+)
+
+// Template はラベル1枚分のレイアウト定義
+type Template struct {
+	WidthMM  float64
+	HeightMM float64
+	Fields   []Field
+	Barcode  bool
+}
+
+const (
+	marginMM     = 2.0
+	quietModules = 10
+)
+
+// Render は在庫1単位につき1ページ(1ラベル)のPDFを書き出す
+func Render(w io.Writer, font *pdf.Font, tmpl Template, stocks []*model.Stock) error {
+	doc := pdf.New(font)
+	doc.Title = "在庫ラベル"
+
+	for _, stock := range stocks {
+		for i := 0; i < stock.Quantity; i++ {
+			if err := renderPage(doc.AddPage(pdf.MM(tmpl.WidthMM), pdf.MM(tmpl.HeightMM)), tmpl, stock); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := doc.WriteTo(w)
+
+	return err
+}
+
+// Count は出力されるラベル枚数を返す
+func Count(stocks []*model.Stock) int {
+	n := 0
+	for _, stock := range stocks {
+		n += stock.Quantity
+	}
+
+	return n
+}
+
+func renderPage(p *pdf.Page, tmpl Template, stock *model.Stock) error {
+	margin := pdf.MM(marginMM)
+	width := p.Width - margin*2
+	// 文字サイズはラベルの高さに合わせて調整する
+	size := min(max(p.Height/10, 5), 10)
+	y := margin
+
+	for _, field := range tmpl.Fields {
+		switch field {
+		case FieldName:
+			for _, line := range p.Wrap(stock.Name, size, width, 2) {
+				y += size
+				p.Text(margin, y, size, line)
+			}
+		case FieldPrice:
+			priceSize := size * 1.5
+			y += priceSize
+			p.Text(margin, y, priceSize, "￥"+renderer.Comma(stock.Price))
+		case FieldCode:
+			if code, ok := stock.Code(); ok {
+				y += size
+				p.Text(margin, y, size, p.Truncate(code, size, width))
+			}
+		case FieldSerialNumber:
+			if stock.SerialNumber != nil {
+				y += size
+				p.Text(margin, y, size, p.Truncate("S/N "+*stock.SerialNumber, size, width))
+			}
+		case FieldStockID:
+			y += size
+			p.Text(margin, y, size, fmt.Sprintf("No.%d", stock.ID))
+		}
+		y += size * 0.2
+	}
+
+	if !tmpl.Barcode {
+		return nil
+	}
+
+	code, ok := stock.Code()
+	if !ok {
+		return nil
+	}
+
+	modules, err := barcode.Code128Modules(code)
+	if err != nil {
+		return fmt.Errorf("stock %d: %w", stock.ID, err)
+	}
+
+	// 残りの領域にバーコードと読み取り用の文字列を配置する
+	textSize := size * 0.8
+	barHeight := p.Height - margin - textSize*1.2 - y
+	if barHeight <= 0 {
+		return nil
+	}
+	moduleWidth := width / float64(len(modules)+quietModules*2)
+	barsWidth := moduleWidth * float64(len(modules))
+	x := margin + (width-barsWidth)/2
+
+	p.Bars(x, y, moduleWidth, barHeight, modules)
+	p.TextCenter(p.Width/2, p.Height-margin, textSize, code)
+
+	return nil
+}
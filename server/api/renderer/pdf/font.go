@@ -0,0 +1,541 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"sort"
+	"unicode/utf16"
+)
+
+// ErrUnsupportedFont はTrueTypeのアウトラインを持たないなど、埋め込めないフォントの場合のエラー
+var ErrUnsupportedFont = errors.New("unsupported font")
+
+// Font はPDFに埋め込むTrueTypeフォント
+// 書き出す際には使用したグリフだけを残したサブセットを埋め込む
+type Font struct {
+	tables     map[string][]byte
+	name       string
+	unitsPerEm int
+	numGlyphs  int
+	longLoca   bool
+	cmap       map[rune]uint16
+	advances   []uint16
+	bbox       [4]int
+	ascent     int
+	descent    int
+	capHeight  int
+}
+
+// fontTables はサブセットに残すテーブル。グリフの描画とヒンティングに必要なものに限る
+var fontTables = []string{"cvt ", "fpgm", "glyf", "head", "hhea", "hmtx", "loca", "maxp", "prep"}
+
+// LoadFont はTrueType(.ttf)またはTrueTypeコレクション(.ttc)の先頭のフォントを読み込む
+func LoadFont(path string) (*Font, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseFont(data)
+}
+
+func ParseFont(data []byte) (*Font, error) {
+	base := 0
+	if len(data) >= 16 && string(data[:4]) == "ttcf" {
+		base = int(binary.BigEndian.Uint32(data[12:]))
+	}
+	if len(data) < base+12 {
+		return nil, fmt.Errorf("%w: truncated header", ErrUnsupportedFont)
+	}
+
+	f := &Font{tables: map[string][]byte{}}
+	numTables := int(binary.BigEndian.Uint16(data[base+4:]))
+	for i := 0; i < numTables; i++ {
+		rec := base + 12 + i*16
+		if len(data) < rec+16 {
+			return nil, fmt.Errorf("%w: truncated table directory", ErrUnsupportedFont)
+		}
+		offset := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, fmt.Errorf("%w: table %q out of range", ErrUnsupportedFont, data[rec:rec+4])
+		}
+		f.tables[string(data[rec:rec+4])] = data[offset : offset+length]
+	}
+
+	for _, tag := range []string{"cmap", "glyf", "head", "hhea", "hmtx", "loca", "maxp"} {
+		if f.tables[tag] == nil {
+			// CFFのアウトラインを持つOpenTypeフォントはglyfがない
+			return nil, fmt.Errorf("%w: missing %q table", ErrUnsupportedFont, tag)
+		}
+	}
+
+	if err := f.parseMetrics(); err != nil {
+		return nil, err
+	}
+	if err := f.parseCmap(); err != nil {
+		return nil, err
+	}
+	f.name = f.postScriptName()
+
+	return f, nil
+}
+
+func (f *Font) parseMetrics() error {
+	head, hhea, maxp, hmtx := f.tables["head"], f.tables["hhea"], f.tables["maxp"], f.tables["hmtx"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 {
+		return fmt.Errorf("%w: truncated head, hhea or maxp", ErrUnsupportedFont)
+	}
+
+	f.unitsPerEm = int(binary.BigEndian.Uint16(head[18:]))
+	if f.unitsPerEm == 0 {
+		return fmt.Errorf("%w: unitsPerEm is 0", ErrUnsupportedFont)
+	}
+	for i := range f.bbox {
+		f.bbox[i] = f.scale(int(int16(binary.BigEndian.Uint16(head[36+i*2:]))))
+	}
+	f.longLoca = binary.BigEndian.Uint16(head[50:]) == 1
+	f.ascent = f.scale(int(int16(binary.BigEndian.Uint16(hhea[4:]))))
+	f.descent = f.scale(int(int16(binary.BigEndian.Uint16(hhea[6:]))))
+	f.capHeight = f.ascent
+	if os2 := f.tables["OS/2"]; len(os2) >= 90 && binary.BigEndian.Uint16(os2) >= 2 {
+		f.capHeight = f.scale(int(int16(binary.BigEndian.Uint16(os2[88:]))))
+	}
+
+	f.numGlyphs = int(binary.BigEndian.Uint16(maxp[4:]))
+	numMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	if numMetrics == 0 || numMetrics > f.numGlyphs || len(hmtx) < numMetrics*4 {
+		return fmt.Errorf("%w: invalid hmtx", ErrUnsupportedFont)
+	}
+	// numberOfHMetrics以降のグリフは最後の送り幅を共有する
+	f.advances = make([]uint16, f.numGlyphs)
+	for gid := range f.advances {
+		f.advances[gid] = binary.BigEndian.Uint16(hmtx[min(gid, numMetrics-1)*4:])
+	}
+
+	locaSize := 2
+	if f.longLoca {
+		locaSize = 4
+	}
+	if len(f.tables["loca"]) < (f.numGlyphs+1)*locaSize {
+		return fmt.Errorf("%w: truncated loca", ErrUnsupportedFont)
+	}
+
+	return nil
+}
+
+// parseCmap はUnicodeの文字からグリフIDへの対応を読み込む
+// 全Unicodeのformat 12を優先し、なければBMPのformat 4を使う
+func (f *Font) parseCmap() error {
+	cmap := f.tables["cmap"]
+	if len(cmap) < 4 {
+		return fmt.Errorf("%w: truncated cmap", ErrUnsupportedFont)
+	}
+
+	var bmp, full []byte
+	for i := 0; i < int(binary.BigEndian.Uint16(cmap[2:])); i++ {
+		rec := 4 + i*8
+		if len(cmap) < rec+8 {
+			break
+		}
+		platform, encoding := binary.BigEndian.Uint16(cmap[rec:]), binary.BigEndian.Uint16(cmap[rec+2:])
+		offset := int(binary.BigEndian.Uint32(cmap[rec+4:]))
+		if offset+2 > len(cmap) {
+			continue
+		}
+		sub := cmap[offset:]
+		switch format := binary.BigEndian.Uint16(sub); {
+		case format == 12 && (platform == 0 || (platform == 3 && encoding == 10)):
+			full = sub
+		case format == 4 && (platform == 0 || (platform == 3 && encoding == 1)):
+			bmp = sub
+		}
+	}
+
+	f.cmap = map[rune]uint16{}
+	switch {
+	case full != nil:
+		return f.parseCmap12(full)
+	case bmp != nil:
+		return f.parseCmap4(bmp)
+	default:
+		return fmt.Errorf("%w: no unicode cmap", ErrUnsupportedFont)
+	}
+}
+
+func (f *Font) parseCmap4(sub []byte) error {
+	if len(sub) < 14 {
+		return fmt.Errorf("%w: truncated cmap format 4", ErrUnsupportedFont)
+	}
+	segCount := int(binary.BigEndian.Uint16(sub[6:])) / 2
+	endCodes := 14
+	startCodes := endCodes + segCount*2 + 2
+	idDeltas := startCodes + segCount*2
+	idRangeOffsets := idDeltas + segCount*2
+	if len(sub) < idRangeOffsets+segCount*2 {
+		return fmt.Errorf("%w: truncated cmap format 4", ErrUnsupportedFont)
+	}
+
+	for i := 0; i < segCount; i++ {
+		end := int(binary.BigEndian.Uint16(sub[endCodes+i*2:]))
+		start := int(binary.BigEndian.Uint16(sub[startCodes+i*2:]))
+		delta := binary.BigEndian.Uint16(sub[idDeltas+i*2:])
+		rangeOffset := int(binary.BigEndian.Uint16(sub[idRangeOffsets+i*2:]))
+		for c := start; c <= end && c < 0xffff; c++ {
+			gid := uint16(c) + delta
+			if rangeOffset != 0 {
+				// idRangeOffsetは自身の位置からglyphIdArrayまでのバイト数
+				addr := idRangeOffsets + i*2 + rangeOffset + (c-start)*2
+				if addr+2 > len(sub) {
+					break
+				}
+				gid = binary.BigEndian.Uint16(sub[addr:])
+				if gid != 0 {
+					gid += delta
+				}
+			}
+			if gid != 0 && int(gid) < f.numGlyphs {
+				f.cmap[rune(c)] = gid
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f *Font) parseCmap12(sub []byte) error {
+	if len(sub) < 16 {
+		return fmt.Errorf("%w: truncated cmap format 12", ErrUnsupportedFont)
+	}
+	numGroups := int(binary.BigEndian.Uint32(sub[12:]))
+	if len(sub) < 16+numGroups*12 {
+		return fmt.Errorf("%w: truncated cmap format 12", ErrUnsupportedFont)
+	}
+
+	for i := 0; i < numGroups; i++ {
+		group := sub[16+i*12:]
+		start, end := binary.BigEndian.Uint32(group), binary.BigEndian.Uint32(group[4:])
+		startGlyph := binary.BigEndian.Uint32(group[8:])
+		if end > unicodeMax || start > end {
+			continue
+		}
+		for c := start; c <= end; c++ {
+			gid := startGlyph + (c - start)
+			if gid != 0 && int(gid) < f.numGlyphs {
+				f.cmap[rune(c)] = uint16(gid)
+			}
+		}
+	}
+
+	return nil
+}
+
+const unicodeMax = 0x10ffff
+
+// postScriptName はnameテーブルのPostScript名(nameID 6)を返す
+func (f *Font) postScriptName() string {
+	name := f.tables["name"]
+	if len(name) < 6 {
+		return "Embedded"
+	}
+	count := int(binary.BigEndian.Uint16(name[2:]))
+	stringOffset := int(binary.BigEndian.Uint16(name[4:]))
+	for i := 0; i < count; i++ {
+		rec := 6 + i*12
+		if len(name) < rec+12 || binary.BigEndian.Uint16(name[rec+6:]) != 6 {
+			continue
+		}
+		platform := binary.BigEndian.Uint16(name[rec:])
+		length := int(binary.BigEndian.Uint16(name[rec+8:]))
+		offset := stringOffset + int(binary.BigEndian.Uint16(name[rec+10:]))
+		if offset+length > len(name) {
+			continue
+		}
+		raw := name[offset : offset+length]
+		var s string
+		switch platform {
+		case 1:
+			s = string(raw)
+		case 0, 3:
+			units := make([]uint16, len(raw)/2)
+			for j := range units {
+				units[j] = binary.BigEndian.Uint16(raw[j*2:])
+			}
+			s = string(utf16.Decode(units))
+		default:
+			continue
+		}
+		if s = sanitizeName(s); s != "" {
+			return s
+		}
+	}
+
+	return "Embedded"
+}
+
+// sanitizeName はPDFの名前オブジェクトに使えない文字を取り除く
+func sanitizeName(s string) string {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		if r > 0x20 && r < 0x7f && r != '/' && r != '(' && r != ')' && r != '<' && r != '>' &&
+			r != '[' && r != ']' && r != '{' && r != '}' && r != '%' && r != '#' {
+			out = append(out, r)
+		}
+	}
+
+	return string(out)
+}
+
+func (f *Font) scale(v int) int {
+	return int(math.Round(float64(v) * 1000 / float64(f.unitsPerEm)))
+}
+
+// glyph は文字のグリフIDを返す。フォントにない文字は.notdef(0)になる
+func (f *Font) glyph(r rune) uint16 {
+	return f.cmap[r]
+}
+
+// width はグリフの送り幅を1/1000em単位で返す
+func (f *Font) width(gid uint16) int {
+	return f.scale(int(f.advances[gid]))
+}
+
+func (f *Font) glyphData(gid uint16) []byte {
+	loca, glyf := f.tables["loca"], f.tables["glyf"]
+	var start, end int
+	if f.longLoca {
+		start, end = int(binary.BigEndian.Uint32(loca[gid*4:])), int(binary.BigEndian.Uint32(loca[gid*4+4:]))
+	} else {
+		start, end = int(binary.BigEndian.Uint16(loca[gid*2:]))*2, int(binary.BigEndian.Uint16(loca[gid*2+2:]))*2
+	}
+	if start >= end || end > len(glyf) {
+		return nil
+	}
+
+	return glyf[start:end]
+}
+
+// components は複合グリフが参照するグリフIDを返す
+func components(data []byte) []uint16 {
+	if len(data) < 10 || int16(binary.BigEndian.Uint16(data)) >= 0 {
+		return nil
+	}
+
+	const (
+		argsAreWords   = 0x0001
+		haveScale      = 0x0008
+		moreComponents = 0x0020
+		haveXYScale    = 0x0040
+		haveTwoByTwo   = 0x0080
+	)
+	var gids []uint16
+	for pos := 10; pos+4 <= len(data); {
+		flags := binary.BigEndian.Uint16(data[pos:])
+		gids = append(gids, binary.BigEndian.Uint16(data[pos+2:]))
+		pos += 4
+		if flags&argsAreWords != 0 {
+			pos += 4
+		} else {
+			pos += 2
+		}
+		switch {
+		case flags&haveScale != 0:
+			pos += 2
+		case flags&haveXYScale != 0:
+			pos += 4
+		case flags&haveTwoByTwo != 0:
+			pos += 8
+		}
+		if flags&moreComponents == 0 {
+			break
+		}
+	}
+
+	return gids
+}
+
+// subset は指定したグリフ以外のアウトラインを空にしたフォントを返す
+// グリフIDを変えないため、PDFからはCIDをそのままグリフIDとして参照できる
+func (f *Font) subset(used map[uint16]rune) []byte {
+	// .notdefと、複合グリフが参照する部品のグリフも残す
+	keep := map[uint16]bool{}
+	queue := []uint16{0}
+	for gid := range used {
+		queue = append(queue, gid)
+	}
+	for len(queue) > 0 {
+		gid := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if keep[gid] {
+			continue
+		}
+		keep[gid] = true
+		for _, c := range components(f.glyphData(gid)) {
+			if int(c) < f.numGlyphs {
+				queue = append(queue, c)
+			}
+		}
+	}
+
+	var glyf []byte
+	loca := make([]byte, (f.numGlyphs+1)*4)
+	for gid := 0; gid < f.numGlyphs; gid++ {
+		binary.BigEndian.PutUint32(loca[gid*4:], uint32(len(glyf)))
+		if keep[uint16(gid)] {
+			glyf = append(glyf, f.glyphData(uint16(gid))...)
+			for len(glyf)%4 != 0 {
+				glyf = append(glyf, 0)
+			}
+		}
+	}
+	binary.BigEndian.PutUint32(loca[f.numGlyphs*4:], uint32(len(glyf)))
+
+	head := append([]byte(nil), f.tables["head"]...)
+	binary.BigEndian.PutUint32(head[8:], 0)
+	binary.BigEndian.PutUint16(head[50:], 1)
+
+	// postはグリフ名を持たないformat 3にする
+	post := make([]byte, 32)
+	copy(post, f.tables["post"])
+	binary.BigEndian.PutUint32(post, 0x00030000)
+
+	tables := map[string][]byte{"cmap": subsetCmap(used), "glyf": glyf, "loca": loca, "head": head, "post": post}
+	for _, tag := range fontTables {
+		if tables[tag] == nil && f.tables[tag] != nil {
+			tables[tag] = f.tables[tag]
+		}
+	}
+
+	return writeFont(tables)
+}
+
+// subsetCmap は使用した文字だけを載せたcmap(format 4)を作る
+// PDFからはグリフIDで参照するが、cmapのないフォントを受け付けないビューアがあるため付けておく
+func subsetCmap(used map[uint16]rune) []byte {
+	chars := map[rune]uint16{}
+	for gid, r := range used {
+		if gid != 0 && r < 0xffff {
+			chars[r] = gid
+		}
+	}
+	codes := make([]int, 0, len(chars))
+	for r := range chars {
+		codes = append(codes, int(r))
+	}
+	sort.Ints(codes)
+
+	// 1文字ずつのセグメントと、終端の0xFFFFのセグメントを並べる
+	segCount := len(codes) + 1
+	sub := make([]byte, 16+segCount*8)
+	binary.BigEndian.PutUint16(sub, 4)
+	binary.BigEndian.PutUint16(sub[2:], uint16(len(sub)))
+	binary.BigEndian.PutUint16(sub[6:], uint16(segCount*2))
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= segCount {
+		entrySelector++
+	}
+	binary.BigEndian.PutUint16(sub[8:], uint16(2<<entrySelector))
+	binary.BigEndian.PutUint16(sub[10:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(sub[12:], uint16(segCount*2-2<<entrySelector))
+
+	endCodes, startCodes := 14, 16+segCount*2
+	idDeltas := startCodes + segCount*2
+	for i := 0; i < segCount; i++ {
+		code, delta := 0xffff, uint16(1)
+		if i < len(codes) {
+			code, delta = codes[i], chars[rune(codes[i])]-uint16(codes[i])
+		}
+		binary.BigEndian.PutUint16(sub[endCodes+i*2:], uint16(code))
+		binary.BigEndian.PutUint16(sub[startCodes+i*2:], uint16(code))
+		binary.BigEndian.PutUint16(sub[idDeltas+i*2:], delta)
+	}
+
+	cmap := make([]byte, 12, 12+len(sub))
+	binary.BigEndian.PutUint16(cmap[2:], 1)
+	binary.BigEndian.PutUint16(cmap[4:], 3)
+	binary.BigEndian.PutUint16(cmap[6:], 1)
+	binary.BigEndian.PutUint32(cmap[8:], 12)
+
+	return append(cmap, sub...)
+}
+
+// writeFont はテーブルをTrueTypeのファイルとして並べる
+func writeFont(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= len(tags) {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * 16
+
+	out := make([]byte, 12+len(tags)*16)
+	binary.BigEndian.PutUint32(out, 0x00010000)
+	binary.BigEndian.PutUint16(out[4:], uint16(len(tags)))
+	binary.BigEndian.PutUint16(out[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(out[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(out[10:], uint16(len(tags)*16-searchRange))
+
+	headOffset := 0
+	for i, tag := range tags {
+		data := tables[tag]
+		rec := out[12+i*16:]
+		copy(rec, tag)
+		binary.BigEndian.PutUint32(rec[4:], checksum(data))
+		binary.BigEndian.PutUint32(rec[8:], uint32(len(out)))
+		binary.BigEndian.PutUint32(rec[12:], uint32(len(data)))
+		if tag == "head" {
+			headOffset = len(out)
+		}
+		out = append(out, data...)
+		for len(out)%4 != 0 {
+			out = append(out, 0)
+		}
+	}
+
+	binary.BigEndian.PutUint32(out[headOffset+8:], 0xb1b0afba-checksum(out))
+
+	return out
+}
+
+func checksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+
+	return sum
+}
+
+// subsetTag はサブセットのフォント名に付ける6文字の接頭辞を使用したグリフから決める
+func subsetTag(used map[uint16]rune) string {
+	gids := make([]int, 0, len(used))
+	for gid := range used {
+		gids = append(gids, int(gid))
+	}
+	sort.Ints(gids)
+
+	h := fnv.New32a()
+	for _, gid := range gids {
+		h.Write([]byte{byte(gid >> 8), byte(gid)})
+	}
+	sum := h.Sum32()
+
+	tag := make([]byte, 6)
+	for i := range tag {
+		tag[i] = 'A' + byte(sum%26)
+		sum /= 26
+	}
+
+	return string(tag)
+}
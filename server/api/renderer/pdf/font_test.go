@@ -0,0 +1,169 @@
+package pdf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestParseFont(t *testing.T) {
+	f, err := ParseFont(goregular.TTF)
+	if err != nil {
+		t.Fatalf("ParseFont() error = %v", err)
+	}
+
+	if f.name != "GoRegular" {
+		t.Errorf("name = %q, want %q", f.name, "GoRegular")
+	}
+	if f.glyph('A') == 0 {
+		t.Error("glyph('A') = 0, want a glyph")
+	}
+	if got := f.glyph('あ'); got != 0 {
+		t.Errorf("glyph('あ') = %d, want .notdef", got)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{name: "empty", data: nil, wantErr: true},
+		{name: "truncated", data: goregular.TTF[:64], wantErr: true},
+		{name: "not a font", data: bytes.Repeat([]byte{0}, 256), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseFont(tt.data); (err != nil) != tt.wantErr {
+				t.Errorf("ParseFont() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFontSubset(t *testing.T) {
+	f, err := ParseFont(goregular.TTF)
+	if err != nil {
+		t.Fatalf("ParseFont() error = %v", err)
+	}
+
+	// Åは複合グリフのため、部品のグリフも残る必要がある
+	used := map[uint16]rune{}
+	for _, r := range "AÅ" {
+		used[f.glyph(r)] = r
+	}
+	data := f.subset(used)
+	if len(data) >= len(goregular.TTF) {
+		t.Errorf("subset size = %d, want smaller than %d", len(data), len(goregular.TTF))
+	}
+
+	sub, err := sfnt.Parse(data)
+	if err != nil {
+		t.Fatalf("sfnt.Parse(subset) error = %v", err)
+	}
+	if sub.NumGlyphs() != f.numGlyphs {
+		t.Errorf("NumGlyphs() = %d, want %d", sub.NumGlyphs(), f.numGlyphs)
+	}
+
+	var buf sfnt.Buffer
+	ppem := fixed.I(f.unitsPerEm)
+	tests := []struct {
+		r        rune
+		wantKept bool
+	}{
+		{r: 'A', wantKept: true},
+		{r: 'Å', wantKept: true},
+		{r: 'B', wantKept: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.r), func(t *testing.T) {
+			segments, err := sub.LoadGlyph(&buf, sfnt.GlyphIndex(f.glyph(tt.r)), ppem, nil)
+			if err != nil {
+				t.Fatalf("LoadGlyph() error = %v", err)
+			}
+			if kept := len(segments) > 0; kept != tt.wantKept {
+				t.Errorf("kept = %v, want %v", kept, tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestDocumentEmbedsFont(t *testing.T) {
+	f, err := ParseFont(goregular.TTF)
+	if err != nil {
+		t.Fatalf("ParseFont() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		font *Font
+		want []string
+		not  []string
+	}{
+		{
+			name: "embedded",
+			font: f,
+			want: []string{"/Subtype /CIDFontType2", "/Encoding /Identity-H", "/FontFile2 9 0 R", "/ToUnicode 10 0 R", "+GoRegular"},
+			not:  []string{"HeiseiKakuGo-W5"},
+		},
+		{
+			name: "viewer font",
+			font: nil,
+			want: []string{"/BaseFont /HeiseiKakuGo-W5", "/Encoding /UniJIS-UTF16-H"},
+			not:  []string{"/FontFile2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := New(tt.font)
+			doc.AddPage(A4Width, A4Height).Text(10, 10, 12, "Go")
+
+			var buf bytes.Buffer
+			if _, err := doc.WriteTo(&buf); err != nil {
+				t.Fatalf("WriteTo() error = %v", err)
+			}
+			out := buf.String()
+			for _, s := range tt.want {
+				if !strings.Contains(out, s) {
+					t.Errorf("output does not contain %q", s)
+				}
+			}
+			for _, s := range tt.not {
+				if strings.Contains(out, s) {
+					t.Errorf("output contains %q", s)
+				}
+			}
+		})
+	}
+}
+
+func TestPageTextWidth(t *testing.T) {
+	f, err := ParseFont(goregular.TTF)
+	if err != nil {
+		t.Fatalf("ParseFont() error = %v", err)
+	}
+
+	embedded := New(f).AddPage(A4Width, A4Height)
+	viewer := New(nil).AddPage(A4Width, A4Height)
+
+	tests := []struct {
+		name string
+		page *Page
+		s    string
+		want float64
+	}{
+		{name: "viewer half width", page: viewer, s: "AB", want: 10},
+		{name: "viewer full width", page: viewer, s: "あい", want: 20},
+		{name: "embedded uses advances", page: embedded, s: "i", want: float64(f.width(f.glyph('i'))) / 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.page.TextWidth(tt.s, 10); got != tt.want {
+				t.Errorf("TextWidth(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
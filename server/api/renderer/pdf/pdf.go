@@ -0,0 +1,346 @@
+// Package pdf は帳票出力用の最小限のPDFライタ
+//
+// 日本語は同梱のTrueTypeフォントから使用したグリフだけを埋め込んで描画する
+// フォントを指定しない場合はAdobe-Japan1のCIDフォント(HeiseiKakuGo-W5)を埋め込まずに参照し、
+// ビューア側の標準日本語フォントで描画する。開発環境でフォントがない場合に限って使う
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// MM はミリメートルをポイントに変換する
+func MM(mm float64) float64 {
+	return mm * 72 / 25.4
+}
+
+// 用紙サイズ(pt)
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+type Document struct {
+	Title string
+	pages []*Page
+	font  *Font
+	// used は描画したグリフIDと元の文字。埋め込むサブセットとToUnicodeの対応表に使う
+	used map[uint16]rune
+}
+
+// Page は1ページ分の描画内容を保持する
+// 座標は左上を原点とし、下方向をyの正方向とする
+type Page struct {
+	Width   float64
+	Height  float64
+	doc     *Document
+	content bytes.Buffer
+}
+
+// New は文字をfontで描画する文書を作る。fontがnilの場合はフォントを埋め込まない
+func New(font *Font) *Document {
+	return &Document{font: font, used: map[uint16]rune{}}
+}
+
+func (d *Document) AddPage(width, height float64) *Page {
+	p := &Page{Width: width, Height: height, doc: d}
+	d.pages = append(d.pages, p)
+
+	return p
+}
+
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text はベースライン位置(x, y)に文字列を描画する
+func (p *Page) Text(x, y, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /F1 %s Tf %s %s Td <%s> Tj ET\n", num(size), num(x), num(p.Height-y), p.doc.encodeText(s))
+}
+
+// TextRight は右端をxに揃えて文字列を描画する
+func (p *Page) TextRight(x, y, size float64, s string) {
+	p.Text(x-p.TextWidth(s, size), y, size, s)
+}
+
+// TextCenter は中央をxに揃えて文字列を描画する
+func (p *Page) TextCenter(x, y, size float64, s string) {
+	p.Text(x-p.TextWidth(s, size)/2, y, size, s)
+}
+
+// FillRect は左上(x, y)から塗りつぶし矩形を描画する
+func (p *Page) FillRect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n", num(x), num(p.Height-y-h), num(w), num(h))
+}
+
+// StrokeRect は左上(x, y)から枠線のみの矩形を描画する
+func (p *Page) StrokeRect(x, y, w, h, lineWidth float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n", num(lineWidth), num(x), num(p.Height-y-h), num(w), num(h))
+}
+
+// Line は(x1, y1)から(x2, y2)へ直線を描画する
+func (p *Page) Line(x1, y1, x2, y2, lineWidth float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n", num(lineWidth), num(x1), num(p.Height-y1), num(x2), num(p.Height-y2))
+}
+
+// Bars はモジュール列(trueが黒)をバーコードとして描画する
+func (p *Page) Bars(x, y, moduleWidth, height float64, modules []bool) {
+	for i := 0; i < len(modules); {
+		if !modules[i] {
+			i++
+			continue
+		}
+		// 連続する黒モジュールはまとめて1本の矩形にする
+		j := i
+		for j < len(modules) && modules[j] {
+			j++
+		}
+		p.FillRect(x+float64(i)*moduleWidth, y, float64(j-i)*moduleWidth, height)
+		i = j
+	}
+}
+
+// TextWidth は文字列の描画幅(pt)を返す
+// フォントを埋め込まない場合は、W配列と合わせて半角文字は0.5em、それ以外は1emとして扱う
+func (p *Page) TextWidth(s string, size float64) float64 {
+	font := p.doc.font
+	w := 0
+	for _, r := range s {
+		switch {
+		case font != nil:
+			w += font.width(font.glyph(r))
+		case isHalfWidth(r):
+			w += 500
+		default:
+			w += 1000
+		}
+	}
+
+	return float64(w) * size / 1000
+}
+
+// Truncate は描画幅がmaxWidthに収まるよう末尾を省略する
+func (p *Page) Truncate(s string, size, maxWidth float64) string {
+	if p.TextWidth(s, size) <= maxWidth {
+		return s
+	}
+
+	const ellipsis = "…"
+	runes := []rune(s)
+	for len(runes) > 0 && p.TextWidth(string(runes)+ellipsis, size) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + ellipsis
+}
+
+// Wrap は描画幅がmaxWidthに収まるよう文字列を折り返す
+// maxLinesを超える分は最終行の末尾を省略する
+func (p *Page) Wrap(s string, size, maxWidth float64, maxLines int) []string {
+	var lines []string
+	var line []rune
+	for _, r := range s {
+		if len(line) > 0 && p.TextWidth(string(append(line, r)), size) > maxWidth {
+			lines = append(lines, string(line))
+			line = nil
+		}
+		line = append(line, r)
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+
+	if len(lines) > maxLines {
+		rest := strings.Join(lines[maxLines-1:], "")
+		lines = append(lines[:maxLines-1], p.Truncate(rest, size, maxWidth))
+	}
+
+	return lines
+}
+
+func isHalfWidth(r rune) bool {
+	return (r >= 0x20 && r <= 0x7e) || (r >= 0xff61 && r <= 0xff9f)
+}
+
+// WriteTo はPDFとしてシリアライズする
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	var offsets []int
+
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: Catalog, 2: Pages, 3-5: フォント, 6: Info, 7以降: ページとコンテンツ
+	// フォントを埋め込む場合は、ページの後にフォントファイルとToUnicodeを置く
+	const firstPageObj = 7
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+i*2)
+	}
+	fontFileObj := firstPageObj + len(d.pages)*2
+
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	if d.font == nil {
+		obj("<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5 /Encoding /UniJIS-UTF16-H /DescendantFonts [4 0 R] >>")
+		obj("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5 " +
+			"/CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> " +
+			"/FontDescriptor 5 0 R /DW 1000 /W [1 95 500 231 632 500] >>")
+		obj("<< /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4 /FontBBox [-92 -250 1010 922] " +
+			"/ItalicAngle 0 /Ascent 752 /Descent -221 /CapHeight 737 /StemV 114 >>")
+	} else {
+		f := d.font
+		name := subsetTag(d.used) + "+" + f.name
+		obj(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H "+
+			"/DescendantFonts [4 0 R] /ToUnicode %d 0 R >>", name, fontFileObj+1))
+		obj(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s "+
+			"/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+			"/FontDescriptor 5 0 R /DW 1000 /W [%s] /CIDToGIDMap /Identity >>", name, d.widths()))
+		obj(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [%d %d %d %d] "+
+			"/ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+			name, f.bbox[0], f.bbox[1], f.bbox[2], f.bbox[3], f.ascent, f.descent, f.capHeight, fontFileObj))
+	}
+	obj(fmt.Sprintf("<< /Producer (summer-internship-2024-backend) /Title <FEFF%s> >>", encode(d.Title)))
+
+	for _, p := range d.pages {
+		z, err := deflate(p.content.Bytes())
+		if err != nil {
+			return 0, err
+		}
+
+		contentObj := len(offsets) + 2
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+			"/Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			num(p.Width), num(p.Height), contentObj))
+		obj(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(z), z))
+	}
+
+	if d.font != nil {
+		file := d.font.subset(d.used)
+		z, err := deflate(file)
+		if err != nil {
+			return 0, err
+		}
+		obj(fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(z), len(file), z))
+
+		cmap := d.toUnicode()
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(cmap), cmap))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 6 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	n, err := w.Write(buf.Bytes())
+
+	return int64(n), err
+}
+
+// encodeText は文字列を/F1で描画する文字コードの16進表記に変換する
+// フォントを埋め込む場合はグリフIDを、埋め込まない場合はUTF-16BEを使う
+func (d *Document) encodeText(s string) string {
+	if d.font == nil {
+		return encode(s)
+	}
+
+	var sb strings.Builder
+	for _, r := range s {
+		gid := d.font.glyph(r)
+		if _, ok := d.used[gid]; !ok {
+			d.used[gid] = r
+		}
+		fmt.Fprintf(&sb, "%04X", gid)
+	}
+
+	return sb.String()
+}
+
+// widths は描画したグリフの送り幅をCIDフォントのW配列の形式で返す
+func (d *Document) widths() string {
+	var sb strings.Builder
+	for _, gid := range d.usedGlyphs() {
+		fmt.Fprintf(&sb, "%d [%d] ", gid, d.font.width(gid))
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// toUnicode はテキストの抽出・検索のためにグリフIDから文字への対応表(CMap)を返す
+func (d *Document) toUnicode() string {
+	var sb strings.Builder
+	sb.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	// .notdefはフォントにない文字を表すため対応を載せない。bfcharは1ブロックにつき100件まで
+	gids := d.usedGlyphs()
+	if len(gids) > 0 && gids[0] == 0 {
+		gids = gids[1:]
+	}
+	for len(gids) > 0 {
+		n := min(len(gids), 100)
+		fmt.Fprintf(&sb, "%d beginbfchar\n", n)
+		for _, gid := range gids[:n] {
+			fmt.Fprintf(&sb, "<%04X> <%s>\n", gid, encode(string(d.used[gid])))
+		}
+		sb.WriteString("endbfchar\n")
+		gids = gids[n:]
+	}
+
+	sb.WriteString("endcmap\nCMapName currentdict /CIDInit /ProcSet findresource /defineresource exec pop\nend\nend")
+
+	return sb.String()
+}
+
+func (d *Document) usedGlyphs() []uint16 {
+	gids := make([]uint16, 0, len(d.used))
+	for gid := range d.used {
+		gids = append(gids, gid)
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+
+	return gids
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return z.Bytes(), nil
+}
+
+// encode は文字列をUTF-16BEの16進表記に変換する
+func encode(s string) string {
+	var sb strings.Builder
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&sb, "%04X", u)
+	}
+
+	return sb.String()
+}
+
+func num(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
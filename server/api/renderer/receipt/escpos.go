@@ -0,0 +1,93 @@
+package receipt
+
+import (
+	"bytes"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// escpos はESC/POSのコマンド列を組み立てる
+// 日本語はShift_JISの漢字モードで送る。国際文字は日本を選び、0x5Cを円記号として印字する
+type escpos struct {
+	buf bytes.Buffer
+}
+
+const (
+	esc = 0x1b
+	fs  = 0x1c
+	gs  = 0x1d
+)
+
+func (p *escpos) init() {
+	p.buf.Write([]byte{esc, '@'})    // 初期化
+	p.buf.Write([]byte{esc, 'R', 8}) // 国際文字: 日本
+	p.buf.Write([]byte{fs, 'C', 1})  // 漢字コード: Shift_JIS
+	p.buf.Write([]byte{fs, '&'})     // 漢字モード
+}
+
+func (p *escpos) line(e element, text string) {
+	p.buf.Write([]byte{esc, 'a', byte(e.align)})
+	if e.bold {
+		p.buf.Write([]byte{esc, 'E', 1})
+	}
+	if e.large {
+		p.buf.Write([]byte{gs, '!', 0x11}) // 縦横2倍
+		p.buf.Write([]byte{fs, '!', 0x0c})
+	}
+
+	p.buf.Write(encode(text))
+	p.buf.WriteByte('\n')
+
+	if e.large {
+		p.buf.Write([]byte{gs, '!', 0})
+		p.buf.Write([]byte{fs, '!', 0})
+	}
+	if e.bold {
+		p.buf.Write([]byte{esc, 'E', 0})
+	}
+}
+
+// barcode はCODE128のバーコードを中央に印字し、下に読み取り用の文字を添える
+func (p *escpos) barcode(code string, paperWidth int) {
+	moduleWidth := byte(3)
+	if paperWidth == model.PaperWidth58 {
+		moduleWidth = 2
+	}
+	data := append([]byte("{B"), []byte(code)...)
+
+	p.buf.Write([]byte{esc, 'a', byte(alignCenter)})
+	p.buf.Write([]byte{gs, 'h', 80})          // 高さ (ドット)
+	p.buf.Write([]byte{gs, 'w', moduleWidth}) // モジュール幅
+	p.buf.Write([]byte{gs, 'H', 2})           // 読み取り用の文字を下に印字
+	p.buf.Write([]byte{gs, 'f', 0})
+	p.buf.Write([]byte{gs, 'k', 73, byte(len(data))})
+	p.buf.Write(data)
+	p.buf.WriteByte('\n')
+	p.buf.Write([]byte{esc, 'a', byte(alignLeft)})
+}
+
+// cut は用紙を送ってパーシャルカットする
+func (p *escpos) cut() {
+	p.buf.Write([]byte{gs, 'V', 66, 3})
+}
+
+// encode は文字列をShift_JISに変換する。変換できない文字は桁数が変わらないよう全角の？にする
+func encode(s string) []byte {
+	encoder := japanese.ShiftJIS.NewEncoder()
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r == '¥' {
+			out = append(out, 0x5c)
+			continue
+		}
+		b, err := encoder.Bytes([]byte(string(r)))
+		if err != nil {
+			out = append(out, 0x81, 0x48)
+			continue
+		}
+		out = append(out, b...)
+	}
+
+	return out
+}
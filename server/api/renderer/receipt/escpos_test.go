@@ -0,0 +1,161 @@
+package receipt
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []byte
+	}{
+		{name: "ascii", s: "No.1", want: []byte("No.1")},
+		{name: "yen sign", s: "¥100", want: []byte{0x5c, '1', '0', '0'}},
+		{name: "hiragana", s: "あ", want: []byte{0x82, 0xa0}},
+		{name: "kanji", s: "合計", want: []byte{0x8d, 0x87, 0x8c, 0x76}},
+		{name: "half width katakana", s: "ｱ", want: []byte{0xb1}},
+		{name: "unsupported is full width question mark", s: "a😀b", want: []byte{'a', 0x81, 0x48, 'b'}},
+		{name: "empty", s: "", want: []byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encode(tt.s); !bytes.Equal(got, tt.want) {
+				t.Errorf("encode(%q) = % x, want % x", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscposLine(t *testing.T) {
+	tests := []struct {
+		name string
+		e    element
+		want []byte
+	}{
+		{
+			name: "left",
+			e:    element{},
+			want: []byte{esc, 'a', 0, 'a', 'b', '\n'},
+		},
+		{
+			name: "bold center",
+			e:    element{align: alignCenter, bold: true},
+			want: []byte{esc, 'a', 1, esc, 'E', 1, 'a', 'b', '\n', esc, 'E', 0},
+		},
+		{
+			name: "large right",
+			e:    element{align: alignRight, large: true},
+			want: []byte{esc, 'a', 2, gs, '!', 0x11, fs, '!', 0x0c, 'a', 'b', '\n', gs, '!', 0, fs, '!', 0},
+		},
+		{
+			name: "bold and large",
+			e:    element{bold: true, large: true},
+			want: []byte{esc, 'a', 0, esc, 'E', 1, gs, '!', 0x11, fs, '!', 0x0c, 'a', 'b', '\n', gs, '!', 0, fs, '!', 0, esc, 'E', 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &escpos{}
+			p.line(tt.e, "ab")
+			if got := p.buf.Bytes(); !bytes.Equal(got, tt.want) {
+				t.Errorf("line() = % x, want % x", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscposBarcode(t *testing.T) {
+	tests := []struct {
+		name        string
+		paperWidth  int
+		moduleWidth byte
+	}{
+		{name: "58mm", paperWidth: model.PaperWidth58, moduleWidth: 2},
+		{name: "80mm", paperWidth: model.PaperWidth80, moduleWidth: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &escpos{}
+			p.barcode("000123", tt.paperWidth)
+
+			want := []byte{esc, 'a', 1, gs, 'h', 80, gs, 'w', tt.moduleWidth, gs, 'H', 2, gs, 'f', 0, gs, 'k', 73, 8}
+			want = append(want, "{B000123\n"...)
+			want = append(want, esc, 'a', 0)
+			if got := p.buf.Bytes(); !bytes.Equal(got, want) {
+				t.Errorf("barcode() = % x, want % x", got, want)
+			}
+		})
+	}
+}
+
+func TestRenderESCPOS(t *testing.T) {
+	r := &Receipt{
+		StoreName: "渋谷店",
+		OrderID:   123,
+		SoldAt:    time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC),
+		Total:     1100,
+		Barcode:   "000123",
+	}
+
+	tests := []struct {
+		name        string
+		tmpl        Template
+		wantBarcode bool
+	}{
+		{name: "with barcode", tmpl: Template{PaperWidth: model.PaperWidth58, ShowBarcode: true}, wantBarcode: true},
+		{name: "without barcode", tmpl: Template{PaperWidth: model.PaperWidth80}, wantBarcode: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := RenderESCPOS(&buf, tt.tmpl, r); err != nil {
+				t.Fatalf("RenderESCPOS() error = %v", err)
+			}
+			got := buf.Bytes()
+
+			start := []byte{esc, '@', esc, 'R', 8, fs, 'C', 1, fs, '&'}
+			if !bytes.HasPrefix(got, start) {
+				t.Errorf("output does not start with the init sequence: % x", got[:min(len(got), len(start))])
+			}
+			if cut := []byte{gs, 'V', 66, 3}; !bytes.HasSuffix(got, cut) {
+				t.Errorf("output does not end with a partial cut")
+			}
+			if !bytes.Contains(got, encode("渋谷店")) {
+				t.Errorf("output does not contain the store name")
+			}
+			// 合計は拡大文字のため、半分の桁数で左右に揃える
+			total := encode(spread("合計", "¥1,100", Columns(tt.tmpl.PaperWidth)/2))
+			if !bytes.Contains(got, total) {
+				t.Errorf("output does not contain the total line %q", spread("合計", "¥1,100", Columns(tt.tmpl.PaperWidth)/2))
+			}
+			if hasBarcode := bytes.Contains(got, []byte{gs, 'k', 73}); hasBarcode != tt.wantBarcode {
+				t.Errorf("barcode printed = %v, want %v", hasBarcode, tt.wantBarcode)
+			}
+		})
+	}
+}
+
+func TestSpread(t *testing.T) {
+	tests := []struct {
+		name        string
+		left, right string
+		columns     int
+		want        string
+	}{
+		{name: "padded", left: "合計", right: "¥100", columns: 12, want: "合計    ¥100"},
+		{name: "left truncated", left: "とても長い商品名", right: "¥100", columns: 12, want: "とても  ¥100"},
+		{name: "wide character not split", left: "あいう", right: "¥1", columns: 7, want: "あい ¥1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := spread(tt.left, tt.right, tt.columns); got != tt.want {
+				t.Errorf("spread(%q, %q, %d) = %q, want %q", tt.left, tt.right, tt.columns, got, tt.want)
+			}
+		})
+	}
+}
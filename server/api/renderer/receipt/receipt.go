@@ -0,0 +1,325 @@
+// Package receipt は店頭で発行するレシートを描画する
+//
+// 同じレイアウトをレシートプリンタ向けのESC/POSのコマンド列と、確認用のプレーンテキストに書き出す
+// 1行の桁数は半角1桁・全角2桁で数え、58mm幅は32桁、80mm幅は48桁とする
+package receipt
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer"
+)
+
+type Format string
+
+const (
+	FormatESCPOS Format = "escpos" // レシートプリンタ向けのESC/POSのコマンド列
+	FormatText   Format = "text"   // 確認用のプレーンテキスト
+)
+
+// Render は指定した形式でレシートを書き出す
+func Render(w io.Writer, format Format, tmpl Template, r *Receipt) error {
+	if format == FormatText {
+		return RenderText(w, tmpl, r)
+	}
+
+	return RenderESCPOS(w, tmpl, r)
+}
+
+// Template はテナントの印字設定を描画用にしたもの
+type Template struct {
+	PaperWidth       int
+	Header           []string
+	Footer           []string
+	ShowStoreAddress bool
+	ShowBarcode      bool
+}
+
+// Receipt はレシート1枚分の記載内容
+type Receipt struct {
+	StoreName        string
+	StoreAddress     string
+	StorePhoneNumber string
+	// 適格簡易請求書として記載する登録番号。空の場合は記載しない
+	RegistrationNumber string
+	OrderID            int
+	// 取引日時
+	SoldAt   time.Time
+	Lines    model.InvoiceLines
+	Taxes    model.TaxBreakdowns
+	Total    int
+	Payments []*model.Payment
+	Refunds  []*model.Refund
+	// バーコードに印字する発注番号
+	Barcode string
+}
+
+// Columns は用紙幅に対応する1行の桁数を返す
+func Columns(paperWidth int) int {
+	if paperWidth == model.PaperWidth58 {
+		return 32
+	}
+
+	return 48
+}
+
+type align int
+
+const (
+	alignLeft align = iota
+	alignCenter
+	alignRight
+)
+
+// element はレシートの1行、またはバーコード
+// rightがある行は印字する桁数に合わせてtextを左端、rightを右端に揃える
+type element struct {
+	text    string
+	right   string
+	align   align
+	bold    bool
+	large   bool
+	barcode string
+}
+
+func (e element) format(columns int) string {
+	if e.right == "" {
+		return e.text
+	}
+
+	return spread(e.text, e.right, columns)
+}
+
+// RenderESCPOS はレシートをESC/POSのコマンド列で書き出す
+func RenderESCPOS(w io.Writer, tmpl Template, r *Receipt) error {
+	p := &escpos{}
+	p.init()
+	for _, e := range layout(tmpl, r) {
+		if e.barcode != "" {
+			p.barcode(e.barcode, tmpl.PaperWidth)
+			continue
+		}
+		columns := Columns(tmpl.PaperWidth)
+		if e.large {
+			columns /= 2
+		}
+		p.line(e, e.format(columns))
+	}
+	p.cut()
+
+	_, err := w.Write(p.buf.Bytes())
+
+	return err
+}
+
+// RenderText はレシートを確認用のプレーンテキストで書き出す。拡大文字は通常の大きさで表示する
+func RenderText(w io.Writer, tmpl Template, r *Receipt) error {
+	columns := Columns(tmpl.PaperWidth)
+
+	var b strings.Builder
+	for _, e := range layout(tmpl, r) {
+		text := e.format(columns)
+		if e.barcode != "" {
+			text = "[バーコード] " + e.barcode
+		}
+		switch e.align {
+		case alignCenter:
+			text = strings.Repeat(" ", max(columns-width(text), 0)/2) + text
+		case alignRight:
+			text = strings.Repeat(" ", max(columns-width(text), 0)) + text
+		}
+		b.WriteString(strings.TrimRight(text, " "))
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// layout はレシートを行に分ける。拡大文字で折り返す行は半分の桁数に収める
+func layout(tmpl Template, r *Receipt) []element {
+	columns := Columns(tmpl.PaperWidth)
+	rule := element{text: strings.Repeat("-", columns)}
+	var out []element
+
+	// 店舗
+	for _, s := range wrap(r.StoreName, columns/2) {
+		out = append(out, element{text: s, align: alignCenter, bold: true, large: true})
+	}
+	if tmpl.ShowStoreAddress {
+		for _, s := range wrap(r.StoreAddress, columns) {
+			out = append(out, element{text: s, align: alignCenter})
+		}
+		if r.StorePhoneNumber != "" {
+			out = append(out, element{text: "TEL " + r.StorePhoneNumber, align: alignCenter})
+		}
+	}
+	for _, line := range tmpl.Header {
+		for _, s := range wrap(line, columns) {
+			out = append(out, element{text: s, align: alignCenter})
+		}
+	}
+	out = append(out, element{})
+
+	out = append(out, element{text: r.SoldAt.Format("2006年01月02日 15:04"), right: fmt.Sprintf("No.%06d", r.OrderID)})
+	if r.RegistrationNumber != "" {
+		out = append(out, element{text: "登録番号 " + r.RegistrationNumber})
+	}
+	out = append(out, rule)
+
+	// 明細
+	reduced := false
+	for _, line := range r.Lines {
+		mark := ""
+		if line.Reduced() {
+			mark = "※"
+			reduced = true
+		}
+		amount := yen(line.Amount) + mark
+		if line.UnitPrice != nil && line.Quantity > 1 {
+			for _, s := range wrap(line.Description, columns) {
+				out = append(out, element{text: s})
+			}
+			out = append(out, element{text: fmt.Sprintf("  %s × %d", yen(*line.UnitPrice), line.Quantity), right: amount})
+			continue
+		}
+		out = append(out, element{text: line.Description, right: amount})
+	}
+	out = append(out, rule)
+
+	// 合計と税率ごとの内訳
+	out = append(out, element{text: "合計", right: yen(r.Total), bold: true, large: true})
+	for _, tax := range r.Taxes {
+		out = append(out, element{text: fmt.Sprintf("(%d%%対象", tax.Rate), right: yen(tax.Amount) + ")"})
+		out = append(out, element{text: "( 内消費税等", right: yen(tax.Tax) + ")"})
+	}
+	if reduced {
+		out = append(out, element{text: "※は軽減税率対象商品"})
+	}
+
+	// 支払い
+	if len(r.Payments) > 0 || len(r.Refunds) > 0 {
+		out = append(out, rule)
+	}
+	for _, p := range r.Payments {
+		if p.Method == model.PaymentCash && p.Tendered != nil {
+			out = append(out, element{text: "お預り (現金)", right: yen(*p.Tendered)})
+			out = append(out, element{text: "お釣り", right: yen(*p.Tendered - p.Amount)})
+			continue
+		}
+		out = append(out, element{text: methodLabel(p.Method), right: yen(p.Amount)})
+	}
+	for _, refund := range r.Refunds {
+		out = append(out, element{text: "返金", right: yen(-refund.Amount)})
+	}
+
+	if len(tmpl.Footer) > 0 {
+		out = append(out, element{})
+	}
+	for _, line := range tmpl.Footer {
+		for _, s := range wrap(line, columns) {
+			out = append(out, element{text: s, align: alignCenter})
+		}
+	}
+
+	if tmpl.ShowBarcode && r.Barcode != "" {
+		out = append(out, element{}, element{barcode: r.Barcode, align: alignCenter})
+	}
+
+	return out
+}
+
+func methodLabel(method model.PaymentMethod) string {
+	switch method {
+	case model.PaymentCash:
+		return "現金"
+	case model.PaymentCreditCard:
+		return "クレジットカード"
+	case model.PaymentQR:
+		return "QRコード決済"
+	case model.PaymentBankTransfer:
+		return "銀行振込"
+	case model.PaymentPoints:
+		return "ポイント"
+	}
+
+	return string(method)
+}
+
+func yen(n int) string {
+	if n < 0 {
+		return "-¥" + renderer.Comma(-n)
+	}
+
+	return "¥" + renderer.Comma(n)
+}
+
+// spread は左右の文字列を行の両端に揃える。収まらない場合は左の文字列を切り詰める
+func spread(left, right string, columns int) string {
+	room := columns - width(right) - 1
+	left = truncate(left, room)
+
+	return left + strings.Repeat(" ", max(columns-width(left)-width(right), 1)) + right
+}
+
+// width は文字列の桁数を返す。半角文字は1桁、それ以外は2桁とする
+func width(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+
+	return n
+}
+
+func runeWidth(r rune) int {
+	if r < 0x80 || r == '¥' || (r >= 0xFF61 && r <= 0xFF9F) {
+		return 1
+	}
+
+	return 2
+}
+
+// truncate は桁数に収まるよう末尾を切り詰める
+func truncate(s string, columns int) string {
+	if width(s) <= columns {
+		return s
+	}
+
+	n := 0
+	for i, r := range s {
+		if n+runeWidth(r) > columns {
+			return s[:i]
+		}
+		n += runeWidth(r)
+	}
+
+	return s
+}
+
+// wrap は桁数ごとに折り返す
+func wrap(s string, columns int) []string {
+	if s == "" || columns <= 0 {
+		return nil
+	}
+
+	var lines []string
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		if n+runeWidth(r) > columns {
+			lines = append(lines, b.String())
+			b.Reset()
+			n = 0
+		}
+		b.WriteRune(r)
+		n += runeWidth(r)
+	}
+
+	return append(lines, b.String())
+}
@@ -0,0 +1,22 @@
+// Package renderer は帳票(ラベル・請求書・レシート)の描画で共通に使う処理をまとめる
+package renderer
+
+import "strconv"
+
+// Comma は整数を3桁区切りの文字列に整形する
+func Comma(n int) string {
+	if n < 0 {
+		return "-" + Comma(-n)
+	}
+
+	s := strconv.Itoa(n)
+	out := make([]byte, 0, len(s)+len(s)/3)
+	for i := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, s[i])
+	}
+
+	return string(out)
+}
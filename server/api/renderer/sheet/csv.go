@@ -0,0 +1,89 @@
+package sheet
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+type csvWriter struct {
+	w        *csv.Writer
+	out      io.Writer
+	row      []string
+	shiftJIS bool
+}
+
+// NewCSV はExcelでそのまま開けるCSVを書き出すWriterを返す
+// UTF-8の場合は先頭にBOMを付け、Shift_JISの場合は文字コードを変換する
+func NewCSV(w io.Writer, enc Encoding) (Writer, error) {
+	out := w
+	if enc == EncodingShiftJIS {
+		out = transform.NewWriter(w, japanese.ShiftJIS.NewEncoder())
+	} else if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+
+	cw := csv.NewWriter(out)
+	cw.UseCRLF = true
+
+	return &csvWriter{w: cw, out: out, shiftJIS: enc == EncodingShiftJIS}, nil
+}
+
+func (c *csvWriter) Write(row ...interface{}) error {
+	c.row = c.row[:0]
+	for _, v := range row {
+		s, numeric := cell(v)
+		if !numeric {
+			s = escapeFormula(s)
+		}
+		if c.shiftJIS {
+			s = replaceUnsupported(s)
+		}
+		c.row = append(c.row, s)
+	}
+
+	return c.w.Write(c.row)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return err
+	}
+	if tw, ok := c.out.(*transform.Writer); ok {
+		return tw.Close()
+	}
+
+	return nil
+}
+
+// replaceUnsupported はShift_JISで表現できない文字(絵文字など)を?に置き換える
+func replaceUnsupported(s string) string {
+	if _, err := japanese.ShiftJIS.NewEncoder().String(s); err == nil {
+		return s
+	}
+
+	var b strings.Builder
+	enc := japanese.ShiftJIS.NewEncoder()
+	for _, r := range s {
+		if _, err := enc.String(string(r)); err != nil {
+			b.WriteByte('?')
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// escapeFormula は表計算ソフトで数式として解釈される文字列の先頭に'を付ける
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
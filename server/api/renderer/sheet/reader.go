@@ -0,0 +1,336 @@
+package sheet
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// Reader は1行ずつ読み込む。読み終えるとio.EOFを返す
+// 空行は読み飛ばすため、エラーの表示にはRowでファイル上の行番号を取得する
+type Reader interface {
+	Read() ([]string, error)
+	// Row は直前に読み込んだ行のファイル上の行番号(1始まり)
+	Row() int
+}
+
+// DetectFormat はファイルの先頭からxlsx(zip)かCSVかを判定する
+func DetectFormat(data []byte) Format {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return FormatXLSX
+	}
+
+	return FormatCSV
+}
+
+// NewReader はファイル全体から形式に応じたReaderを返す
+// CSVはBOMがあればUTF-8、なければencodingに従って読み込む
+func NewReader(data []byte, encoding Encoding) (Reader, error) {
+	if DetectFormat(data) == FormatXLSX {
+		return NewXLSXReader(bytes.NewReader(data), int64(len(data)))
+	}
+
+	return NewCSVReader(bytes.NewReader(data), encoding), nil
+}
+
+type csvReader struct {
+	r *csv.Reader
+}
+
+func NewCSVReader(r io.Reader, encoding Encoding) Reader {
+	br := bufio.NewReader(r)
+	var in io.Reader = br
+	if bom, _ := br.Peek(3); bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		br.Discard(3)
+	} else if encoding == EncodingShiftJIS {
+		in = transform.NewReader(br, japanese.ShiftJIS.NewDecoder())
+	}
+
+	cr := csv.NewReader(in)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	return &csvReader{r: cr}
+}
+
+func (c *csvReader) Read() ([]string, error) {
+	return c.r.Read()
+}
+
+func (c *csvReader) Row() int {
+	line, _ := c.r.FieldPos(0)
+
+	return line
+}
+
+// xlsxReader は最初のシートを1行ずつ読み込む
+// 共有文字列はメモリに読み込み、シートはXMLを逐次解析する
+type xlsxReader struct {
+	strings []string
+	sheet   io.ReadCloser
+	dec     *xml.Decoder
+	row     int
+}
+
+func NewXLSXReader(r io.ReaderAt, size int64) (Reader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	x := &xlsxReader{}
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if x.strings, err = readSharedStrings(f); err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("xlsx: worksheet %s not found", sheetPath)
+	}
+	if x.sheet, err = f.Open(); err != nil {
+		return nil, err
+	}
+	x.dec = xml.NewDecoder(x.sheet)
+
+	return x, nil
+}
+
+func (x *xlsxReader) Read() ([]string, error) {
+	for {
+		tok, err := x.dec.Token()
+		if err == io.EOF {
+			x.sheet.Close()
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+
+		// 空行は<row>自体が省略されるため、行番号は属性から取得する
+		x.row++
+		if n, err := strconv.Atoi(attr(start, "r")); err == nil {
+			x.row = n
+		}
+
+		return x.readRow(start)
+	}
+}
+
+func (x *xlsxReader) Row() int {
+	return x.row
+}
+
+func (x *xlsxReader) readRow(start xml.StartElement) ([]string, error) {
+	var row []string
+	for {
+		tok, err := x.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "c" {
+				continue
+			}
+			col := len(row)
+			if ref := attr(t, "r"); ref != "" {
+				col = columnIndex(ref)
+			}
+			value, err := x.readCell(t)
+			if err != nil {
+				return nil, err
+			}
+			for len(row) < col {
+				row = append(row, "")
+			}
+			row = append(row, value)
+		case xml.EndElement:
+			if t.Name.Local == start.Name.Local {
+				return row, nil
+			}
+		}
+	}
+}
+
+func (x *xlsxReader) readCell(start xml.StartElement) (string, error) {
+	typ := attr(start, "t")
+	var value strings.Builder
+	var inValue bool
+	for {
+		tok, err := x.dec.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			// 数値・共有文字列は<v>、インライン文字列は<is><t>に値が入る
+			inValue = t.Name.Local == "v" || t.Name.Local == "t"
+		case xml.CharData:
+			if inValue {
+				value.Write(t)
+			}
+		case xml.EndElement:
+			if t.Name.Local == "v" || t.Name.Local == "t" {
+				inValue = false
+			}
+			if t.Name.Local != start.Name.Local {
+				continue
+			}
+			if typ == "s" {
+				i, err := strconv.Atoi(value.String())
+				if err != nil || i < 0 || i >= len(x.strings) {
+					return "", fmt.Errorf("xlsx: invalid shared string index %q", value.String())
+				}
+				return x.strings[i], nil
+			}
+			return value.String(), nil
+		}
+	}
+}
+
+// firstSheetPath はworkbook.xmlの最初のシートのパスを返す
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	var workbook struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeFile(files, "xl/workbook.xml", &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", errors.New("xlsx: workbook has no sheets")
+	}
+
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeFile(files, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].ID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+
+	return "", errors.New("xlsx: first sheet not found")
+}
+
+func readSharedStrings(f *zip.File) ([]string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	// 書式付きの文字列は<r><t>に分割されるため、<si>ごとに<t>を連結する
+	var values []string
+	var current strings.Builder
+	var inText bool
+	dec := xml.NewDecoder(rc)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "si":
+				current.Reset()
+			case "t":
+				inText = true
+			case "rPh":
+				// ふりがなは値に含めない
+				if err := dec.Skip(); err != nil {
+					return nil, err
+				}
+			}
+		case xml.CharData:
+			if inText {
+				current.Write(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "si":
+				values = append(values, current.String())
+			}
+		}
+	}
+}
+
+func decodeFile(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("xlsx: %s not found", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+func attr(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// columnIndex はA1形式のセル参照から0始まりの列番号を返す
+func columnIndex(ref string) int {
+	n := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		n = n*26 + int(r-'A'+1)
+	}
+
+	return n - 1
+}
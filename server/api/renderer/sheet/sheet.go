@@ -0,0 +1,97 @@
+// Package sheet は一覧データをCSV・Excel(xlsx)形式で1行ずつ書き出す
+// 行をメモリに溜めずに出力先へ書き込むため、件数が多くてもメモリ使用量は一定になる
+package sheet
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ContentType はHTTPレスポンスのContent-Typeを返す
+func (f Format) ContentType(encoding Encoding) string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	if encoding == EncodingShiftJIS {
+		return "text/csv; charset=Shift_JIS"
+	}
+
+	return "text/csv; charset=UTF-8"
+}
+
+type Encoding string
+
+const (
+	EncodingUTF8     Encoding = "utf-8"     // BOM付きUTF-8
+	EncodingShiftJIS Encoding = "shift_jis" // Shift_JIS（表現できない文字は?に置き換える）
+)
+
+// Writer は1行ずつ書き出す。最初の行を見出しとして扱う
+type Writer interface {
+	Write(row ...interface{}) error
+	// Close は書き出しを完了する。出力先のCloseは行わない
+	Close() error
+}
+
+// New は形式に応じたWriterを返す。sheetNameはxlsxのシート名に使う
+func New(w io.Writer, format Format, encoding Encoding, sheetName string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSV(w, encoding)
+	case FormatXLSX:
+		return NewXLSX(w, sheetName), nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// cell は値を書き出し用の文字列に変換する。数値の場合はnumericがtrueになる
+func cell(v interface{}) (s string, numeric bool) {
+	switch v := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, false
+	case *string:
+		if v == nil {
+			return "", false
+		}
+		return *v, false
+	case int:
+		return strconv.Itoa(v), true
+	case *int:
+		if v == nil {
+			return "", false
+		}
+		return strconv.Itoa(*v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), false
+	case time.Time:
+		if v.IsZero() {
+			return "", false
+		}
+		return v.Local().Format("2006-01-02 15:04:05"), false
+	case *time.Time:
+		if v == nil {
+			return "", false
+		}
+		return cell(*v)
+	case fmt.Stringer:
+		return v.String(), false
+	default:
+		return fmt.Sprint(v), false
+	}
+}
@@ -0,0 +1,208 @@
+package sheet
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+// testRows は見出しと、数値・数式に見える文字列・空の値・日時を含む行
+func testRows() [][]interface{} {
+	var missing *int
+	return [][]interface{}{
+		{"ID", "商品名", "備考"},
+		{1, "=SUM(A1)", "a,b"},
+		{-5, "-5", nil},
+		{missing, "😀", time.Date(2024, 8, 1, 9, 30, 0, 0, time.Local)},
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding Encoding
+		want     string
+	}{
+		{
+			name:     "utf-8 with bom",
+			encoding: EncodingUTF8,
+			want: "\ufeffID,商品名,備考\r\n" +
+				"1,'=SUM(A1),\"a,b\"\r\n" +
+				"-5,'-5,\r\n" +
+				",😀,2024-08-01 09:30:00\r\n",
+		},
+		{
+			name:     "shift_jis replaces unsupported characters",
+			encoding: EncodingShiftJIS,
+			want: "ID,商品名,備考\r\n" +
+				"1,'=SUM(A1),\"a,b\"\r\n" +
+				"-5,'-5,\r\n" +
+				",?,2024-08-01 09:30:00\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := New(&buf, FormatCSV, tt.encoding, "")
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			for _, row := range testRows() {
+				if err := w.Write(row...); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			got := buf.String()
+			if tt.encoding == EncodingShiftJIS {
+				if got, err = japanese.ShiftJIS.NewDecoder().String(got); err != nil {
+					t.Fatalf("decode Shift_JIS: %v", err)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXLSXWriter(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]interface{}
+		want [][]string
+	}{
+		{
+			name: "values are kept as is",
+			rows: testRows(),
+			want: [][]string{
+				{"ID", "商品名", "備考"},
+				{"1", "=SUM(A1)", "a,b"},
+				{"-5", "-5"},
+				{"", "😀", "2024-08-01 09:30:00"},
+			},
+		},
+		{
+			name: "header only",
+			rows: [][]interface{}{{"ID", "<name> & \"note\""}},
+			want: [][]string{{"ID", "<name> & \"note\""}},
+		},
+		{
+			name: "no rows",
+			rows: nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := New(&buf, FormatXLSX, EncodingUTF8, "在庫")
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			for _, row := range tt.rows {
+				if err := w.Write(row...); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			r, err := NewReader(buf.Bytes(), EncodingUTF8)
+			if err != nil {
+				t.Fatalf("NewReader() error = %v", err)
+			}
+			var got [][]string
+			for {
+				row, err := r.Read()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Read() error = %v", err)
+				}
+				got = append(got, row)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rows = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXLSXSheetName(t *testing.T) {
+	tests := []struct {
+		name   string
+		sheet  string
+		sheets int
+		i      int
+		want   string
+	}{
+		{name: "default", sheet: "", sheets: 1, i: 1, want: "Sheet"},
+		{name: "invalid characters", sheet: "在庫/一覧[2024]", sheets: 1, i: 1, want: "在庫_一覧_2024_"},
+		{name: "numbered when split", sheet: "在庫", sheets: 2, i: 2, want: "在庫 (2)"},
+		{name: "truncated to 31 characters", sheet: strings.Repeat("あ", 40), sheets: 1, i: 1, want: strings.Repeat("あ", 31)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := &xlsxWriter{name: tt.sheet, sheets: tt.sheets}
+			if got := x.sheetName(tt.i); got != tt.want {
+				t.Errorf("sheetName(%d) = %q, want %q", tt.i, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumn(t *testing.T) {
+	tests := []struct {
+		i    int
+		want string
+	}{
+		{i: 0, want: "A"},
+		{i: 25, want: "Z"},
+		{i: 26, want: "AA"},
+		{i: 701, want: "ZZ"},
+		{i: 702, want: "AAA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := column(tt.i); got != tt.want {
+				t.Errorf("column(%d) = %q, want %q", tt.i, got, tt.want)
+			}
+			if got := columnIndex(tt.want + "1"); got != tt.i {
+				t.Errorf("columnIndex(%q) = %d, want %d", tt.want+"1", got, tt.i)
+			}
+		})
+	}
+}
+
+func TestEscapeFormula(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "=1+1", want: "'=1+1"},
+		{s: "+81", want: "'+81"},
+		{s: "-1", want: "'-1"},
+		{s: "@SUM(A1)", want: "'@SUM(A1)"},
+		{s: "\tcmd", want: "'\tcmd"},
+		{s: "a=b", want: "a=b"},
+		{s: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			if got := escapeFormula(tt.s); got != tt.want {
+				t.Errorf("escapeFormula(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package sheet
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxRows はxlsxの1シートに書き込める行数の上限
+// 超えた場合は見出しを付けた新しいシートに続きを書き込む
+const maxRows = 1048576
+
+type xlsxWriter struct {
+	zw     *zip.Writer
+	sheet  *bufio.Writer
+	name   string
+	header []interface{}
+	sheets int
+	rows   int
+	err    error
+}
+
+// NewXLSX はxlsxを書き出すWriterを返す
+// zipの各パートを順に書き込むため、シートの内容は出力先へ逐次書き出される
+func NewXLSX(w io.Writer, sheetName string) Writer {
+	return &xlsxWriter{zw: zip.NewWriter(w), name: sheetName}
+}
+
+func (x *xlsxWriter) Write(row ...interface{}) error {
+	if x.err != nil {
+		return x.err
+	}
+
+	header := x.header == nil
+	if header {
+		x.header = append([]interface{}{}, row...)
+	}
+	if x.sheet == nil || x.rows == maxRows {
+		if x.err = x.nextSheet(); x.err != nil {
+			return x.err
+		}
+		// 2枚目以降のシートにも見出しを付ける
+		if !header {
+			if x.err = x.writeRow(x.header, true); x.err != nil {
+				return x.err
+			}
+		}
+	}
+	x.err = x.writeRow(row, header)
+
+	return x.err
+}
+
+func (x *xlsxWriter) Close() error {
+	if x.err != nil {
+		return x.err
+	}
+	if x.sheet == nil {
+		if err := x.nextSheet(); err != nil {
+			return err
+		}
+	}
+	if err := x.endSheet(); err != nil {
+		return err
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", x.contentTypes()},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", x.workbook()},
+		{"xl/_rels/workbook.xml.rels", x.workbookRels()},
+		{"xl/styles.xml", styles},
+	}
+	for _, part := range parts {
+		f, err := x.zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	return x.zw.Close()
+}
+
+func (x *xlsxWriter) nextSheet() error {
+	if x.sheet != nil {
+		if err := x.endSheet(); err != nil {
+			return err
+		}
+	}
+
+	x.sheets++
+	x.rows = 0
+	f, err := x.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", x.sheets))
+	if err != nil {
+		return err
+	}
+	x.sheet = bufio.NewWriter(f)
+	_, err = x.sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	return err
+}
+
+func (x *xlsxWriter) endSheet() error {
+	if _, err := x.sheet.WriteString(`</sheetData></worksheet>`); err != nil {
+		return err
+	}
+
+	return x.sheet.Flush()
+}
+
+func (x *xlsxWriter) writeRow(row []interface{}, header bool) error {
+	x.rows++
+	r := strconv.Itoa(x.rows)
+	w := x.sheet
+
+	w.WriteString(`<row r="` + r + `">`)
+	for i, v := range row {
+		s, numeric := cell(v)
+		if s == "" {
+			continue
+		}
+		ref := column(i) + r
+		switch {
+		case header:
+			w.WriteString(`<c r="` + ref + `" t="inlineStr" s="1"><is><t xml:space="preserve">`)
+			xml.EscapeText(w, []byte(s))
+			w.WriteString(`</t></is></c>`)
+		case numeric:
+			w.WriteString(`<c r="` + ref + `"><v>` + s + `</v></c>`)
+		default:
+			w.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+			xml.EscapeText(w, []byte(s))
+			w.WriteString(`</t></is></c>`)
+		}
+	}
+	_, err := w.WriteString(`</row>`)
+
+	return err
+}
+
+// column は0始まりの列番号をA, B, ..., Z, AA のような列名に変換する
+func column(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+
+	return name
+}
+
+func (x *xlsxWriter) sheetName(i int) string {
+	name := x.name
+	if name == "" {
+		name = "Sheet"
+	}
+	// シート名に使えない文字を置き換え、31文字に収める
+	name = strings.NewReplacer(":", "_", "\\", "_", "/", "_", "?", "_", "*", "_", "[", "_", "]", "_").Replace(name)
+	if x.sheets > 1 {
+		name = fmt.Sprintf("%s (%d)", name, i)
+	}
+	if r := []rune(name); len(r) > 31 {
+		name = string(r[:31])
+	}
+
+	return name
+}
+
+func (x *xlsxWriter) contentTypes() string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= x.sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+
+	return b.String()
+}
+
+func (x *xlsxWriter) workbook() string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i := 1; i <= x.sheets; i++ {
+		b.WriteString(`<sheet name="`)
+		xml.EscapeText(&b, []byte(x.sheetName(i)))
+		fmt.Fprintf(&b, `" sheetId="%d" r:id="rId%d"/>`, i, i)
+	}
+	b.WriteString(`</sheets></workbook>`)
+
+	return b.String()
+}
+
+func (x *xlsxWriter) workbookRels() string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= x.sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, x.sheets+1)
+	b.WriteString(`</Relationships>`)
+
+	return b.String()
+}
+
+const rootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// styles は標準(0)と見出し用の太字(1)の書式のみを定義する
+const styles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Yu Gothic"/></font><font><b/><sz val="11"/><name val="Yu Gothic"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+)
+
+// GetUnparsedCustomers は住所が未分割の顧客をID順に取得する。削除済みの顧客も含む
+func (r *repository) GetUnparsedCustomers(ctx context.Context, tenantID *string, afterID string, limit int) ([]*model.Customer, error) {
+	customers := []*model.Customer{}
+
+	tx := r.db.Unscoped().
+		Where("prefecture IS NULL AND address IS NOT NULL AND address <> '' AND id::text > ?", afterID)
+	if tenantID != nil {
+		tx = tx.Where("tenant_id = ?", *tenantID)
+	}
+	if err := tx.
+		Order("id::text").
+		Limit(limit).
+		Find(&customers).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+// UpdateCustomerAddress は顧客の分割した住所の列のみを更新する
+func (r *repository) UpdateCustomerAddress(ctx context.Context, customer model.Customer) error {
+	return r.db.Unscoped().
+		Model(&model.Customer{}).
+		Where("tenant_id = ? AND id = ?", customer.TenantID, customer.ID).
+		Select("postal_code", "prefecture", "city", "street", "building").
+		Updates(&customer).
+		Error
+}
+
+// GetUnparsedStores は住所が未分割の店舗をID順に取得する。削除済みの店舗も含む
+func (r *repository) GetUnparsedStores(ctx context.Context, tenantID *string, afterID string, limit int) ([]*model.Store, error) {
+	stores := []*model.Store{}
+
+	tx := r.db.Unscoped().
+		Where("prefecture IS NULL AND address IS NOT NULL AND address <> '' AND id::text > ?", afterID)
+	if tenantID != nil {
+		tx = tx.Where("tenant_id = ?", *tenantID)
+	}
+	if err := tx.
+		Order("id::text").
+		Limit(limit).
+		Find(&stores).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return stores, nil
+}
+
+// UpdateStoreAddress は店舗の郵便番号と分割した住所の列のみを更新する
+func (r *repository) UpdateStoreAddress(ctx context.Context, store model.Store) error {
+	return r.db.Unscoped().
+		Model(&model.Store{}).
+		Where("tenant_id = ? AND id = ?", store.TenantID, store.ID).
+		Select("zip_code", "prefecture", "city", "street", "building").
+		Updates(&store).
+		Error
+}
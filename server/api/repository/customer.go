@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
@@ -40,14 +41,22 @@ func (r *repository) GetCustomer(ctx context.Context, tenantID, customerID strin
 }
 
 func (r *repository) CreateCustomer(ctx context.Context, customer model.Customer) (*string, error) {
-	if err := r.db.Create(&customer).Error; err != nil {
+	if err := r.indexCustomer(ctx, &customer); err != nil {
 		return nil, err
 	}
 
+	if err := r.db.Create(&customer).Error; err != nil {
+		return nil, r.translateError(err)
+	}
+
 	return &customer.ID, nil
 }
 
 func (r *repository) UpdateCustomer(ctx context.Context, customer model.Customer) (*model.Customer, error) {
+	if err := r.indexCustomer(ctx, &customer); err != nil {
+		return nil, err
+	}
+
 	if err := r.db.
 		Clauses(clause.Returning{}).
 		Where(
@@ -55,8 +64,10 @@ func (r *repository) UpdateCustomer(ctx context.Context, customer model.Customer
 			customer.TenantID,
 			customer.ID,
 		).
+		// 建物名などを空にする更新を反映するため、ゼロ値も更新する
+		Select("name", "email", "phone_number", "phone_number_display", "phone_country", "address", "postal_code", "prefecture", "city", "street", "building", "email_bidx", "phone_number_bidx", "updated_at").
 		Updates(&customer).Error; err != nil {
-		return nil, err
+		return nil, r.translateError(err)
 	}
 
 	return &customer, nil
@@ -70,3 +81,50 @@ func (r *repository) DeleteCustomer(ctx context.Context, tenantID, customerID st
 	).
 		Delete(&model.Customer{}).Error
 }
+
+// FindCustomerByEmail はメールアドレスの大文字・小文字を区別せずに顧客を検索する
+// メールアドレスは暗号化しているため、ブラインドインデックスで照合する
+func (r *repository) FindCustomerByEmail(ctx context.Context, tenantID, email string) (*model.Customer, error) {
+	customer := &model.Customer{}
+
+	index, err := r.keys.blindIndex(ctx, "email", normalizeEmailIndex(email))
+	if err != nil {
+		return nil, err
+	}
+	if index == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if err := r.db.
+		Where("tenant_id = ? AND email_bidx = ?", tenantID, *index).
+		Order("created_at").
+		First(&customer).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+// FindCustomerByPhoneNumber はE.164形式の電話番号で顧客を検索する
+func (r *repository) FindCustomerByPhoneNumber(ctx context.Context, tenantID, phoneNumber string) (*model.Customer, error) {
+	customer := &model.Customer{}
+
+	index, err := r.keys.blindIndex(ctx, "phone_number", phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	if index == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if err := r.db.
+		Where("tenant_id = ? AND phone_number_bidx = ?", tenantID, *index).
+		Order("created_at").
+		First(&customer).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return customer, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+)
+
+// GetCustomerConsents は顧客の同意・撤回の記録を新しい順に取得する
+func (r *repository) GetCustomerConsents(ctx context.Context, tenantID, customerID string) ([]*model.CustomerConsent, error) {
+	consents := []*model.CustomerConsent{}
+
+	if err := r.db.
+		Where("tenant_id = ? AND customer_id = ?", tenantID, customerID).
+		Order("recorded_at DESC, id DESC").
+		Find(&consents).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return consents, nil
+}
+
+// GetCurrentCustomerConsents は顧客・目的ごとに最新の同意・撤回の記録を取得する
+func (r *repository) GetCurrentCustomerConsents(ctx context.Context, tenantID string, customerIDs []string) ([]*model.CustomerConsent, error) {
+	consents := []*model.CustomerConsent{}
+
+	if err := r.db.
+		Select("DISTINCT ON (customer_id, purpose) *").
+		Where("tenant_id = ? AND customer_id IN ?", tenantID, customerIDs).
+		Order("customer_id, purpose, recorded_at DESC, id DESC").
+		Find(&consents).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return consents, nil
+}
+
+func (r *repository) CreateCustomerConsent(ctx context.Context, consent model.CustomerConsent) (*model.CustomerConsent, error) {
+	if err := r.db.Create(&consent).Error; err != nil {
+		return nil, err
+	}
+
+	return &consent, nil
+}
+
+// GetMergedCustomers は顧客に統合された顧客を、統合元がさらに統合した顧客も含めて取得する。統合元は削除済み
+func (r *repository) GetMergedCustomers(ctx context.Context, tenantID, customerID string) ([]*model.Customer, error) {
+	customers := []*model.Customer{}
+
+	if err := r.db.Unscoped().
+		Where(`customers.id IN (
+			WITH RECURSIVE merged AS (
+				SELECT source_customer_id FROM customer_merges WHERE tenant_id = @tenant AND target_customer_id = @customer
+				UNION
+				SELECT m.source_customer_id FROM customer_merges AS m
+				JOIN merged ON m.target_customer_id = merged.source_customer_id
+				WHERE m.tenant_id = @tenant
+			)
+			SELECT source_customer_id FROM merged
+		)`, map[string]interface{}{"tenant": tenantID, "customer": customerID}).
+		Where("customers.tenant_id = ?", tenantID).
+		Order("customers.created_at, customers.id").
+		Find(&customers).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+// AnonymizeCustomers は顧客の個人情報を消去して削除済みにし、匿名化した件数を返す
+// 発注は会計のために残すため、顧客の行は物理削除しない。匿名化済みの顧客は対象外
+func (r *repository) AnonymizeCustomers(ctx context.Context, tenantID string, customerIDs []string, now time.Time) (int64, error) {
+	result := r.db.Unscoped().
+		Model(&model.Customer{}).
+		Where("tenant_id = ? AND id IN ? AND anonymized_at IS NULL", tenantID, customerIDs).
+		Updates(map[string]interface{}{
+			"name":                 "",
+			"email":                "",
+			"phone_number":         "",
+			"phone_number_display": "",
+			"phone_country":        "",
+			"address":              "",
+			"postal_code":          "",
+			"prefecture":           "",
+			"city":                 "",
+			"street":               "",
+			"building":             "",
+			"email_bidx":           nil,
+			"phone_number_bidx":    nil,
+			"recency_score":        nil,
+			"frequency_score":      nil,
+			"monetary_score":       nil,
+			"recency_days":         nil,
+			"frequency":            0,
+			"monetary":             0,
+			"rfm_scored_at":        nil,
+			"anonymized_at":        now,
+			"deleted_at":           gorm.Expr("COALESCE(deleted_at, ?)", now),
+			"updated_at":           now,
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// ScrubCustomerMergeSnapshots は統合履歴に残した統合元の顧客情報を消去する
+func (r *repository) ScrubCustomerMergeSnapshots(ctx context.Context, tenantID string, customerIDs []string) error {
+	return r.db.Model(&model.CustomerMerge{}).
+		Where("tenant_id = ? AND source_customer_id IN ?", tenantID, customerIDs).
+		Update("source", model.CustomerSnapshot{}).
+		Error
+}
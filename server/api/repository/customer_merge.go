@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm/clause"
+)
+
+// GetActiveCustomers は削除されていない顧客を全件取得する。重複の判定に使うため発注は読み込まない
+func (r *repository) GetActiveCustomers(ctx context.Context, tenantID string) ([]*model.Customer, error) {
+	customers := []*model.Customer{}
+
+	if err := r.db.
+		Where("tenant_id = ?", tenantID).
+		Order("created_at, id").
+		Find(&customers).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+// LockCustomers は削除されていない顧客を行ロックして取得する
+func (r *repository) LockCustomers(ctx context.Context, tenantID string, customerIDs []string) ([]*model.Customer, error) {
+	customers := []*model.Customer{}
+
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tenant_id = ? AND id IN ?", tenantID, customerIDs).
+		Order("id").
+		Find(&customers).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+// ReassignCustomerReferences は統合元の顧客を参照するレコードを統合先に付け替え、付け替えた発注のIDを返す
+// 顧客を参照するテーブルを追加した場合はここで付け替える
+func (r *repository) ReassignCustomerReferences(ctx context.Context, fromCustomerID, toCustomerID string) ([]int, error) {
+	orders := []*model.Order{}
+
+	if err := r.db.Model(&orders).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("customer_id = ?", fromCustomerID).
+		Update("customer_id", toCustomerID).
+		Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&model.CustomerConsent{}).
+		Where("customer_id = ?", fromCustomerID).
+		Update("customer_id", toCustomerID).
+		Error; err != nil {
+		return nil, err
+	}
+
+	// 未使用のポイントと有効期限も付け替わる。顧客の残高の移行は呼び出し側で行う
+	if err := r.db.Model(&model.PointTransaction{}).
+		Where("customer_id = ?", fromCustomerID).
+		Update("customer_id", toCustomerID).
+		Error; err != nil {
+		return nil, err
+	}
+
+	orderIDs := make([]int, 0, len(orders))
+	for _, order := range orders {
+		orderIDs = append(orderIDs, order.ID)
+	}
+
+	return orderIDs, nil
+}
+
+// CreateCustomerMerge は統合履歴を保存する。統合元の顧客情報のうち顧客で暗号化している項目は暗号化して保存する
+func (r *repository) CreateCustomerMerge(ctx context.Context, merge model.CustomerMerge) (*model.CustomerMerge, error) {
+	source := merge.Source
+	sealed, err := r.sealCustomerSnapshot(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	merge.Source = sealed
+	if err := r.db.Create(&merge).Error; err != nil {
+		return nil, err
+	}
+	merge.Source = source
+
+	return &merge, nil
+}
+
+// GetCustomerMerges は顧客が統合先または統合元になった履歴を新しい順に取得する
+func (r *repository) GetCustomerMerges(ctx context.Context, tenantID, customerID string) ([]*model.CustomerMerge, error) {
+	merges := []*model.CustomerMerge{}
+
+	if err := r.db.
+		Where("tenant_id = ?", tenantID).
+		Where("target_customer_id = ? OR source_customer_id = ?", customerID, customerID).
+		Order("merged_at DESC, id DESC").
+		Find(&merges).
+		Error; err != nil {
+		return nil, err
+	}
+	if err := r.openCustomerSnapshots(ctx, merges); err != nil {
+		return nil, err
+	}
+
+	return merges, nil
+}
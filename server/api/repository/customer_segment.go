@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *repository) GetCustomerSegments(ctx context.Context, tenantID string) ([]*model.CustomerSegment, error) {
+	segments := []*model.CustomerSegment{}
+
+	if err := r.db.
+		Where("tenant_id = ?", tenantID).
+		Order("name").
+		Find(&segments).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return segments, nil
+}
+
+func (r *repository) GetCustomerSegment(ctx context.Context, tenantID string, segmentID int) (*model.CustomerSegment, error) {
+	segment := &model.CustomerSegment{}
+
+	if err := r.db.
+		Where("tenant_id = ? AND id = ?", tenantID, segmentID).
+		First(&segment).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+func (r *repository) CreateCustomerSegment(ctx context.Context, segment model.CustomerSegment) (*model.CustomerSegment, error) {
+	if err := r.db.Create(&segment).Error; err != nil {
+		return nil, r.translateError(err)
+	}
+
+	return &segment, nil
+}
+
+func (r *repository) UpdateCustomerSegment(ctx context.Context, segment model.CustomerSegment) (*model.CustomerSegment, error) {
+	result := r.db.
+		Clauses(clause.Returning{}).
+		Where("tenant_id = ? AND id = ?", segment.TenantID, segment.ID).
+		Select("name", "description", "filter", "updated_at").
+		Updates(&segment)
+	if result.Error != nil {
+		return nil, r.translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &segment, nil
+}
+
+func (r *repository) DeleteCustomerSegment(ctx context.Context, tenantID string, segmentID int) error {
+	result := r.db.
+		Where("tenant_id = ? AND id = ?", tenantID, segmentID).
+		Delete(&model.CustomerSegment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// segmentCustomers は絞り込み条件に該当する削除されていない顧客のクエリを返す
+func (r *repository) segmentCustomers(tenantID string, filter model.SegmentFilter) *gorm.DB {
+	tx := r.db.Model(&model.Customer{}).
+		Where("customers.tenant_id = ?", tenantID)
+
+	for _, cond := range []struct {
+		query string
+		value *int
+	}{
+		{"customers.recency_score >= ?", filter.MinRecencyScore},
+		{"customers.recency_score <= ?", filter.MaxRecencyScore},
+		{"customers.frequency_score >= ?", filter.MinFrequencyScore},
+		{"customers.frequency_score <= ?", filter.MaxFrequencyScore},
+		{"customers.monetary_score >= ?", filter.MinMonetaryScore},
+		{"customers.monetary_score <= ?", filter.MaxMonetaryScore},
+		{"customers.recency_days >= ?", filter.MinRecencyDays},
+		{"customers.recency_days <= ?", filter.MaxRecencyDays},
+		{"customers.frequency >= ?", filter.MinFrequency},
+		{"customers.monetary >= ?", filter.MinMonetary},
+	} {
+		if cond.value != nil {
+			tx = tx.Where(cond.query, *cond.value)
+		}
+	}
+	if len(filter.Prefectures) > 0 {
+		tx = tx.Where("customers.prefecture IN ?", filter.Prefectures)
+	}
+
+	return tx
+}
+
+// CountSegmentCustomers は絞り込み条件に該当する顧客の件数を取得する
+func (r *repository) CountSegmentCustomers(ctx context.Context, tenantID string, filter model.SegmentFilter) (int64, error) {
+	var count int64
+
+	if err := r.segmentCustomers(tenantID, filter).
+		Count(&count).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetSegmentCustomers は絞り込み条件に該当する顧客を累計購入額の多い順に取得する
+func (r *repository) GetSegmentCustomers(ctx context.Context, tenantID string, filter model.SegmentFilter, limit, offset int) ([]*model.Customer, error) {
+	customers := []*model.Customer{}
+
+	if err := r.segmentCustomers(tenantID, filter).
+		Order("customers.monetary DESC, customers.id").
+		Limit(limit).
+		Offset(offset).
+		Find(&customers).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+// EachSegmentCustomer はGetSegmentCustomersと同じ条件の顧客を1件ずつfnに渡す。limitに-1を指定すると全件が対象
+func (r *repository) EachSegmentCustomer(ctx context.Context, tenantID string, filter model.SegmentFilter, limit, offset int, fn func(*model.Customer) error) error {
+	return each(r.segmentCustomers(tenantID, filter).
+		Order("customers.monetary DESC, customers.id").
+		Limit(limit).
+		Offset(offset), fn)
+}
+
+// ScoreCustomers は発注から顧客のRFMスコアを算出して保存する。tenantIDがnilの場合は全テナントが対象
+//
+// スコアはテナント内での累積分布を5段階にしたもので、同じ値の顧客は同じスコアになる。
+// キャンセルされた発注は含めず、対象の発注がない顧客のスコアは空にする。削除済みの顧客は更新しない
+// 累計購入額は値引き・ポイント利用・返品を差し引いた額とする
+func (r *repository) ScoreCustomers(ctx context.Context, tenantID *string, now time.Time) (int64, error) {
+	result := r.db.Exec(`
+		WITH stats AS (
+			SELECT c.id, c.tenant_id, MAX(o.created_at) AS last_order_at, COUNT(o.id) AS frequency,
+				COALESCE(SUM(`+netOrderAmount+`), 0) AS monetary
+			FROM customers AS c
+			JOIN orders AS o ON o.customer_id = c.id AND o.status IS DISTINCT FROM @cancelled
+			`+joinReturnedAmounts+`
+			WHERE c.deleted_at IS NULL AND (CAST(@tenant AS uuid) IS NULL OR c.tenant_id = @tenant)
+			GROUP BY c.id, c.tenant_id
+		), scored AS (
+			SELECT id, last_order_at, frequency, monetary,
+				CEIL(5 * CUME_DIST() OVER (PARTITION BY tenant_id ORDER BY last_order_at)) AS recency_score,
+				CEIL(5 * CUME_DIST() OVER (PARTITION BY tenant_id ORDER BY frequency)) AS frequency_score,
+				CEIL(5 * CUME_DIST() OVER (PARTITION BY tenant_id ORDER BY monetary)) AS monetary_score
+			FROM stats
+		)
+		UPDATE customers AS c SET
+			recency_score = s.recency_score,
+			frequency_score = s.frequency_score,
+			monetary_score = s.monetary_score,
+			recency_days = FLOOR(EXTRACT(EPOCH FROM CAST(@now AS timestamptz) - s.last_order_at) / 86400),
+			frequency = COALESCE(s.frequency, 0),
+			monetary = COALESCE(s.monetary, 0),
+			rfm_scored_at = @now
+		FROM customers AS t
+		LEFT JOIN scored AS s ON s.id = t.id
+		WHERE c.id = t.id AND t.deleted_at IS NULL AND (CAST(@tenant AS uuid) IS NULL OR t.tenant_id = @tenant)`,
+		map[string]interface{}{"cancelled": model.StatusCancelled, "tenant": tenantID, "now": now})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
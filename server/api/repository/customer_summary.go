@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+)
+
+// GetCustomerSummary は顧客の発注をSQLで集計する。顧客が存在しない場合は gorm.ErrRecordNotFound を返す
+func (r *repository) GetCustomerSummary(ctx context.Context, tenantID, customerID string) (*model.CustomerSummary, error) {
+	summaries := []*model.CustomerSummary{}
+
+	if err := r.db.Table("customers AS c").
+		Select(`c.id AS customer_id,
+			COALESCE(SUM(`+netOrderAmount+`), 0) AS lifetime_spend,
+			COUNT(o.id) AS order_count,
+			COALESCE(SUM(o.quantity), 0) AS units,
+			COALESCE(ROUND(SUM(`+netOrderAmount+`)::numeric / NULLIF(COUNT(o.id), 0)), 0)::bigint AS average_basket,
+			MIN(o.created_at) AS first_purchase_at,
+			MAX(o.created_at) AS last_purchase_at`).
+		Joins("LEFT JOIN orders AS o ON o.customer_id = c.id AND o.status IS DISTINCT FROM ?", model.StatusCancelled).
+		Joins(joinReturnedAmounts).
+		Where("c.tenant_id = ? AND c.id = ?", tenantID, customerID).
+		Group("c.id").
+		Scan(&summaries).
+		Error; err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return summaries[0], nil
+}
+
+// GetCustomerCategorySpends は顧客の購入額の多い分類を上位から取得する
+func (r *repository) GetCustomerCategorySpends(ctx context.Context, customerID string, limit int) ([]*model.CategorySpend, error) {
+	spends := []*model.CategorySpend{}
+
+	if err := r.db.Table("orders AS o").
+		Select(`s.category,
+			COALESCE(SUM(`+netOrderAmount+`), 0) AS spend,
+			COUNT(*) AS order_count,
+			COALESCE(SUM(o.quantity), 0) AS units`).
+		Joins("JOIN stocks AS s ON o.stock_id = s.id").
+		Joins(joinReturnedAmounts).
+		Where("o.customer_id = ? AND o.status IS DISTINCT FROM ? AND s.category IS NOT NULL", customerID, model.StatusCancelled).
+		Group("s.category").
+		Order("spend DESC, order_count DESC, s.category").
+		Limit(limit).
+		Scan(&spends).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return spends, nil
+}
+
+// CheckCustomerExists は発注を読み込まずに顧客の存在を確認する。削除済みの顧客も存在するとみなす
+func (r *repository) CheckCustomerExists(ctx context.Context, tenantID, customerID string) error {
+	return r.db.Unscoped().
+		Select("id").
+		Where("tenant_id = ? AND id = ?", tenantID, customerID).
+		First(&model.Customer{}).
+		Error
+}
+
+// GetCustomerOrders は顧客の発注を新しい順に取得する
+func (r *repository) GetCustomerOrders(ctx context.Context, customerID string, limit, offset int) ([]*model.Order, error) {
+	orders := []*model.Order{}
+
+	if err := r.db.
+		Where("customer_id = ?", customerID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&orders).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// CountCustomerOrders は顧客の発注の件数を取得する
+func (r *repository) CountCustomerOrders(ctx context.Context, customerID string) (int64, error) {
+	var count int64
+
+	if err := r.db.Model(&model.Order{}).
+		Where("customer_id = ?", customerID).
+		Count(&count).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
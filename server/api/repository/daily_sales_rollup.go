@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+)
+
+const (
+	// netOrderAmount は発注 o の売上額。値引き・ポイント利用・完了した返品で返した額を差し引く
+	// 返品の額は joinReturnedAmounts で結合した r から求める
+	netOrderAmount = "(COALESCE(o.total_amount, 0) - o.discount_amount - o.points_used - COALESCE(r.amount, 0))"
+	// joinReturnedAmounts は発注ごとに完了した返品で返金、またはポイントで返還した額を r として結合する
+	joinReturnedAmounts = "LEFT JOIN (SELECT order_id, SUM(amount) AS amount FROM order_returns WHERE status = 'COMPLETED' GROUP BY order_id) AS r ON r.order_id = o.id"
+)
+
+// dailySalesFromOrders は発注を日次集計の粒度で集計するサブクエリ
+func (r *repository) dailySalesFromOrders(tenantID *string, timeZone string) *gorm.DB {
+	tx := r.db.Table("orders AS o").
+		Select(`c.tenant_id, s.store_id, o.stock_id, (o.created_at AT TIME ZONE ?)::date AS sales_date, o.status,
+			COALESCE(SUM(`+netOrderAmount+`), 0) AS revenue, COUNT(*) AS order_count, COALESCE(SUM(o.quantity), 0) AS units`, timeZone).
+		Joins("JOIN customers AS c ON o.customer_id = c.id").
+		Joins("JOIN stocks AS s ON o.stock_id = s.id").
+		Joins(joinReturnedAmounts).
+		Where("c.tenant_id IS NOT NULL AND s.store_id IS NOT NULL AND o.status IS NOT NULL AND o.created_at IS NOT NULL").
+		Group("1, 2, 3, 4, 5")
+	if tenantID != nil {
+		tx = tx.Where("c.tenant_id = ?", *tenantID)
+	}
+
+	return tx
+}
+
+// ApplyOrdersToDailySales は発注の現在の内容を日次集計に加算する
+// signに-1を指定すると減算する。発注の更新や返品の完了時は変更前に減算し、変更後に加算する
+func (r *repository) ApplyOrdersToDailySales(ctx context.Context, orderIDs []int, sign int, timeZone string) error {
+	if len(orderIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	return r.db.Exec(`
+		INSERT INTO daily_sales_rollups (created_at, updated_at, tenant_id, store_id, stock_id, sales_date, status, revenue, order_count, units)
+		SELECT ?, ?, c.tenant_id, s.store_id, o.stock_id, (o.created_at AT TIME ZONE ?)::date, o.status,
+			? * COALESCE(SUM(`+netOrderAmount+`), 0), ? * COUNT(*), ? * COALESCE(SUM(o.quantity), 0)
+		FROM orders AS o
+		JOIN customers AS c ON o.customer_id = c.id
+		JOIN stocks AS s ON o.stock_id = s.id
+		`+joinReturnedAmounts+`
+		WHERE o.id IN ? AND c.tenant_id IS NOT NULL AND s.store_id IS NOT NULL AND o.status IS NOT NULL AND o.created_at IS NOT NULL
+		GROUP BY 3, 4, 5, 6, 7
+		ON CONFLICT (tenant_id, store_id, stock_id, sales_date, status) DO UPDATE SET
+			revenue = daily_sales_rollups.revenue + EXCLUDED.revenue,
+			order_count = daily_sales_rollups.order_count + EXCLUDED.order_count,
+			units = daily_sales_rollups.units + EXCLUDED.units,
+			updated_at = EXCLUDED.updated_at`,
+		now, now, timeZone, sign, sign, sign, orderIDs,
+	).Error
+}
+
+// RebuildDailySales は日次集計を発注から作り直し、作成した行数を返す
+// トランザクション内で呼び出すこと。作り直しの間は発注による差分更新を待たせる
+func (r *repository) RebuildDailySales(ctx context.Context, tenantID *string, timeZone string) (int64, error) {
+	if err := r.db.Exec("LOCK TABLE daily_sales_rollups IN EXCLUSIVE MODE").Error; err != nil {
+		return 0, err
+	}
+
+	tx := r.db.Session(&gorm.Session{AllowGlobalUpdate: true})
+	if tenantID != nil {
+		tx = r.db.Where("tenant_id = ?", *tenantID)
+	}
+	if err := tx.Delete(&model.DailySalesRollup{}).Error; err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	result := r.db.Exec(`
+		INSERT INTO daily_sales_rollups (created_at, updated_at, tenant_id, store_id, stock_id, sales_date, status, revenue, order_count, units)
+		SELECT ?, ?, raw.* FROM (?) AS raw`,
+		now, now, r.dailySalesFromOrders(tenantID, timeZone),
+	)
+
+	return result.RowsAffected, result.Error
+}
+
+// GetDailySalesDiscrepancies は日次集計と発注からの集計を突き合わせ、一致しない行を返す
+// 件数が0になった日次集計の行は存在しないものとして扱う
+func (r *repository) GetDailySalesDiscrepancies(ctx context.Context, tenantID *string, timeZone string) ([]*model.DailySalesDiscrepancy, error) {
+	rollups := r.db.Table("daily_sales_rollups").
+		Select("tenant_id, store_id, stock_id, sales_date, status, revenue, order_count, units").
+		Where("order_count <> 0 OR revenue <> 0 OR units <> 0")
+	if tenantID != nil {
+		rollups = rollups.Where("tenant_id = ?", *tenantID)
+	}
+
+	discrepancies := []*model.DailySalesDiscrepancy{}
+	if err := r.db.Raw(`
+		SELECT
+			COALESCE(e.tenant_id, a.tenant_id) AS tenant_id,
+			COALESCE(e.store_id, a.store_id) AS store_id,
+			COALESCE(e.stock_id, a.stock_id) AS stock_id,
+			COALESCE(e.sales_date, a.sales_date) AS sales_date,
+			COALESCE(e.status, a.status) AS status,
+			COALESCE(e.revenue, 0) AS expected_revenue,
+			COALESCE(e.order_count, 0) AS expected_order_count,
+			COALESCE(e.units, 0) AS expected_units,
+			COALESCE(a.revenue, 0) AS actual_revenue,
+			COALESCE(a.order_count, 0) AS actual_order_count,
+			COALESCE(a.units, 0) AS actual_units
+		FROM (?) AS e
+		FULL OUTER JOIN (?) AS a
+			ON e.tenant_id = a.tenant_id AND e.store_id = a.store_id AND e.stock_id = a.stock_id
+			AND e.sales_date = a.sales_date AND e.status = a.status
+		WHERE e.revenue IS DISTINCT FROM a.revenue
+			OR e.order_count IS DISTINCT FROM a.order_count
+			OR e.units IS DISTINCT FROM a.units
+		ORDER BY 1, 4, 2, 3, 5`,
+		r.dailySalesFromOrders(tenantID, timeZone), rollups,
+	).Scan(&discrepancies).Error; err != nil {
+		return nil, err
+	}
+
+	return discrepancies, nil
+}
@@ -0,0 +1,519 @@
+package repository
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/kms"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/config"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// ciphertextPrefix は暗号化した値の先頭に付ける。続けてデータ鍵のIDと「:」、Base64のnonceと暗号文が入る
+	ciphertextPrefix = "enc:v1:"
+	dataKeySize      = 32
+	// activeKeyTTL は有効なデータ鍵を読み直す間隔。他のプロセスでローテーションした鍵を使い始めるまでの時間
+	activeKeyTTL = time.Minute
+)
+
+// encryptedColumns は顧客の暗号化する列。model.Customerでserializer:encryptedを指定した列と揃える
+var encryptedColumns = []string{"email", "phone_number", "phone_number_display", "address", "street", "building"}
+
+// encryptedSnapshotKeys は統合履歴に残す統合元の顧客情報 (customer_merges.source) のうち暗号化する項目
+var encryptedSnapshotKeys = []string{"email", "phone_number", "address"}
+
+func newKeyProvider(cfg *config.Config) (kms.Provider, error) {
+	switch cfg.KeyProvider {
+	case config.KeyProviderKeyfile:
+		return kms.NewKeyfile(cfg.Keyfile, cfg.Env == config.Local)
+	case config.KeyProviderAWSKMS:
+		if cfg.KMSKeyID == "" {
+			return nil, errors.New("KMS_KEY_ID is required for the aws_kms key provider")
+		}
+
+		return kms.NewAWS(kms.AWSConfig{
+			Endpoint:  cfg.KMSEndpoint,
+			Region:    cfg.KMSRegion,
+			KeyID:     cfg.KMSKeyID,
+			AccessKey: cfg.KMSAccessKey,
+			SecretKey: cfg.KMSSecretKey,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown key provider: %s", cfg.KeyProvider)
+	}
+}
+
+// keyRing はマスター鍵で包んだデータ鍵をDBから読み込み、復号した鍵をプロセス内に保持する
+// マスター鍵のプロバイダを呼ぶのはデータ鍵の読み込み時だけで、値ごとには呼ばない
+type keyRing struct {
+	db       *gorm.DB
+	provider kms.Provider
+
+	mu       sync.RWMutex
+	keys     map[int][]byte
+	active   int
+	index    []byte
+	loadedAt time.Time
+}
+
+func newKeyRing(db *gorm.DB, provider kms.Provider) *keyRing {
+	return &keyRing{
+		db:       db,
+		provider: provider,
+		keys:     map[int][]byte{},
+	}
+}
+
+// current は暗号化に使う有効なデータ鍵とブラインドインデックスの鍵を返す。なければ作成する
+func (k *keyRing) current(ctx context.Context) (int, []byte, []byte, error) {
+	k.mu.RLock()
+	if time.Since(k.loadedAt) < activeKeyTTL {
+		defer k.mu.RUnlock()
+
+		return k.active, k.keys[k.active], k.index, nil
+	}
+	k.mu.RUnlock()
+
+	encryption, err := k.activeKey(ctx, model.DataKeyEncryption)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	index, err := k.activeKey(ctx, model.DataKeyBlindIndex)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.active, k.index, k.loadedAt = encryption.ID, k.keys[index.ID], time.Now()
+
+	return k.active, k.keys[k.active], k.index, nil
+}
+
+func (k *keyRing) activeKey(ctx context.Context, purpose model.DataKeyPurpose) (*model.DataKey, error) {
+	key := &model.DataKey{}
+	err := k.db.Where("purpose = ? AND active", purpose).First(key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 他のプロセスが同時に作成した場合は一意制約で弾かれるため、作成後に読み直す
+		if _, err := k.createKey(ctx, k.db.Clauses(clause.OnConflict{DoNothing: true}), purpose); err != nil {
+			return nil, err
+		}
+		err = k.db.Where("purpose = ? AND active", purpose).First(key).Error
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := k.unwrap(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// createKey は新しいデータ鍵を生成し、マスター鍵で包んで有効な鍵として保存する
+func (k *keyRing) createKey(ctx context.Context, tx *gorm.DB, purpose model.DataKeyPurpose) (*model.DataKey, error) {
+	plaintext := make([]byte, dataKeySize)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, err
+	}
+	wrapped, err := k.provider.Encrypt(ctx, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &model.DataKey{
+		Purpose:     purpose,
+		WrappedKey:  wrapped,
+		MasterKeyID: k.provider.KeyID(),
+		Active:      true,
+	}
+	if err := tx.Create(key).Error; err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// unwrap はデータ鍵を復号してキャッシュする
+func (k *keyRing) unwrap(ctx context.Context, key *model.DataKey) ([]byte, error) {
+	k.mu.RLock()
+	plaintext, ok := k.keys[key.ID]
+	k.mu.RUnlock()
+	if ok {
+		return plaintext, nil
+	}
+
+	plaintext, err := k.provider.Decrypt(ctx, key.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("data key %d: %w", key.ID, err)
+	}
+
+	k.mu.Lock()
+	k.keys[key.ID] = plaintext
+	k.mu.Unlock()
+
+	return plaintext, nil
+}
+
+// key は復号に使うデータ鍵を返す。無効にした鍵も対象
+func (k *keyRing) key(ctx context.Context, id int) ([]byte, error) {
+	k.mu.RLock()
+	plaintext, ok := k.keys[id]
+	k.mu.RUnlock()
+	if ok {
+		return plaintext, nil
+	}
+
+	key := &model.DataKey{}
+	if err := k.db.Where("purpose = ? AND id = ?", model.DataKeyEncryption, id).First(key).Error; err != nil {
+		return nil, fmt.Errorf("data key %d: %w", id, err)
+	}
+
+	return k.unwrap(ctx, key)
+}
+
+// encrypt は列名を付加データとしてAES-GCMで暗号化する。別の列に値を移しても復号できない
+// 空文字は暗号化しない
+func (k *keyRing) encrypt(ctx context.Context, column, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	id, key, _, err := k.current(ctx)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(column))
+
+	return ciphertextPrefix + strconv.Itoa(id) + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decrypt は暗号化した値を復号する。暗号化する前から残っている平文の値はそのまま返す
+func (k *keyRing) decrypt(ctx context.Context, column, value string) (string, error) {
+	if !strings.HasPrefix(value, ciphertextPrefix) {
+		return value, nil
+	}
+
+	idText, encoded, ok := strings.Cut(strings.TrimPrefix(value, ciphertextPrefix), ":")
+	id, err := strconv.Atoi(idText)
+	if !ok || err != nil {
+		return "", fmt.Errorf("%s: malformed ciphertext", column)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("%s: malformed ciphertext: %w", column, err)
+	}
+
+	key, err := k.key(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("%s: malformed ciphertext", column)
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(column))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", column, err)
+	}
+
+	return string(plaintext), nil
+}
+
+// blindIndex は正規化した値のHMAC-SHA256を返す。空文字の場合はnil
+func (k *keyRing) blindIndex(ctx context.Context, column, normalized string) (*string, error) {
+	if normalized == "" {
+		return nil, nil
+	}
+
+	_, _, key, err := k.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(column + ":" + normalized))
+	index := hex.EncodeToString(mac.Sum(nil))
+
+	return &index, nil
+}
+
+// reset は次の暗号化の前に有効なデータ鍵を読み直させる
+func (k *keyRing) reset() {
+	k.mu.Lock()
+	k.loadedAt = time.Time{}
+	k.mu.Unlock()
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptedSerializer はserializer:encryptedを指定した文字列のフィールドを保存時に暗号化し、読み込み時に復号する
+type encryptedSerializer struct {
+	keys *keyRing
+}
+
+func (s *encryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	}
+
+	plaintext, err := s.keys.decrypt(ctx, field.DBName, value)
+	if err != nil {
+		return err
+	}
+	field.ReflectValueOf(ctx, dst).SetString(plaintext)
+
+	return nil
+}
+
+func (s *encryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	plaintext, _ := fieldValue.(string)
+
+	return s.keys.encrypt(ctx, field.DBName, plaintext)
+}
+
+// normalizeEmailIndex はメールアドレスを大文字・小文字を区別せずに検索するための正規化
+func normalizeEmailIndex(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// indexCustomer は顧客のメールアドレス・電話番号のブラインドインデックスを設定する
+func (r *repository) indexCustomer(ctx context.Context, customer *model.Customer) error {
+	var err error
+	if customer.EmailIndex, err = r.keys.blindIndex(ctx, "email", normalizeEmailIndex(customer.Email)); err != nil {
+		return err
+	}
+	if customer.PhoneNumberIndex, err = r.keys.blindIndex(ctx, "phone_number", customer.PhoneNumber); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RotateDataKey は新しいデータ鍵を作成して有効にする。以前の鍵は復号のために残す
+func (r *repository) RotateDataKey(ctx context.Context) (*model.DataKey, error) {
+	var key *model.DataKey
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.DataKey{}).
+			Where("purpose = ? AND active", model.DataKeyEncryption).
+			Update("active", false).
+			Error; err != nil {
+			return err
+		}
+
+		var err error
+		key, err = r.keys.createKey(ctx, tx, model.DataKeyEncryption)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.keys.reset()
+
+	return key, nil
+}
+
+// RewrapDataKeys は現在のマスター鍵以外で包んだデータ鍵を現在のマスター鍵で包み直す
+// マスター鍵を切り替えた後、以前のマスター鍵を廃棄する前に実行する
+func (r *repository) RewrapDataKeys(ctx context.Context) (int64, error) {
+	keys := []*model.DataKey{}
+	if err := r.db.
+		Where("master_key_id <> ?", r.keys.provider.KeyID()).
+		Order("id").
+		Find(&keys).
+		Error; err != nil {
+		return 0, err
+	}
+
+	var rewrapped int64
+	for _, key := range keys {
+		plaintext, err := r.keys.unwrap(ctx, key)
+		if err != nil {
+			return rewrapped, err
+		}
+		wrapped, err := r.keys.provider.Encrypt(ctx, plaintext)
+		if err != nil {
+			return rewrapped, err
+		}
+		if err := r.db.Model(key).
+			Select("wrapped_key", "master_key_id", "updated_at").
+			Updates(&model.DataKey{WrappedKey: wrapped, MasterKeyID: r.keys.provider.KeyID()}).
+			Error; err != nil {
+			return rewrapped, err
+		}
+		rewrapped++
+	}
+
+	return rewrapped, nil
+}
+
+// GetCustomersToReencrypt は有効なデータ鍵以外で暗号化した列、平文の列、ブラインドインデックスが
+// 未設定の列がある顧客をID順に取得する。削除済みの顧客も含む
+func (r *repository) GetCustomersToReencrypt(ctx context.Context, afterID string, limit int) ([]*model.Customer, error) {
+	active, _, _, err := r.keys.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prefix := ciphertextPrefix + strconv.Itoa(active) + ":%"
+
+	conds := make([]string, 0, len(encryptedColumns)+2)
+	args := make([]interface{}, 0, len(encryptedColumns))
+	for _, column := range encryptedColumns {
+		conds = append(conds, fmt.Sprintf("(%s <> '' AND %s NOT LIKE ?)", column, column))
+		args = append(args, prefix)
+	}
+	conds = append(conds,
+		"(email <> '' AND email_bidx IS NULL)",
+		"(phone_number <> '' AND phone_number_bidx IS NULL)",
+	)
+
+	customers := []*model.Customer{}
+	if err := r.db.Unscoped().
+		Where("id::text > ?", afterID).
+		Where(strings.Join(conds, " OR "), args...).
+		Order("id::text").
+		Limit(limit).
+		Find(&customers).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+// ReencryptCustomer は顧客の暗号化する列を有効なデータ鍵で暗号化し直し、ブラインドインデックスを設定し直す
+// 値は変わらないため更新日時は変更しない
+func (r *repository) ReencryptCustomer(ctx context.Context, customer model.Customer) error {
+	if err := r.indexCustomer(ctx, &customer); err != nil {
+		return err
+	}
+
+	return r.db.Unscoped().
+		Model(&model.Customer{}).
+		Where("id = ?", customer.ID).
+		Select("email", "phone_number", "phone_number_display", "address", "street", "building", "email_bidx", "phone_number_bidx").
+		UpdateColumns(&customer).
+		Error
+}
+
+// snapshotFields は統合元の顧客情報の暗号化する項目を付加データに使う名前とともに返す
+func snapshotFields(s *model.CustomerSnapshot) map[string]*string {
+	return map[string]*string{
+		"source.email":        &s.Email,
+		"source.phone_number": &s.PhoneNumber,
+		"source.address":      &s.Address,
+	}
+}
+
+// sealCustomerSnapshot は統合元の顧客情報のメールアドレス・電話番号・住所を暗号化する
+func (r *repository) sealCustomerSnapshot(ctx context.Context, s model.CustomerSnapshot) (model.CustomerSnapshot, error) {
+	for name, value := range snapshotFields(&s) {
+		sealed, err := r.keys.encrypt(ctx, name, *value)
+		if err != nil {
+			return s, err
+		}
+		*value = sealed
+	}
+
+	return s, nil
+}
+
+// openCustomerSnapshots は統合履歴の統合元の顧客情報を復号する。暗号化する前の平文の値はそのまま返す
+func (r *repository) openCustomerSnapshots(ctx context.Context, merges []*model.CustomerMerge) error {
+	for _, merge := range merges {
+		for name, value := range snapshotFields(&merge.Source) {
+			plaintext, err := r.keys.decrypt(ctx, name, *value)
+			if err != nil {
+				return fmt.Errorf("customer merge %d: %w", merge.ID, err)
+			}
+			*value = plaintext
+		}
+	}
+
+	return nil
+}
+
+// GetCustomerMergesToReencrypt は統合元の顧客情報に有効なデータ鍵以外で暗号化した項目、
+// または平文の項目がある統合履歴をID順に復号して取得する
+func (r *repository) GetCustomerMergesToReencrypt(ctx context.Context, afterID, limit int) ([]*model.CustomerMerge, error) {
+	active, _, _, err := r.keys.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prefix := ciphertextPrefix + strconv.Itoa(active) + ":%"
+
+	conds := make([]string, 0, len(encryptedSnapshotKeys))
+	args := make([]interface{}, 0, len(encryptedSnapshotKeys))
+	for _, key := range encryptedSnapshotKeys {
+		conds = append(conds, fmt.Sprintf("(COALESCE(source->>'%s', '') <> '' AND source->>'%s' NOT LIKE ?)", key, key))
+		args = append(args, prefix)
+	}
+
+	merges := []*model.CustomerMerge{}
+	if err := r.db.
+		Where("id > ?", afterID).
+		Where(strings.Join(conds, " OR "), args...).
+		Order("id").
+		Limit(limit).
+		Find(&merges).
+		Error; err != nil {
+		return nil, err
+	}
+	if err := r.openCustomerSnapshots(ctx, merges); err != nil {
+		return nil, err
+	}
+
+	return merges, nil
+}
+
+// ReencryptCustomerMerge は統合元の顧客情報を有効なデータ鍵で暗号化し直す。更新日時は変更しない
+func (r *repository) ReencryptCustomerMerge(ctx context.Context, merge model.CustomerMerge) error {
+	sealed, err := r.sealCustomerSnapshot(ctx, merge.Source)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Model(&model.CustomerMerge{}).
+		Where("id = ?", merge.ID).
+		UpdateColumn("source", sealed).
+		Error
+}
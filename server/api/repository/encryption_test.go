@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/kms"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/config"
+	"github.com/google/uuid"
+)
+
+// newTestKeyRing はDBを使わずに、マスター鍵で包んだデータ鍵を読み込んだ鍵束を作成する
+// ID 1が暗号化、ID 2がブラインドインデックスの有効な鍵
+func newTestKeyRing(t *testing.T) *keyRing {
+	t.Helper()
+
+	provider, err := kms.NewKeyfile(filepath.Join(t.TempDir(), "master.key"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := newKeyRing(nil, provider)
+	wrapTestKey(t, k, 1)
+	index := wrapTestKey(t, k, 2)
+	k.active, k.index, k.loadedAt = 1, index, time.Now()
+
+	return k
+}
+
+// wrapTestKey は新しいデータ鍵をマスター鍵で包み、復号して鍵束に読み込む
+func wrapTestKey(t *testing.T, k *keyRing, id int) []byte {
+	t.Helper()
+
+	plaintext := make([]byte, dataKeySize)
+	if _, err := rand.Read(plaintext); err != nil {
+		t.Fatal(err)
+	}
+	wrapped, err := k.provider.Encrypt(context.Background(), plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := k.unwrap(context.Background(), &model.DataKey{ID: id, WrappedKey: wrapped})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func TestKeyRingEncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	k := newTestKeyRing(t)
+
+	tests := []struct {
+		name      string
+		plaintext string
+	}{
+		{name: "email", plaintext: "taro@example.com"},
+		{name: "multibyte", plaintext: "東京都千代田区1-1"},
+		{name: "empty is not encrypted", plaintext: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealed, err := k.encrypt(ctx, "address", tt.plaintext)
+			if err != nil {
+				t.Fatalf("encrypt() error = %v", err)
+			}
+			if tt.plaintext == "" {
+				if sealed != "" {
+					t.Errorf("encrypt() = %q, want empty", sealed)
+				}
+				return
+			}
+			if !strings.HasPrefix(sealed, ciphertextPrefix+"1:") || strings.Contains(sealed, tt.plaintext) {
+				t.Errorf("encrypt() = %q", sealed)
+			}
+
+			got, err := k.decrypt(ctx, "address", sealed)
+			if err != nil {
+				t.Fatalf("decrypt() error = %v", err)
+			}
+			if got != tt.plaintext {
+				t.Errorf("decrypt() = %q, want %q", got, tt.plaintext)
+			}
+
+			// 別の列に移した値は復号できない
+			if _, err := k.decrypt(ctx, "street", sealed); err == nil {
+				t.Error("decrypt() with another column succeeded")
+			}
+		})
+	}
+
+	t.Run("plaintext before encryption is returned as is", func(t *testing.T) {
+		got, err := k.decrypt(ctx, "email", "taro@example.com")
+		if err != nil || got != "taro@example.com" {
+			t.Errorf("decrypt() = %q, %v", got, err)
+		}
+	})
+
+	malformed := []string{
+		ciphertextPrefix + "x:AAAA",
+		ciphertextPrefix + "1",
+		ciphertextPrefix + "1:%%%",
+		ciphertextPrefix + "1:AAAA",
+	}
+	for _, value := range malformed {
+		if _, err := k.decrypt(ctx, "email", value); err == nil {
+			t.Errorf("decrypt(%q) succeeded", value)
+		}
+	}
+}
+
+func TestKeyRingRotation(t *testing.T) {
+	ctx := context.Background()
+	k := newTestKeyRing(t)
+
+	before, err := k.encrypt(ctx, "email", "taro@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexBefore, err := k.blindIndex(ctx, "email", "taro@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// RotateDataKeyと同じく新しい鍵を有効にし、読み直す前の状態にする
+	next := wrapTestKey(t, k, 3)
+	k.reset()
+	k.mu.Lock()
+	k.active, k.loadedAt = 3, time.Now()
+	k.mu.Unlock()
+	if _, key, _, _ := k.current(ctx); string(key) != string(next) {
+		t.Fatal("current() did not return the rotated key")
+	}
+
+	after, err := k.encrypt(ctx, "email", "taro@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(after, ciphertextPrefix+"3:") {
+		t.Errorf("encrypt() after rotation = %q, want key 3", after)
+	}
+
+	for _, sealed := range []string{before, after} {
+		got, err := k.decrypt(ctx, "email", sealed)
+		if err != nil || got != "taro@example.com" {
+			t.Errorf("decrypt(%q) = %q, %v", sealed, got, err)
+		}
+	}
+
+	// ブラインドインデックスの鍵はローテーションしないため、検索できるままになる
+	indexAfter, err := k.blindIndex(ctx, "email", "taro@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *indexAfter != *indexBefore {
+		t.Errorf("blindIndex() changed after rotation: %s -> %s", *indexBefore, *indexAfter)
+	}
+	if other, _ := k.blindIndex(ctx, "phone_number", "taro@example.com"); *other == *indexBefore {
+		t.Error("blindIndex() is the same for different columns")
+	}
+}
+
+func TestRotateDataKey(t *testing.T) {
+	if os.Getenv("DB_HOST") == "" {
+		t.Skip("DB_HOST is not set")
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Env != config.Local {
+		t.Skipf("writes to the database; run only in %s", config.Local)
+	}
+	cfg.Keyfile = filepath.Join(t.TempDir(), "master.key")
+
+	r, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 鍵束は読み込みにトランザクションの外の接続を使うため、ローテーションは確定させ、作成した顧客は後で削除する
+	re := r.(*repository)
+	ctx := context.Background()
+	tenantID := uuid.NewString()
+	if err := re.db.Exec(`INSERT INTO tenants (id, name) VALUES (?, 'test')`, tenantID).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		re.db.Exec(`DELETE FROM customers WHERE tenant_id = ?`, tenantID)
+		re.db.Exec(`DELETE FROM tenants WHERE id = ?`, tenantID)
+	})
+
+	customer := &model.Customer{TenantID: tenantID, Name: "test", Email: "Taro@example.com"}
+	if err := re.indexCustomer(ctx, customer); err != nil {
+		t.Fatal(err)
+	}
+	if err := re.db.Create(customer).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.RotateDataKey(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := &model.Customer{}
+	if err := re.db.Where("id = ?", customer.ID).First(stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Email != customer.Email {
+		t.Errorf("Email after rotation = %q, want %q", stored.Email, customer.Email)
+	}
+
+	customers, err := r.GetCustomersToReencrypt(ctx, "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsCustomer(customers, customer.ID) {
+		t.Fatal("GetCustomersToReencrypt() does not include the customer encrypted with the previous key")
+	}
+	if err := r.ReencryptCustomer(ctx, *stored); err != nil {
+		t.Fatal(err)
+	}
+	customers, err = r.GetCustomersToReencrypt(ctx, "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if containsCustomer(customers, customer.ID) {
+		t.Error("GetCustomersToReencrypt() still includes the re-encrypted customer")
+	}
+
+	var raw string
+	if err := re.db.Raw(`SELECT email FROM customers WHERE id = ?`, customer.ID).Scan(&raw).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(raw, ciphertextPrefix) || strings.Contains(raw, "example.com") {
+		t.Errorf("email is stored as %q", raw)
+	}
+}
+
+func containsCustomer(customers []*model.Customer, id string) bool {
+	for _, c := range customers {
+		if c.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+)
+
+// each はクエリの結果を全件メモリに載せずに1行ずつfnに渡す
+func each[T any](tx *gorm.DB, fn func(*T) error) error {
+	rows, err := tx.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v T
+		if err := tx.ScanRows(rows, &v); err != nil {
+			return err
+		}
+		if err := fn(&v); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// EachUser はGetUsersと同じ条件のユーザーを1件ずつfnに渡す。limitに-1を指定すると全件が対象
+func (r *repository) EachUser(ctx context.Context, tenantID string, limit, offset int, fn func(*model.User) error) error {
+	return each(r.db.Unscoped().Model(&model.User{}).
+		Joins("LEFT JOIN stores AS s ON users.store_id = s.id").
+		Where("s.tenant_id = ?", tenantID).
+		Order("users.created_at, users.id").
+		Limit(limit).
+		Offset(offset), fn)
+}
+
+// EachStock はGetStocksと同じ条件の在庫を1件ずつfnに渡す。limitに-1を指定すると全件が対象
+func (r *repository) EachStock(ctx context.Context, storeID string, limit, offset int, fn func(*model.Stock) error) error {
+	return each(r.db.Unscoped().Model(&model.Stock{}).
+		Where("stocks.store_id = ?", storeID).
+		Order("stocks.id").
+		Limit(limit).
+		Offset(offset), fn)
+}
+
+// EachCustomer はGetCustomersと同じ条件の顧客を1件ずつfnに渡す。limitに-1を指定すると全件が対象
+func (r *repository) EachCustomer(ctx context.Context, tenantID string, limit, offset int, fn func(*model.Customer) error) error {
+	return each(r.db.Unscoped().Model(&model.Customer{}).
+		Where("customers.tenant_id = ?", tenantID).
+		Order("customers.created_at, customers.id").
+		Limit(limit).
+		Offset(offset), fn)
+}
+
+// EachOrder はGetOrdersと同じ条件の発注を1件ずつfnに渡す。limitに-1を指定すると全件が対象
+func (r *repository) EachOrder(ctx context.Context, tenantID string, limit, offset int, fn func(*model.Order) error) error {
+	return each(r.db.Unscoped().Model(&model.Order{}).
+		Joins("JOIN customers AS c ON orders.customer_id = c.id").
+		Where("c.tenant_id = ?", tenantID).
+		Order("orders.id").
+		Limit(limit).
+		Offset(offset), fn)
+}
+
+// EachStockMovement はGetStockMovementsと同じ条件の入出庫履歴を1件ずつfnに渡す。limitに-1を指定すると全件が対象
+func (r *repository) EachStockMovement(ctx context.Context, stockID int, limit, offset int, fn func(*model.StockMovement) error) error {
+	return each(r.db.Model(&model.StockMovement{}).
+		Where("stock_movements.stock_id = ?", stockID).
+		Order("stock_movements.occurred_at DESC, stock_movements.id DESC").
+		Limit(limit).
+		Offset(offset), fn)
+}
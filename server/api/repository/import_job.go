@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *repository) GetImportJobs(ctx context.Context, tenantID string, limit, offset int) ([]*model.ImportJob, error) {
+	jobs := []*model.ImportJob{}
+
+	if err := r.db.
+		Where("tenant_id = ?", tenantID).
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&jobs).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *repository) GetImportJob(ctx context.Context, tenantID string, jobID int) (*model.ImportJob, error) {
+	job := &model.ImportJob{}
+
+	if err := r.db.
+		Where("tenant_id = ? AND id = ?", tenantID, jobID).
+		First(&job).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (r *repository) CreateImportJob(ctx context.Context, job model.ImportJob) (*model.ImportJob, error) {
+	if err := r.db.Create(&job).Error; err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *repository) UpdateImportJob(ctx context.Context, job model.ImportJob) (*model.ImportJob, error) {
+	if err := r.db.Save(&job).Error; err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// ClaimImportJob は処理待ちの取り込みを1件取り出して処理中にする
+// 複数のワーカーが同じジョブを取らないよう、ロック中の行は読み飛ばす
+// 処理待ちがない場合はnilを返す
+func (r *repository) ClaimImportJob(ctx context.Context) (*model.ImportJob, error) {
+	var job *model.ImportJob
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		found := &model.ImportJob{}
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", model.ImportPending).
+			Order("id").
+			First(found).
+			Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		found.Status = model.ImportRunning
+		found.StartedAt = &now
+		if err := tx.Save(found).Error; err != nil {
+			return err
+		}
+		job = found
+
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// FailStaleImportJobs は一定時間進捗が更新されていない処理中の取り込みを失敗にする
+// 処理中にプロセスが停止したジョブは、途中まで反映済みのため自動では再実行しない
+func (r *repository) FailStaleImportJobs(ctx context.Context, before time.Time, message string) (int64, error) {
+	result := r.db.Model(&model.ImportJob{}).
+		Where("status = ? AND updated_at < ?", model.ImportRunning, before).
+		Updates(map[string]interface{}{
+			"status":      model.ImportFailed,
+			"error":       message,
+			"finished_at": time.Now(),
+		})
+
+	return result.RowsAffected, result.Error
+}
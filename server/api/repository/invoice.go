@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm/clause"
+)
+
+// GetInvoiceIssuer はテナントの請求書の発行者情報を取得する。未登録の場合は gorm.ErrRecordNotFound を返す
+func (r *repository) GetInvoiceIssuer(ctx context.Context, tenantID string) (*model.InvoiceIssuer, error) {
+	issuer := &model.InvoiceIssuer{}
+
+	if err := r.db.
+		Where("tenant_id = ?", tenantID).
+		First(&issuer).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return issuer, nil
+}
+
+func (r *repository) SaveInvoiceIssuer(ctx context.Context, issuer model.InvoiceIssuer) (*model.InvoiceIssuer, error) {
+	if err := r.db.
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&issuer).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return &issuer, nil
+}
+
+// NextInvoiceNumber はテナント・種別ごとの請求書番号の連番を採番する
+// 採番した行はトランザクションの終了までロックされるため、請求書の作成と同じトランザクションで呼ぶと欠番が生じない
+func (r *repository) NextInvoiceNumber(ctx context.Context, tenantID string, invoiceType model.InvoiceType) (int, error) {
+	var number int
+
+	if err := r.db.Raw(`INSERT INTO invoice_sequences (tenant_id, type, last_number) VALUES (?, ?, 1)
+		ON CONFLICT (tenant_id, type) DO UPDATE SET last_number = invoice_sequences.last_number + 1
+		RETURNING last_number`, tenantID, invoiceType).
+		Scan(&number).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return number, nil
+}
+
+// GetInvoices は発注の請求書・返還請求書を発行順に取得する
+func (r *repository) GetInvoices(ctx context.Context, tenantID string, orderID int) ([]*model.Invoice, error) {
+	invoices := []*model.Invoice{}
+
+	if err := r.db.
+		Where("tenant_id = ? AND order_id = ?", tenantID, orderID).
+		Order("issued_at, id").
+		Find(&invoices).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
+func (r *repository) GetInvoice(ctx context.Context, tenantID string, invoiceID int) (*model.Invoice, error) {
+	invoice := &model.Invoice{}
+
+	if err := r.db.
+		Where("tenant_id = ? AND id = ?", tenantID, invoiceID).
+		First(&invoice).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// GetActiveInvoice は発注の返還請求書で取り消されていない請求書を取得する。ない場合は gorm.ErrRecordNotFound を返す
+func (r *repository) GetActiveInvoice(ctx context.Context, tenantID string, orderID int) (*model.Invoice, error) {
+	invoice := &model.Invoice{}
+
+	if err := r.db.
+		Where("tenant_id = ? AND order_id = ? AND type = ?", tenantID, orderID, model.InvoiceTypeInvoice).
+		Where("NOT EXISTS (SELECT 1 FROM invoices AS cn WHERE cn.original_invoice_id = invoices.id)").
+		Order("issued_at DESC, id DESC").
+		First(&invoice).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func (r *repository) CreateInvoice(ctx context.Context, invoice model.Invoice) (*model.Invoice, error) {
+	if err := r.db.Create(&invoice).Error; err != nil {
+		return nil, r.translateError(err)
+	}
+
+	return &invoice, nil
+}
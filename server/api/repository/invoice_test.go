@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/config"
+	"github.com/google/uuid"
+)
+
+// errRollback はテストで作成したデータを残さないよう、トランザクションを取り消すためのエラー
+var errRollback = errors.New("rollback")
+
+// TestNextInvoiceNumber は請求書番号をテナント・種別ごとに1から欠番なく採番することを確かめる
+// マイグレーション済みのローカル環境のDBが必要なため、DB_HOSTが未設定の場合はスキップする
+func TestNextInvoiceNumber(t *testing.T) {
+	if os.Getenv("DB_HOST") == "" {
+		t.Skip("DB_HOST is not set")
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Env != config.Local {
+		t.Skipf("writes to the database; run only in %s", config.Local)
+	}
+	cfg.Keyfile = filepath.Join(t.TempDir(), "master.key")
+
+	r, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	err = r.Transaction(ctx, func(tx RepositoryInterface) error {
+		tenants := []string{uuid.NewString(), uuid.NewString()}
+		for _, id := range tenants {
+			if err := tx.GetDB().Exec(`INSERT INTO tenants (id, name) VALUES (?, 'test')`, id).Error; err != nil {
+				return err
+			}
+		}
+
+		tests := []struct {
+			tenantID    string
+			invoiceType model.InvoiceType
+			want        int
+		}{
+			{tenants[0], model.InvoiceTypeInvoice, 1},
+			{tenants[0], model.InvoiceTypeInvoice, 2},
+			{tenants[0], model.InvoiceTypeCreditNote, 1},
+			{tenants[1], model.InvoiceTypeInvoice, 1},
+			{tenants[0], model.InvoiceTypeInvoice, 3},
+		}
+		for _, tt := range tests {
+			got, err := tx.NextInvoiceNumber(ctx, tt.tenantID, tt.invoiceType)
+			if err != nil {
+				return err
+			}
+			if got != tt.want {
+				t.Errorf("NextInvoiceNumber(%s, %s) = %d, want %d", tt.tenantID, tt.invoiceType, got, tt.want)
+			}
+		}
+
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatal(err)
+	}
+}
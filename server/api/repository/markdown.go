@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+)
+
+// GetMarkdownRules はテナントの自動値下げの段階を経過日数の順に取得する
+func (r *repository) GetMarkdownRules(ctx context.Context, tenantID string) ([]*model.MarkdownRule, error) {
+	rules := []*model.MarkdownRule{}
+
+	if err := r.db.
+		Where("tenant_id = ?", tenantID).
+		Order("age_days").
+		Find(&rules).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// ReplaceMarkdownRules はテナントの自動値下げの段階をすべて置き換える
+func (r *repository) ReplaceMarkdownRules(ctx context.Context, tenantID string, rules []*model.MarkdownRule) error {
+	if err := r.db.
+		Where("tenant_id = ?", tenantID).
+		Delete(&model.MarkdownRule{}).
+		Error; err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	return r.db.Create(&rules).Error
+}
+
+// GetMarkdownTenantIDs は自動値下げの段階を登録しているテナントを取得する
+func (r *repository) GetMarkdownTenantIDs(ctx context.Context) ([]string, error) {
+	tenantIDs := []string{}
+
+	if err := r.db.Model(&model.MarkdownRule{}).
+		Distinct("tenant_id").
+		Order("tenant_id").
+		Pluck("tenant_id", &tenantIDs).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return tenantIDs, nil
+}
+
+// GetMarkdownCandidates は指定日時以前に登録された在庫のうち、数量が残っているものをID順に取得する
+// 基準価格は自動値下げ以外で最後に適用した価格の変更から求める
+func (r *repository) GetMarkdownCandidates(ctx context.Context, tenantID string, createdBefore time.Time, afterID, limit int) ([]*model.MarkdownCandidate, error) {
+	candidates := []*model.MarkdownCandidate{}
+
+	if err := r.db.Table("stocks AS s").
+		Select(`s.id AS stock_id, s.store_id, s.name, s.price, s.created_at,
+			COALESCE((
+				SELECT c.price FROM stock_price_changes AS c
+				WHERE c.stock_id = s.id AND c.status = @applied AND c.markdown_age_days IS NULL
+				ORDER BY c.effective_from DESC, c.id DESC
+				LIMIT 1
+			), s.price) AS base_price,
+			(
+				SELECT MAX(c.markdown_age_days) FROM stock_price_changes AS c
+				WHERE c.stock_id = s.id AND c.status = @applied
+			) AS markdown_age_days`, map[string]interface{}{"applied": model.PriceChangeApplied}).
+		Joins("JOIN stores AS st ON st.id = s.store_id").
+		Where("st.tenant_id = ? AND s.quantity > 0 AND s.created_at <= ? AND s.id > ?", tenantID, createdBefore, afterID).
+		Order("s.id").
+		Limit(limit).
+		Scan(&candidates).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
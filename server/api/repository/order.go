@@ -27,6 +27,7 @@ func (r *repository) GetOrder(ctx context.Context, tenantID string, orderID int)
 	order := &model.Order{}
 
 	if err := r.db.Unscoped().
+		Preload("Discounts").
 		Joins("JOIN customers AS c ON orders.customer_id = c.id").
 		Where("c.tenant_id = ? AND orders.id = ?", tenantID, orderID).
 		First(&order).
@@ -39,7 +40,7 @@ func (r *repository) GetOrder(ctx context.Context, tenantID string, orderID int)
 
 func (r *repository) CreateOrder(ctx context.Context, order model.Order) (*int, error) {
 	if err := r.db.Create(&order).Error; err != nil {
-		return nil, err
+		return nil, r.translateError(err)
 	}
 
 	return &order.ID, nil
@@ -62,6 +63,7 @@ func (r *repository) CreateBulkOrder(ctx context.Context, orders []model.Order)
 func (r *repository) UpdateOrder(ctx context.Context, order model.Order) (*model.Order, error) {
 	if err := r.db.
 		Clauses(clause.Returning{}).
+		Omit(clause.Associations).
 		Where("id = ?", order.ID).
 		Updates(&order).Error; err != nil {
 		return nil, err
@@ -69,3 +71,14 @@ func (r *repository) UpdateOrder(ctx context.Context, order model.Order) (*model
 
 	return &order, nil
 }
+
+// LockOrder は発注の行をトランザクションの終了までロックする
+// 更新前の内容との差分で在庫や集計を更新するため、同じ発注の同時更新を直列化する
+func (r *repository) LockOrder(ctx context.Context, orderID int) error {
+	return r.db.Model(&model.Order{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", orderID).
+		Find(&[]int{}).
+		Error
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm/clause"
+)
+
+// GetOrderReturns はテナントの返品を新しい順に取得する。状態・発注を指定した場合は絞り込む
+func (r *repository) GetOrderReturns(ctx context.Context, tenantID string, status *model.ReturnStatus, orderID *int, limit, offset int) ([]*model.OrderReturn, error) {
+	returns := []*model.OrderReturn{}
+
+	tx := r.db.Where("tenant_id = ?", tenantID)
+	if status != nil {
+		tx = tx.Where("status = ?", *status)
+	}
+	if orderID != nil {
+		tx = tx.Where("order_id = ?", *orderID)
+	}
+
+	if err := tx.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&returns).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return returns, nil
+}
+
+func (r *repository) GetOrderReturn(ctx context.Context, tenantID string, returnID int) (*model.OrderReturn, error) {
+	ret := &model.OrderReturn{}
+
+	if err := r.db.
+		Where("tenant_id = ? AND id = ?", tenantID, returnID).
+		First(&ret).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// LockOrderReturn は返品を行ロックして取得する
+func (r *repository) LockOrderReturn(ctx context.Context, tenantID string, returnID int) (*model.OrderReturn, error) {
+	ret := &model.OrderReturn{}
+
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tenant_id = ? AND id = ?", tenantID, returnID).
+		First(&ret).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *repository) CreateOrderReturn(ctx context.Context, ret model.OrderReturn) (*model.OrderReturn, error) {
+	if err := r.db.Create(&ret).Error; err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (r *repository) UpdateOrderReturn(ctx context.Context, ret model.OrderReturn) (*model.OrderReturn, error) {
+	if err := r.db.Save(&ret).Error; err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+// SumReturnedAmount は発注の完了した返品で返金、またはポイントで返還した額の合計を返す
+func (r *repository) SumReturnedAmount(ctx context.Context, orderID int) (int, error) {
+	var amount int
+
+	if err := r.db.Model(&model.OrderReturn{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("order_id = ? AND status = ?", orderID, model.ReturnCompleted).
+		Scan(&amount).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return amount, nil
+}
+
+// CountExchangeReturns は交換品の発注として指定した返品の件数を返す
+func (r *repository) CountExchangeReturns(ctx context.Context, exchangeOrderID int) (int64, error) {
+	var count int64
+
+	if err := r.db.Model(&model.OrderReturn{}).
+		Where("exchange_order_id = ?", exchangeOrderID).
+		Count(&count).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountReturnedQuantity は発注の返品のうち返品不可としたものを除いた数量の合計を返す
+func (r *repository) CountReturnedQuantity(ctx context.Context, orderID int) (int, error) {
+	var quantity int
+
+	if err := r.db.Model(&model.OrderReturn{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("order_id = ? AND status <> ?", orderID, model.ReturnRejected).
+		Scan(&quantity).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return quantity, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetPayments は発注の支払いを支払日時の順に取得する
+func (r *repository) GetPayments(ctx context.Context, orderID int) ([]*model.Payment, error) {
+	payments := []*model.Payment{}
+
+	if err := r.db.
+		Where("order_id = ?", orderID).
+		Order("paid_at, id").
+		Find(&payments).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+func (r *repository) CreatePayment(ctx context.Context, payment model.Payment) (*model.Payment, error) {
+	if err := r.db.Create(&payment).Error; err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+// GetRefunds は発注の返金を返金日時の順に取得する
+func (r *repository) GetRefunds(ctx context.Context, orderID int) ([]*model.Refund, error) {
+	refunds := []*model.Refund{}
+
+	if err := r.db.
+		Where("order_id = ?", orderID).
+		Order("refunded_at, id").
+		Find(&refunds).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return refunds, nil
+}
+
+func (r *repository) CreateRefund(ctx context.Context, refund model.Refund) (*model.Refund, error) {
+	if err := r.db.Create(&refund).Error; err != nil {
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+// SettlePayment は決済代行会社の結果待ちの支払いに結果を反映する。参照番号が空の場合は変更しない
+// 結果待ちでない場合は他の処理が反映済みのため、gorm.ErrRecordNotFoundを返す
+func (r *repository) SettlePayment(ctx context.Context, paymentID int, status model.ChargeStatus, reference string) (*model.Payment, error) {
+	payment := &model.Payment{}
+
+	values := map[string]interface{}{"status": status}
+	if reference != "" {
+		values["reference"] = reference
+	}
+	result := r.db.Model(payment).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND status = ?", paymentID, model.ChargePending).
+		Updates(values)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return payment, nil
+}
+
+// SettleRefund は決済代行会社の結果待ちの返金に結果を反映する。参照番号が空の場合は変更しない
+// 結果待ちでない場合は他の処理が反映済みのため、gorm.ErrRecordNotFoundを返す
+func (r *repository) SettleRefund(ctx context.Context, refundID int, status model.RefundStatus, reference string) (*model.Refund, error) {
+	refund := &model.Refund{}
+
+	values := map[string]interface{}{"status": status}
+	if reference != "" {
+		values["reference"] = reference
+	}
+	result := r.db.Model(refund).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND status = ?", refundID, model.RefundPending).
+		Updates(values)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return refund, nil
+}
+
+// GetPendingPayments はbefore以前に記録した決済代行会社の結果待ちの支払いをID順に取得する
+func (r *repository) GetPendingPayments(ctx context.Context, before time.Time, afterID, limit int) ([]*model.Payment, error) {
+	payments := []*model.Payment{}
+
+	if err := r.db.
+		Where("status = ? AND created_at <= ? AND id > ?", model.ChargePending, before, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&payments).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+// GetPendingRefunds はbefore以前に記録した決済代行会社の結果待ちの返金をID順に取得する
+func (r *repository) GetPendingRefunds(ctx context.Context, before time.Time, afterID, limit int) ([]*model.Refund, error) {
+	refunds := []*model.Refund{}
+
+	if err := r.db.
+		Where("status = ? AND created_at <= ? AND id > ?", model.RefundPending, before, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&refunds).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return refunds, nil
+}
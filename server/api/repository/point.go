@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInsufficientPoints はポイント残高が不足している場合のエラー
+var ErrInsufficientPoints = errors.New("insufficient point balance")
+
+// LockPointAccount は顧客のポイント残高を行ロックして取得する。削除済みの顧客も対象
+// 同じ顧客のポイントの増減はこのロックで直列化する。顧客が別のテナントに属する場合はgorm.ErrRecordNotFoundを返す
+func (r *repository) LockPointAccount(ctx context.Context, tenantID, customerID string) (*model.Customer, error) {
+	customer := &model.Customer{}
+
+	if err := r.db.Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "tenant_id", "point_balance").
+		Where("tenant_id = ? AND id = ?", tenantID, customerID).
+		First(&customer).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+// GetPointBalance は顧客のポイント残高を取得する。削除済みの顧客も対象
+func (r *repository) GetPointBalance(ctx context.Context, tenantID, customerID string) (int, error) {
+	customer := &model.Customer{}
+
+	if err := r.db.Unscoped().
+		Select("id", "point_balance").
+		Where("tenant_id = ? AND id = ?", tenantID, customerID).
+		First(&customer).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return customer.PointBalance, nil
+}
+
+// AdjustPointBalance はポイント残高をdeltaだけ増減し、増減後の残高を返す。残高がマイナスになる場合は更新しない
+func (r *repository) AdjustPointBalance(ctx context.Context, customerID string, delta int) (int, error) {
+	customer := &model.Customer{}
+
+	result := r.db.Unscoped().
+		Model(customer).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "point_balance"}}}).
+		Where("id = ? AND point_balance + ? >= 0", customerID, delta).
+		UpdateColumn("point_balance", gorm.Expr("point_balance + ?", delta))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, ErrInsufficientPoints
+	}
+
+	return customer.PointBalance, nil
+}
+
+// ConsumePointLots は未使用のポイントを有効期限の近いものから消し込む。無期限のポイントは最後に使う
+// 残高と未使用のポイントの合計は一致するため、残高を減らした後に呼ぶ
+func (r *repository) ConsumePointLots(ctx context.Context, customerID string, points int) error {
+	lots := []*model.PointTransaction{}
+
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("customer_id = ? AND remaining > 0", customerID).
+		Order("expires_at NULLS LAST, id").
+		Find(&lots).
+		Error; err != nil {
+		return err
+	}
+
+	for _, lot := range lots {
+		if points == 0 {
+			break
+		}
+		used := min(lot.Remaining, points)
+		if err := r.db.Model(lot).
+			UpdateColumn("remaining", gorm.Expr("remaining - ?", used)).
+			Error; err != nil {
+			return err
+		}
+		points -= used
+	}
+	if points > 0 {
+		return ErrInsufficientPoints
+	}
+
+	return nil
+}
+
+func (r *repository) CreatePointTransaction(ctx context.Context, transaction model.PointTransaction) (*model.PointTransaction, error) {
+	if err := r.db.Create(&transaction).Error; err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+// GetOrderPointTotals は発注で付与したポイントと利用したポイントを、取り消しを差し引いて返す
+func (r *repository) GetOrderPointTotals(ctx context.Context, orderID int) (int, int, error) {
+	var totals struct {
+		Earned   int
+		Redeemed int
+	}
+
+	if err := r.db.Model(&model.PointTransaction{}).
+		Select(`COALESCE(SUM(points) FILTER (WHERE type IN ?), 0) AS earned,
+			COALESCE(-SUM(points) FILTER (WHERE type IN ?), 0) AS redeemed`,
+			[]model.PointTransactionType{model.PointEarn, model.PointEarnReversal},
+			[]model.PointTransactionType{model.PointRedeem, model.PointRedeemRefund}).
+		Where("order_id = ?", orderID).
+		Scan(&totals).
+		Error; err != nil {
+		return 0, 0, err
+	}
+
+	return totals.Earned, totals.Redeemed, nil
+}
+
+func (r *repository) GetPointTransactions(ctx context.Context, customerID string, limit, offset int) ([]*model.PointTransaction, error) {
+	transactions := []*model.PointTransaction{}
+
+	if err := r.db.
+		Where("customer_id = ?", customerID).
+		Order("occurred_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&transactions).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (r *repository) CountPointTransactions(ctx context.Context, customerID string) (int64, error) {
+	var count int64
+
+	if err := r.db.Model(&model.PointTransaction{}).
+		Where("customer_id = ?", customerID).
+		Count(&count).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetPointExpiries は顧客の未使用のポイントを失効日時ごとに合計して近い順に返す。無期限のポイントは含めない
+func (r *repository) GetPointExpiries(ctx context.Context, customerID string) ([]*model.PointExpiry, error) {
+	expiries := []*model.PointExpiry{}
+
+	if err := r.db.Model(&model.PointTransaction{}).
+		Select("expires_at, SUM(remaining) AS points").
+		Where("customer_id = ? AND remaining > 0 AND expires_at IS NOT NULL", customerID).
+		Group("expires_at").
+		Order("expires_at").
+		Scan(&expiries).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return expiries, nil
+}
+
+// GetCustomersWithExpiredPoints は有効期限を過ぎた未使用のポイントがある顧客を取得する。顧客のIDとテナントIDのみ取得する
+func (r *repository) GetCustomersWithExpiredPoints(ctx context.Context, now time.Time, limit int) ([]*model.Customer, error) {
+	customers := []*model.Customer{}
+
+	if err := r.db.Model(&model.PointTransaction{}).
+		Distinct("customer_id AS id", "tenant_id").
+		Where("remaining > 0 AND expires_at <= ?", now).
+		Limit(limit).
+		Scan(&customers).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return customers, nil
+}
+
+// ExpirePointLots は顧客の有効期限を過ぎた未使用のポイントを0にし、失効したポイントの合計を返す
+func (r *repository) ExpirePointLots(ctx context.Context, customerID string, now time.Time) (int, error) {
+	lots := []*model.PointTransaction{}
+
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "remaining").
+		Where("customer_id = ? AND remaining > 0 AND expires_at <= ?", customerID, now).
+		Find(&lots).
+		Error; err != nil {
+		return 0, err
+	}
+	if len(lots) == 0 {
+		return 0, nil
+	}
+
+	expired := 0
+	ids := make([]int, 0, len(lots))
+	for _, lot := range lots {
+		expired += lot.Remaining
+		ids = append(ids, lot.ID)
+	}
+
+	if err := r.db.Model(&model.PointTransaction{}).
+		Where("id IN ?", ids).
+		UpdateColumn("remaining", 0).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *repository) GetPromotions(ctx context.Context, tenantID string) ([]*model.Promotion, error) {
+	promotions := []*model.Promotion{}
+
+	if err := r.db.
+		Where("tenant_id = ?", tenantID).
+		Order("id DESC").
+		Find(&promotions).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+func (r *repository) GetPromotion(ctx context.Context, tenantID string, promotionID int) (*model.Promotion, error) {
+	promotion := &model.Promotion{}
+
+	if err := r.db.
+		Where("tenant_id = ? AND id = ?", tenantID, promotionID).
+		First(&promotion).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (r *repository) CreatePromotion(ctx context.Context, promotion model.Promotion) (*model.Promotion, error) {
+	if err := r.db.Create(&promotion).Error; err != nil {
+		return nil, r.translateError(err)
+	}
+
+	return &promotion, nil
+}
+
+func (r *repository) UpdatePromotion(ctx context.Context, promotion model.Promotion) (*model.Promotion, error) {
+	result := r.db.
+		Clauses(clause.Returning{}).
+		Where("tenant_id = ? AND id = ?", promotion.TenantID, promotion.ID).
+		Select("name", "description", "discount_type", "discount_value", "stock_id", "category", "store_id",
+			"starts_at", "ends_at", "min_spend", "usage_limit_per_customer", "usage_limit", "requires_coupon", "active", "updated_at").
+		Updates(&promotion)
+	if result.Error != nil {
+		return nil, r.translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &promotion, nil
+}
+
+// DeletePromotion はキャンペーンとクーポンを削除する。発注に適用済みのキャンペーンは削除できない
+func (r *repository) DeletePromotion(ctx context.Context, tenantID string, promotionID int) error {
+	result := r.db.
+		Where("tenant_id = ? AND id = ?", tenantID, promotionID).
+		Delete(&model.Promotion{})
+	if result.Error != nil {
+		return r.translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) GetCoupons(ctx context.Context, tenantID string, promotionID int) ([]*model.Coupon, error) {
+	coupons := []*model.Coupon{}
+
+	if err := r.db.
+		Where("tenant_id = ? AND promotion_id = ?", tenantID, promotionID).
+		Order("code").
+		Find(&coupons).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return coupons, nil
+}
+
+func (r *repository) CreateCoupon(ctx context.Context, coupon model.Coupon) (*model.Coupon, error) {
+	if err := r.db.Create(&coupon).Error; err != nil {
+		return nil, r.translateError(err)
+	}
+
+	return &coupon, nil
+}
+
+// DeleteCoupon はクーポンを削除する。発注に適用済みのクーポンは削除できない
+func (r *repository) DeleteCoupon(ctx context.Context, tenantID string, promotionID, couponID int) error {
+	result := r.db.
+		Where("tenant_id = ? AND promotion_id = ? AND id = ?", tenantID, promotionID, couponID).
+		Delete(&model.Coupon{})
+	if result.Error != nil {
+		return r.translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetPromotionTarget は発注明細の在庫が属する店舗・テナントと分類を取得する
+func (r *repository) GetPromotionTarget(ctx context.Context, stockID int) (*model.PromotionTarget, error) {
+	target := &model.PromotionTarget{}
+
+	if err := r.db.Model(&model.Stock{}).
+		Select("stores.tenant_id, stocks.store_id, stocks.id AS stock_id, stocks.category").
+		Joins("JOIN stores ON stores.id = stocks.store_id").
+		Where("stocks.id = ?", stockID).
+		Take(target).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// GetAutomaticPromotions はクーポンが不要で、発注明細が対象・期間・金額の条件を満たす有効なキャンペーンを取得する
+func (r *repository) GetAutomaticPromotions(ctx context.Context, target model.PromotionTarget, amount int, now time.Time) ([]*model.Promotion, error) {
+	promotions := []*model.Promotion{}
+
+	if err := r.applicablePromotions(target, amount, now).
+		Where("requires_coupon = false").
+		Order("id").
+		Find(&promotions).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+// LockPromotion は有効なキャンペーンを行ロックして取得する
+// 利用回数の判定から値引きの記録までの間に、上限のあるキャンペーンを同時に適用しないようにする
+func (r *repository) LockPromotion(ctx context.Context, promotionID int) (*model.Promotion, error) {
+	promotion := &model.Promotion{}
+
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND active", promotionID).
+		First(&promotion).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// LockCouponPromotion はクーポンのキャンペーンを、発注明細が条件を満たす場合に行ロックして取得する
+func (r *repository) LockCouponPromotion(ctx context.Context, target model.PromotionTarget, amount int, now time.Time, promotionID int) (*model.Promotion, error) {
+	promotion := &model.Promotion{}
+
+	if err := r.applicablePromotions(target, amount, now).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", promotionID).
+		First(&promotion).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// LockCoupon はテナントの有効なクーポンをコードで行ロックして取得する
+func (r *repository) LockCoupon(ctx context.Context, tenantID, code string) (*model.Coupon, error) {
+	coupon := &model.Coupon{}
+
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tenant_id = ? AND code = ? AND active", tenantID, code).
+		First(&coupon).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+// CountPromotionUsage はキャンペーンを適用した発注の件数を数える。キャンセルされた発注は含めない
+// customerIDを指定した場合はその顧客の発注に絞り込む
+func (r *repository) CountPromotionUsage(ctx context.Context, promotionID int, customerID *string) (int64, error) {
+	var count int64
+
+	tx := r.db.Model(&model.OrderDiscount{}).
+		Joins("JOIN orders AS o ON o.id = order_discounts.order_id").
+		Where("order_discounts.promotion_id = ? AND o.status <> ?", promotionID, model.StatusCancelled)
+	if customerID != nil {
+		tx = tx.Where("o.customer_id = ?", *customerID)
+	}
+
+	if err := tx.Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountCouponUsage はクーポンを適用した発注の件数を数える。キャンセルされた発注は含めない
+func (r *repository) CountCouponUsage(ctx context.Context, couponID int) (int64, error) {
+	var count int64
+
+	if err := r.db.Model(&model.OrderDiscount{}).
+		Joins("JOIN orders AS o ON o.id = order_discounts.order_id").
+		Where("order_discounts.coupon_id = ? AND o.status <> ?", couponID, model.StatusCancelled).
+		Count(&count).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *repository) CreateOrderDiscounts(ctx context.Context, discounts []*model.OrderDiscount) error {
+	if len(discounts) == 0 {
+		return nil
+	}
+
+	return r.db.Create(&discounts).Error
+}
+
+// applicablePromotions は発注明細が対象・期間・金額の条件を満たす有効なキャンペーンのクエリを返す
+func (r *repository) applicablePromotions(target model.PromotionTarget, amount int, now time.Time) *gorm.DB {
+	return r.db.Model(&model.Promotion{}).
+		Where("tenant_id = ? AND active", target.TenantID).
+		Where("stock_id IS NULL OR stock_id = ?", target.StockID).
+		Where("category IS NULL OR category = ?", target.Category).
+		Where("store_id IS NULL OR store_id = ?", target.StoreID).
+		Where("starts_at IS NULL OR starts_at <= ?", now).
+		Where("ends_at IS NULL OR ends_at > ?", now).
+		Where("min_spend <= ?", amount)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetReceiptTemplate はレシートの印字設定を取得する。未登録の場合は既定値を返す
+func (r *repository) GetReceiptTemplate(ctx context.Context, tenantID string) (*model.ReceiptTemplate, error) {
+	tmpl := &model.ReceiptTemplate{}
+
+	err := r.db.
+		Where("tenant_id = ?", tenantID).
+		First(&tmpl).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.DefaultReceiptTemplate(tenantID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+func (r *repository) SaveReceiptTemplate(ctx context.Context, tmpl model.ReceiptTemplate) (*model.ReceiptTemplate, error) {
+	if err := r.db.
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&tmpl).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return &tmpl, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+)
+
+// GetSalesReport は売上の日次集計を集計軸ごとにSQLで集計する
+// 集計軸を指定しない場合は全体の合計を1行で返す
+func (r *repository) GetSalesReport(ctx context.Context, query model.SalesReportQuery) ([]*model.SalesReportRow, error) {
+	var columns, groups []string
+	var args []interface{}
+	// 集計軸の列をSELECT句での位置でGROUP BYに指定する
+	addKey := func(column string) {
+		columns = append(columns, column)
+		groups = append(groups, strconv.Itoa(len(columns)))
+	}
+
+	// 担当者と在庫名は在庫の現在の情報を使う
+	tx := r.db.Table("daily_sales_rollups AS d").
+		Joins("LEFT JOIN stocks AS s ON d.stock_id = s.id")
+
+	if query.Period != nil {
+		addKey("to_char(date_trunc(?, d.sales_date), 'YYYY-MM-DD') AS period_start")
+		args = append(args, string(*query.Period))
+	}
+	for _, dimension := range query.GroupBy {
+		switch dimension {
+		case model.DimensionStore:
+			addKey("d.store_id")
+			columns = append(columns, "MAX(st.name) AS store_name")
+			tx = tx.Joins("LEFT JOIN stores AS st ON d.store_id = st.id")
+		case model.DimensionUser:
+			addKey("s.user_id")
+			columns = append(columns, "MAX(u.name) AS user_name")
+			tx = tx.Joins("LEFT JOIN users AS u ON s.user_id = u.id")
+		case model.DimensionStock:
+			addKey("d.stock_id")
+			columns = append(columns, "MAX(s.name) AS stock_name")
+		case model.DimensionStatus:
+			addKey("d.status")
+		}
+	}
+
+	columns = append(columns,
+		"COALESCE(SUM(d.revenue), 0) AS revenue",
+		"COALESCE(SUM(d.order_count), 0) AS order_count",
+		"COALESCE(SUM(d.units), 0) AS units",
+		"COALESCE(ROUND(SUM(d.revenue)::numeric / NULLIF(SUM(d.order_count), 0)), 0)::bigint AS average_order_value",
+	)
+
+	tx = tx.Select(strings.Join(columns, ", "), args...).
+		Where("d.tenant_id = ?", query.TenantID)
+	if query.From != nil {
+		tx = tx.Where("d.sales_date >= ?", query.From.Format("2006-01-02"))
+	}
+	if query.To != nil {
+		tx = tx.Where("d.sales_date < ?", query.To.Format("2006-01-02"))
+	}
+	if len(query.Statuses) > 0 {
+		tx = tx.Where("d.status IN ?", query.Statuses)
+	}
+	if query.StoreID != nil {
+		tx = tx.Where("d.store_id = ?", *query.StoreID)
+	}
+	if len(groups) > 0 {
+		// 発注の取消などで件数が0になった行は返さない
+		tx = tx.Group(strings.Join(groups, ", ")).
+			Having("SUM(d.order_count) <> 0").
+			Order(strings.Join(groups, ", "))
+	}
+
+	rows := []*model.SalesReportRow{}
+	if err := tx.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -2,16 +2,25 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
 	"github.com/buysell-technologies/summer-internship-2024-backend/config"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
 )
 
 type RepositoryInterface interface {
 	GetDB() *gorm.DB
+	Transaction(ctx context.Context, fn func(tx RepositoryInterface) error) error
+	/* tenant setting */
+	GetTenantSetting(ctx context.Context, tenantID string) (*model.TenantSetting, error)
+	SaveTenantSetting(ctx context.Context, setting model.TenantSetting) (*model.TenantSetting, error)
+	/* store */
+	GetStores(ctx context.Context, tenantID string) ([]*model.Store, error)
+	GetStore(ctx context.Context, tenantID, storeID string) (*model.Store, error)
 	/* user */
 	GetUsers(ctx context.Context, tenantID string, limit, offset int) ([]*model.User, error)
 	GetUser(ctx context.Context, tenantID, userID string) (*model.User, error)
@@ -21,26 +30,187 @@ type RepositoryInterface interface {
 	/* stock */
 	GetStocks(ctx context.Context, storeID string, limit, offset int) ([]*model.Stock, error)
 	GetStock(ctx context.Context, storeID, stockID string) (*model.Stock, error)
+	LockStock(ctx context.Context, storeID, stockID string) (*model.Stock, error)
 	CreateStock(ctx context.Context, stock model.Stock) (*int, error)
 	CreateBulkStock(ctx context.Context, stocks []model.Stock) ([]*int, error)
 	UpdateStock(ctx context.Context, stock model.Stock) (*model.Stock, error)
 	DeleteStock(ctx context.Context, storeID, stockID string) error
+	GetStockByCode(ctx context.Context, storeID, code string) (*model.Stock, error)
+	GetStockOwner(ctx context.Context, tenantID string, stockID int) (*model.StockOwner, error)
+	GetStocksByIDs(ctx context.Context, storeID string, stockIDs []int) ([]*model.Stock, error)
+	GetStockByBarcode(ctx context.Context, storeID, barcode string) (*model.Stock, error)
+	/* stock movement */
+	CreateStockMovement(ctx context.Context, movement model.StockMovement) (*model.StockMovement, error)
+	AdjustStockQuantity(ctx context.Context, stockID, delta int) error
+	ShiftStockQuantity(ctx context.Context, stockID, delta int) error
+	SumStockMovementsSince(ctx context.Context, stockID int, since time.Time) (int, error)
+	GetStockMovements(ctx context.Context, stockID int, limit, offset int) ([]*model.StockMovement, error)
+	EachStockLedgerEntry(ctx context.Context, tenantID string, asOf time.Time, fn func(*model.StockLedgerEntry) error) error
+	/* stock price */
+	CreateStockPriceChange(ctx context.Context, change model.StockPriceChange) (*model.StockPriceChange, error)
+	GetStockPriceChanges(ctx context.Context, stockID int, status model.PriceChangeStatus, from, to *time.Time) ([]*model.StockPriceChange, error)
+	CancelStockPriceChange(ctx context.Context, stockID, changeID int) (*model.StockPriceChange, error)
+	LockDuePriceChanges(ctx context.Context, now time.Time, limit int) ([]*model.StockPriceChange, error)
+	SetStockPrice(ctx context.Context, stockID, price int) (int, error)
+	MarkPriceChangeApplied(ctx context.Context, changeID, previousPrice int, appliedAt time.Time) error
+	/* markdown */
+	GetMarkdownRules(ctx context.Context, tenantID string) ([]*model.MarkdownRule, error)
+	ReplaceMarkdownRules(ctx context.Context, tenantID string, rules []*model.MarkdownRule) error
+	GetMarkdownTenantIDs(ctx context.Context) ([]string, error)
+	GetMarkdownCandidates(ctx context.Context, tenantID string, createdBefore time.Time, afterID, limit int) ([]*model.MarkdownCandidate, error)
+	/* stocktake */
+	GetStocktakes(ctx context.Context, storeID string, limit, offset int) ([]*model.Stocktake, error)
+	GetStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error)
+	LockStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error)
+	CreateStocktake(ctx context.Context, stocktake model.Stocktake) (*model.Stocktake, error)
+	UpdateStocktake(ctx context.Context, stocktake model.Stocktake) (*model.Stocktake, error)
+	GetStocktakeItems(ctx context.Context, stocktakeID int) ([]*model.StocktakeItem, error)
+	UpdateStocktakeItem(ctx context.Context, item model.StocktakeItem) error
+	SaveStocktakeCounts(ctx context.Context, stocktakeID int, counts []model.StocktakeCount) error
+	/* stock image */
+	GetStockImages(ctx context.Context, stockID int) ([]*model.StockImage, error)
+	GetStockImage(ctx context.Context, stockID, imageID int) (*model.StockImage, error)
+	CreateStockImage(ctx context.Context, image model.StockImage) (*model.StockImage, error)
+	UpdateStockImagePositions(ctx context.Context, stockID int, imageIDs []int) error
+	DeleteStockImage(ctx context.Context, stockID, imageID int) error
 	/* customer */
 	GetCustomers(ctx context.Context, tenantID string, limit, offset int) ([]*model.Customer, error)
 	GetCustomer(ctx context.Context, tenantID, customerID string) (*model.Customer, error)
 	CreateCustomer(ctx context.Context, customer model.Customer) (*string, error)
 	UpdateCustomer(ctx context.Context, customer model.Customer) (*model.Customer, error)
 	DeleteCustomer(ctx context.Context, tenantID, customerID string) error
+	FindCustomerByEmail(ctx context.Context, tenantID, email string) (*model.Customer, error)
+	FindCustomerByPhoneNumber(ctx context.Context, tenantID, phoneNumber string) (*model.Customer, error)
+	/* customer merge */
+	GetActiveCustomers(ctx context.Context, tenantID string) ([]*model.Customer, error)
+	LockCustomers(ctx context.Context, tenantID string, customerIDs []string) ([]*model.Customer, error)
+	ReassignCustomerReferences(ctx context.Context, fromCustomerID, toCustomerID string) ([]int, error)
+	CreateCustomerMerge(ctx context.Context, merge model.CustomerMerge) (*model.CustomerMerge, error)
+	GetCustomerMerges(ctx context.Context, tenantID, customerID string) ([]*model.CustomerMerge, error)
+	/* customer consent */
+	GetCustomerConsents(ctx context.Context, tenantID, customerID string) ([]*model.CustomerConsent, error)
+	GetCurrentCustomerConsents(ctx context.Context, tenantID string, customerIDs []string) ([]*model.CustomerConsent, error)
+	CreateCustomerConsent(ctx context.Context, consent model.CustomerConsent) (*model.CustomerConsent, error)
+	GetMergedCustomers(ctx context.Context, tenantID, customerID string) ([]*model.Customer, error)
+	AnonymizeCustomers(ctx context.Context, tenantID string, customerIDs []string, now time.Time) (int64, error)
+	ScrubCustomerMergeSnapshots(ctx context.Context, tenantID string, customerIDs []string) error
+	/* encryption */
+	RotateDataKey(ctx context.Context) (*model.DataKey, error)
+	RewrapDataKeys(ctx context.Context) (int64, error)
+	GetCustomersToReencrypt(ctx context.Context, afterID string, limit int) ([]*model.Customer, error)
+	ReencryptCustomer(ctx context.Context, customer model.Customer) error
+	GetCustomerMergesToReencrypt(ctx context.Context, afterID, limit int) ([]*model.CustomerMerge, error)
+	ReencryptCustomerMerge(ctx context.Context, merge model.CustomerMerge) error
+	/* customer summary */
+	GetCustomerSummary(ctx context.Context, tenantID, customerID string) (*model.CustomerSummary, error)
+	GetCustomerCategorySpends(ctx context.Context, customerID string, limit int) ([]*model.CategorySpend, error)
+	CheckCustomerExists(ctx context.Context, tenantID, customerID string) error
+	GetCustomerOrders(ctx context.Context, customerID string, limit, offset int) ([]*model.Order, error)
+	CountCustomerOrders(ctx context.Context, customerID string) (int64, error)
+	/* customer segment */
+	GetCustomerSegments(ctx context.Context, tenantID string) ([]*model.CustomerSegment, error)
+	GetCustomerSegment(ctx context.Context, tenantID string, segmentID int) (*model.CustomerSegment, error)
+	CreateCustomerSegment(ctx context.Context, segment model.CustomerSegment) (*model.CustomerSegment, error)
+	UpdateCustomerSegment(ctx context.Context, segment model.CustomerSegment) (*model.CustomerSegment, error)
+	DeleteCustomerSegment(ctx context.Context, tenantID string, segmentID int) error
+	CountSegmentCustomers(ctx context.Context, tenantID string, filter model.SegmentFilter) (int64, error)
+	GetSegmentCustomers(ctx context.Context, tenantID string, filter model.SegmentFilter, limit, offset int) ([]*model.Customer, error)
+	EachSegmentCustomer(ctx context.Context, tenantID string, filter model.SegmentFilter, limit, offset int, fn func(*model.Customer) error) error
+	ScoreCustomers(ctx context.Context, tenantID *string, now time.Time) (int64, error)
+	/* address */
+	GetUnparsedCustomers(ctx context.Context, tenantID *string, afterID string, limit int) ([]*model.Customer, error)
+	UpdateCustomerAddress(ctx context.Context, customer model.Customer) error
+	GetUnparsedStores(ctx context.Context, tenantID *string, afterID string, limit int) ([]*model.Store, error)
+	UpdateStoreAddress(ctx context.Context, store model.Store) error
 	/* order */
 	GetOrders(ctx context.Context, tenantID string, limit, offset int) ([]*model.Order, error)
 	GetOrder(ctx context.Context, tenantID string, orderID int) (*model.Order, error)
 	CreateOrder(ctx context.Context, order model.Order) (*int, error)
 	CreateBulkOrder(ctx context.Context, orders []model.Order) ([]*int, error)
 	UpdateOrder(ctx context.Context, order model.Order) (*model.Order, error)
+	LockOrder(ctx context.Context, orderID int) error
+	/* payment */
+	GetPayments(ctx context.Context, orderID int) ([]*model.Payment, error)
+	CreatePayment(ctx context.Context, payment model.Payment) (*model.Payment, error)
+	GetRefunds(ctx context.Context, orderID int) ([]*model.Refund, error)
+	CreateRefund(ctx context.Context, refund model.Refund) (*model.Refund, error)
+	SettlePayment(ctx context.Context, paymentID int, status model.ChargeStatus, reference string) (*model.Payment, error)
+	SettleRefund(ctx context.Context, refundID int, status model.RefundStatus, reference string) (*model.Refund, error)
+	GetPendingPayments(ctx context.Context, before time.Time, afterID, limit int) ([]*model.Payment, error)
+	GetPendingRefunds(ctx context.Context, before time.Time, afterID, limit int) ([]*model.Refund, error)
+	/* order return */
+	GetOrderReturns(ctx context.Context, tenantID string, status *model.ReturnStatus, orderID *int, limit, offset int) ([]*model.OrderReturn, error)
+	GetOrderReturn(ctx context.Context, tenantID string, returnID int) (*model.OrderReturn, error)
+	LockOrderReturn(ctx context.Context, tenantID string, returnID int) (*model.OrderReturn, error)
+	CreateOrderReturn(ctx context.Context, ret model.OrderReturn) (*model.OrderReturn, error)
+	UpdateOrderReturn(ctx context.Context, ret model.OrderReturn) (*model.OrderReturn, error)
+	SumReturnedAmount(ctx context.Context, orderID int) (int, error)
+	CountReturnedQuantity(ctx context.Context, orderID int) (int, error)
+	CountExchangeReturns(ctx context.Context, exchangeOrderID int) (int64, error)
+	/* invoice */
+	GetInvoiceIssuer(ctx context.Context, tenantID string) (*model.InvoiceIssuer, error)
+	SaveInvoiceIssuer(ctx context.Context, issuer model.InvoiceIssuer) (*model.InvoiceIssuer, error)
+	NextInvoiceNumber(ctx context.Context, tenantID string, invoiceType model.InvoiceType) (int, error)
+	GetInvoices(ctx context.Context, tenantID string, orderID int) ([]*model.Invoice, error)
+	GetInvoice(ctx context.Context, tenantID string, invoiceID int) (*model.Invoice, error)
+	GetActiveInvoice(ctx context.Context, tenantID string, orderID int) (*model.Invoice, error)
+	CreateInvoice(ctx context.Context, invoice model.Invoice) (*model.Invoice, error)
+	/* receipt */
+	GetReceiptTemplate(ctx context.Context, tenantID string) (*model.ReceiptTemplate, error)
+	SaveReceiptTemplate(ctx context.Context, tmpl model.ReceiptTemplate) (*model.ReceiptTemplate, error)
+	/* promotion */
+	GetPromotions(ctx context.Context, tenantID string) ([]*model.Promotion, error)
+	GetPromotion(ctx context.Context, tenantID string, promotionID int) (*model.Promotion, error)
+	CreatePromotion(ctx context.Context, promotion model.Promotion) (*model.Promotion, error)
+	UpdatePromotion(ctx context.Context, promotion model.Promotion) (*model.Promotion, error)
+	DeletePromotion(ctx context.Context, tenantID string, promotionID int) error
+	GetCoupons(ctx context.Context, tenantID string, promotionID int) ([]*model.Coupon, error)
+	CreateCoupon(ctx context.Context, coupon model.Coupon) (*model.Coupon, error)
+	DeleteCoupon(ctx context.Context, tenantID string, promotionID, couponID int) error
+	GetPromotionTarget(ctx context.Context, stockID int) (*model.PromotionTarget, error)
+	GetAutomaticPromotions(ctx context.Context, target model.PromotionTarget, amount int, now time.Time) ([]*model.Promotion, error)
+	LockPromotion(ctx context.Context, promotionID int) (*model.Promotion, error)
+	LockCouponPromotion(ctx context.Context, target model.PromotionTarget, amount int, now time.Time, promotionID int) (*model.Promotion, error)
+	LockCoupon(ctx context.Context, tenantID, code string) (*model.Coupon, error)
+	CountPromotionUsage(ctx context.Context, promotionID int, customerID *string) (int64, error)
+	CountCouponUsage(ctx context.Context, couponID int) (int64, error)
+	CreateOrderDiscounts(ctx context.Context, discounts []*model.OrderDiscount) error
+	/* point */
+	GetPointBalance(ctx context.Context, tenantID, customerID string) (int, error)
+	LockPointAccount(ctx context.Context, tenantID, customerID string) (*model.Customer, error)
+	AdjustPointBalance(ctx context.Context, customerID string, delta int) (int, error)
+	ConsumePointLots(ctx context.Context, customerID string, points int) error
+	CreatePointTransaction(ctx context.Context, transaction model.PointTransaction) (*model.PointTransaction, error)
+	GetOrderPointTotals(ctx context.Context, orderID int) (int, int, error)
+	GetPointTransactions(ctx context.Context, customerID string, limit, offset int) ([]*model.PointTransaction, error)
+	CountPointTransactions(ctx context.Context, customerID string) (int64, error)
+	GetPointExpiries(ctx context.Context, customerID string) ([]*model.PointExpiry, error)
+	GetCustomersWithExpiredPoints(ctx context.Context, now time.Time, limit int) ([]*model.Customer, error)
+	ExpirePointLots(ctx context.Context, customerID string, now time.Time) (int, error)
+	/* export */
+	EachUser(ctx context.Context, tenantID string, limit, offset int, fn func(*model.User) error) error
+	EachStock(ctx context.Context, storeID string, limit, offset int, fn func(*model.Stock) error) error
+	EachCustomer(ctx context.Context, tenantID string, limit, offset int, fn func(*model.Customer) error) error
+	EachOrder(ctx context.Context, tenantID string, limit, offset int, fn func(*model.Order) error) error
+	EachStockMovement(ctx context.Context, stockID int, limit, offset int, fn func(*model.StockMovement) error) error
+	/* import job */
+	GetImportJobs(ctx context.Context, tenantID string, limit, offset int) ([]*model.ImportJob, error)
+	GetImportJob(ctx context.Context, tenantID string, jobID int) (*model.ImportJob, error)
+	CreateImportJob(ctx context.Context, job model.ImportJob) (*model.ImportJob, error)
+	UpdateImportJob(ctx context.Context, job model.ImportJob) (*model.ImportJob, error)
+	ClaimImportJob(ctx context.Context) (*model.ImportJob, error)
+	FailStaleImportJobs(ctx context.Context, before time.Time, message string) (int64, error)
+	/* daily sales rollup */
+	ApplyOrdersToDailySales(ctx context.Context, orderIDs []int, sign int, timeZone string) error
+	RebuildDailySales(ctx context.Context, tenantID *string, timeZone string) (int64, error)
+	GetDailySalesDiscrepancies(ctx context.Context, tenantID *string, timeZone string) ([]*model.DailySalesDiscrepancy, error)
+	/* report */
+	GetSalesReport(ctx context.Context, query model.SalesReportQuery) ([]*model.SalesReportRow, error)
 }
 
 type repository struct {
-	db *gorm.DB
+	db   *gorm.DB
+	keys *keyRing
 }
 
 func New(cfg *config.Config) (RepositoryInterface, error) {
@@ -60,9 +230,33 @@ func New(cfg *config.Config) (RepositoryInterface, error) {
 		return nil, err
 	}
 
-	return &repository{db}, nil
+	provider, err := newKeyProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	keys := newKeyRing(db, provider)
+	schema.RegisterSerializer("encrypted", &encryptedSerializer{keys: keys})
+
+	return &repository{db: db, keys: keys}, nil
 }
 
 func (re *repository) GetDB() *gorm.DB {
 	return re.db
 }
+
+// Transaction はfnに渡したリポジトリの操作を1つのトランザクションで実行する
+func (re *repository) Transaction(ctx context.Context, fn func(tx RepositoryInterface) error) error {
+	return re.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&repository{db: tx, keys: re.keys})
+	})
+}
+
+// translateError は一意制約・外部キー制約の違反をgorm.ErrDuplicatedKeyなどに変換する
+// 制約の違反を利用者の入力の誤りとして扱う書き込みに限って使う
+func (r *repository) translateError(err error) error {
+	if translator, ok := r.db.Dialector.(gorm.ErrorTranslator); ok {
+		return translator.Translate(err)
+	}
+
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm/clause"
 )
 
 func (r *repository) GetStocks(ctx context.Context, storeID string, limit, offset int) ([]*model.Stock, error) {
@@ -34,9 +35,25 @@ func (r *repository) GetStock(ctx context.Context, storeID, stockID string) (*mo
 	return stock, nil
 }
 
+// LockStock は在庫を行ロックして取得する
+// 取得した数量との差分で入出庫履歴を残すため、発注や入荷による同時の数量の変更を待たせる
+func (r *repository) LockStock(ctx context.Context, storeID, stockID string) (*model.Stock, error) {
+	stock := &model.Stock{}
+
+	if err := r.db.Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("stocks.store_id = ? AND stocks.id = ?", storeID, stockID).
+		First(&stock).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
+
 func (r *repository) CreateStock(ctx context.Context, stock model.Stock) (*int, error) {
 	if err := r.db.Create(&stock).Error; err != nil {
-		return nil, err
+		return nil, r.translateError(err)
 	}
 
 	return &stock.ID, nil
@@ -44,7 +61,7 @@ func (r *repository) CreateStock(ctx context.Context, stock model.Stock) (*int,
 
 func (r *repository) CreateBulkStock(ctx context.Context, stocks []model.Stock) ([]*int, error) {
 	if err := r.db.CreateInBatches(stocks, 1000).Error; err != nil {
-		return nil, err
+		return nil, r.translateError(err)
 	}
 
 	var stockIDs []*int
@@ -60,11 +77,16 @@ func (r *repository) UpdateStock(ctx context.Context, stock model.Stock) (*model
 	if err := r.db.Model(&model.Stock{}).
 		Where("id = ?", stock.ID).
 		Updates(map[string]interface{}{
-			"name":     stock.Name,
-			"quantity": stock.Quantity,
-			"price":    stock.Price,
+			"name":          stock.Name,
+			"quantity":      stock.Quantity,
+			"price":         stock.Price,
+			"barcode":       stock.Barcode,
+			"jan":           stock.JAN,
+			"serial_number": stock.SerialNumber,
+			"category":      stock.Category,
+			"tax_rate":      stock.TaxRate,
 		}).Error; err != nil {
-		return nil, err
+		return nil, r.translateError(err)
 	}
 
 	// 更新後のデータを取得
@@ -85,3 +107,66 @@ func (r *repository) DeleteStock(ctx context.Context, storeID, stockID string) e
 
 	return nil
 }
+
+// GetStockByCode は識別コードで在庫を取得する
+// 一意制約は列ごとのため、同じコードが別の在庫の別の列に登録されている場合はバーコード、JAN、シリアル番号の順に優先する
+func (r *repository) GetStockByCode(ctx context.Context, storeID, code string) (*model.Stock, error) {
+	stock := &model.Stock{}
+
+	if err := r.db.Unscoped().
+		Where("stocks.store_id = ?", storeID).
+		Where("stocks.barcode = ? OR stocks.jan = ? OR stocks.serial_number = ?", code, code, code).
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "CASE WHEN stocks.barcode = ? THEN 0 WHEN stocks.jan = ? THEN 1 ELSE 2 END",
+			Vars: []interface{}{code, code},
+		}}).
+		Take(&stock).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
+
+// GetStockOwner は在庫が属するテナント・店舗を取得する。在庫が別のテナントに属する場合はgorm.ErrRecordNotFoundを返す
+func (r *repository) GetStockOwner(ctx context.Context, tenantID string, stockID int) (*model.StockOwner, error) {
+	owner := &model.StockOwner{}
+
+	if err := r.db.Unscoped().
+		Model(&model.Stock{}).
+		Select("stores.tenant_id, stocks.store_id, stocks.id AS stock_id").
+		Joins("JOIN stores ON stores.id = stocks.store_id").
+		Where("stores.tenant_id = ? AND stocks.id = ?", tenantID, stockID).
+		Take(owner).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return owner, nil
+}
+
+func (r *repository) GetStocksByIDs(ctx context.Context, storeID string, stockIDs []int) ([]*model.Stock, error) {
+	stocks := []*model.Stock{}
+
+	if err := r.db.Unscoped().
+		Where("stocks.store_id = ? AND stocks.id IN ?", storeID, stockIDs).
+		Find(&stocks).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return stocks, nil
+}
+
+func (r *repository) GetStockByBarcode(ctx context.Context, storeID, barcode string) (*model.Stock, error) {
+	stock := &model.Stock{}
+
+	if err := r.db.Unscoped().
+		Where("stocks.store_id = ? AND stocks.barcode = ?", storeID, barcode).
+		First(&stock).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return stock, nil
+}
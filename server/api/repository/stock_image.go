@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+)
+
+func (r *repository) GetStockImages(ctx context.Context, stockID int) ([]*model.StockImage, error) {
+	images := []*model.StockImage{}
+
+	if err := r.db.
+		Where("stock_images.stock_id = ?", stockID).
+		Order("stock_images.position, stock_images.id").
+		Find(&images).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (r *repository) GetStockImage(ctx context.Context, stockID, imageID int) (*model.StockImage, error) {
+	image := &model.StockImage{}
+
+	if err := r.db.
+		Where("stock_images.stock_id = ? AND stock_images.id = ?", stockID, imageID).
+		First(&image).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (r *repository) CreateStockImage(ctx context.Context, image model.StockImage) (*model.StockImage, error) {
+	if err := r.db.Create(&image).Error; err != nil {
+		return nil, err
+	}
+
+	return &image, nil
+}
+
+func (r *repository) UpdateStockImagePositions(ctx context.Context, stockID int, imageIDs []int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, imageID := range imageIDs {
+			if err := tx.Model(&model.StockImage{}).
+				Where("stock_id = ? AND id = ?", stockID, imageID).
+				Update("position", position).
+				Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *repository) DeleteStockImage(ctx context.Context, stockID, imageID int) error {
+	return r.db.Where("stock_id = ? AND id = ?", stockID, imageID).
+		Delete(&model.StockImage{}).
+		Error
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+)
+
+// ErrInsufficientStock は在庫数量が不足している場合のエラー
+var ErrInsufficientStock = errors.New("insufficient stock quantity")
+
+func (r *repository) CreateStockMovement(ctx context.Context, movement model.StockMovement) (*model.StockMovement, error) {
+	if err := r.db.Create(&movement).Error; err != nil {
+		return nil, err
+	}
+
+	return &movement, nil
+}
+
+// AdjustStockQuantity は在庫数量をdeltaだけ増減する。数量がマイナスになる場合は更新しない
+func (r *repository) AdjustStockQuantity(ctx context.Context, stockID, delta int) error {
+	result := r.db.Model(&model.Stock{}).
+		Where("id = ? AND quantity + ? >= 0", stockID, delta).
+		Update("quantity", gorm.Expr("quantity + ?", delta))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInsufficientStock
+	}
+
+	return nil
+}
+
+// ShiftStockQuantity は在庫数量をdeltaだけ増減する。数量がマイナスになる場合も更新する
+// 発注による出庫に使い、在庫数量の不足で発注を拒否しない
+func (r *repository) ShiftStockQuantity(ctx context.Context, stockID, delta int) error {
+	return r.db.Model(&model.Stock{}).
+		Where("id = ?", stockID).
+		Update("quantity", gorm.Expr("quantity + ?", delta)).
+		Error
+}
+
+// SumStockMovementsSince は指定日時より後に記録された在庫の入出庫の数量を合計する
+func (r *repository) SumStockMovementsSince(ctx context.Context, stockID int, since time.Time) (int, error) {
+	var total int
+
+	if err := r.db.Model(&model.StockMovement{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("stock_id = ? AND created_at > ?", stockID, since).
+		Scan(&total).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *repository) GetStockMovements(ctx context.Context, stockID int, limit, offset int) ([]*model.StockMovement, error) {
+	movements := []*model.StockMovement{}
+
+	if err := r.db.
+		Where("stock_movements.stock_id = ?", stockID).
+		Order("stock_movements.occurred_at DESC, stock_movements.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&movements).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+// EachStockLedgerEntry はテナントの基準日時までの入出庫履歴を在庫ごとに発生順で走査する
+// 件数が多くなるため全件をメモリに載せずに1行ずつ処理する
+func (r *repository) EachStockLedgerEntry(ctx context.Context, tenantID string, asOf time.Time, fn func(*model.StockLedgerEntry) error) error {
+	rows, err := r.db.Model(&model.StockMovement{}).
+		Select("stock_movements.*, stocks.store_id, stocks.name AS stock_name").
+		Joins("JOIN stocks ON stocks.id = stock_movements.stock_id").
+		Joins("JOIN stores ON stores.id = stocks.store_id").
+		Where("stores.tenant_id = ? AND stock_movements.occurred_at <= ?", tenantID, asOf).
+		Order("stocks.store_id, stock_movements.stock_id, stock_movements.occurred_at, stock_movements.id").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry model.StockLedgerEntry
+		if err := r.db.ScanRows(rows, &entry); err != nil {
+			return err
+		}
+		if err := fn(&entry); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
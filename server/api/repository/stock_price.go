@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *repository) CreateStockPriceChange(ctx context.Context, change model.StockPriceChange) (*model.StockPriceChange, error) {
+	if err := r.db.Create(&change).Error; err != nil {
+		return nil, err
+	}
+
+	return &change, nil
+}
+
+// GetStockPriceChanges は在庫の価格の変更を有効日時の順に取得する。期間は適用済みの変更の絞り込みに使う
+func (r *repository) GetStockPriceChanges(ctx context.Context, stockID int, status model.PriceChangeStatus, from, to *time.Time) ([]*model.StockPriceChange, error) {
+	changes := []*model.StockPriceChange{}
+
+	tx := r.db.Where("stock_id = ? AND status = ?", stockID, status)
+	if from != nil {
+		tx = tx.Where("effective_from >= ?", *from)
+	}
+	if to != nil {
+		tx = tx.Where("effective_from < ?", *to)
+	}
+
+	if err := tx.
+		Order("effective_from, id").
+		Find(&changes).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// CancelStockPriceChange は適用待ちの価格の変更を取り消す。適用待ちでない場合はgorm.ErrRecordNotFoundを返す
+func (r *repository) CancelStockPriceChange(ctx context.Context, stockID, changeID int) (*model.StockPriceChange, error) {
+	change := &model.StockPriceChange{}
+
+	result := r.db.Model(change).
+		Clauses(clause.Returning{}).
+		Where("stock_id = ? AND id = ? AND status = ?", stockID, changeID, model.PriceChangeScheduled).
+		Update("status", model.PriceChangeCancelled)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return change, nil
+}
+
+// LockDuePriceChanges は有効日時を過ぎた適用待ちの価格の変更を有効日時の順に行ロックして取得する
+// 他のワーカーがロック中の変更は飛ばす
+func (r *repository) LockDuePriceChanges(ctx context.Context, now time.Time, limit int) ([]*model.StockPriceChange, error) {
+	changes := []*model.StockPriceChange{}
+
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND effective_from <= ?", model.PriceChangeScheduled, now).
+		Order("effective_from, id").
+		Limit(limit).
+		Find(&changes).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// SetStockPrice は在庫の販売価格を変更し、変更前の価格を返す
+func (r *repository) SetStockPrice(ctx context.Context, stockID, price int) (int, error) {
+	stock := &model.Stock{}
+
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "price").
+		Where("id = ?", stockID).
+		First(&stock).
+		Error; err != nil {
+		return 0, err
+	}
+
+	previous := stock.Price
+	if err := r.db.Model(&model.Stock{}).
+		Where("id = ?", stockID).
+		Update("price", price).
+		Error; err != nil {
+		return 0, err
+	}
+
+	return previous, nil
+}
+
+// MarkPriceChangeApplied は価格の変更を適用済みにし、変更前の価格を記録する
+func (r *repository) MarkPriceChangeApplied(ctx context.Context, changeID, previousPrice int, appliedAt time.Time) error {
+	return r.db.Model(&model.StockPriceChange{}).
+		Where("id = ?", changeID).
+		Updates(map[string]interface{}{
+			"status":         model.PriceChangeApplied,
+			"previous_price": previousPrice,
+			"applied_at":     appliedAt,
+		}).
+		Error
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *repository) GetStocktakes(ctx context.Context, storeID string, limit, offset int) ([]*model.Stocktake, error) {
+	stocktakes := []*model.Stocktake{}
+
+	if err := r.db.
+		Where("stocktakes.store_id = ?", storeID).
+		Order("stocktakes.id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&stocktakes).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return stocktakes, nil
+}
+
+func (r *repository) GetStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error) {
+	stocktake := &model.Stocktake{}
+
+	if err := r.db.
+		Where("stocktakes.store_id = ? AND stocktakes.id = ?", storeID, stocktakeID).
+		First(&stocktake).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return stocktake, nil
+}
+
+// LockStocktake は棚卸を行ロックして取得する。状態の確認から更新までの間に他の操作で状態が変わらないようにする
+// トランザクション内で呼び出すこと
+func (r *repository) LockStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error) {
+	stocktake := &model.Stocktake{}
+
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("stocktakes.store_id = ? AND stocktakes.id = ?", storeID, stocktakeID).
+		First(&stocktake).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return stocktake, nil
+}
+
+// CreateStocktake は棚卸を作成し、店舗の全在庫の数量をスナップショットとして保存する
+func (r *repository) CreateStocktake(ctx context.Context, stocktake model.Stocktake) (*model.Stocktake, error) {
+	if err := r.db.Create(&stocktake).Error; err != nil {
+		return nil, r.translateError(err)
+	}
+
+	if err := r.db.Exec(`
+		INSERT INTO stocktake_items (created_at, updated_at, stocktake_id, stock_id, name, price, system_quantity)
+		SELECT ?, ?, ?, stocks.id, stocks.name, stocks.price, stocks.quantity
+		FROM stocks
+		WHERE stocks.store_id = ?`,
+		stocktake.SnapshotAt, stocktake.SnapshotAt, stocktake.ID, stocktake.StoreID,
+	).Error; err != nil {
+		return nil, err
+	}
+
+	return &stocktake, nil
+}
+
+func (r *repository) UpdateStocktake(ctx context.Context, stocktake model.Stocktake) (*model.Stocktake, error) {
+	if err := r.db.Save(&stocktake).Error; err != nil {
+		return nil, err
+	}
+
+	return &stocktake, nil
+}
+
+func (r *repository) GetStocktakeItems(ctx context.Context, stocktakeID int) ([]*model.StocktakeItem, error) {
+	items := []*model.StocktakeItem{}
+
+	if err := r.db.
+		Where("stocktake_items.stocktake_id = ?", stocktakeID).
+		Order("stocktake_items.stock_id").
+		Find(&items).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *repository) UpdateStocktakeItem(ctx context.Context, item model.StocktakeItem) error {
+	return r.db.Save(&item).Error
+}
+
+// SaveStocktakeCounts は端末ごとのカウントを登録し、在庫ごとの実数を合計し直す
+// 同じ端末から同じ在庫のカウントが再送された場合は上書きする
+func (r *repository) SaveStocktakeCounts(ctx context.Context, stocktakeID int, counts []model.StocktakeCount) error {
+	if err := r.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "stocktake_id"}, {Name: "stock_id"}, {Name: "device_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quantity", "counted_at", "updated_at"}),
+		}).
+		Create(&counts).
+		Error; err != nil {
+		return err
+	}
+
+	stockIDs := make([]int, 0, len(counts))
+	for _, count := range counts {
+		stockIDs = append(stockIDs, count.StockID)
+	}
+
+	return r.db.Model(&model.StocktakeItem{}).
+		Where("stocktake_id = ? AND stock_id IN ?", stocktakeID, stockIDs).
+		Updates(map[string]interface{}{
+			"counted_quantity": gorm.Expr(
+				"(SELECT SUM(c.quantity) FROM stocktake_counts AS c WHERE c.stocktake_id = stocktake_items.stocktake_id AND c.stock_id = stocktake_items.stock_id)",
+			),
+			"updated_at": time.Now(),
+		}).
+		Error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+)
+
+func (r *repository) GetStores(ctx context.Context, tenantID string) ([]*model.Store, error) {
+	stores := []*model.Store{}
+
+	if err := r.db.
+		Where("stores.tenant_id = ?", tenantID).
+		Order("stores.name").
+		Find(&stores).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return stores, nil
+}
+
+// GetStore は店舗を取得する。過去の発注の帳票に使うため削除済みの店舗も対象にする
+func (r *repository) GetStore(ctx context.Context, tenantID, storeID string) (*model.Store, error) {
+	store := &model.Store{}
+
+	if err := r.db.Unscoped().
+		Where("stores.tenant_id = ? AND stores.id = ?", tenantID, storeID).
+		First(&store).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetTenantSetting はテナント設定を取得する。未登録の場合は既定値を返す
+func (r *repository) GetTenantSetting(ctx context.Context, tenantID string) (*model.TenantSetting, error) {
+	setting := &model.TenantSetting{}
+
+	err := r.db.
+		Where("tenant_settings.tenant_id = ?", tenantID).
+		First(&setting).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return model.DefaultTenantSetting(tenantID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return setting, nil
+}
+
+func (r *repository) SaveTenantSetting(ctx context.Context, setting model.TenantSetting) (*model.TenantSetting, error) {
+	if err := r.db.
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&setting).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return &setting, nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/address"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+)
+
+// addressNormalizeBatchSize は既存の住所を分割する際に1度に読み込む件数
+const addressNormalizeBatchSize = 500
+
+// LookupAddress は郵便番号に対応する住所の候補を取得する
+func (u *usecase) LookupAddress(ctx context.Context, postalCode string) ([]*model.AddressCandidate, error) {
+	code, ok := address.NormalizePostalCode(postalCode)
+	if !ok {
+		return nil, ErrInvalidPostalCode
+	}
+
+	entries := address.Lookup(code)
+	if len(entries) == 0 {
+		return nil, ErrUnknownPostalCode
+	}
+
+	candidates := make([]*model.AddressCandidate, 0, len(entries))
+	for _, e := range entries {
+		candidates = append(candidates, &model.AddressCandidate{
+			PostalCode: address.FormatPostalCode(e.PostalCode),
+			Prefecture: e.Prefecture,
+			City:       e.City,
+			Town:       e.Town,
+		})
+	}
+
+	return candidates, nil
+}
+
+// NormalizeAddresses は未分割の顧客・店舗の住所を分割して保存する。郵便番号の実在は検証しない
+func (u *usecase) NormalizeAddresses(ctx context.Context, tenantID *string) (*model.AddressNormalization, error) {
+	result := &model.AddressNormalization{}
+
+	afterID := ""
+	for {
+		customers, err := u.Repository.GetUnparsedCustomers(ctx, tenantID, afterID, addressNormalizeBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, customer := range customers {
+			a := address.Parse(customer.Address, "")
+			if a.Prefecture == "" {
+				result.CustomersUnparsed++
+				continue
+			}
+
+			customer.PostalCode, customer.Prefecture, customer.City, customer.Street, customer.Building =
+				a.PostalCode, a.Prefecture, a.City, a.Street, a.Building
+			if err := u.Repository.UpdateCustomerAddress(ctx, *customer); err != nil {
+				return nil, err
+			}
+			result.CustomersParsed++
+		}
+
+		if len(customers) < addressNormalizeBatchSize {
+			break
+		}
+		afterID = customers[len(customers)-1].ID
+	}
+
+	afterID = ""
+	for {
+		stores, err := u.Repository.GetUnparsedStores(ctx, tenantID, afterID, addressNormalizeBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, store := range stores {
+			a := address.Parse(store.Address, store.ZipCode)
+			if a.Prefecture == "" {
+				result.StoresUnparsed++
+				continue
+			}
+
+			if a.PostalCode != "" {
+				store.ZipCode = a.PostalCode
+			}
+			store.Prefecture, store.City, store.Street, store.Building = a.Prefecture, a.City, a.Street, a.Building
+			if err := u.Repository.UpdateStoreAddress(ctx, *store); err != nil {
+				return nil, err
+			}
+			result.StoresParsed++
+		}
+
+		if len(stores) < addressNormalizeBatchSize {
+			break
+		}
+		afterID = stores[len(stores)-1].ID
+	}
+
+	return result, nil
+}
+
+// resolveAddress は入力の住所を分割済みの住所と1行の住所にそろえる
+//
+// 都道府県が指定された場合は分割済みの項目から1行の住所を作り、指定されない場合は1行の住所を分割する。
+// 郵便番号は郵便番号データにある場合のみ都道府県・市区町村と一致することを検証する。
+// 同梱のデータはすべての郵便番号を含むとは限らないため、データにない郵便番号は拒否しない
+func resolveAddress(text string, in address.Address) (address.Address, string, error) {
+	var a address.Address
+	if in.Prefecture != "" {
+		if !address.IsPrefecture(in.Prefecture) {
+			return a, "", ErrInvalidPrefecture
+		}
+		a = in
+		text = address.Format(in)
+	} else {
+		a = address.Parse(text, in.PostalCode)
+	}
+
+	if a.PostalCode == "" {
+		return a, text, nil
+	}
+
+	code, ok := address.NormalizePostalCode(a.PostalCode)
+	if !ok {
+		return a, "", ErrInvalidPostalCode
+	}
+	a.PostalCode = address.FormatPostalCode(code)
+
+	entries := address.Lookup(code)
+	if len(entries) == 0 || a.Prefecture == "" {
+		return a, text, nil
+	}
+	for _, e := range entries {
+		if e.Prefecture == a.Prefecture && strings.HasPrefix(a.City, e.City) {
+			return a, text, nil
+		}
+	}
+
+	return a, "", ErrPostalCodeMismatch
+}
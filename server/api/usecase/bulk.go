@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"gorm.io/gorm"
+)
+
+func (u *usecase) checkBulkSize(n int) error {
+	if limit := u.Config.MaxBulkItems; n > limit {
+		return fmt.Errorf("%w: %d items (max %d)", ErrTooManyBulkItems, n, limit)
+	}
+
+	return nil
+}
+
+// createBulkItems は1件ずつcreateを実行し、件ごとの結果を返す
+// atomicの場合は全件を1つのトランザクションで作成し、1件でも失敗すれば全件を取り消して失敗の原因を返す
+// atomicでない場合は1件ずつ確定し、失敗した行は結果に記録して残りの作成を続ける
+func (u *usecase) createBulkItems(ctx context.Context, n int, invalid []error, atomic bool, create func(tx repository.RepositoryInterface, i int) (*int, error)) (*model.BulkResult, error) {
+	if err := u.checkBulkSize(n); err != nil {
+		return nil, err
+	}
+
+	result := &model.BulkResult{
+		Atomic: atomic,
+		Items:  make([]*model.BulkItemResult, n),
+	}
+	hasInvalid := false
+	for i := range result.Items {
+		result.Items[i] = &model.BulkItemResult{Index: i, Status: model.BulkItemSkipped}
+		if i < len(invalid) && invalid[i] != nil {
+			result.Items[i].Status = model.BulkItemFailed
+			result.Items[i].Error = invalid[i].Error()
+			hasInvalid = true
+		}
+	}
+
+	if atomic {
+		if hasInvalid {
+			return result.Tally(), ErrInvalidBulkItems
+		}
+
+		failed := -1
+		err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+			for i, item := range result.Items {
+				id, err := create(tx, i)
+				if err != nil {
+					failed = i
+					return err
+				}
+				item.ID = id
+			}
+
+			return nil
+		})
+		if err != nil {
+			// 作成済みの行もロールバックされている
+			for _, item := range result.Items {
+				item.ID = nil
+			}
+			if failed < 0 {
+				return result.Tally(), err
+			}
+			result.Items[failed].Status = model.BulkItemFailed
+			result.Items[failed].Error = bulkItemError(err)
+
+			return result.Tally(), fmt.Errorf("item %d: %w", failed, err)
+		}
+
+		for _, item := range result.Items {
+			item.Status = model.BulkItemCreated
+		}
+
+		return result.Tally(), nil
+	}
+
+	for i, item := range result.Items {
+		if item.Status == model.BulkItemFailed {
+			continue
+		}
+
+		err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+			id, err := create(tx, i)
+			item.ID = id
+
+			return err
+		})
+		if err != nil {
+			item.ID = nil
+			item.Status = model.BulkItemFailed
+			item.Error = bulkItemError(err)
+			continue
+		}
+		item.Status = model.BulkItemCreated
+	}
+
+	return result.Tally(), nil
+}
+
+// bulkItemError は結果に記録するエラーの内容。想定外のエラーは内部の情報を返さない
+// 制約の違反はデータベースのエラー文に制約名などが含まれるため、固定の文言にする
+func bulkItemError(err error) string {
+	switch {
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return "duplicated key"
+	case errors.Is(err, gorm.ErrForeignKeyViolated),
+		errors.Is(err, gorm.ErrRecordNotFound):
+		return "referenced record does not exist"
+	case errors.Is(err, ErrInsufficientPoints),
+		errors.Is(err, ErrPointsExceedAmount),
+		errors.Is(err, ErrInvalidCoupon),
+		errors.Is(err, ErrPromotionNotApplicable):
+		return err.Error()
+	default:
+		return "internal error"
+	}
+}
@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/address"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/phone"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
 )
 
@@ -39,12 +41,35 @@ func (u *usecase) GetCustomer(ctx context.Context, tenantID, customerID string)
 }
 
 func (u *usecase) CreateCustomer(ctx context.Context, customer request.CreateCustomerRequest) (*string, error) {
+	number, ok := phone.Parse(customer.PhoneNumber, customer.PhoneCountry)
+	if !ok {
+		return nil, ErrInvalidPhoneNumber
+	}
+
+	a, text, err := resolveAddress(customer.Address, address.Address{
+		PostalCode: customer.PostalCode,
+		Prefecture: customer.Prefecture,
+		City:       customer.City,
+		Street:     customer.Street,
+		Building:   customer.Building,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	customerID, err := u.Repository.CreateCustomer(ctx, model.Customer{
-		TenantID:    customer.TenantID,
-		Name:        customer.Name,
-		Email:       customer.Email,
-		PhoneNumber: customer.PhoneNumber,
-		Address:     customer.Address,
+		TenantID:           customer.TenantID,
+		Name:               customer.Name,
+		Email:              customer.Email,
+		PhoneNumber:        number.E164,
+		PhoneNumberDisplay: number.Display,
+		PhoneCountry:       number.Country,
+		Address:            text,
+		PostalCode:         a.PostalCode,
+		Prefecture:         a.Prefecture,
+		City:               a.City,
+		Street:             a.Street,
+		Building:           a.Building,
 	})
 	if err != nil {
 		return nil, err
@@ -59,10 +84,33 @@ func (u *usecase) UpdateCustomer(ctx context.Context, customer request.UpdateCus
 		return nil, err
 	}
 
+	number, ok := phone.Parse(customer.PhoneNumber, customer.PhoneCountry)
+	if !ok {
+		return nil, ErrInvalidPhoneNumber
+	}
+
+	a, text, err := resolveAddress(customer.Address, address.Address{
+		PostalCode: customer.PostalCode,
+		Prefecture: customer.Prefecture,
+		City:       customer.City,
+		Street:     customer.Street,
+		Building:   customer.Building,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	customerModel.Name = customer.Name
 	customerModel.Email = customer.Email
-	customerModel.PhoneNumber = customer.PhoneNumber
-	customerModel.Address = customer.Address
+	customerModel.PhoneNumber = number.E164
+	customerModel.PhoneNumberDisplay = number.Display
+	customerModel.PhoneCountry = number.Country
+	customerModel.Address = text
+	customerModel.PostalCode = a.PostalCode
+	customerModel.Prefecture = a.Prefecture
+	customerModel.City = a.City
+	customerModel.Street = a.Street
+	customerModel.Building = a.Building
 
 	return u.Repository.UpdateCustomer(ctx, *customerModel)
 }
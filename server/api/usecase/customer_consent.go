@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+)
+
+func (u *usecase) GetCustomerConsents(ctx context.Context, tenantID, customerID string) (*model.CustomerConsents, error) {
+	if _, err := u.Repository.GetCustomer(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	history, err := u.Repository.GetCustomerConsents(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := u.Repository.GetCurrentCustomerConsents(ctx, tenantID, []string{customerID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CustomerConsents{
+		Current: current,
+		History: history,
+	}, nil
+}
+
+// RecordCustomerConsent は同意・撤回を記録する。過去の記録は変更しない
+func (u *usecase) RecordCustomerConsent(ctx context.Context, input request.RecordCustomerConsentRequest) (*model.CustomerConsent, error) {
+	customer, err := u.Repository.GetCustomer(ctx, input.TenantID, input.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if customer.AnonymizedAt != nil {
+		return nil, ErrCustomerAnonymized
+	}
+
+	recordedAt := time.Now()
+	if input.RecordedAt != nil {
+		recordedAt = *input.RecordedAt
+	}
+
+	return u.Repository.CreateCustomerConsent(ctx, model.CustomerConsent{
+		TenantID:   input.TenantID,
+		CustomerID: input.CustomerID,
+		Purpose:    input.Purpose,
+		Granted:    input.Granted,
+		Source:     input.Source,
+		Note:       input.Note,
+		RecordedAt: recordedAt,
+	})
+}
+
+// GetCustomerPersonalData は開示請求に応じて顧客に紐づく情報をまとめて返す
+// 削除済みの顧客や、統合により削除された統合元の顧客の情報も保有している限り含める
+func (u *usecase) GetCustomerPersonalData(ctx context.Context, tenantID, customerID string) (*model.CustomerPersonalData, error) {
+	customer, err := u.Repository.GetCustomer(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	consents, err := u.Repository.GetCustomerConsents(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	merges, err := u.Repository.GetCustomerMerges(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := u.Repository.GetMergedCustomers(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CustomerPersonalData{
+		ExportedAt:      time.Now(),
+		Customer:        customer,
+		Consents:        consents,
+		Merges:          merges,
+		MergedCustomers: merged,
+	}, nil
+}
+
+// AnonymizeCustomer は削除請求に応じて顧客の個人情報を消去する
+// 発注は会計のために顧客との紐づけごと残し、顧客の行は氏名・連絡先・住所を消去して削除済みにする
+// 統合された顧客の情報と統合履歴の顧客情報も消去し、有効な同意は撤回として記録する
+func (u *usecase) AnonymizeCustomer(ctx context.Context, tenantID, customerID string) (*model.CustomerAnonymization, error) {
+	if _, err := u.Repository.GetCustomer(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	result := &model.CustomerAnonymization{
+		CustomerID:   customerID,
+		AnonymizedAt: time.Now(),
+	}
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		// 更新時の行ロックで同じ顧客の匿名化が同時に実行されないようにする
+		anonymized, err := tx.AnonymizeCustomers(ctx, tenantID, []string{customerID}, result.AnonymizedAt)
+		if err != nil {
+			return err
+		}
+		if anonymized == 0 {
+			return ErrCustomerAnonymized
+		}
+
+		merged, err := tx.GetMergedCustomers(ctx, tenantID, customerID)
+		if err != nil {
+			return err
+		}
+		ids := []string{customerID}
+		for _, c := range merged {
+			ids = append(ids, c.ID)
+		}
+		if len(merged) > 0 {
+			if _, err := tx.AnonymizeCustomers(ctx, tenantID, ids[1:], result.AnonymizedAt); err != nil {
+				return err
+			}
+		}
+		if err := tx.ScrubCustomerMergeSnapshots(ctx, tenantID, ids); err != nil {
+			return err
+		}
+
+		consents, err := tx.GetCurrentCustomerConsents(ctx, tenantID, ids)
+		if err != nil {
+			return err
+		}
+		for _, consent := range consents {
+			if !consent.Granted {
+				continue
+			}
+			if _, err := tx.CreateCustomerConsent(ctx, model.CustomerConsent{
+				TenantID:   tenantID,
+				CustomerID: consent.CustomerID,
+				Purpose:    consent.Purpose,
+				Granted:    false,
+				Source:     model.ConsentSourceAnonymization,
+				RecordedAt: result.AnonymizedAt,
+			}); err != nil {
+				return err
+			}
+		}
+
+		orders, err := tx.CountCustomerOrders(ctx, customerID)
+		if err != nil {
+			return err
+		}
+
+		result.AnonymizedCustomerIDs = ids
+		result.RetainedOrders = int(orders)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
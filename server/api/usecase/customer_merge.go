@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/dedupe"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultMinNameSimilarity は氏名のみで重複候補とする類似度の既定値
+	defaultMinNameSimilarity = 0.8
+	// nameWindow は氏名の並び順で前後何件までを比較するか
+	nameWindow = 20
+)
+
+type dedupeEntry struct {
+	customer *model.Customer
+	phone    string
+	email    string
+	name     string
+}
+
+// GetCustomerDuplicates は重複の可能性がある顧客の組を返す
+// 電話番号・メールアドレスは正規化して完全一致、氏名は類似度で判定する
+// 氏名の比較は全件の総当たりを避け、正規化した氏名の並び順で近いもの同士に限る
+func (u *usecase) GetCustomerDuplicates(ctx context.Context, input request.GetCustomerDuplicatesRequest) ([]*model.CustomerDuplicate, error) {
+	customers, err := u.Repository.GetActiveCustomers(ctx, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	minSimilarity := defaultMinNameSimilarity
+	if input.MinNameSimilarity != nil {
+		minSimilarity = *input.MinNameSimilarity
+	}
+
+	entries := make([]dedupeEntry, len(customers))
+	target := -1
+	for i, c := range customers {
+		entries[i] = dedupeEntry{
+			customer: c,
+			phone:    dedupe.NormalizePhoneNumber(c.PhoneNumber),
+			email:    dedupe.NormalizeEmail(c.Email),
+			name:     dedupe.NormalizeName(c.Name),
+		}
+		if input.CustomerID != nil && c.ID == *input.CustomerID {
+			target = i
+		}
+	}
+	if input.CustomerID != nil && target < 0 {
+		return nil, fmt.Errorf("customer %s: %w", *input.CustomerID, gorm.ErrRecordNotFound)
+	}
+
+	pairs := map[[2]int]*model.CustomerDuplicate{}
+	add := func(i, j int, reason model.DuplicateReason) {
+		if i == j || (target >= 0 && i != target && j != target) {
+			return
+		}
+		// 先に登録された顧客を基準にする。対象の顧客が指定された場合はその顧客を基準にする
+		if j < i {
+			i, j = j, i
+		}
+		if target == j {
+			i, j = j, i
+		}
+		key := [2]int{i, j}
+		pair, ok := pairs[key]
+		if !ok {
+			pair = &model.CustomerDuplicate{
+				Customer:       entries[i].customer,
+				Candidate:      entries[j].customer,
+				NameSimilarity: dedupe.NameSimilarity(entries[i].name, entries[j].name),
+			}
+			pairs[key] = pair
+		}
+		pair.Reasons = append(pair.Reasons, reason)
+	}
+
+	byPhone := map[string][]int{}
+	byEmail := map[string][]int{}
+	for i, e := range entries {
+		if e.phone != "" {
+			byPhone[e.phone] = append(byPhone[e.phone], i)
+		}
+		if e.email != "" {
+			byEmail[e.email] = append(byEmail[e.email], i)
+		}
+	}
+	for _, group := range []struct {
+		buckets map[string][]int
+		reason  model.DuplicateReason
+	}{{byPhone, model.DuplicatePhoneNumber}, {byEmail, model.DuplicateEmail}} {
+		for _, bucket := range group.buckets {
+			for a := 0; a < len(bucket); a++ {
+				for b := a + 1; b < len(bucket); b++ {
+					add(bucket[a], bucket[b], group.reason)
+				}
+			}
+		}
+	}
+
+	similar := func(i, j int) bool {
+		return entries[i].name != "" && entries[j].name != "" &&
+			dedupe.NameSimilarity(entries[i].name, entries[j].name) >= minSimilarity
+	}
+	if target >= 0 {
+		for j := range entries {
+			if j != target && similar(target, j) {
+				add(target, j, model.DuplicateName)
+			}
+		}
+	} else {
+		order := make([]int, len(entries))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return entries[order[a]].name < entries[order[b]].name
+		})
+		for a := range order {
+			for b := a + 1; b < len(order) && b <= a+nameWindow; b++ {
+				if similar(order[a], order[b]) {
+					add(order[a], order[b], model.DuplicateName)
+				}
+			}
+		}
+	}
+
+	duplicates := make([]*model.CustomerDuplicate, 0, len(pairs))
+	for _, pair := range pairs {
+		duplicates = append(duplicates, pair)
+	}
+	// 一致した項目が多く、氏名が近い組から並べる
+	sort.Slice(duplicates, func(a, b int) bool {
+		da, db := duplicates[a], duplicates[b]
+		if len(da.Reasons) != len(db.Reasons) {
+			return len(da.Reasons) > len(db.Reasons)
+		}
+		if da.NameSimilarity != db.NameSimilarity {
+			return da.NameSimilarity > db.NameSimilarity
+		}
+		if da.Customer.ID != db.Customer.ID {
+			return da.Customer.ID < db.Customer.ID
+		}
+		return da.Candidate.ID < db.Candidate.ID
+	})
+
+	return duplicates, nil
+}
+
+// MergeCustomers は統合元の顧客の発注を統合先に付け替え、統合元を削除する
+// 統合元の顧客情報と付け替えた発注は統合履歴に残す。統合先の顧客情報は変更しない
+func (u *usecase) MergeCustomers(ctx context.Context, input request.MergeCustomersRequest) ([]*model.CustomerMerge, error) {
+	sourceIDs := make([]string, 0, len(input.SourceIDs))
+	seen := map[string]bool{}
+	for _, id := range input.SourceIDs {
+		if id == input.TargetID {
+			return nil, ErrMergeSameCustomer
+		}
+		if !seen[id] {
+			seen[id] = true
+			sourceIDs = append(sourceIDs, id)
+		}
+	}
+
+	var merges []*model.CustomerMerge
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		ids := append([]string{input.TargetID}, sourceIDs...)
+		customers, err := tx.LockCustomers(ctx, input.TenantID, ids)
+		if err != nil {
+			return err
+		}
+		found := make(map[string]*model.Customer, len(customers))
+		for _, c := range customers {
+			found[c.ID] = c
+		}
+		for _, id := range ids {
+			if _, ok := found[id]; !ok {
+				return fmt.Errorf("customer %s: %w", id, gorm.ErrRecordNotFound)
+			}
+		}
+
+		now := time.Now()
+		for _, id := range sourceIDs {
+			source := found[id]
+			orderIDs, err := tx.ReassignCustomerReferences(ctx, source.ID, input.TargetID)
+			if err != nil {
+				return err
+			}
+			if err := transferPoints(ctx, tx, source, found[input.TargetID]); err != nil {
+				return err
+			}
+			if err := tx.DeleteCustomer(ctx, input.TenantID, source.ID); err != nil {
+				return err
+			}
+
+			merge, err := tx.CreateCustomerMerge(ctx, model.CustomerMerge{
+				TenantID:         input.TenantID,
+				TargetCustomerID: input.TargetID,
+				SourceCustomerID: source.ID,
+				Source: model.CustomerSnapshot{
+					Name:        source.Name,
+					Email:       source.Email,
+					PhoneNumber: source.PhoneNumber,
+					Address:     source.Address,
+				},
+				OrderIDs: orderIDs,
+				Note:     input.Note,
+				MergedAt: now,
+			})
+			if err != nil {
+				return err
+			}
+			merges = append(merges, merge)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return merges, nil
+}
+
+func (u *usecase) GetCustomerMerges(ctx context.Context, tenantID, customerID string) ([]*model.CustomerMerge, error) {
+	if _, err := u.Repository.GetCustomer(ctx, tenantID, customerID); err != nil {
+		return nil, err
+	}
+
+	return u.Repository.GetCustomerMerges(ctx, tenantID, customerID)
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+)
+
+func (u *usecase) GetCustomerSegments(ctx context.Context, tenantID string) ([]*model.CustomerSegment, error) {
+	return u.Repository.GetCustomerSegments(ctx, tenantID)
+}
+
+func (u *usecase) GetCustomerSegment(ctx context.Context, tenantID string, segmentID int) (*model.CustomerSegment, error) {
+	return u.Repository.GetCustomerSegment(ctx, tenantID, segmentID)
+}
+
+func (u *usecase) CreateCustomerSegment(ctx context.Context, input request.CreateCustomerSegmentRequest) (*model.CustomerSegment, error) {
+	return u.Repository.CreateCustomerSegment(ctx, model.CustomerSegment{
+		TenantID:    input.TenantID,
+		Name:        input.Name,
+		Description: input.Description,
+		Filter:      input.Filter,
+	})
+}
+
+func (u *usecase) UpdateCustomerSegment(ctx context.Context, input request.UpdateCustomerSegmentRequest) (*model.CustomerSegment, error) {
+	return u.Repository.UpdateCustomerSegment(ctx, model.CustomerSegment{
+		ID:          input.SegmentID,
+		TenantID:    input.TenantID,
+		Name:        input.Name,
+		Description: input.Description,
+		Filter:      input.Filter,
+	})
+}
+
+func (u *usecase) DeleteCustomerSegment(ctx context.Context, tenantID string, segmentID int) error {
+	return u.Repository.DeleteCustomerSegment(ctx, tenantID, segmentID)
+}
+
+// CountCustomerSegment は顧客セグメントに該当する顧客の件数を数える
+func (u *usecase) CountCustomerSegment(ctx context.Context, tenantID string, segmentID int) (*model.SegmentCount, error) {
+	segment, err := u.Repository.GetCustomerSegment(ctx, tenantID, segmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := u.Repository.CountSegmentCustomers(ctx, tenantID, segment.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SegmentCount{SegmentID: segment.ID, Count: count}, nil
+}
+
+// GetSegmentCustomers は顧客セグメントに該当する顧客を累計購入額の多い順に取得する
+func (u *usecase) GetSegmentCustomers(ctx context.Context, input request.GetSegmentCustomersRequest) ([]*model.Customer, error) {
+	var validLimit, validOffset int
+	if input.Limit == nil || *input.Limit > 50000 {
+		validLimit = 50000
+	} else {
+		validLimit = *input.Limit
+	}
+
+	if input.Offset == nil {
+		validOffset = 0
+	} else {
+		validOffset = *input.Offset
+	}
+
+	segment, err := u.Repository.GetCustomerSegment(ctx, input.TenantID, input.SegmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.Repository.GetSegmentCustomers(ctx, input.TenantID, segment.Filter, validLimit, validOffset)
+}
+
+func (u *usecase) ExportSegmentCustomers(ctx context.Context, input request.GetSegmentCustomersRequest, w sheet.Writer) error {
+	limit, offset := exportRange(input.Limit, input.Offset)
+
+	segment, err := u.Repository.GetCustomerSegment(ctx, input.TenantID, input.SegmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := writeCustomerHeader(w); err != nil {
+		return err
+	}
+
+	return u.Repository.EachSegmentCustomer(ctx, input.TenantID, segment.Filter, limit, offset, func(customer *model.Customer) error {
+		return writeCustomer(w, customer)
+	})
+}
+
+// ScoreCustomers は顧客のRFMスコアを算出し直す。tenantIDがnilの場合は全テナントが対象
+func (u *usecase) ScoreCustomers(ctx context.Context, tenantID *string) (int64, error) {
+	return u.Repository.ScoreCustomers(ctx, tenantID, time.Now())
+}
+
+// RefreshCustomerScores は定期実行用に全テナントの顧客のRFMスコアを算出し直す
+func (u *usecase) RefreshCustomerScores(ctx context.Context) error {
+	_, err := u.ScoreCustomers(ctx, nil)
+
+	return err
+}
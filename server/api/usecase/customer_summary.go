@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+)
+
+const (
+	// favoriteCategoryLimit は顧客の購入実績に含める分類の件数
+	favoriteCategoryLimit = 5
+	// customerOrdersDefaultLimit は発注履歴の1ページの既定の件数
+	customerOrdersDefaultLimit = 20
+	// customerOrdersMaxLimit は発注履歴の1ページの件数の上限
+	customerOrdersMaxLimit = 100
+)
+
+// GetCustomerSummary は顧客の累計購入額・発注件数・よく購入する分類などを集計する
+func (u *usecase) GetCustomerSummary(ctx context.Context, tenantID, customerID string) (*model.CustomerSummary, error) {
+	summary, err := u.Repository.GetCustomerSummary(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary.FavoriteCategories, err = u.Repository.GetCustomerCategorySpends(ctx, customerID, favoriteCategoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// GetCustomerOrders は顧客の発注履歴を新しい順にページ単位で取得する
+func (u *usecase) GetCustomerOrders(ctx context.Context, input request.GetCustomerOrdersRequest) (*model.CustomerOrderHistory, error) {
+	limit := customerOrdersDefaultLimit
+	if input.Limit != nil {
+		limit = min(*input.Limit, customerOrdersMaxLimit)
+	}
+	offset := 0
+	if input.Offset != nil {
+		offset = *input.Offset
+	}
+
+	if err := u.Repository.CheckCustomerExists(ctx, input.TenantID, input.CustomerID); err != nil {
+		return nil, err
+	}
+
+	total, err := u.Repository.CountCustomerOrders(ctx, input.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := u.Repository.GetCustomerOrders(ctx, input.CustomerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CustomerOrderHistory{
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+		Orders: orders,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+)
+
+// RebuildDailySales は売上の日次集計を発注から作り直す。tenantIDがnilの場合は全テナントが対象
+func (u *usecase) RebuildDailySales(ctx context.Context, tenantID *string) (int64, error) {
+	var rows int64
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		var err error
+		rows, err = tx.RebuildDailySales(ctx, tenantID, u.Config.ReportTimeZone)
+
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rows, nil
+}
+
+// VerifyDailySales は売上の日次集計と発注からの集計を突き合わせ、不一致を返す
+func (u *usecase) VerifyDailySales(ctx context.Context, tenantID *string) ([]*model.DailySalesDiscrepancy, error) {
+	return u.Repository.GetDailySalesDiscrepancies(ctx, tenantID, u.Config.ReportTimeZone)
+}
+
+// addOrdersToDailySales は発注の現在の内容を売上の日次集計に加算する。signが-1の場合は減算する
+func (u *usecase) addOrdersToDailySales(ctx context.Context, tx repository.RepositoryInterface, sign int, orderIDs ...int) error {
+	return tx.ApplyOrdersToDailySales(ctx, orderIDs, sign, u.Config.ReportTimeZone)
+}
@@ -0,0 +1,334 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/config"
+	"github.com/google/uuid"
+)
+
+const (
+	testTenantID   = "00000000-0000-0000-0000-000000000001"
+	testStoreID    = "00000000-0000-0000-0000-000000000002"
+	testCustomerID = "00000000-0000-0000-0000-000000000003"
+)
+
+// rollupKey は日次集計の1行を特定する
+type rollupKey struct {
+	stockID int
+	date    string
+	status  model.OrderStatus
+}
+
+type rollupRow struct {
+	revenue, orderCount, units int
+}
+
+// rollupRepository は発注と日次集計をメモリに持つリポジトリ
+// 日次集計への加算・減算をSQLと同じく発注の現在の内容で行い、利用側の呼び出し順を確かめる
+type rollupRepository struct {
+	repository.RepositoryInterface
+
+	orders  map[int]*model.Order
+	rollups map[rollupKey]rollupRow
+}
+
+func newRollupRepository() *rollupRepository {
+	return &rollupRepository{orders: map[int]*model.Order{}, rollups: map[rollupKey]rollupRow{}}
+}
+
+// expected は発注から集計し直した日次集計を返す
+func (r *rollupRepository) expected() map[rollupKey]rollupRow {
+	rows := map[rollupKey]rollupRow{}
+	for _, o := range r.orders {
+		key, row := rollupOf(o)
+		sum := rows[key]
+		rows[key] = rollupRow{sum.revenue + row.revenue, sum.orderCount + row.orderCount, sum.units + row.units}
+	}
+
+	return rows
+}
+
+// actual は件数が0になった行を除いた日次集計を返す
+func (r *rollupRepository) actual() map[rollupKey]rollupRow {
+	rows := map[rollupKey]rollupRow{}
+	for key, row := range r.rollups {
+		if row != (rollupRow{}) {
+			rows[key] = row
+		}
+	}
+
+	return rows
+}
+
+func rollupOf(o *model.Order) (rollupKey, rollupRow) {
+	return rollupKey{o.StockID, o.CreatedAt.Format("2006-01-02"), o.Status},
+		rollupRow{o.AmountDue(), 1, o.Quantity}
+}
+
+func (r *rollupRepository) Transaction(ctx context.Context, fn func(tx repository.RepositoryInterface) error) error {
+	return fn(r)
+}
+
+func (r *rollupRepository) GetStockOwner(ctx context.Context, tenantID string, stockID int) (*model.StockOwner, error) {
+	return &model.StockOwner{TenantID: tenantID, StoreID: testStoreID, StockID: stockID}, nil
+}
+
+func (r *rollupRepository) GetPromotionTarget(ctx context.Context, stockID int) (*model.PromotionTarget, error) {
+	return &model.PromotionTarget{TenantID: testTenantID, StoreID: testStoreID, StockID: stockID}, nil
+}
+
+func (r *rollupRepository) GetAutomaticPromotions(ctx context.Context, target model.PromotionTarget, amount int, now time.Time) ([]*model.Promotion, error) {
+	return nil, nil
+}
+
+func (r *rollupRepository) CreateOrderDiscounts(ctx context.Context, discounts []*model.OrderDiscount) error {
+	return nil
+}
+
+func (r *rollupRepository) CreateOrder(ctx context.Context, order model.Order) (*int, error) {
+	order.ID = len(r.orders) + 1
+	order.CreatedAt = time.Now()
+	r.orders[order.ID] = &order
+
+	return &order.ID, nil
+}
+
+func (r *rollupRepository) CreateBulkOrder(ctx context.Context, orders []model.Order) ([]*int, error) {
+	ids := make([]*int, 0, len(orders))
+	for _, order := range orders {
+		id, err := r.CreateOrder(ctx, order)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (r *rollupRepository) LockOrder(ctx context.Context, orderID int) error {
+	return nil
+}
+
+func (r *rollupRepository) GetOrder(ctx context.Context, tenantID string, orderID int) (*model.Order, error) {
+	order, ok := r.orders[orderID]
+	if !ok {
+		return nil, errors.New("order not found")
+	}
+	copied := *order
+
+	return &copied, nil
+}
+
+func (r *rollupRepository) UpdateOrder(ctx context.Context, order model.Order) (*model.Order, error) {
+	r.orders[order.ID] = &order
+
+	return r.GetOrder(ctx, testTenantID, order.ID)
+}
+
+func (r *rollupRepository) CountReturnedQuantity(ctx context.Context, orderID int) (int, error) {
+	return 0, nil
+}
+
+func (r *rollupRepository) ShiftStockQuantity(ctx context.Context, stockID, delta int) error {
+	return nil
+}
+
+func (r *rollupRepository) CreateStockMovement(ctx context.Context, movement model.StockMovement) (*model.StockMovement, error) {
+	return &movement, nil
+}
+
+func (r *rollupRepository) LockPointAccount(ctx context.Context, tenantID, customerID string) (*model.Customer, error) {
+	return &model.Customer{ID: customerID, TenantID: tenantID}, nil
+}
+
+func (r *rollupRepository) GetTenantSetting(ctx context.Context, tenantID string) (*model.TenantSetting, error) {
+	return &model.TenantSetting{TenantID: tenantID}, nil
+}
+
+func (r *rollupRepository) ApplyOrdersToDailySales(ctx context.Context, orderIDs []int, sign int, timeZone string) error {
+	for _, id := range orderIDs {
+		key, row := rollupOf(r.orders[id])
+		sum := r.rollups[key]
+		r.rollups[key] = rollupRow{sum.revenue + sign*row.revenue, sum.orderCount + sign*row.orderCount, sum.units + sign*row.units}
+	}
+
+	return nil
+}
+
+// TestDailySalesIncrementalRefresh は発注の作成・更新による日次集計の差分更新が、発注から集計し直した結果と一致することを確かめる
+// DBを使わずに実行できるよう、リポジトリはメモリ上の実装に置き換える
+func TestDailySalesIncrementalRefresh(t *testing.T) {
+	ctx := context.Background()
+	r := newRollupRepository()
+	u := usecase.NewUsecase(&usecase.UsecaseBundle{Config: &config.Config{Bulk: config.Bulk{MaxBulkItems: 10}}, Repository: r})
+
+	newOrder := func(stockID, amount, quantity int, status string) request.CreateOrderRequest {
+		return request.CreateOrderRequest{
+			TenantID:     testTenantID,
+			TotalAmount:  amount,
+			Quantity:     quantity,
+			DeliveryDate: "2025-10-01",
+			Status:       status,
+			StockID:      stockID,
+			CustomerID:   testCustomerID,
+		}
+	}
+
+	orderID, err := u.CreateOrder(ctx, newOrder(1, 3000, 3, "PENDING"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderIDs, err := u.CreateBulkOrder(ctx, []request.CreateOrderRequest{
+		newOrder(1, 1000, 1, "PENDING"),
+		newOrder(2, 2000, 2, "SHIPPED"),
+		newOrder(2, 500, 1, "SHIPPED"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		update request.UpdateOrderRequest
+	}{
+		{"金額と数量の変更", request.UpdateOrderRequest{ID: *orderID, TotalAmount: 4000, Quantity: 4, Status: "PENDING"}},
+		{"ステータスの変更", request.UpdateOrderRequest{ID: *orderID, TotalAmount: 4000, Quantity: 4, Status: "DELIVERED"}},
+		{"キャンセル", request.UpdateOrderRequest{ID: *orderIDs[0], TotalAmount: 1000, Quantity: 1, Status: "CANCELLED"}},
+		{"変更なし", request.UpdateOrderRequest{ID: *orderIDs[1], TotalAmount: 2000, Quantity: 2, Status: "SHIPPED"}},
+		{"同じ行の別の発注の変更", request.UpdateOrderRequest{ID: *orderIDs[2], TotalAmount: 800, Quantity: 2, Status: "SHIPPED"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.update.TenantID = testTenantID
+			tt.update.DeliveryDate = "2025-10-01"
+			if _, err := u.UpdateOrder(ctx, tt.update); err != nil {
+				t.Fatal(err)
+			}
+
+			expected, actual := r.expected(), r.actual()
+			if len(expected) != len(actual) {
+				t.Errorf("rows = %d, want %d", len(actual), len(expected))
+			}
+			for key, want := range expected {
+				if got := actual[key]; got != want {
+					t.Errorf("%+v = %+v, want %+v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+// errRollback はテストで作成したデータを残さないよう、トランザクションを取り消すためのエラー
+var errRollback = errors.New("rollback")
+
+// TestDailySalesFollowOrders は発注の作成・更新を日次集計に反映し、発注からの集計と一致することを確かめる
+// マイグレーション済みのローカル環境のDBが必要なため、DB_HOSTが未設定の場合はスキップする (例: docker compose exec api go test ./...)
+func TestDailySalesFollowOrders(t *testing.T) {
+	if os.Getenv("DB_HOST") == "" {
+		t.Skip("DB_HOST is not set")
+	}
+
+	cfg, err := config.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Env != config.Local {
+		t.Skipf("writes to the database; run only in %s", config.Local)
+	}
+	cfg.Keyfile = filepath.Join(t.TempDir(), "master.key")
+
+	r, err := repository.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	err = r.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		tenantID := uuid.NewString()
+		storeID := uuid.NewString()
+		customerID := uuid.NewString()
+		var stockID int
+
+		db := tx.GetDB()
+		if err := db.Exec(`INSERT INTO tenants (id, name) VALUES (?, 'test')`, tenantID).Error; err != nil {
+			return err
+		}
+		if err := db.Exec(`INSERT INTO stores (id, name, tenant_id) VALUES (?, 'test', ?)`, storeID, tenantID).Error; err != nil {
+			return err
+		}
+		if err := db.Exec(`INSERT INTO customers (id, name, tenant_id) VALUES (?, 'test', ?)`, customerID, tenantID).Error; err != nil {
+			return err
+		}
+		if err := db.Raw(`INSERT INTO stocks (name, quantity, price, store_id) VALUES ('test', 100, 1000, ?) RETURNING id`, storeID).
+			Scan(&stockID).Error; err != nil {
+			return err
+		}
+
+		u := usecase.NewUsecase(&usecase.UsecaseBundle{Config: cfg, Repository: tx})
+		newOrder := func(amount, quantity int, status string) request.CreateOrderRequest {
+			return request.CreateOrderRequest{
+				TenantID:     tenantID,
+				TotalAmount:  amount,
+				Quantity:     quantity,
+				DeliveryDate: "2025-10-01",
+				Status:       status,
+				StockID:      stockID,
+				CustomerID:   customerID,
+			}
+		}
+
+		orderID, err := u.CreateOrder(ctx, newOrder(3000, 3, "PENDING"))
+		if err != nil {
+			return err
+		}
+		orderIDs, err := u.CreateBulkOrder(ctx, []request.CreateOrderRequest{
+			newOrder(1000, 1, "PENDING"),
+			newOrder(2000, 2, "SHIPPED"),
+		})
+		if err != nil {
+			return err
+		}
+
+		updates := []request.UpdateOrderRequest{
+			// 金額と数量の変更
+			{ID: *orderID, TotalAmount: 4000, Quantity: 4, Status: "PENDING"},
+			// ステータスの変更
+			{ID: *orderID, TotalAmount: 4000, Quantity: 4, Status: "DELIVERED"},
+			// キャンセル
+			{ID: *orderIDs[0], TotalAmount: 1000, Quantity: 1, Status: "CANCELLED"},
+			// 変更なし
+			{ID: *orderIDs[1], TotalAmount: 2000, Quantity: 2, Status: "SHIPPED"},
+		}
+		for _, update := range updates {
+			update.TenantID = tenantID
+			update.DeliveryDate = "2025-10-01"
+			if _, err := u.UpdateOrder(ctx, update); err != nil {
+				return err
+			}
+		}
+
+		discrepancies, err := u.VerifyDailySales(ctx, &tenantID)
+		if err != nil {
+			return err
+		}
+		for _, d := range discrepancies {
+			t.Errorf("discrepancy: %+v", *d)
+		}
+
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatal(err)
+	}
+}
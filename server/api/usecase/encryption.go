@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+)
+
+// reencryptBatchSize は顧客の個人情報を暗号化し直す際に1度に読み込む件数
+const reencryptBatchSize = 500
+
+// RotateEncryptionKey は新しいデータ鍵を作成し、データ鍵を現在のマスター鍵で包み直してから顧客の個人情報を暗号化し直す
+func (u *usecase) RotateEncryptionKey(ctx context.Context) (*model.KeyRotation, error) {
+	key, err := u.Repository.RotateDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rewrapped, err := u.Repository.RewrapDataKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reencrypted, err := u.ReencryptCustomers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.KeyRotation{
+		DataKeyID:   key.ID,
+		Rewrapped:   rewrapped,
+		Reencrypted: reencrypted,
+	}, nil
+}
+
+// ReencryptCustomers は平文のまま、または有効なデータ鍵以外で暗号化された顧客と統合履歴の個人情報を
+// 有効なデータ鍵で暗号化し直し、暗号化した件数を返す。途中で中断しても再実行すれば続きから処理する
+func (u *usecase) ReencryptCustomers(ctx context.Context) (int, error) {
+	reencrypted := 0
+
+	afterID := ""
+	for {
+		customers, err := u.Repository.GetCustomersToReencrypt(ctx, afterID, reencryptBatchSize)
+		if err != nil {
+			return reencrypted, err
+		}
+
+		for _, customer := range customers {
+			if err := u.Repository.ReencryptCustomer(ctx, *customer); err != nil {
+				return reencrypted, err
+			}
+			reencrypted++
+		}
+
+		if len(customers) < reencryptBatchSize {
+			break
+		}
+		afterID = customers[len(customers)-1].ID
+	}
+
+	afterMergeID := 0
+	for {
+		merges, err := u.Repository.GetCustomerMergesToReencrypt(ctx, afterMergeID, reencryptBatchSize)
+		if err != nil {
+			return reencrypted, err
+		}
+
+		for _, merge := range merges {
+			if err := u.Repository.ReencryptCustomerMerge(ctx, *merge); err != nil {
+				return reencrypted, err
+			}
+			reencrypted++
+		}
+
+		if len(merges) < reencryptBatchSize {
+			break
+		}
+		afterMergeID = merges[len(merges)-1].ID
+	}
+
+	return reencrypted, nil
+}
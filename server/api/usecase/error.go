@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/payment"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+)
+
+var (
+	// ErrInsufficientStock は在庫数量が不足している場合のエラー
+	ErrInsufficientStock = repository.ErrInsufficientStock
+	// ErrInsufficientPoints はポイント残高が不足している場合のエラー
+	ErrInsufficientPoints = repository.ErrInsufficientPoints
+	// ErrPointsExceedAmount は値引き額と利用するポイントの合計が発注の金額を超える場合のエラー
+	ErrPointsExceedAmount = errors.New("discounts and points used exceed the total amount")
+	// ErrInvalidPromotion はキャンペーンの条件の組み合わせが正しくない場合のエラー
+	ErrInvalidPromotion = errors.New("invalid promotion")
+	// ErrInvalidCoupon はクーポンコードが存在しない、または無効な場合のエラー
+	ErrInvalidCoupon = errors.New("invalid coupon code")
+	// ErrPromotionNotApplicable はクーポンのキャンペーンが発注の条件を満たさない、または利用回数の上限に達した場合のエラー
+	ErrPromotionNotApplicable = errors.New("promotion is not applicable to the order")
+	// ErrOrderHasDiscounts は値引きを適用した発注の金額を変更しようとした場合のエラー
+	ErrOrderHasDiscounts = errors.New("order has discounts")
+	// ErrPriceChangeNotInFuture は予約する価格の変更の有効日時が現在より前の場合のエラー
+	ErrPriceChangeNotInFuture = errors.New("effective_from must be in the future")
+	// ErrPaymentDeclined は決済代行会社が決済を承認しなかった場合のエラー
+	ErrPaymentDeclined = payment.ErrDeclined
+	// ErrPaymentUnavailable は決済代行会社による決済を使わない設定でクレジットカード・QRコード決済をしようとした場合のエラー
+	ErrPaymentUnavailable = payment.ErrUnavailable
+	// ErrPaymentTokenRequired はクレジットカード・QRコード決済でトークンが指定されていない場合のエラー
+	ErrPaymentTokenRequired = errors.New("token is required for gateway payments")
+	// ErrPaymentExceedsBalance は支払額が発注の未払いの残額を超える場合のエラー
+	ErrPaymentExceedsBalance = errors.New("payment exceeds the order balance")
+	// ErrOrderCancelled はキャンセルした発注に支払いを記録しようとした場合のエラー
+	ErrOrderCancelled = errors.New("order has been cancelled")
+	// ErrRefundNotAllowed はキャンセルしていない発注でキャンセルによる返金をしようとした場合のエラー
+	ErrRefundNotAllowed = errors.New("refund is not allowed for the order")
+	// ErrRefundExceedsPayment は返金額が支払いのうち返金していない金額を超える場合のエラー
+	ErrRefundExceedsPayment = errors.New("refund exceeds the refundable amount of the payment")
+	// ErrRefundFailed は記録した返金を決済代行会社が受け付けなかった場合のエラー
+	ErrRefundFailed = errors.New("payment gateway did not accept the refund")
+	// ErrOrderNotDelivered は納品済みでない発注の返品を受け付けようとした場合のエラー
+	ErrOrderNotDelivered = errors.New("order has not been delivered")
+	// ErrReturnExceedsQuantity は返品の数量の合計が発注の数量を超える場合のエラー
+	ErrReturnExceedsQuantity = errors.New("return quantity exceeds the order quantity")
+	// ErrReturnStatus は返品の状態が操作を受け付けない場合のエラー
+	ErrReturnStatus = errors.New("operation is not allowed in the current return status")
+	// ErrExchangeOrderRequired は交換で完了する返品に交換品の発注が指定されていない場合のエラー
+	ErrExchangeOrderRequired = errors.New("exchange_order_id is required for exchanges")
+	// ErrInvalidExchangeOrder は交換品の発注が返品の発注自身、別の顧客の発注、返品の受付より前の発注、または別の返品の交換品の場合のエラー
+	ErrInvalidExchangeOrder = errors.New("exchange order must be a new order of the same customer placed after the return")
+	// ErrReturnAmountExceeded は返品の返金額が、発注の支払いが必要な金額の按分額から返品済みの額を差し引いた額を超える場合のエラー
+	ErrReturnAmountExceeded = errors.New("return amount exceeds the refundable share of the order")
+	// ErrOrderHasReturns は返品を受け付けた発注の状態・数量を変更しようとした場合のエラー
+	ErrOrderHasReturns = errors.New("order has returns")
+	// ErrInvoiceIssuerNotSet は請求書の発行者情報 (登録番号) が未登録のテナントで請求書を発行しようとした場合のエラー
+	ErrInvoiceIssuerNotSet = errors.New("invoice issuer is not configured")
+	// ErrInvoiceCredited は返還請求書で取り消し済みの請求書、または返還請求書自体を取り消そうとした場合のエラー
+	ErrInvoiceCredited = errors.New("invoice has already been credited or is a credit note")
+	// ErrInvalidMarkdownRules は自動値下げの段階の経過日数が重複している、または値下げ率が経過日数の順に大きくならない場合のエラー
+	ErrInvalidMarkdownRules = errors.New("invalid markdown rules")
+	// ErrStockCodeNotSet は在庫に識別コードが登録されていない場合のエラー
+	ErrStockCodeNotSet = errors.New("stock has no barcode, jan or serial number")
+	// ErrStockCodeNotEncodable は在庫の識別コードにバーコードで表せない文字が含まれる場合のエラー
+	ErrStockCodeNotEncodable = errors.New("stock code cannot be printed as a barcode")
+	// ErrNoLabels は出力対象のラベルが1枚もない場合のエラー
+	ErrNoLabels = errors.New("no labels to print: all stocks are out of stock")
+	// ErrTooManyLabels はラベルの出力枚数が上限を超えた場合のエラー
+	ErrTooManyLabels = errors.New("too many labels requested")
+	// ErrFontUnavailable は帳票に埋め込むフォントを読み込めず、PDFを作れない場合のエラー
+	ErrFontUnavailable = errors.New("pdf font is not available")
+	// ErrImageTooLarge はアップロードされた画像がサイズ上限を超えた場合のエラー
+	ErrImageTooLarge = errors.New("image is too large")
+	// ErrUnsupportedImageType はアップロードされたファイルが対応する画像形式でない場合のエラー
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	// ErrInvalidImageOrder は画像の並び替え指定が登録済みの画像と一致しない場合のエラー
+	ErrInvalidImageOrder = errors.New("invalid image order")
+	// ErrStocktakeStatus は棚卸の状態が操作を受け付けない場合のエラー
+	ErrStocktakeStatus = errors.New("operation is not allowed in the current stocktake status")
+	// ErrStocktakeInProgress は店舗で進行中の棚卸が既にある場合のエラー
+	ErrStocktakeInProgress = errors.New("another stocktake is in progress for this store")
+	// ErrStockNotInStocktake は棚卸開始時点に存在しなかった在庫を指定した場合のエラー
+	ErrStockNotInStocktake = errors.New("stock is not part of the stocktake")
+	// ErrStocktakeItemNotCounted は実数が未カウントの在庫を承認しようとした場合のエラー
+	ErrStocktakeItemNotCounted = errors.New("stocktake item has not been counted")
+	// ErrImportTooLarge は取り込みファイルがサイズ上限を超えた場合のエラー
+	ErrImportTooLarge = errors.New("import file is too large")
+	// ErrInvalidImportFile は取り込みファイルをCSV・Excelとして読み込めない場合のエラー
+	ErrInvalidImportFile = errors.New("invalid import file")
+	// ErrInvalidImportMapping は列の対応付けが不正、または必須の列がない場合のエラー
+	ErrInvalidImportMapping = errors.New("invalid import column mapping")
+	// ErrTooManyBulkItems は一括作成の件数が上限を超えた場合のエラー
+	ErrTooManyBulkItems = errors.New("too many items in bulk request")
+	// ErrInvalidBulkItems は全件一括の作成で入力検証に失敗した行がある場合のエラー
+	ErrInvalidBulkItems = errors.New("bulk request contains invalid items")
+	// ErrMergeSameCustomer は統合元に統合先の顧客自身を指定した場合のエラー
+	ErrMergeSameCustomer = errors.New("cannot merge a customer into itself")
+	// ErrInvalidPostalCode は郵便番号が7桁の数字でない場合のエラー
+	ErrInvalidPostalCode = errors.New("invalid postal code")
+	// ErrUnknownPostalCode は郵便番号が郵便番号データに存在しない場合のエラー
+	ErrUnknownPostalCode = errors.New("unknown postal code")
+	// ErrPostalCodeMismatch は郵便番号と都道府県・市区町村が一致しない場合のエラー
+	ErrPostalCodeMismatch = errors.New("postal code does not match the address")
+	// ErrInvalidPrefecture は都道府県名が正しくない場合のエラー
+	ErrInvalidPrefecture = errors.New("invalid prefecture")
+	// ErrInvalidPhoneNumber は電話番号を国の番号体系として解釈できない場合のエラー
+	ErrInvalidPhoneNumber = errors.New("invalid phone number")
+	// ErrCustomerAnonymized は個人情報を匿名化済みの顧客を操作しようとした場合のエラー
+	ErrCustomerAnonymized = errors.New("customer has been anonymized")
+)
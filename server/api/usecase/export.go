@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"gorm.io/gorm"
+)
+
+// exportRange は出力範囲を返す。一覧の取得と異なり、件数の指定がなければ全件を出力する
+func exportRange(limit, offset *int) (int, int) {
+	validLimit, validOffset := -1, 0
+	if limit != nil {
+		validLimit = *limit
+	}
+	if offset != nil {
+		validOffset = *offset
+	}
+
+	return validLimit, validOffset
+}
+
+// deletedAt は論理削除日時を出力用に変換する
+func deletedAt(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+
+	return &d.Time
+}
+
+// genderLabel は性別を一覧の表示と同じ日本語の表記に変換する
+func genderLabel(gender *string) *string {
+	if gender == nil {
+		return nil
+	}
+
+	labels := map[string]string{"male": "男性", "female": "女性"}
+	if label, ok := labels[*gender]; ok {
+		return &label
+	}
+
+	return gender
+}
+
+func (u *usecase) ExportUsers(ctx context.Context, input request.GetUsersRequest, w sheet.Writer) error {
+	limit, offset := exportRange(input.Limit, input.Offset)
+
+	if err := w.Write("ID", "名前", "メールアドレス", "社員番号", "性別", "店舗ID", "登録日時", "更新日時", "削除日時"); err != nil {
+		return err
+	}
+
+	return u.Repository.EachUser(ctx, input.TenantID, limit, offset, func(user *model.User) error {
+		return w.Write(user.ID, user.Name, user.Email, user.EmployeeNumber, genderLabel(user.Gender), user.StoreID,
+			user.CreatedAt, user.UpdatedAt, deletedAt(user.DeletedAt))
+	})
+}
+
+func (u *usecase) ExportStocks(ctx context.Context, input request.GetStocksRequest, w sheet.Writer) error {
+	limit, offset := exportRange(input.Limit, input.Offset)
+
+	if err := w.Write("ID", "商品名", "数量", "販売価格", "バーコード", "JAN", "シリアル番号", "分類", "店舗ID", "担当者ID", "登録日時", "更新日時"); err != nil {
+		return err
+	}
+
+	return u.Repository.EachStock(ctx, input.StoreID, limit, offset, func(stock *model.Stock) error {
+		return w.Write(stock.ID, stock.Name, stock.Quantity, stock.Price, stock.Barcode, stock.JAN, stock.SerialNumber, stock.Category,
+			stock.StoreID, stock.UserID, stock.CreatedAt, stock.UpdatedAt)
+	})
+}
+
+func (u *usecase) ExportCustomers(ctx context.Context, input request.GetCustomersRequest, w sheet.Writer) error {
+	limit, offset := exportRange(input.Limit, input.Offset)
+
+	if err := writeCustomerHeader(w); err != nil {
+		return err
+	}
+
+	return u.Repository.EachCustomer(ctx, input.TenantID, limit, offset, func(customer *model.Customer) error {
+		return writeCustomer(w, customer)
+	})
+}
+
+// writeCustomerHeader は顧客一覧と顧客セグメントの出力に共通の見出し行を書き出す
+func writeCustomerHeader(w sheet.Writer) error {
+	return w.Write("ID", "名前", "メールアドレス", "電話番号", "電話番号（表示用）", "電話番号の国",
+		"住所", "郵便番号", "都道府県", "市区町村", "町域・番地", "建物名",
+		"R", "F", "M", "最終購入からの日数", "発注件数", "累計購入額", "登録日時", "更新日時", "削除日時")
+}
+
+func writeCustomer(w sheet.Writer, customer *model.Customer) error {
+	return w.Write(customer.ID, customer.Name, customer.Email, customer.PhoneNumber, customer.PhoneNumberDisplay, customer.PhoneCountry,
+		customer.Address, customer.PostalCode, customer.Prefecture, customer.City, customer.Street, customer.Building,
+		customer.RecencyScore, customer.FrequencyScore, customer.MonetaryScore, customer.RecencyDays, customer.Frequency, customer.Monetary,
+		customer.CreatedAt, customer.UpdatedAt, deletedAt(customer.DeletedAt))
+}
+
+func (u *usecase) ExportOrders(ctx context.Context, input request.GetOrdersRequest, w sheet.Writer) error {
+	limit, offset := exportRange(input.Limit, input.Offset)
+
+	if err := w.Write("ID", "合計金額", "数量", "納品日", "ステータス", "在庫ID", "顧客ID", "登録日時", "更新日時"); err != nil {
+		return err
+	}
+
+	return u.Repository.EachOrder(ctx, input.TenantID, limit, offset, func(order *model.Order) error {
+		return w.Write(order.ID, order.TotalAmount, order.Quantity, order.DeliveryDate, string(order.Status),
+			order.StockID, order.CustomerID, order.CreatedAt, order.UpdatedAt)
+	})
+}
+
+func (u *usecase) ExportStockMovements(ctx context.Context, input request.GetStockMovementsRequest, w sheet.Writer) error {
+	limit, offset := exportRange(input.Limit, input.Offset)
+
+	stock, err := u.Repository.GetStock(ctx, input.StoreID, input.StockID)
+	if err != nil {
+		return err
+	}
+
+	if err := w.Write("ID", "在庫ID", "種別", "数量", "単価", "発注ID", "理由", "発生日時", "登録日時"); err != nil {
+		return err
+	}
+
+	return u.Repository.EachStockMovement(ctx, stock.ID, limit, offset, func(m *model.StockMovement) error {
+		return w.Write(m.ID, m.StockID, string(m.Type), m.Quantity, m.UnitCost, m.OrderID, m.Reason, m.OccurredAt, m.CreatedAt)
+	})
+}
+
+func (u *usecase) ExportStocktakes(ctx context.Context, input request.GetStocktakesRequest, w sheet.Writer) error {
+	limit, offset := exportRange(input.Limit, input.Offset)
+	if limit < 0 {
+		limit = 50000
+	}
+
+	// 棚卸は店舗ごとの件数が少ないため一覧と同じ方法で取得する
+	stocktakes, err := u.Repository.GetStocktakes(ctx, input.StoreID, limit, offset)
+	if err != nil {
+		return err
+	}
+
+	if err := w.Write("ID", "ステータス", "メモ", "開始日時", "締切日時", "完了日時"); err != nil {
+		return err
+	}
+	for _, s := range stocktakes {
+		if err := w.Write(s.ID, string(s.Status), s.Note, s.SnapshotAt, s.ClosedAt, s.CompletedAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,668 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/address"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/phone"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxImportRowErrors はジョブに保存する行エラーの上限
+	maxImportRowErrors = 1000
+	// importProgressInterval は進捗を保存する行数の間隔
+	importProgressInterval = 100
+)
+
+// importField は取り込み先の項目
+type importField struct {
+	name string
+	// 列の対応付けが指定されない場合に自動で対応付ける見出し。出力ファイルの見出しと揃える
+	headers  []string
+	required bool
+}
+
+var importFields = map[model.ImportTarget][]importField{
+	model.ImportStocks: {
+		{name: "name", headers: []string{"商品名"}, required: true},
+		{name: "quantity", headers: []string{"数量"}, required: true},
+		{name: "price", headers: []string{"販売価格"}, required: true},
+		{name: "user_id", headers: []string{"担当者ID"}, required: true},
+		{name: "barcode", headers: []string{"バーコード"}},
+		{name: "jan", headers: []string{"JAN"}},
+		{name: "serial_number", headers: []string{"シリアル番号"}},
+		{name: "category", headers: []string{"分類"}},
+		{name: "unit_cost", headers: []string{"取得原価"}},
+	},
+	model.ImportCustomers: {
+		{name: "name", headers: []string{"名前"}, required: true},
+		{name: "email", headers: []string{"メールアドレス"}, required: true},
+		{name: "phone_number", headers: []string{"電話番号"}, required: true},
+		{name: "phone_country", headers: []string{"電話番号の国"}},
+		{name: "address", headers: []string{"住所"}, required: true},
+		{name: "postal_code", headers: []string{"郵便番号"}},
+	},
+}
+
+// ImportStocks は在庫を取り込む。バーコードが一致する在庫は更新する
+func (u *usecase) ImportStocks(ctx context.Context, input request.ImportRequest) (*model.ImportJob, error) {
+	input.MatchBy = "barcode"
+
+	return u.startImport(ctx, model.ImportStocks, input)
+}
+
+// ImportCustomers は顧客を取り込む。メールアドレスまたは電話番号が一致する顧客は更新する
+func (u *usecase) ImportCustomers(ctx context.Context, input request.ImportRequest) (*model.ImportJob, error) {
+	if input.MatchBy == "" {
+		input.MatchBy = "email"
+	}
+
+	return u.startImport(ctx, model.ImportCustomers, input)
+}
+
+func (u *usecase) GetImportJobs(ctx context.Context, input request.GetImportJobsRequest) ([]*model.ImportJob, error) {
+	var validLimit, validOffset int
+	if input.Limit == nil || *input.Limit > 50000 {
+		validLimit = 50000
+	} else {
+		validLimit = *input.Limit
+	}
+
+	if input.Offset == nil {
+		validOffset = 0
+	} else {
+		validOffset = *input.Offset
+	}
+
+	return u.Repository.GetImportJobs(ctx, input.TenantID, validLimit, validOffset)
+}
+
+func (u *usecase) GetImportJob(ctx context.Context, tenantID string, jobID int) (*model.ImportJob, error) {
+	return u.Repository.GetImportJob(ctx, tenantID, jobID)
+}
+
+// ProcessImportJobs は処理待ちの取り込みを順に実行する。ワーカーから定期的に呼び出す
+func (u *usecase) ProcessImportJobs(ctx context.Context) error {
+	before := time.Now().Add(-u.Config.ImportStaleAfter)
+	if _, err := u.Repository.FailStaleImportJobs(ctx, before, "import was interrupted"); err != nil {
+		return err
+	}
+
+	for ctx.Err() == nil {
+		job, err := u.Repository.ClaimImportJob(ctx)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			return nil
+		}
+
+		body, readErr := u.readImportFile(ctx, job.FileKey)
+		if readErr != nil {
+			err = u.finishImportJob(ctx, job, readErr)
+		} else {
+			err = u.runImportJob(ctx, job, body)
+		}
+		if err != nil {
+			return err
+		}
+
+		// 取り込み後のファイルは残さない
+		if err := u.Storage.Delete(ctx, job.FileKey); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// startImport はファイルを受け付けてジョブを作成する
+// 小さいファイルはその場で取り込み、大きいファイルはストレージに保存してワーカーに任せる
+func (u *usecase) startImport(ctx context.Context, target model.ImportTarget, input request.ImportRequest) (*model.ImportJob, error) {
+	limit := u.Config.MaxImportSize
+	if input.Size > limit {
+		return nil, fmt.Errorf("%w: %d bytes (max %d)", ErrImportTooLarge, input.Size, limit)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(input.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: max %d bytes", ErrImportTooLarge, limit)
+	}
+
+	job := model.ImportJob{
+		TenantID:      input.TenantID,
+		StoreID:       input.StoreID,
+		Target:        target,
+		Status:        model.ImportPending,
+		DryRun:        input.DryRun,
+		FileName:      input.FileName,
+		Encoding:      input.Encoding,
+		Mapping:       input.Mapping,
+		MatchBy:       input.MatchBy,
+		DefaultUserID: input.DefaultUserID,
+		Errors:        model.ImportRowErrors{},
+	}
+
+	// ファイル形式や列の誤りは受付時に返す
+	if _, _, err := openImport(&job, body); err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) <= u.Config.ImportSyncSize {
+		now := time.Now()
+		job.Status = model.ImportRunning
+		job.StartedAt = &now
+		created, err := u.Repository.CreateImportJob(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+
+		return created, u.runImportJob(ctx, created, body)
+	}
+
+	ext := path.Ext(input.FileName)
+	if ext == "" {
+		ext = "." + string(sheet.DetectFormat(body))
+	}
+	job.FileKey = path.Join("imports", input.TenantID, uuid.NewString()+ext)
+	if err := u.Storage.Put(ctx, job.FileKey, body, "application/octet-stream"); err != nil {
+		return nil, err
+	}
+
+	created, err := u.Repository.CreateImportJob(ctx, job)
+	if err != nil {
+		if err := u.Storage.Delete(ctx, job.FileKey); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (u *usecase) readImportFile(ctx context.Context, key string) ([]byte, error) {
+	rc, err := u.Storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+// runImportJob は全行を取り込み、結果をジョブに保存する
+// 行ごとのエラーは記録して続行し、ファイルの読み込みやDBの障害の場合はジョブを失敗にする
+func (u *usecase) runImportJob(ctx context.Context, job *model.ImportJob, body []byte) error {
+	return u.finishImportJob(ctx, job, u.importRows(ctx, job, body))
+}
+
+func (u *usecase) finishImportJob(ctx context.Context, job *model.ImportJob, err error) error {
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = model.ImportCompleted
+	if err != nil {
+		job.Status = model.ImportFailed
+		job.Error = err.Error()
+	}
+
+	saved, err := u.Repository.UpdateImportJob(ctx, *job)
+	if err != nil {
+		return err
+	}
+	*job = *saved
+
+	return nil
+}
+
+func (u *usecase) importRows(ctx context.Context, job *model.ImportJob, body []byte) error {
+	total, err := countImportRows(job, body)
+	if err != nil {
+		return err
+	}
+	job.TotalRows = total
+
+	r, columns, err := openImport(job, body)
+	if err != nil {
+		return err
+	}
+
+	// ドライランで同じファイル内の重複を登録済みとして数えるため、一致判定に使った値を記録する
+	seen := map[string]bool{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: row %d: %w", ErrInvalidImportFile, r.Row(), err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		values := make(map[string]string, len(columns))
+		for field, i := range columns {
+			if i < len(record) {
+				values[field] = strings.TrimSpace(record[i])
+			}
+		}
+
+		row := r.Row()
+		var created bool
+		var rowErrors []model.ImportRowError
+		switch job.Target {
+		case model.ImportStocks:
+			created, rowErrors, err = u.importStockRow(ctx, job, row, values, seen)
+		case model.ImportCustomers:
+			created, rowErrors, err = u.importCustomerRow(ctx, job, row, values, seen)
+		default:
+			err = fmt.Errorf("unknown import target: %s", job.Target)
+		}
+		if err != nil {
+			return fmt.Errorf("row %d: %w", row, err)
+		}
+
+		job.ProcessedRows++
+		switch {
+		case len(rowErrors) > 0:
+			job.FailedRows++
+			for _, e := range rowErrors {
+				if len(job.Errors) < maxImportRowErrors {
+					job.Errors = append(job.Errors, e)
+				}
+			}
+		case created:
+			job.CreatedRows++
+		default:
+			job.UpdatedRows++
+		}
+
+		if job.ProcessedRows%importProgressInterval == 0 {
+			saved, err := u.Repository.UpdateImportJob(ctx, *job)
+			if err != nil {
+				return err
+			}
+			*job = *saved
+		}
+	}
+}
+
+// importStockRow は在庫1行を検証して登録・更新する。ドライランの場合は既存の有無の判定のみ行う
+// 既存の在庫の取得原価は入庫ごとに記録するため、更新時は取得原価の列を使わない
+func (u *usecase) importStockRow(ctx context.Context, job *model.ImportJob, row int, values map[string]string, seen map[string]bool) (bool, []model.ImportRowError, error) {
+	p := importRowParser{row: row}
+	in := request.ImportStockRow{
+		Name:         values["name"],
+		Quantity:     p.int("quantity", values["quantity"]),
+		Price:        p.int("price", values["price"]),
+		UserID:       values["user_id"],
+		Barcode:      optionalString(values["barcode"]),
+		JAN:          optionalString(values["jan"]),
+		SerialNumber: optionalString(values["serial_number"]),
+		Category:     optionalString(values["category"]),
+		UnitCost:     p.optionalInt("unit_cost", values["unit_cost"]),
+	}
+	if in.UserID == "" && job.DefaultUserID != nil {
+		in.UserID = *job.DefaultUserID
+	}
+	if len(p.errors) > 0 {
+		return false, p.errors, nil
+	}
+	if errs := u.validateImportRow(row, &in); len(errs) > 0 {
+		return false, errs, nil
+	}
+
+	// バーコードのない行は常に新規登録とする
+	var existing *model.Stock
+	var err error
+	if in.Barcode != nil {
+		existing, err = u.Repository.GetStockByBarcode(ctx, job.StoreID, *in.Barcode)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			existing, err = nil, nil
+		}
+		if err != nil {
+			return false, nil, err
+		}
+	}
+
+	if job.DryRun {
+		created := existing == nil
+		if in.Barcode != nil {
+			created = created && !seen[*in.Barcode]
+			seen[*in.Barcode] = true
+		}
+
+		return created, nil, nil
+	}
+
+	if existing == nil {
+		_, err = u.CreateStock(ctx, request.CreateStockRequest{
+			Name:         in.Name,
+			Quantity:     in.Quantity,
+			Price:        in.Price,
+			StoreID:      job.StoreID,
+			UserID:       in.UserID,
+			Barcode:      in.Barcode,
+			JAN:          in.JAN,
+			SerialNumber: in.SerialNumber,
+			Category:     in.Category,
+			UnitCost:     in.UnitCost,
+		})
+	} else {
+		// 空欄の識別コード・分類は登録済みの値を残す
+		jan, serialNumber, category := existing.JAN, existing.SerialNumber, existing.Category
+		if in.JAN != nil {
+			jan = in.JAN
+		}
+		if in.SerialNumber != nil {
+			serialNumber = in.SerialNumber
+		}
+		if in.Category != nil {
+			category = in.Category
+		}
+		_, err = u.UpdateStock(ctx, request.UpdateStockRequest{
+			StockID:      strconv.Itoa(existing.ID),
+			Name:         in.Name,
+			Quantity:     in.Quantity,
+			Price:        in.Price,
+			StoreID:      job.StoreID,
+			UserID:       existing.UserID,
+			Barcode:      existing.Barcode,
+			JAN:          jan,
+			SerialNumber: serialNumber,
+			Category:     category,
+		})
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return false, []model.ImportRowError{{Row: row, Message: "barcode, jan or serial number is already in use"}}, nil
+	}
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return false, []model.ImportRowError{{Row: row, Field: "user_id", Message: "user does not exist"}}, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+
+	return existing == nil, nil, nil
+}
+
+// importCustomerRow は顧客1行を検証して登録・更新する。ドライランの場合は既存の有無の判定のみ行う
+func (u *usecase) importCustomerRow(ctx context.Context, job *model.ImportJob, row int, values map[string]string, seen map[string]bool) (bool, []model.ImportRowError, error) {
+	in := request.ImportCustomerRow{
+		Name:         values["name"],
+		Email:        values["email"],
+		PhoneNumber:  values["phone_number"],
+		PhoneCountry: values["phone_country"],
+		Address:      values["address"],
+		PostalCode:   values["postal_code"],
+	}
+	if errs := u.validateImportRow(row, &in); len(errs) > 0 {
+		return false, errs, nil
+	}
+	if _, _, err := resolveAddress(in.Address, address.Address{PostalCode: in.PostalCode}); err != nil {
+		return false, []model.ImportRowError{{Row: row, Field: "postal_code", Message: err.Error()}}, nil
+	}
+
+	var key string
+	var existing *model.Customer
+	var err error
+	switch job.MatchBy {
+	case "phone_number":
+		number, _ := phone.Parse(in.PhoneNumber, in.PhoneCountry)
+		key = number.E164
+		existing, err = u.Repository.FindCustomerByPhoneNumber(ctx, job.TenantID, number.E164)
+	default:
+		key = strings.ToLower(in.Email)
+		existing, err = u.Repository.FindCustomerByEmail(ctx, job.TenantID, in.Email)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		existing, err = nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+
+	if job.DryRun {
+		created := existing == nil && !seen[key]
+		seen[key] = true
+
+		return created, nil, nil
+	}
+
+	if existing == nil {
+		_, err = u.CreateCustomer(ctx, request.CreateCustomerRequest{
+			TenantID:     job.TenantID,
+			Name:         in.Name,
+			Email:        in.Email,
+			PhoneNumber:  in.PhoneNumber,
+			PhoneCountry: in.PhoneCountry,
+			Address:      in.Address,
+			PostalCode:   in.PostalCode,
+		})
+	} else {
+		_, err = u.UpdateCustomer(ctx, request.UpdateCustomerRequest{
+			ID:           existing.ID,
+			TenantID:     job.TenantID,
+			Name:         in.Name,
+			Email:        in.Email,
+			PhoneNumber:  in.PhoneNumber,
+			PhoneCountry: in.PhoneCountry,
+			Address:      in.Address,
+			PostalCode:   in.PostalCode,
+		})
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return false, []model.ImportRowError{{Row: row, Message: "customer already exists"}}, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+
+	return existing == nil, nil, nil
+}
+
+// validateImportRow は登録APIと同じ検証ルールで1行を検証し、項目ごとのエラーを返す
+func (u *usecase) validateImportRow(row int, v interface{}) []model.ImportRowError {
+	err := u.Validator.Validate(v)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return []model.ImportRowError{{Row: row, Message: err.Error()}}
+	}
+
+	t := reflect.TypeOf(v).Elem()
+	errs := make([]model.ImportRowError, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		field := fe.Field()
+		if sf, ok := t.FieldByName(fe.StructField()); ok {
+			field = strings.Split(sf.Tag.Get("json"), ",")[0]
+		}
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+		errs = append(errs, model.ImportRowError{
+			Row:     row,
+			Field:   field,
+			Message: fmt.Sprintf("failed on the '%s' rule", rule),
+		})
+	}
+
+	return errs
+}
+
+// openImport はファイルを開いて見出し行を読み込み、各項目が何列目かを返す
+func openImport(job *model.ImportJob, body []byte) (sheet.Reader, map[string]int, error) {
+	r, err := sheet.NewReader(body, sheet.Encoding(job.Encoding))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidImportFile, err)
+	}
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil, fmt.Errorf("%w: no header row", ErrInvalidImportFile)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidImportFile, err)
+	}
+
+	columns, err := importColumns(job, header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r, columns, nil
+}
+
+// importColumns は列見出しと項目を対応付ける
+// 対応付けの指定がない場合は、項目名または出力ファイルと同じ見出しの列を使う
+func importColumns(job *model.ImportJob, header []string) (map[string]int, error) {
+	fields := importFields[job.Target]
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[f.name] = true
+	}
+
+	columns := map[string]int{}
+	if len(job.Mapping) > 0 {
+		for h, field := range job.Mapping {
+			if !known[field] {
+				return nil, fmt.Errorf("%w: unknown field %q for column %q", ErrInvalidImportMapping, field, h)
+			}
+		}
+		for i, h := range header {
+			if field, ok := job.Mapping[strings.TrimSpace(h)]; ok {
+				columns[field] = i
+			}
+		}
+	} else {
+		for i, h := range header {
+			h = strings.TrimSpace(h)
+			for _, f := range fields {
+				if _, ok := columns[f.name]; ok {
+					continue
+				}
+				if strings.EqualFold(h, f.name) || containsString(f.headers, h) {
+					columns[f.name] = i
+				}
+			}
+		}
+	}
+
+	var missing []string
+	for _, f := range fields {
+		if _, ok := columns[f.name]; ok || !f.required {
+			continue
+		}
+		// 担当者は列の代わりに既定の従業員を指定できる
+		if f.name == "user_id" && job.DefaultUserID != nil {
+			continue
+		}
+		missing = append(missing, f.name)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing columns: %s", ErrInvalidImportMapping, strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+// countImportRows は見出しと空行を除いた行数を数える。進捗の表示に使う
+func countImportRows(job *model.ImportJob, body []byte) (int, error) {
+	r, _, err := openImport(job, body)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: row %d: %w", ErrInvalidImportFile, r.Row(), err)
+		}
+		if !isBlankRecord(record) {
+			n++
+		}
+	}
+}
+
+// importRowParser はセルの値を数値に変換し、変換できない項目をエラーとして集める
+type importRowParser struct {
+	row    int
+	errors []model.ImportRowError
+}
+
+func (p *importRowParser) int(field, value string) int {
+	v := p.optionalInt(field, value)
+	if v == nil {
+		return 0
+	}
+
+	return *v
+}
+
+// optionalInt は桁区切りのカンマを除いて整数に変換する。空欄の場合はnil
+func (p *importRowParser) optionalInt(field, value string) *int {
+	if value == "" {
+		return nil
+	}
+
+	v, err := strconv.Atoi(strings.ReplaceAll(value, ",", ""))
+	if err != nil {
+		p.errors = append(p.errors, model.ImportRowError{Row: p.row, Field: field, Message: "must be an integer"})
+		return nil
+	}
+
+	return &v
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
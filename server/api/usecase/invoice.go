@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/address"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/invoice"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/pdf"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"gorm.io/gorm"
+)
+
+func (u *usecase) GetInvoiceIssuer(ctx context.Context, tenantID string) (*model.InvoiceIssuer, error) {
+	return u.Repository.GetInvoiceIssuer(ctx, tenantID)
+}
+
+// UpdateInvoiceIssuer は請求書の発行者情報を登録する。発行済みの請求書の記載内容は変わらない
+func (u *usecase) UpdateInvoiceIssuer(ctx context.Context, input request.UpdateInvoiceIssuerRequest) (*model.InvoiceIssuer, error) {
+	postalCode := input.PostalCode
+	if postalCode != "" {
+		code, ok := address.NormalizePostalCode(postalCode)
+		if !ok {
+			return nil, ErrInvalidPostalCode
+		}
+		postalCode = code
+	}
+
+	return u.Repository.SaveInvoiceIssuer(ctx, model.InvoiceIssuer{
+		TenantID:           input.TenantID,
+		RegistrationNumber: input.RegistrationNumber,
+		Name:               input.Name,
+		PostalCode:         postalCode,
+		Address:            input.Address,
+		PhoneNumber:        input.PhoneNumber,
+	})
+}
+
+// GetOrderInvoicePDF は発注の有効な請求書をPDFで返す。まだ発行していない場合はこの時点の発注の内容で発行する
+func (u *usecase) GetOrderInvoicePDF(ctx context.Context, tenantID string, orderID int) ([]byte, error) {
+	// PDFにできない場合は請求書を発行しない
+	font, err := u.pdfFont()
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(u.Config.ReportTimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	var issued *model.Invoice
+	err = u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		// 同じ発注の請求書を同時に発行しないよう発注をロックする
+		if err := tx.LockOrder(ctx, orderID); err != nil {
+			return err
+		}
+		order, err := tx.GetOrder(ctx, tenantID, orderID)
+		if err != nil {
+			return err
+		}
+
+		issued, err = tx.GetActiveInvoice(ctx, tenantID, order.ID)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if order.Status == model.StatusCancelled {
+			return ErrOrderCancelled
+		}
+		issued, err = issueInvoice(ctx, tx, tenantID, order, loc)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renderInvoice(font, issued, nil, loc)
+}
+
+// GetOrderInvoices は発注の請求書・返還請求書を発行順に取得する
+func (u *usecase) GetOrderInvoices(ctx context.Context, tenantID string, orderID int) ([]*model.Invoice, error) {
+	if _, err := u.Repository.GetOrder(ctx, tenantID, orderID); err != nil {
+		return nil, err
+	}
+
+	return u.Repository.GetInvoices(ctx, tenantID, orderID)
+}
+
+func (u *usecase) GetInvoice(ctx context.Context, tenantID string, invoiceID int) (*model.Invoice, error) {
+	return u.Repository.GetInvoice(ctx, tenantID, invoiceID)
+}
+
+// GetInvoicePDF は発行済みの請求書・返還請求書を発行時点の記載内容でPDFにする
+func (u *usecase) GetInvoicePDF(ctx context.Context, tenantID string, invoiceID int) ([]byte, error) {
+	font, err := u.pdfFont()
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(u.Config.ReportTimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := u.Repository.GetInvoice(ctx, tenantID, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var original *model.Invoice
+	if inv.OriginalInvoiceID != nil {
+		original, err = u.Repository.GetInvoice(ctx, tenantID, *inv.OriginalInvoiceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return renderInvoice(font, inv, original, loc)
+}
+
+// CreateCreditNote は発行済みの請求書を取り消す返還請求書を発行する
+// 取り消した後に発注の請求書を取得すると、その時点の発注の内容で請求書を発行し直す
+func (u *usecase) CreateCreditNote(ctx context.Context, input request.CreateCreditNoteRequest) (*model.Invoice, error) {
+	var created *model.Invoice
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		original, err := tx.GetInvoice(ctx, input.TenantID, input.InvoiceID)
+		if err != nil {
+			return err
+		}
+		if err := tx.LockOrder(ctx, original.OrderID); err != nil {
+			return err
+		}
+
+		// 取り消せるのは発注の有効な請求書のみ
+		active, err := tx.GetActiveInvoice(ctx, input.TenantID, original.OrderID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvoiceCredited
+		}
+		if err != nil {
+			return err
+		}
+		if active.ID != original.ID {
+			return ErrInvoiceCredited
+		}
+
+		issuer, err := invoiceIssuer(ctx, tx, input.TenantID)
+		if err != nil {
+			return err
+		}
+
+		lines := make(model.InvoiceLines, 0, len(original.Lines))
+		for _, line := range original.Lines {
+			negated := *line
+			negated.Amount = -line.Amount
+			lines = append(lines, &negated)
+		}
+
+		created, err = createInvoice(ctx, tx, issuer, model.Invoice{
+			TenantID:          input.TenantID,
+			OrderID:           original.OrderID,
+			Type:              model.InvoiceTypeCreditNote,
+			OriginalInvoiceID: &original.ID,
+			Reason:            input.Reason,
+			TransactionDate:   original.TransactionDate,
+			RecipientName:     original.RecipientName,
+			Lines:             lines,
+			Taxes:             lineTaxes(lines),
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// issueInvoice は発注の内容と完了した返品から請求書を発行する
+// 取引年月日は発注日をlocの日付で記載する
+func issueInvoice(ctx context.Context, tx repository.RepositoryInterface, tenantID string, order *model.Order, loc *time.Location) (*model.Invoice, error) {
+	issuer, err := invoiceIssuer(ctx, tx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := tx.GetStockOwner(ctx, tenantID, order.StockID)
+	if err != nil {
+		return nil, err
+	}
+	stock, err := tx.GetStock(ctx, owner.StoreID, strconv.Itoa(order.StockID))
+	if err != nil {
+		return nil, err
+	}
+	customer, err := tx.GetCustomer(ctx, tenantID, order.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 返還請求書の後に発行し直す請求書には、完了した返品で返した額を差し引いて記載する
+	completed := model.ReturnCompleted
+	returns, err := tx.GetOrderReturns(ctx, tenantID, &completed, &order.ID, order.Quantity, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := append(orderLines(order, stock), returnLines(returns, stock)...)
+
+	return createInvoice(ctx, tx, issuer, model.Invoice{
+		TenantID:        tenantID,
+		OrderID:         order.ID,
+		Type:            model.InvoiceTypeInvoice,
+		TransactionDate: order.CreatedAt.In(loc),
+		RecipientName:   customer.Name,
+		Lines:           lines,
+		Taxes:           lineTaxes(lines),
+	})
+}
+
+// orderLines は発注を税込金額の明細にする
+// 値引き・ポイント利用は負の金額の明細とし、在庫と同じ税率の対象金額から差し引く
+func orderLines(order *model.Order, stock *model.Stock) model.InvoiceLines {
+	item := &model.InvoiceLine{
+		Description: stock.Name,
+		Quantity:    order.Quantity,
+		Amount:      order.TotalAmount,
+		TaxRate:     stock.TaxRate,
+	}
+	if order.Quantity > 0 && order.TotalAmount%order.Quantity == 0 {
+		unitPrice := order.TotalAmount / order.Quantity
+		item.UnitPrice = &unitPrice
+	}
+
+	lines := model.InvoiceLines{item}
+	for _, d := range order.Discounts {
+		lines = append(lines, &model.InvoiceLine{Description: "値引き (" + d.Name + ")", Amount: -d.Amount, TaxRate: stock.TaxRate})
+	}
+	if order.PointsUsed > 0 {
+		lines = append(lines, &model.InvoiceLine{Description: "ポイント値引き", Amount: -order.PointsUsed, TaxRate: stock.TaxRate})
+	}
+
+	return lines
+}
+
+// returnLines は返品で返金、またはポイントで返還した額を負の金額の明細にする。交換で完了した返品は含めない
+func returnLines(returns []*model.OrderReturn, stock *model.Stock) model.InvoiceLines {
+	lines := model.InvoiceLines{}
+	for _, ret := range returns {
+		if ret.Amount > 0 {
+			lines = append(lines, &model.InvoiceLine{Description: "返品", Quantity: ret.Quantity, Amount: -ret.Amount, TaxRate: stock.TaxRate})
+		}
+	}
+
+	return lines
+}
+
+// lineTaxes は明細を税率ごとに合計して消費税額を求める
+func lineTaxes(lines model.InvoiceLines) model.TaxBreakdowns {
+	amounts := map[int]int{}
+	for _, line := range lines {
+		amounts[line.TaxRate] += line.Amount
+	}
+
+	return model.NewTaxBreakdowns(amounts)
+}
+
+// createInvoice は発行者の情報と合計を記載し、種別ごとの連番を採番して請求書を作成する
+func createInvoice(ctx context.Context, tx repository.RepositoryInterface, issuer *model.InvoiceIssuer, inv model.Invoice) (*model.Invoice, error) {
+	number, err := tx.NextInvoiceNumber(ctx, inv.TenantID, inv.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	inv.Number = fmt.Sprintf("%s%08d", inv.Type.NumberPrefix(), number)
+	inv.IssuedAt = time.Now()
+	inv.RegistrationNumber = issuer.RegistrationNumber
+	inv.IssuerName = issuer.Name
+	inv.IssuerPostalCode = issuer.PostalCode
+	inv.IssuerAddress = issuer.Address
+	inv.IssuerPhoneNumber = issuer.PhoneNumber
+	for _, tax := range inv.Taxes {
+		inv.Total += tax.Amount
+		inv.Tax += tax.Tax
+	}
+
+	return tx.CreateInvoice(ctx, inv)
+}
+
+func invoiceIssuer(ctx context.Context, tx repository.RepositoryInterface, tenantID string) (*model.InvoiceIssuer, error) {
+	issuer, err := tx.GetInvoiceIssuer(ctx, tenantID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvoiceIssuerNotSet
+	}
+
+	return issuer, err
+}
+
+// renderInvoice は発行日をlocの日付で記載してPDFにする
+func renderInvoice(font *pdf.Font, inv, original *model.Invoice, loc *time.Location) ([]byte, error) {
+	inv.IssuedAt = inv.IssuedAt.In(loc)
+
+	var buf bytes.Buffer
+	if err := invoice.Render(&buf, font, inv, original); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
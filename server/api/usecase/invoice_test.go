@@ -0,0 +1,174 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/config"
+	"gorm.io/gorm"
+)
+
+// invoiceRepository は発注・返品に加えて請求書と種別ごとの連番をメモリに持つリポジトリ
+type invoiceRepository struct {
+	*paymentRepository
+
+	invoices []*model.Invoice
+	numbers  map[model.InvoiceType]int
+}
+
+func (r *invoiceRepository) Transaction(ctx context.Context, fn func(tx repository.RepositoryInterface) error) error {
+	return fn(r)
+}
+
+func (r *invoiceRepository) GetInvoiceIssuer(ctx context.Context, tenantID string) (*model.InvoiceIssuer, error) {
+	return &model.InvoiceIssuer{TenantID: tenantID, RegistrationNumber: "T1234567890123", Name: "テスト商店"}, nil
+}
+
+func (r *invoiceRepository) NextInvoiceNumber(ctx context.Context, tenantID string, invoiceType model.InvoiceType) (int, error) {
+	r.numbers[invoiceType]++
+
+	return r.numbers[invoiceType], nil
+}
+
+func (r *invoiceRepository) GetInvoice(ctx context.Context, tenantID string, invoiceID int) (*model.Invoice, error) {
+	for _, inv := range r.invoices {
+		if inv.ID == invoiceID {
+			copied := *inv
+
+			return &copied, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *invoiceRepository) GetActiveInvoice(ctx context.Context, tenantID string, orderID int) (*model.Invoice, error) {
+	credited := map[int]bool{}
+	for _, inv := range r.invoices {
+		if inv.OriginalInvoiceID != nil {
+			credited[*inv.OriginalInvoiceID] = true
+		}
+	}
+	for i := len(r.invoices) - 1; i >= 0; i-- {
+		inv := r.invoices[i]
+		if inv.OrderID == orderID && inv.Type == model.InvoiceTypeInvoice && !credited[inv.ID] {
+			copied := *inv
+
+			return &copied, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *invoiceRepository) CreateInvoice(ctx context.Context, inv model.Invoice) (*model.Invoice, error) {
+	inv.ID = len(r.invoices) + 1
+	r.invoices = append(r.invoices, &inv)
+	copied := inv
+
+	return &copied, nil
+}
+
+func (r *invoiceRepository) GetStock(ctx context.Context, storeID, stockID string) (*model.Stock, error) {
+	return &model.Stock{Name: "ハンドバッグ", TaxRate: 10}, nil
+}
+
+func (r *invoiceRepository) GetCustomer(ctx context.Context, tenantID, customerID string) (*model.Customer, error) {
+	return &model.Customer{ID: customerID, TenantID: tenantID, Name: "山田太郎"}, nil
+}
+
+func (r *invoiceRepository) GetOrderReturns(ctx context.Context, tenantID string, status *model.ReturnStatus, orderID *int, limit, offset int) ([]*model.OrderReturn, error) {
+	returns := []*model.OrderReturn{}
+	for _, ret := range r.returns {
+		if (status == nil || ret.Status == *status) && (orderID == nil || ret.OrderID == *orderID) {
+			returns = append(returns, ret)
+		}
+	}
+
+	return returns, nil
+}
+
+// lineAmounts は明細の説明と金額を並べる
+func lineAmounts(inv *model.Invoice) []string {
+	amounts := make([]string, 0, len(inv.Lines))
+	for _, line := range inv.Lines {
+		amounts = append(amounts, fmt.Sprintf("%s:%d", line.Description, line.Amount))
+	}
+
+	return amounts
+}
+
+// TestCreateCreditNote は請求書を返還請求書で取り消し、返品を完了した後に発行し直す
+// 発注は2点で10,000円から1,000円を値引きし、1点を4,500円で返品する
+func TestCreateCreditNote(t *testing.T) {
+	ctx := context.Background()
+	order := testOrder()
+	order.DiscountAmount = 1000
+	order.Discounts = []*model.OrderDiscount{{Name: "秋のセール", Amount: 1000}}
+	r := &invoiceRepository{paymentRepository: newPaymentRepository(order), numbers: map[model.InvoiceType]int{}}
+	r.stocks[1] = 0
+	u := usecase.NewUsecase(&usecase.UsecaseBundle{
+		Config:     &config.Config{Env: config.Local, Report: config.Report{ReportTimeZone: "Asia/Tokyo"}},
+		Repository: r,
+	})
+
+	if _, err := u.GetOrderInvoicePDF(ctx, testTenantID, 1); err != nil {
+		t.Fatalf("GetOrderInvoicePDF() error = %v", err)
+	}
+	// 有効な請求書がある間は発行し直さない
+	if _, err := u.GetOrderInvoicePDF(ctx, testTenantID, 1); err != nil {
+		t.Fatalf("GetOrderInvoicePDF() error = %v", err)
+	}
+	if len(r.invoices) != 1 {
+		t.Fatalf("invoices = %d, want 1", len(r.invoices))
+	}
+	original := r.invoices[0]
+	if original.Number != "INV-00000001" || original.Total != 9000 {
+		t.Errorf("invoice = %s total %d, want INV-00000001 total 9000", original.Number, original.Total)
+	}
+
+	r.returns = append(r.returns, &model.OrderReturn{ID: 1, OrderID: 1, Quantity: 1, Status: model.ReturnCompleted, Amount: 4500})
+
+	credit, err := u.CreateCreditNote(ctx, request.CreateCreditNoteRequest{TenantID: testTenantID, InvoiceID: original.ID, Reason: "返品"})
+	if err != nil {
+		t.Fatalf("CreateCreditNote() error = %v", err)
+	}
+	if credit.Number != "CN-00000001" || credit.Type != model.InvoiceTypeCreditNote || credit.OriginalInvoiceID == nil || *credit.OriginalInvoiceID != original.ID {
+		t.Errorf("credit note = %+v", credit)
+	}
+	if credit.Total != -original.Total || credit.Tax != -original.Tax {
+		t.Errorf("credit note total %d tax %d, want %d and %d", credit.Total, credit.Tax, -original.Total, -original.Tax)
+	}
+	for i, line := range credit.Lines {
+		if line.Amount != -original.Lines[i].Amount || line.Description != original.Lines[i].Description {
+			t.Errorf("credit note line %d = %+v, want the negated %+v", i, line, original.Lines[i])
+		}
+	}
+
+	if _, err := u.CreateCreditNote(ctx, request.CreateCreditNoteRequest{TenantID: testTenantID, InvoiceID: original.ID}); !errors.Is(err, usecase.ErrInvoiceCredited) {
+		t.Errorf("CreateCreditNote() of a credited invoice error = %v, want %v", err, usecase.ErrInvoiceCredited)
+	}
+	if _, err := u.CreateCreditNote(ctx, request.CreateCreditNoteRequest{TenantID: testTenantID, InvoiceID: credit.ID}); !errors.Is(err, usecase.ErrInvoiceCredited) {
+		t.Errorf("CreateCreditNote() of a credit note error = %v, want %v", err, usecase.ErrInvoiceCredited)
+	}
+
+	// 発行し直す請求書は返品で返した額を差し引く
+	if _, err := u.GetOrderInvoicePDF(ctx, testTenantID, 1); err != nil {
+		t.Fatalf("GetOrderInvoicePDF() error = %v", err)
+	}
+	reissued := r.invoices[len(r.invoices)-1]
+	want := []string{"ハンドバッグ:10000", "値引き (秋のセール):-1000", "返品:-4500"}
+	if got := lineAmounts(reissued); !slices.Equal(got, want) {
+		t.Errorf("reissued lines = %v, want %v", got, want)
+	}
+	if reissued.Number != "INV-00000002" || reissued.Total != 4500 {
+		t.Errorf("reissued invoice = %s total %d, want INV-00000002 total 4500", reissued.Number, reissued.Total)
+	}
+}
@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/valuation"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"gorm.io/gorm"
+)
+
+// markdownBatchSize は自動値下げの対象の在庫を1回に読み込む件数
+const markdownBatchSize = 500
+
+// errMarkdownPriceChanged は試算後に販売価格が変更されたため自動値下げを見送る場合のエラー
+var errMarkdownPriceChanged = errors.New("stock price changed after evaluation")
+
+func (u *usecase) GetMarkdownRules(ctx context.Context, tenantID string) (*model.MarkdownRuleSet, error) {
+	setting, err := u.Repository.GetTenantSetting(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := u.Repository.GetMarkdownRules(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.MarkdownRuleSet{
+		TenantID:    tenantID,
+		FloorAtCost: setting.MarkdownFloorAtCost,
+		Rules:       rules,
+	}, nil
+}
+
+// UpdateMarkdownRules はテナントの自動値下げの段階を指定した内容で置き換える
+// 経過日数が大きい段階ほど値下げ率を大きくする必要がある
+func (u *usecase) UpdateMarkdownRules(ctx context.Context, input request.UpdateMarkdownRulesRequest) (*model.MarkdownRuleSet, error) {
+	rules := make([]*model.MarkdownRule, 0, len(input.Rules))
+	for _, r := range input.Rules {
+		rules = append(rules, &model.MarkdownRule{
+			TenantID:        input.TenantID,
+			AgeDays:         r.AgeDays,
+			DiscountPercent: r.DiscountPercent,
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].AgeDays < rules[j].AgeDays
+	})
+	for i := 1; i < len(rules); i++ {
+		if rules[i].AgeDays == rules[i-1].AgeDays {
+			return nil, fmt.Errorf("%w: age_days %d is duplicated", ErrInvalidMarkdownRules, rules[i].AgeDays)
+		}
+		if rules[i].DiscountPercent <= rules[i-1].DiscountPercent {
+			return nil, fmt.Errorf("%w: discount_percent must increase with age_days", ErrInvalidMarkdownRules)
+		}
+	}
+
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		if input.FloorAtCost != nil {
+			setting, err := tx.GetTenantSetting(ctx, input.TenantID)
+			if err != nil {
+				return err
+			}
+			setting.MarkdownFloorAtCost = *input.FloorAtCost
+			if _, err := tx.SaveTenantSetting(ctx, *setting); err != nil {
+				return err
+			}
+		}
+
+		return tx.ReplaceMarkdownRules(ctx, input.TenantID, rules)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.GetMarkdownRules(ctx, input.TenantID)
+}
+
+// PreviewMarkdowns は自動値下げを実行した場合に変更される販売価格を試算する。販売価格は変更しない
+func (u *usecase) PreviewMarkdowns(ctx context.Context, tenantID string) (*model.MarkdownPlan, error) {
+	return u.planMarkdowns(ctx, tenantID, time.Now(), nil)
+}
+
+// ApplyMarkdowns は自動値下げの段階に従って在庫の販売価格を下げ、変更した在庫の件数を返す
+// テナントの指定がない場合は段階を登録しているすべてのテナントが対象
+func (u *usecase) ApplyMarkdowns(ctx context.Context, tenantID *string) (int, error) {
+	var tenantIDs []string
+	if tenantID != nil {
+		tenantIDs = []string{*tenantID}
+	} else {
+		var err error
+		tenantIDs, err = u.Repository.GetMarkdownTenantIDs(ctx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	applied := 0
+	for _, id := range tenantIDs {
+		_, err := u.planMarkdowns(ctx, id, time.Now(), func(item *model.MarkdownItem) error {
+			err := u.applyMarkdown(ctx, item)
+			// 試算後に価格が変更された、または削除された在庫は飛ばす
+			if errors.Is(err, errMarkdownPriceChanged) || errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			applied++
+
+			return nil
+		})
+		if err != nil {
+			return applied, err
+		}
+	}
+
+	return applied, nil
+}
+
+// ProcessMarkdowns は定期実行用に全テナントの自動値下げを実行する
+func (u *usecase) ProcessMarkdowns(ctx context.Context) error {
+	_, err := u.ApplyMarkdowns(ctx, nil)
+
+	return err
+}
+
+// planMarkdowns は在庫ごとに到達した最も大きい段階を求め、基準価格から値下げした価格を試算する
+// 適用済みの段階以下の段階は再度適用せず、現在の価格より下がらない場合は対象としない
+// fnを指定した場合は値下げする在庫ごとに呼び出す
+func (u *usecase) planMarkdowns(ctx context.Context, tenantID string, now time.Time, fn func(*model.MarkdownItem) error) (*model.MarkdownPlan, error) {
+	setting, err := u.Repository.GetTenantSetting(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &model.MarkdownPlan{
+		TenantID:            tenantID,
+		EvaluatedAt:         now,
+		FloorAtCost:         setting.MarkdownFloorAtCost,
+		Items:               []*model.MarkdownItem{},
+		CostUnknownStockIDs: []int{},
+	}
+
+	rules, err := u.Repository.GetMarkdownRules(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return plan, nil
+	}
+
+	costs, err := u.stockUnitCosts(ctx, tenantID, setting.ValuationMethod, now)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBefore := now.AddDate(0, 0, -rules[0].AgeDays)
+	afterID := 0
+	for {
+		candidates, err := u.Repository.GetMarkdownCandidates(ctx, tenantID, createdBefore, afterID, markdownBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range candidates {
+			afterID = c.StockID
+
+			ageDays := int(now.Sub(c.CreatedAt) / (24 * time.Hour))
+			var rule *model.MarkdownRule
+			for _, r := range rules {
+				if r.AgeDays <= ageDays {
+					rule = r
+				}
+			}
+			if rule == nil || (c.MarkdownAgeDays != nil && *c.MarkdownAgeDays >= rule.AgeDays) {
+				continue
+			}
+
+			item := &model.MarkdownItem{
+				StockID:         c.StockID,
+				StoreID:         c.StoreID,
+				Name:            c.Name,
+				AgeDays:         ageDays,
+				RuleAgeDays:     rule.AgeDays,
+				DiscountPercent: rule.DiscountPercent,
+				BasePrice:       c.BasePrice,
+				CurrentPrice:    c.Price,
+				NewPrice:        c.BasePrice * (100 - rule.DiscountPercent) / 100,
+				UnitCost:        costs[c.StockID],
+			}
+			if setting.MarkdownFloorAtCost {
+				if item.UnitCost == nil {
+					plan.CostUnknownStockIDs = append(plan.CostUnknownStockIDs, c.StockID)
+					continue
+				}
+				if item.NewPrice < *item.UnitCost {
+					item.NewPrice = *item.UnitCost
+					item.FloorApplied = true
+				}
+			}
+			if item.NewPrice >= item.CurrentPrice {
+				continue
+			}
+
+			plan.Items = append(plan.Items, item)
+			if fn != nil {
+				if err := fn(item); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if len(candidates) < markdownBatchSize {
+			break
+		}
+	}
+
+	return plan, nil
+}
+
+// stockUnitCosts はテナントの在庫ごとの取得原価の単価を評価方法に従って求める。取得原価が不明な在庫は含めない
+func (u *usecase) stockUnitCosts(ctx context.Context, tenantID string, method model.ValuationMethod, asOf time.Time) (map[int]*int, error) {
+	costs := map[int]*int{}
+
+	var current *model.StockLedgerEntry
+	var calc *valuation.Calculator
+	flush := func() {
+		if current == nil {
+			return
+		}
+		result := calc.Result()
+		if result.Quantity-result.UnknownCostQuantity != 0 {
+			costs[current.StockID] = &result.UnitCost
+		}
+	}
+
+	err := u.Repository.EachStockLedgerEntry(ctx, tenantID, asOf, func(entry *model.StockLedgerEntry) error {
+		if current == nil || current.StockID != entry.StockID {
+			flush()
+			current = entry
+			calc = valuation.NewCalculator(method)
+		}
+		calc.Add(&entry.StockMovement)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	flush()
+
+	return costs, nil
+}
+
+// applyMarkdown は試算した販売価格を在庫に反映し、価格の履歴に段階とともに記録する
+// 試算後に販売価格が変更されていた場合は反映しない
+func (u *usecase) applyMarkdown(ctx context.Context, item *model.MarkdownItem) error {
+	return u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		previous, err := tx.SetStockPrice(ctx, item.StockID, item.NewPrice)
+		if err != nil {
+			return err
+		}
+		if previous != item.CurrentPrice {
+			return errMarkdownPriceChanged
+		}
+
+		now := time.Now()
+		ageDays := item.RuleAgeDays
+		_, err = tx.CreateStockPriceChange(ctx, model.StockPriceChange{
+			StockID:         item.StockID,
+			Price:           item.NewPrice,
+			PreviousPrice:   &previous,
+			EffectiveFrom:   now,
+			Status:          model.PriceChangeApplied,
+			AppliedAt:       &now,
+			Reason:          fmt.Sprintf("markdown after %d days (-%d%%)", item.RuleAgeDays, item.DiscountPercent),
+			MarkdownAgeDays: &ageDays,
+		})
+
+		return err
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
 )
 
@@ -39,16 +40,12 @@ func (u *usecase) GetOrder(ctx context.Context, tenantID string, orderID int) (*
 }
 
 func (u *usecase) CreateOrder(ctx context.Context, order request.CreateOrderRequest) (*int, error) {
-	var orderStatus model.OrderStatus
-	status := orderStatus.Status(order.Status)
+	var orderID *int
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		var err error
+		orderID, err = u.createOrder(ctx, tx, order)
 
-	orderID, err := u.Repository.CreateOrder(ctx, model.Order{
-		TotalAmount:  order.TotalAmount,
-		Quantity:     order.Quantity,
-		DeliveryDate: order.DeliveryDate,
-		Status:       status,
-		StockID:      order.StockID,
-		CustomerID:   order.CustomerID,
+		return err
 	})
 	if err != nil {
 		return nil, err
@@ -61,6 +58,9 @@ func (u *usecase) CreateBulkOrder(ctx context.Context, orders []request.CreateOr
 	if len(orders) == 0 {
 		return nil, nil
 	}
+	if err := u.checkBulkSize(len(orders)); err != nil {
+		return nil, err
+	}
 
 	var orderModels []model.Order
 	for _, order := range orders {
@@ -69,6 +69,7 @@ func (u *usecase) CreateBulkOrder(ctx context.Context, orders []request.CreateOr
 
 		orderModels = append(orderModels, model.Order{
 			TotalAmount:  order.TotalAmount,
+			PointsUsed:   order.PointsUsed,
 			Quantity:     order.Quantity,
 			DeliveryDate: order.DeliveryDate,
 			Status:       status,
@@ -77,7 +78,46 @@ func (u *usecase) CreateBulkOrder(ctx context.Context, orders []request.CreateOr
 		})
 	}
 
-	orderIDs, err := u.Repository.CreateBulkOrder(ctx, orderModels)
+	var orderIDs []*int
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		var err error
+		evaluator := newDiscountEvaluator(tx)
+		discounts := make([][]*model.OrderDiscount, len(orderModels))
+		for i := range orderModels {
+			// 別のテナントの在庫に対して発注やキャンペーンの適用ができないよう、先に在庫の所属を確かめる
+			if _, err := tx.GetStockOwner(ctx, orders[i].TenantID, orderModels[i].StockID); err != nil {
+				return err
+			}
+			if discounts[i], err = evaluator.apply(ctx, &orderModels[i], orders[i].CouponCode); err != nil {
+				return err
+			}
+			if orderModels[i].DiscountAmount+orderModels[i].PointsUsed > orderModels[i].TotalAmount {
+				return ErrPointsExceedAmount
+			}
+		}
+
+		orderIDs, err = tx.CreateBulkOrder(ctx, orderModels)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int, 0, len(orderModels))
+		for i := range orderModels {
+			orderModels[i].ID = *orderIDs[i]
+			if err := recordDiscounts(ctx, tx, orderModels[i].ID, discounts[i]); err != nil {
+				return err
+			}
+			if err := moveStockForOrder(ctx, tx, orders[i].TenantID, nil, &orderModels[i]); err != nil {
+				return err
+			}
+			if err := u.applyOrderPoints(ctx, tx, orders[i].TenantID, nil, &orderModels[i]); err != nil {
+				return err
+			}
+			ids = append(ids, orderModels[i].ID)
+		}
+
+		return u.addOrdersToDailySales(ctx, tx, 1, ids...)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -85,18 +125,125 @@ func (u *usecase) CreateBulkOrder(ctx context.Context, orders []request.CreateOr
 	return orderIDs, nil
 }
 
+// CreateBulkOrderItems は発注を一括作成し、1件ごとの結果を返す
+func (u *usecase) CreateBulkOrderItems(ctx context.Context, input request.CreateBulkOrderItemsRequest) (*model.BulkResult, error) {
+	return u.createBulkItems(ctx, len(input.Orders), input.Errors, input.Atomic, func(tx repository.RepositoryInterface, i int) (*int, error) {
+		return u.createOrder(ctx, tx, input.Orders[i])
+	})
+}
+
 func (u *usecase) UpdateOrder(ctx context.Context, order request.UpdateOrderRequest) (*model.Order, error) {
-	orderModel, err := u.Repository.GetOrder(ctx, order.TenantID, order.ID)
+	var updatedOrder *model.Order
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		if err := tx.LockOrder(ctx, order.ID); err != nil {
+			return err
+		}
+
+		orderModel, err := tx.GetOrder(ctx, order.TenantID, order.ID)
+		if err != nil {
+			return err
+		}
+		before := *orderModel
+
+		// 更新前の内容を日次集計から差し引き、更新後の内容を加算する
+		if err := u.addOrdersToDailySales(ctx, tx, -1, orderModel.ID); err != nil {
+			return err
+		}
+
+		// 値引き額は作成時の金額で判定しているため、値引きを適用した発注の金額は変更できない
+		if orderModel.DiscountAmount > 0 && order.TotalAmount != orderModel.TotalAmount {
+			return ErrOrderHasDiscounts
+		}
+		if orderModel.DiscountAmount+orderModel.PointsUsed > order.TotalAmount {
+			return ErrPointsExceedAmount
+		}
+
+		// 返品の戻し入れと在庫の消費が二重にならないよう、返品を受け付けた発注の状態・数量は変更できない
+		var orderStatus model.OrderStatus
+		status := orderStatus.Status(order.Status)
+		if status != orderModel.Status || order.Quantity != orderModel.Quantity {
+			returned, err := tx.CountReturnedQuantity(ctx, orderModel.ID)
+			if err != nil {
+				return err
+			}
+			if returned > 0 {
+				return ErrOrderHasReturns
+			}
+		}
+
+		orderModel.TotalAmount = order.TotalAmount
+		orderModel.Quantity = order.Quantity
+		orderModel.DeliveryDate = order.DeliveryDate
+
+		orderModel.Status = status
+
+		updatedOrder, err = tx.UpdateOrder(ctx, *orderModel)
+		if err != nil {
+			return err
+		}
+
+		if err := moveStockForOrder(ctx, tx, order.TenantID, &before, updatedOrder); err != nil {
+			return err
+		}
+		if err := u.applyOrderPoints(ctx, tx, order.TenantID, &before, updatedOrder); err != nil {
+			return err
+		}
+
+		return u.addOrdersToDailySales(ctx, tx, 1, updatedOrder.ID)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	orderModel.TotalAmount = order.TotalAmount
-	orderModel.Quantity = order.Quantity
-	orderModel.DeliveryDate = order.DeliveryDate
+	return updatedOrder, nil
+}
+
+// createOrder は発注にキャンペーンを適用して作成し、在庫の出庫・ポイント・日次集計に反映する
+func (u *usecase) createOrder(ctx context.Context, tx repository.RepositoryInterface, order request.CreateOrderRequest) (*int, error) {
+	// 別のテナントの在庫に対して発注やキャンペーンの適用ができないよう、先に在庫の所属を確かめる
+	if _, err := tx.GetStockOwner(ctx, order.TenantID, order.StockID); err != nil {
+		return nil, err
+	}
 
 	var orderStatus model.OrderStatus
-	orderModel.Status = orderStatus.Status(order.Status)
+	orderModel := model.Order{
+		TotalAmount:  order.TotalAmount,
+		PointsUsed:   order.PointsUsed,
+		Quantity:     order.Quantity,
+		DeliveryDate: order.DeliveryDate,
+		Status:       orderStatus.Status(order.Status),
+		StockID:      order.StockID,
+		CustomerID:   order.CustomerID,
+	}
+
+	discounts, err := newDiscountEvaluator(tx).apply(ctx, &orderModel, order.CouponCode)
+	if err != nil {
+		return nil, err
+	}
+	if orderModel.DiscountAmount+orderModel.PointsUsed > orderModel.TotalAmount {
+		return nil, ErrPointsExceedAmount
+	}
+
+	orderID, err := tx.CreateOrder(ctx, orderModel)
+	if err != nil {
+		return nil, err
+	}
+	orderModel.ID = *orderID
 
-	return u.Repository.UpdateOrder(ctx, *orderModel)
+	if err := recordDiscounts(ctx, tx, orderModel.ID, discounts); err != nil {
+		return nil, err
+	}
+
+	if err := moveStockForOrder(ctx, tx, order.TenantID, nil, &orderModel); err != nil {
+		return nil, err
+	}
+	if err := u.applyOrderPoints(ctx, tx, order.TenantID, nil, &orderModel); err != nil {
+		return nil, err
+	}
+
+	if err := u.addOrdersToDailySales(ctx, tx, 1, *orderID); err != nil {
+		return nil, err
+	}
+
+	return orderID, nil
 }
@@ -0,0 +1,378 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+)
+
+func (u *usecase) GetOrderReturns(ctx context.Context, input request.GetOrderReturnsRequest) ([]*model.OrderReturn, error) {
+	var validLimit, validOffset int
+	if input.Limit == nil || *input.Limit > 50000 {
+		validLimit = 50000
+	} else {
+		validLimit = *input.Limit
+	}
+
+	if input.Offset == nil {
+		validOffset = 0
+	} else {
+		validOffset = *input.Offset
+	}
+
+	var status *model.ReturnStatus
+	if input.Status != nil {
+		s := model.ReturnStatus(*input.Status)
+		status = &s
+	}
+
+	return u.Repository.GetOrderReturns(ctx, input.TenantID, status, input.OrderID, validLimit, validOffset)
+}
+
+func (u *usecase) GetOrderReturn(ctx context.Context, tenantID string, returnID int) (*model.OrderReturn, error) {
+	return u.Repository.GetOrderReturn(ctx, tenantID, returnID)
+}
+
+// CreateOrderReturn は納品済みの発注の返品を受け付ける。返品不可としたものを除き、発注の数量を超えて返品できない
+func (u *usecase) CreateOrderReturn(ctx context.Context, input request.CreateOrderReturnRequest) (*model.OrderReturn, error) {
+	var created *model.OrderReturn
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		if err := tx.LockOrder(ctx, input.OrderID); err != nil {
+			return err
+		}
+		order, err := tx.GetOrder(ctx, input.TenantID, input.OrderID)
+		if err != nil {
+			return err
+		}
+		if order.Status != model.StatusDelivered {
+			return ErrOrderNotDelivered
+		}
+
+		returned, err := tx.CountReturnedQuantity(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		if returned+input.Quantity > order.Quantity {
+			return fmt.Errorf("%w: %d of %d already returned", ErrReturnExceedsQuantity, returned, order.Quantity)
+		}
+
+		created, err = tx.CreateOrderReturn(ctx, model.OrderReturn{
+			TenantID: input.TenantID,
+			OrderID:  order.ID,
+			StockID:  order.StockID,
+			Quantity: input.Quantity,
+			Reason:   input.Reason,
+			Status:   model.ReturnRequested,
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// InspectOrderReturn は返品の検品結果を記録する。完了前であれば検品し直せる
+func (u *usecase) InspectOrderReturn(ctx context.Context, input request.InspectOrderReturnRequest) (*model.OrderReturn, error) {
+	var updated *model.OrderReturn
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		ret, err := tx.LockOrderReturn(ctx, input.TenantID, input.ReturnID)
+		if err != nil {
+			return err
+		}
+		if ret.Status != model.ReturnRequested && ret.Status != model.ReturnInspected {
+			return ErrReturnStatus
+		}
+
+		now := time.Now()
+		condition := model.ReturnCondition(input.Condition)
+		ret.Status = model.ReturnInspected
+		ret.Condition = &condition
+		ret.Note = input.Note
+		ret.InspectedAt = &now
+
+		updated, err = tx.UpdateOrderReturn(ctx, *ret)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RejectOrderReturn は完了前の返品を返品不可とする
+func (u *usecase) RejectOrderReturn(ctx context.Context, input request.RejectOrderReturnRequest) (*model.OrderReturn, error) {
+	var updated *model.OrderReturn
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		ret, err := tx.LockOrderReturn(ctx, input.TenantID, input.ReturnID)
+		if err != nil {
+			return err
+		}
+		if ret.Status != model.ReturnRequested && ret.Status != model.ReturnInspected {
+			return ErrReturnStatus
+		}
+
+		now := time.Now()
+		ret.Status = model.ReturnRejected
+		if input.Note != "" {
+			ret.Note = input.Note
+		}
+		ret.ClosedAt = &now
+
+		updated, err = tx.UpdateOrderReturn(ctx, *ret)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// CompleteOrderReturn は検品済みの返品を戻し入れ、返金・交換・ポイント返還のいずれかで完了する
+// 戻し入れは返品の入出庫として記録し、状態ランクに応じて元とは別の在庫に戻せる
+// 納品で付与したポイントは返品した数量の按分額に相当する分を取り消す
+// 返金・ポイントで返還した額は発注日の売上の日次集計から差し引く
+func (u *usecase) CompleteOrderReturn(ctx context.Context, input request.CompleteOrderReturnRequest) (*model.OrderReturn, error) {
+	resolution := model.ReturnResolution(input.Resolution)
+	if resolution == model.ResolutionExchange && input.ExchangeOrderID == nil {
+		return nil, ErrExchangeOrderRequired
+	}
+
+	var updated *model.OrderReturn
+	var refunds []*model.Refund
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		ret, err := tx.LockOrderReturn(ctx, input.TenantID, input.ReturnID)
+		if err != nil {
+			return err
+		}
+		if ret.Status != model.ReturnInspected {
+			return ErrReturnStatus
+		}
+
+		if err := tx.LockOrder(ctx, ret.OrderID); err != nil {
+			return err
+		}
+		order, err := tx.GetOrder(ctx, input.TenantID, ret.OrderID)
+		if err != nil {
+			return err
+		}
+
+		restock := *ret.Condition != model.ConditionJunk
+		if input.Restock != nil {
+			restock = *input.Restock
+		}
+		if restock {
+			stockID := ret.StockID
+			if input.RestockStockID != nil {
+				stockID = *input.RestockStockID
+			}
+			if err := restockReturn(ctx, tx, input.TenantID, ret, stockID, input.UnitCost); err != nil {
+				return err
+			}
+			ret.RestockStockID = &stockID
+		}
+
+		// 返金・ポイントでの返還は、返品した数量の按分額と、これまでの返品で返した額を除いた残りのうち小さい方までとする
+		returned, err := tx.SumReturnedAmount(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		limit := max(min(order.AmountDue()*ret.Quantity/order.Quantity, order.AmountDue()-returned), 0)
+		amount := limit
+		if input.Amount != nil {
+			amount = *input.Amount
+		}
+		if resolution != model.ResolutionExchange && amount > limit {
+			return fmt.Errorf("%w: max %d", ErrReturnAmountExceeded, limit)
+		}
+
+		if err := revokeReturnPoints(ctx, tx, input.TenantID, order, order.AmountDue()*ret.Quantity/order.Quantity); err != nil {
+			return err
+		}
+
+		switch resolution {
+		case model.ResolutionRefund:
+			if refunds, err = u.refundReturn(ctx, tx, order, ret, amount); err != nil {
+				return err
+			}
+		case model.ResolutionStoreCredit:
+			if err := creditReturn(ctx, tx, input.TenantID, order, amount); err != nil {
+				return err
+			}
+		case model.ResolutionExchange:
+			// 同じ発注を複数の返品の交換品にしないよう、交換品の発注をロックしてから確かめる
+			if err := tx.LockOrder(ctx, *input.ExchangeOrderID); err != nil {
+				return err
+			}
+			exchange, err := tx.GetOrder(ctx, input.TenantID, *input.ExchangeOrderID)
+			if err != nil {
+				return err
+			}
+			if exchange.ID == order.ID || exchange.CustomerID != order.CustomerID || exchange.CreatedAt.Before(ret.CreatedAt) {
+				return ErrInvalidExchangeOrder
+			}
+			linked, err := tx.CountExchangeReturns(ctx, exchange.ID)
+			if err != nil {
+				return err
+			}
+			if linked > 0 {
+				return ErrInvalidExchangeOrder
+			}
+			ret.ExchangeOrderID = &exchange.ID
+			amount = 0
+		}
+
+		// 返品を完了する前の発注の売上を日次集計から差し引き、返した額を除いた売上を加算する
+		if err := u.addOrdersToDailySales(ctx, tx, -1, order.ID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		ret.Status = model.ReturnCompleted
+		ret.Resolution = &resolution
+		ret.Amount = amount
+		if input.Note != "" {
+			ret.Note = input.Note
+		}
+		ret.ClosedAt = &now
+
+		updated, err = tx.UpdateOrderReturn(ctx, *ret)
+		if err != nil {
+			return err
+		}
+
+		return u.addOrdersToDailySales(ctx, tx, 1, order.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 返品は完了したまま、受け付けられなかった返金はFAILEDとして残り、支払いから個別に返金し直せる
+	if err := u.settleRefunds(ctx, refunds...); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// restockReturn は返品をテナントの在庫に戻し入れ、返品の入出庫として記録する
+func restockReturn(ctx context.Context, tx repository.RepositoryInterface, tenantID string, ret *model.OrderReturn, stockID int, unitCost *int) error {
+	if _, err := tx.GetStockOwner(ctx, tenantID, stockID); err != nil {
+		return fmt.Errorf("stock %d: %w", stockID, err)
+	}
+
+	if err := tx.AdjustStockQuantity(ctx, stockID, ret.Quantity); err != nil {
+		return err
+	}
+
+	_, err := tx.CreateStockMovement(ctx, model.StockMovement{
+		StockID:    stockID,
+		Type:       model.MovementReturn,
+		Quantity:   ret.Quantity,
+		UnitCost:   unitCost,
+		OrderID:    &ret.OrderID,
+		Reason:     fmt.Sprintf("return %d (condition %s)", ret.ID, *ret.Condition),
+		OccurredAt: time.Now(),
+	})
+
+	return err
+}
+
+// refundReturn は返品の返金額を新しい支払いから順に、支払った決済手段で返金し、記録した返金を返す
+func (u *usecase) refundReturn(ctx context.Context, tx repository.RepositoryInterface, order *model.Order, ret *model.OrderReturn, amount int) ([]*model.Refund, error) {
+	payments, err := tx.GetPayments(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	refunds, err := tx.GetRefunds(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []*model.Refund
+	remaining := amount
+	for i := len(payments) - 1; i >= 0 && remaining > 0; i-- {
+		refundable := payments[i].RefundableAmount(refunds)
+		if refundable <= 0 {
+			continue
+		}
+		refundAmount := min(refundable, remaining)
+		refund, err := u.refundPayment(ctx, tx, order, request.CreateRefundRequest{
+			TenantID:  ret.TenantID,
+			OrderID:   order.ID,
+			PaymentID: payments[i].ID,
+			Amount:    &refundAmount,
+			Cause:     string(model.RefundReturn),
+			Reason:    ret.Reason,
+			ReturnID:  &ret.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, refund)
+		remaining -= refundAmount
+	}
+	if remaining > 0 {
+		return nil, fmt.Errorf("%w: %d of %d cannot be refunded", ErrRefundExceedsPayment, remaining, amount)
+	}
+
+	return created, nil
+}
+
+// revokeReturnPoints は発注の納品で付与したポイントのうち、返品した額に相当する分を取り消す
+// 付与と同じく額をテナントの付与単位で割ったポイントを、取り消していない付与の範囲で取り消す
+func revokeReturnPoints(ctx context.Context, tx repository.RepositoryInterface, tenantID string, order *model.Order, value int) error {
+	customer, err := tx.LockPointAccount(ctx, tenantID, order.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	earned, _, err := tx.GetOrderPointTotals(ctx, order.ID)
+	if err != nil || earned <= 0 {
+		return err
+	}
+
+	setting, err := tx.GetTenantSetting(ctx, customer.TenantID)
+	if err != nil {
+		return err
+	}
+	if setting.PointEarnUnit <= 0 {
+		return nil
+	}
+
+	points := min(value/setting.PointEarnUnit, earned)
+	if points <= 0 {
+		return nil
+	}
+
+	return revokePoints(ctx, tx, customer, &order.ID, points, time.Now())
+}
+
+// creditReturn は返品の額をポイントとして発注の顧客に返還する
+func creditReturn(ctx context.Context, tx repository.RepositoryInterface, tenantID string, order *model.Order, points int) error {
+	if points <= 0 {
+		return nil
+	}
+
+	customer, err := tx.LockPointAccount(ctx, tenantID, order.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	setting, err := tx.GetTenantSetting(ctx, customer.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return grantPoints(ctx, tx, customer, setting, &order.ID, model.PointReturnCredit, points, time.Now())
+}
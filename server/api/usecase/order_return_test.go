@@ -0,0 +1,272 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"gorm.io/gorm"
+)
+
+func (r *paymentRepository) CountReturnedQuantity(ctx context.Context, orderID int) (int, error) {
+	quantity := 0
+	for _, ret := range r.returns {
+		if ret.OrderID == orderID && ret.Status != model.ReturnRejected {
+			quantity += ret.Quantity
+		}
+	}
+
+	return quantity, nil
+}
+
+func (r *paymentRepository) CreateOrderReturn(ctx context.Context, ret model.OrderReturn) (*model.OrderReturn, error) {
+	ret.ID = len(r.returns) + 1
+	ret.CreatedAt = time.Now()
+	r.returns = append(r.returns, &ret)
+	copied := ret
+
+	return &copied, nil
+}
+
+func (r *paymentRepository) LockOrderReturn(ctx context.Context, tenantID string, returnID int) (*model.OrderReturn, error) {
+	for _, ret := range r.returns {
+		if ret.ID == returnID {
+			copied := *ret
+
+			return &copied, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *paymentRepository) UpdateOrderReturn(ctx context.Context, ret model.OrderReturn) (*model.OrderReturn, error) {
+	for i := range r.returns {
+		if r.returns[i].ID == ret.ID {
+			r.returns[i] = &ret
+		}
+	}
+	copied := ret
+
+	return &copied, nil
+}
+
+func (r *paymentRepository) GetStockOwner(ctx context.Context, tenantID string, stockID int) (*model.StockOwner, error) {
+	if _, ok := r.stocks[stockID]; !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &model.StockOwner{TenantID: tenantID, StoreID: testStoreID, StockID: stockID}, nil
+}
+
+func (r *paymentRepository) AdjustStockQuantity(ctx context.Context, stockID, delta int) error {
+	r.stocks[stockID] += delta
+
+	return nil
+}
+
+func (r *paymentRepository) CreateStockMovement(ctx context.Context, movement model.StockMovement) (*model.StockMovement, error) {
+	r.movements = append(r.movements, movement)
+
+	return &movement, nil
+}
+
+func (r *paymentRepository) LockPointAccount(ctx context.Context, tenantID, customerID string) (*model.Customer, error) {
+	return &model.Customer{ID: customerID, TenantID: tenantID, PointBalance: r.pointBalance}, nil
+}
+
+func (r *paymentRepository) GetTenantSetting(ctx context.Context, tenantID string) (*model.TenantSetting, error) {
+	setting := r.setting
+
+	return &setting, nil
+}
+
+func (r *paymentRepository) GetOrderPointTotals(ctx context.Context, orderID int) (int, int, error) {
+	return r.earned, 0, nil
+}
+
+func (r *paymentRepository) AdjustPointBalance(ctx context.Context, customerID string, delta int) (int, error) {
+	r.pointBalance += delta
+
+	return r.pointBalance, nil
+}
+
+func (r *paymentRepository) ConsumePointLots(ctx context.Context, customerID string, points int) error {
+	return nil
+}
+
+func (r *paymentRepository) CreatePointTransaction(ctx context.Context, transaction model.PointTransaction) (*model.PointTransaction, error) {
+	r.pointTransactions = append(r.pointTransactions, transaction)
+
+	return &transaction, nil
+}
+
+func (r *paymentRepository) ApplyOrdersToDailySales(ctx context.Context, orderIDs []int, sign int, timeZone string) error {
+	return nil
+}
+
+// TestCompleteOrderReturn は返品の受付から完了までを行い、戻し入れ・ポイントの取り消し・返金と発注の支払い状況を確かめる
+// 発注は2点で10,000円をカードで支払い済み、納品で100ポイント (100円につき1ポイント) を付与している
+func TestCompleteOrderReturn(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		quantity   int
+		condition  model.ReturnCondition
+		resolution model.ReturnResolution
+		amount     *int
+		// previous はこれまでにポイントで返還して完了した返品の額
+		previous int
+		wantErr  error
+		// 戻し入れた数量、返金額、ポイント残高 (返還 - 取り消し) の増減
+		wantRestocked int
+		wantRefunded  int
+		wantPoints    int
+		wantStatus    model.PaymentStatus
+		wantAmountDue int
+	}{
+		{
+			name:          "refund of one of two items",
+			quantity:      1,
+			condition:     model.ConditionA,
+			resolution:    model.ResolutionRefund,
+			wantRestocked: 1,
+			wantRefunded:  5000,
+			wantPoints:    -50,
+			wantStatus:    model.PaymentPaid,
+			wantAmountDue: 5000,
+		},
+		{
+			name:          "full return refunds the whole payment",
+			quantity:      2,
+			condition:     model.ConditionB,
+			resolution:    model.ResolutionRefund,
+			wantRestocked: 2,
+			wantRefunded:  10000,
+			wantPoints:    -100,
+			wantStatus:    model.PaymentRefunded,
+			wantAmountDue: 0,
+		},
+		{
+			name:          "store credit is granted as points and leaves the payment",
+			quantity:      1,
+			condition:     model.ConditionC,
+			resolution:    model.ResolutionStoreCredit,
+			wantRestocked: 1,
+			wantPoints:    5000 - 50,
+			wantStatus:    model.PaymentPaid,
+			wantAmountDue: 5000,
+		},
+		{
+			name:          "junk is not restocked",
+			quantity:      1,
+			condition:     model.ConditionJunk,
+			resolution:    model.ResolutionRefund,
+			wantRefunded:  5000,
+			wantPoints:    -50,
+			wantStatus:    model.PaymentPaid,
+			wantAmountDue: 5000,
+		},
+		{
+			name:          "refund is capped at what earlier returns left",
+			quantity:      1,
+			condition:     model.ConditionA,
+			resolution:    model.ResolutionRefund,
+			previous:      8000,
+			wantRestocked: 1,
+			wantRefunded:  2000,
+			wantPoints:    -50,
+			wantStatus:    model.PaymentPaid,
+			wantAmountDue: 0,
+		},
+		{
+			name:       "amount over the share of the returned quantity",
+			quantity:   1,
+			condition:  model.ConditionA,
+			resolution: model.ResolutionRefund,
+			amount:     func() *int { v := 5001; return &v }(),
+			wantErr:    usecase.ErrReturnAmountExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPaymentRepository(testOrder())
+			r.stocks[1] = 0
+			r.earned, r.pointBalance = 100, 100
+			r.setting.PointEarnUnit = 100
+			g := newTestGateway(t, r)
+			u := newPaymentUsecase(r, g)
+
+			if _, err := u.CreatePayment(ctx, request.CreatePaymentRequest{
+				TenantID: testTenantID,
+				OrderID:  1,
+				Method:   "CREDIT_CARD",
+				Amount:   10000,
+				Token:    "tok_visa",
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.previous > 0 {
+				r.returns = append(r.returns, &model.OrderReturn{ID: 100, OrderID: 1, Quantity: 0, Status: model.ReturnCompleted, Amount: tt.previous})
+			}
+
+			created, err := u.CreateOrderReturn(ctx, request.CreateOrderReturnRequest{TenantID: testTenantID, OrderID: 1, Quantity: tt.quantity})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := u.InspectOrderReturn(ctx, request.InspectOrderReturnRequest{TenantID: testTenantID, ReturnID: created.ID, Condition: string(tt.condition)}); err != nil {
+				t.Fatal(err)
+			}
+			completed, err := u.CompleteOrderReturn(ctx, request.CompleteOrderReturnRequest{
+				TenantID:   testTenantID,
+				ReturnID:   created.ID,
+				Resolution: string(tt.resolution),
+				Amount:     tt.amount,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CompleteOrderReturn() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if completed.Status != model.ReturnCompleted {
+				t.Errorf("return status = %s, want %s", completed.Status, model.ReturnCompleted)
+			}
+
+			if got := r.stocks[1]; got != tt.wantRestocked {
+				t.Errorf("restocked = %d, want %d", got, tt.wantRestocked)
+			}
+			if tt.wantRestocked > 0 && (len(r.movements) != 1 || r.movements[0].Type != model.MovementReturn) {
+				t.Errorf("movements = %+v", r.movements)
+			}
+
+			refunded := 0
+			for _, refund := range r.refunds {
+				if refund.Status != model.RefundCompleted || refund.ReturnID == nil || *refund.ReturnID != created.ID {
+					t.Errorf("refund = %+v", refund)
+				}
+				refunded += refund.Amount
+			}
+			if refunded != tt.wantRefunded {
+				t.Errorf("refunded = %d, want %d", refunded, tt.wantRefunded)
+			}
+			if got := r.pointBalance - 100; got != tt.wantPoints {
+				t.Errorf("point balance changed by %d, want %d", got, tt.wantPoints)
+			}
+
+			status, err := u.GetOrderPayments(ctx, testTenantID, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.Status != tt.wantStatus || status.AmountDue != tt.wantAmountDue || status.Balance != 0 {
+				t.Errorf("GetOrderPayments() = status %s, amount due %d, balance %d, want %s, %d, 0",
+					status.Status, status.AmountDue, status.Balance, tt.wantStatus, tt.wantAmountDue)
+			}
+		})
+	}
+}
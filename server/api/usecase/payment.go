@@ -0,0 +1,427 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/payment"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"gorm.io/gorm"
+)
+
+// GetOrderPayments は発注の支払いと返金、支払い状況を取得する
+func (u *usecase) GetOrderPayments(ctx context.Context, tenantID string, orderID int) (*model.OrderPayments, error) {
+	order, err := u.Repository.GetOrder(ctx, tenantID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return orderPayments(ctx, u.Repository, order)
+}
+
+// paymentReconcileBatchSize は結果待ちの支払い・返金を1回に読み込む件数
+const paymentReconcileBatchSize = 100
+
+// CreatePayment は発注に支払いを記録する。未払いの残額を超える支払いは受け付けない
+// クレジットカード・QRコード決済はPENDINGで記録し、トランザクションの確定後に決済代行会社で決済する
+// ポイントは顧客の残高から差し引く
+func (u *usecase) CreatePayment(ctx context.Context, input request.CreatePaymentRequest) (*model.Payment, error) {
+	method := model.PaymentMethod(input.Method)
+	if method.ViaGateway() && input.Token == "" {
+		return nil, ErrPaymentTokenRequired
+	}
+
+	var created *model.Payment
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		if err := tx.LockOrder(ctx, input.OrderID); err != nil {
+			return err
+		}
+		order, err := tx.GetOrder(ctx, input.TenantID, input.OrderID)
+		if err != nil {
+			return err
+		}
+		if order.Status == model.StatusCancelled {
+			return ErrOrderCancelled
+		}
+
+		status, err := orderPayments(ctx, tx, order)
+		if err != nil {
+			return err
+		}
+		if input.Amount > status.Balance {
+			return fmt.Errorf("%w: balance is %d", ErrPaymentExceedsBalance, status.Balance)
+		}
+
+		now := time.Now()
+		p := model.Payment{
+			TenantID:  input.TenantID,
+			OrderID:   order.ID,
+			Method:    method,
+			Amount:    input.Amount,
+			Tendered:  input.Tendered,
+			Reference: input.Reference,
+			Note:      input.Note,
+			Status:    model.ChargeCompleted,
+			PaidAt:    now,
+		}
+
+		switch {
+		case method == model.PaymentPoints:
+			if err := payWithPoints(ctx, tx, input.TenantID, order, input.Amount, now); err != nil {
+				return err
+			}
+		case method.ViaGateway():
+			// 決済中も残額に数えて二重の支払いを防ぐ
+			p.Status = model.ChargePending
+		}
+
+		created, err = tx.CreatePayment(ctx, p)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if created.Status == model.ChargePending {
+		return u.settlePayment(ctx, created, input.Token)
+	}
+
+	return created, nil
+}
+
+// settlePayment は記録済みのPENDINGの支払いを決済代行会社で決済し、結果を支払いに反映する
+// 承認されなかった支払いはFAILEDにする。結果が分からない場合はPENDINGのまま返し、ReconcilePaymentsで確かめる
+func (u *usecase) settlePayment(ctx context.Context, p *model.Payment, token string) (*model.Payment, error) {
+	reference, gatewayErr := u.PaymentGateway.Charge(ctx, payment.ChargeRequest{
+		Method:         string(p.Method),
+		Amount:         p.Amount,
+		Token:          token,
+		Description:    fmt.Sprintf("order %d", p.OrderID),
+		IdempotencyKey: chargeKey(p),
+	})
+	switch {
+	case gatewayErr == nil:
+		return u.finishPayment(ctx, p, model.ChargeCompleted, reference)
+	case payment.Rejected(gatewayErr):
+		if _, err := u.finishPayment(ctx, p, model.ChargeFailed, ""); err != nil {
+			return nil, err
+		}
+
+		return nil, gatewayErr
+	default:
+		return p, nil
+	}
+}
+
+// finishPayment は結果待ちの支払いに結果を反映する。他の処理が先に反映していた場合はその結果を返す
+func (u *usecase) finishPayment(ctx context.Context, p *model.Payment, status model.ChargeStatus, reference string) (*model.Payment, error) {
+	settled, err := u.Repository.SettlePayment(ctx, p.ID, status, reference)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return findPayment(ctx, u.Repository, p.OrderID, p.ID)
+	}
+
+	return settled, err
+}
+
+// CreateRefund は支払いの全部または一部を返金する。支払った決済手段で返金する
+// キャンセルによる返金はキャンセルした発注に限る
+func (u *usecase) CreateRefund(ctx context.Context, input request.CreateRefundRequest) (*model.Refund, error) {
+	var created *model.Refund
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		if err := tx.LockOrder(ctx, input.OrderID); err != nil {
+			return err
+		}
+		order, err := tx.GetOrder(ctx, input.TenantID, input.OrderID)
+		if err != nil {
+			return err
+		}
+
+		created, err = u.refundPayment(ctx, tx, order, input)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.settleRefunds(ctx, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// settleRefunds は記録済みのPENDINGの返金を決済代行会社に依頼し、結果を返金に反映する
+// 返金は取り消せないため、記録を確定してから依頼する。受け付けられなかった返金はFAILEDにし、同じ支払いから再度返金できるようにする
+// 結果が分からない返金はPENDINGのまま残し、ReconcilePaymentsで同じ冪等キーで依頼し直す
+func (u *usecase) settleRefunds(ctx context.Context, refunds ...*model.Refund) error {
+	var errs []error
+	for _, refund := range refunds {
+		if refund.Status != model.RefundPending {
+			continue
+		}
+		if err := u.settleRefund(ctx, refund); err != nil {
+			errs = append(errs, fmt.Errorf("refund %d: %w", refund.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (u *usecase) settleRefund(ctx context.Context, refund *model.Refund) error {
+	paid, err := findPayment(ctx, u.Repository, refund.OrderID, refund.PaymentID)
+	if err != nil {
+		return err
+	}
+
+	reference, gatewayErr := u.PaymentGateway.Refund(ctx, payment.RefundRequest{
+		Reference:      paid.Reference,
+		Amount:         refund.Amount,
+		IdempotencyKey: refundKey(refund),
+	})
+	status := model.RefundCompleted
+	switch {
+	case gatewayErr == nil:
+	case payment.Rejected(gatewayErr):
+		status = model.RefundFailed
+	default:
+		return nil
+	}
+
+	settled, err := u.Repository.SettleRefund(ctx, refund.ID, status, reference)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// 他の処理が先に結果を反映した
+		settled, err = findRefund(ctx, u.Repository, refund.OrderID, refund.ID)
+	}
+	if err != nil {
+		return err
+	}
+	*refund = *settled
+	if refund.Status == model.RefundFailed {
+		return fmt.Errorf("%w: %w", ErrRefundFailed, gatewayErr)
+	}
+
+	return nil
+}
+
+// ReconcilePayments は決済代行会社の結果待ちのまま残った支払い・返金の結果を確かめて反映し、反映した件数を返す
+// 支払いは冪等キーで決済を探し、返金は同じ冪等キーで依頼し直す。結果が分からないものは次の実行まで残す
+func (u *usecase) ReconcilePayments(ctx context.Context) (int, error) {
+	// 決済中・返金の依頼中のものを失敗としないよう、記録から時間が経ったものに限る
+	before := time.Now().Add(-u.Config.PaymentReconcileAfter)
+	reconciled := 0
+
+	for afterID := 0; ; {
+		payments, err := u.Repository.GetPendingPayments(ctx, before, afterID, paymentReconcileBatchSize)
+		if err != nil {
+			return reconciled, err
+		}
+		for _, p := range payments {
+			afterID = p.ID
+
+			status := model.ChargeCompleted
+			reference, err := u.PaymentGateway.FindCharge(ctx, chargeKey(p))
+			switch {
+			case errors.Is(err, payment.ErrUnknownCharge):
+				status = model.ChargeFailed
+			case err != nil:
+				continue
+			}
+			if _, err := u.Repository.SettlePayment(ctx, p.ID, status, reference); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return reconciled, err
+			}
+			reconciled++
+		}
+		if len(payments) < paymentReconcileBatchSize {
+			break
+		}
+	}
+
+	for afterID := 0; ; {
+		refunds, err := u.Repository.GetPendingRefunds(ctx, before, afterID, paymentReconcileBatchSize)
+		if err != nil {
+			return reconciled, err
+		}
+		for _, refund := range refunds {
+			afterID = refund.ID
+
+			if err := u.settleRefund(ctx, refund); err != nil && !errors.Is(err, ErrRefundFailed) {
+				return reconciled, err
+			}
+			if refund.Status != model.RefundPending {
+				reconciled++
+			}
+		}
+		if len(refunds) < paymentReconcileBatchSize {
+			break
+		}
+	}
+
+	return reconciled, nil
+}
+
+// ProcessPendingPayments は定期実行用に結果待ちの支払い・返金の結果を反映する
+func (u *usecase) ProcessPendingPayments(ctx context.Context) error {
+	_, err := u.ReconcilePayments(ctx)
+
+	return err
+}
+
+// refundPayment はロック済みの発注の支払いを返金する
+// 決済代行会社を通す返金はPENDINGで記録し、トランザクションの確定後にsettleRefundsで依頼する
+func (u *usecase) refundPayment(ctx context.Context, tx repository.RepositoryInterface, order *model.Order, input request.CreateRefundRequest) (*model.Refund, error) {
+	cause := model.RefundCause(input.Cause)
+	if cause == model.RefundCancellation && order.Status != model.StatusCancelled {
+		return nil, ErrRefundNotAllowed
+	}
+
+	paid, err := findPayment(ctx, tx, order.ID, input.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := tx.GetRefunds(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	refundable := paid.RefundableAmount(refunds)
+	amount := refundable
+	if input.Amount != nil {
+		amount = *input.Amount
+	}
+	if amount <= 0 || amount > refundable {
+		return nil, fmt.Errorf("%w: refundable amount is %d", ErrRefundExceedsPayment, refundable)
+	}
+
+	now := time.Now()
+	refund := model.Refund{
+		TenantID:   input.TenantID,
+		OrderID:    order.ID,
+		PaymentID:  paid.ID,
+		Amount:     amount,
+		Cause:      cause,
+		Reason:     input.Reason,
+		ReturnID:   input.ReturnID,
+		Reference:  input.Reference,
+		Status:     model.RefundCompleted,
+		RefundedAt: now,
+	}
+
+	switch {
+	case paid.Method == model.PaymentPoints:
+		if err := refundPoints(ctx, tx, input.TenantID, order, amount, now); err != nil {
+			return nil, err
+		}
+	case paid.Method.ViaGateway():
+		refund.Status = model.RefundPending
+	}
+
+	return tx.CreateRefund(ctx, refund)
+}
+
+// chargeKey・refundKey は決済代行会社に渡す冪等キー。依頼し直しても同じキーになるよう記録のIDから作る
+func chargeKey(p *model.Payment) string {
+	return fmt.Sprintf("payment-%d", p.ID)
+}
+
+func refundKey(r *model.Refund) string {
+	return fmt.Sprintf("refund-%d", r.ID)
+}
+
+// findPayment は発注の支払いを取得する
+func findPayment(ctx context.Context, r repository.RepositoryInterface, orderID, paymentID int) (*model.Payment, error) {
+	payments, err := r.GetPayments(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range payments {
+		if p.ID == paymentID {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("payment %d: %w", paymentID, gorm.ErrRecordNotFound)
+}
+
+// findRefund は発注の返金を取得する
+func findRefund(ctx context.Context, r repository.RepositoryInterface, orderID, refundID int) (*model.Refund, error) {
+	refunds, err := r.GetRefunds(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for _, refund := range refunds {
+		if refund.ID == refundID {
+			return refund, nil
+		}
+	}
+
+	return nil, fmt.Errorf("refund %d: %w", refundID, gorm.ErrRecordNotFound)
+}
+
+// orderPayments は発注の支払いと返金、返品で返した額を読み込んで支払い状況を求める
+func orderPayments(ctx context.Context, r repository.RepositoryInterface, order *model.Order) (*model.OrderPayments, error) {
+	payments, err := r.GetPayments(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds, err := r.GetRefunds(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	returned, err := r.SumReturnedAmount(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewOrderPayments(order, payments, refunds, returned), nil
+}
+
+// payWithPoints は発注の顧客のポイント残高から支払う
+func payWithPoints(ctx context.Context, tx repository.RepositoryInterface, tenantID string, order *model.Order, points int, now time.Time) error {
+	customer, err := tx.LockPointAccount(ctx, tenantID, order.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	balance, err := tx.AdjustPointBalance(ctx, customer.ID, -points)
+	if err != nil {
+		return err
+	}
+	if err := tx.ConsumePointLots(ctx, customer.ID, points); err != nil {
+		return err
+	}
+
+	_, err = tx.CreatePointTransaction(ctx, model.PointTransaction{
+		TenantID:   customer.TenantID,
+		CustomerID: customer.ID,
+		OrderID:    &order.ID,
+		Type:       model.PointPayment,
+		Points:     -points,
+		Balance:    balance,
+		OccurredAt: now,
+	})
+
+	return err
+}
+
+// refundPoints はポイントで支払った分を顧客に返還する
+func refundPoints(ctx context.Context, tx repository.RepositoryInterface, tenantID string, order *model.Order, points int, now time.Time) error {
+	customer, err := tx.LockPointAccount(ctx, tenantID, order.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	setting, err := tx.GetTenantSetting(ctx, customer.TenantID)
+	if err != nil {
+		return err
+	}
+
+	return grantPoints(ctx, tx, customer, setting, &order.ID, model.PointPaymentRefund, points, now)
+}
@@ -0,0 +1,497 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/payment"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/config"
+	"gorm.io/gorm"
+)
+
+// paymentRepository は発注と支払い・返金・返品、在庫数、顧客のポイントをメモリに持つリポジトリ
+// トランザクション中かを記録し、決済代行会社をトランザクションの外で呼ぶことを確かめる
+type paymentRepository struct {
+	repository.RepositoryInterface
+
+	inTx     bool
+	orders   map[int]*model.Order
+	payments []*model.Payment
+	refunds  []*model.Refund
+	returns  []*model.OrderReturn
+	// 在庫IDごとの数量と入出庫
+	stocks    map[int]int
+	movements []model.StockMovement
+	// 顧客のポイント残高と履歴、発注で付与したポイント
+	pointBalance      int
+	pointTransactions []model.PointTransaction
+	earned            int
+	setting           model.TenantSetting
+}
+
+func newPaymentRepository(orders ...*model.Order) *paymentRepository {
+	r := &paymentRepository{
+		orders:  map[int]*model.Order{},
+		stocks:  map[int]int{},
+		setting: model.TenantSetting{TenantID: testTenantID},
+	}
+	for _, o := range orders {
+		r.orders[o.ID] = o
+	}
+
+	return r
+}
+
+func (r *paymentRepository) Transaction(ctx context.Context, fn func(tx repository.RepositoryInterface) error) error {
+	r.inTx = true
+	defer func() { r.inTx = false }()
+
+	return fn(r)
+}
+
+func (r *paymentRepository) LockOrder(ctx context.Context, orderID int) error {
+	return nil
+}
+
+func (r *paymentRepository) GetOrder(ctx context.Context, tenantID string, orderID int) (*model.Order, error) {
+	order, ok := r.orders[orderID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *order
+
+	return &copied, nil
+}
+
+func (r *paymentRepository) GetPayments(ctx context.Context, orderID int) ([]*model.Payment, error) {
+	payments := []*model.Payment{}
+	for _, p := range r.payments {
+		if p.OrderID == orderID {
+			copied := *p
+			payments = append(payments, &copied)
+		}
+	}
+
+	return payments, nil
+}
+
+func (r *paymentRepository) CreatePayment(ctx context.Context, p model.Payment) (*model.Payment, error) {
+	p.ID = len(r.payments) + 1
+	p.CreatedAt = time.Now()
+	r.payments = append(r.payments, &p)
+	copied := p
+
+	return &copied, nil
+}
+
+func (r *paymentRepository) GetRefunds(ctx context.Context, orderID int) ([]*model.Refund, error) {
+	refunds := []*model.Refund{}
+	for _, refund := range r.refunds {
+		if refund.OrderID == orderID {
+			copied := *refund
+			refunds = append(refunds, &copied)
+		}
+	}
+
+	return refunds, nil
+}
+
+func (r *paymentRepository) CreateRefund(ctx context.Context, refund model.Refund) (*model.Refund, error) {
+	refund.ID = len(r.refunds) + 1
+	refund.CreatedAt = time.Now()
+	r.refunds = append(r.refunds, &refund)
+	copied := refund
+
+	return &copied, nil
+}
+
+func (r *paymentRepository) SumReturnedAmount(ctx context.Context, orderID int) (int, error) {
+	amount := 0
+	for _, ret := range r.returns {
+		if ret.OrderID == orderID && ret.Status == model.ReturnCompleted {
+			amount += ret.Amount
+		}
+	}
+
+	return amount, nil
+}
+
+func (r *paymentRepository) SettlePayment(ctx context.Context, paymentID int, status model.ChargeStatus, reference string) (*model.Payment, error) {
+	for _, p := range r.payments {
+		if p.ID == paymentID && p.Status == model.ChargePending {
+			p.Status = status
+			if reference != "" {
+				p.Reference = reference
+			}
+			copied := *p
+
+			return &copied, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *paymentRepository) SettleRefund(ctx context.Context, refundID int, status model.RefundStatus, reference string) (*model.Refund, error) {
+	for _, refund := range r.refunds {
+		if refund.ID == refundID && refund.Status == model.RefundPending {
+			refund.Status = status
+			if reference != "" {
+				refund.Reference = reference
+			}
+			copied := *refund
+
+			return &copied, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *paymentRepository) GetPendingPayments(ctx context.Context, before time.Time, afterID, limit int) ([]*model.Payment, error) {
+	payments := []*model.Payment{}
+	for _, p := range r.payments {
+		if p.Status == model.ChargePending && !p.CreatedAt.After(before) && p.ID > afterID && len(payments) < limit {
+			copied := *p
+			payments = append(payments, &copied)
+		}
+	}
+
+	return payments, nil
+}
+
+func (r *paymentRepository) GetPendingRefunds(ctx context.Context, before time.Time, afterID, limit int) ([]*model.Refund, error) {
+	refunds := []*model.Refund{}
+	for _, refund := range r.refunds {
+		if refund.Status == model.RefundPending && !refund.CreatedAt.After(before) && refund.ID > afterID && len(refunds) < limit {
+			copied := *refund
+			refunds = append(refunds, &copied)
+		}
+	}
+
+	return refunds, nil
+}
+
+// testGateway は決済代行会社の応答を差し替える
+// lostの場合は依頼を処理したうえで、応答が届かなかったときと同じくタイムアウトを返す
+type testGateway struct {
+	*payment.Fake
+
+	t        *testing.T
+	repo     *paymentRepository
+	err      error
+	lost     bool
+	charges  []payment.ChargeRequest
+	refunds  []payment.RefundRequest
+	lookedUp []string
+}
+
+func newTestGateway(t *testing.T, repo *paymentRepository) *testGateway {
+	return &testGateway{Fake: payment.NewFake(), t: t, repo: repo}
+}
+
+func (g *testGateway) Charge(ctx context.Context, req payment.ChargeRequest) (string, error) {
+	if g.repo.inTx {
+		g.t.Error("Charge() is called in a transaction")
+	}
+	g.charges = append(g.charges, req)
+	if g.err != nil {
+		return "", g.err
+	}
+	reference, err := g.Fake.Charge(ctx, req)
+	if g.lost {
+		return "", context.DeadlineExceeded
+	}
+
+	return reference, err
+}
+
+func (g *testGateway) Refund(ctx context.Context, req payment.RefundRequest) (string, error) {
+	if g.repo.inTx {
+		g.t.Error("Refund() is called in a transaction")
+	}
+	g.refunds = append(g.refunds, req)
+	if g.err != nil {
+		return "", g.err
+	}
+	reference, err := g.Fake.Refund(ctx, req)
+	if g.lost {
+		return "", context.DeadlineExceeded
+	}
+
+	return reference, err
+}
+
+func (g *testGateway) FindCharge(ctx context.Context, idempotencyKey string) (string, error) {
+	g.lookedUp = append(g.lookedUp, idempotencyKey)
+	if g.err != nil {
+		return "", g.err
+	}
+
+	return g.Fake.FindCharge(ctx, idempotencyKey)
+}
+
+func newPaymentUsecase(r *paymentRepository, g *testGateway) usecase.UsecaseInterface {
+	return usecase.NewUsecase(&usecase.UsecaseBundle{
+		Config:         &config.Config{},
+		Repository:     r,
+		PaymentGateway: g,
+	})
+}
+
+func testOrder() *model.Order {
+	return &model.Order{
+		ID:          1,
+		TotalAmount: 10000,
+		Quantity:    2,
+		Status:      model.StatusDelivered,
+		StockID:     1,
+		CustomerID:  testCustomerID,
+	}
+}
+
+func TestCreatePayment(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// gatewayはnilの場合は承認する
+		gateway    func(g *testGateway)
+		method     string
+		token      string
+		wantErr    error
+		wantStatus model.ChargeStatus
+		// ReconcilePaymentsで確かめた後の状態
+		wantReconciled model.ChargeStatus
+		wantBalance    int
+	}{
+		{
+			name:           "cash is recorded as completed without the gateway",
+			method:         "CASH",
+			wantStatus:     model.ChargeCompleted,
+			wantReconciled: model.ChargeCompleted,
+			wantBalance:    4000,
+		},
+		{
+			name:           "approved card payment",
+			method:         "CREDIT_CARD",
+			token:          "tok_visa",
+			wantStatus:     model.ChargeCompleted,
+			wantReconciled: model.ChargeCompleted,
+			wantBalance:    4000,
+		},
+		{
+			name:           "declined card payment fails and frees the balance",
+			method:         "CREDIT_CARD",
+			token:          payment.FakeDeclineToken,
+			wantErr:        usecase.ErrPaymentDeclined,
+			wantReconciled: model.ChargeFailed,
+			wantBalance:    10000,
+		},
+		{
+			name:           "timeout after the charge stays pending and is completed by reconciliation",
+			gateway:        func(g *testGateway) { g.lost = true },
+			method:         "QR",
+			token:          "qr_code",
+			wantStatus:     model.ChargePending,
+			wantReconciled: model.ChargeCompleted,
+			wantBalance:    4000,
+		},
+		{
+			name:           "timeout before the charge stays pending and fails by reconciliation",
+			gateway:        func(g *testGateway) { g.err = context.DeadlineExceeded },
+			method:         "CREDIT_CARD",
+			token:          "tok_visa",
+			wantStatus:     model.ChargePending,
+			wantReconciled: model.ChargeFailed,
+			wantBalance:    10000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPaymentRepository(testOrder())
+			g := newTestGateway(t, r)
+			if tt.gateway != nil {
+				tt.gateway(g)
+			}
+			u := newPaymentUsecase(r, g)
+
+			created, err := u.CreatePayment(ctx, request.CreatePaymentRequest{
+				TenantID: testTenantID,
+				OrderID:  1,
+				Method:   tt.method,
+				Amount:   6000,
+				Token:    tt.token,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreatePayment() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && created.Status != tt.wantStatus {
+				t.Errorf("CreatePayment() status = %s, want %s", created.Status, tt.wantStatus)
+			}
+			for _, req := range g.charges {
+				if req.IdempotencyKey != "payment-1" {
+					t.Errorf("IdempotencyKey = %q, want %q", req.IdempotencyKey, "payment-1")
+				}
+			}
+
+			// 結果待ちの支払いも残額に数え、二重に支払えないようにする
+			if _, err := u.CreatePayment(ctx, request.CreatePaymentRequest{
+				TenantID: testTenantID,
+				OrderID:  1,
+				Method:   "CASH",
+				Amount:   tt.wantBalance + 1,
+			}); !errors.Is(err, usecase.ErrPaymentExceedsBalance) {
+				t.Errorf("CreatePayment() over the balance error = %v, want %v", err, usecase.ErrPaymentExceedsBalance)
+			}
+
+			g.err, g.lost = nil, false
+			if _, err := u.ReconcilePayments(ctx); err != nil {
+				t.Fatalf("ReconcilePayments() error = %v", err)
+			}
+			if got := r.payments[0].Status; got != tt.wantReconciled {
+				t.Errorf("status after ReconcilePayments() = %s, want %s", got, tt.wantReconciled)
+			}
+			if tt.wantReconciled == model.ChargeCompleted && model.PaymentMethod(tt.method).ViaGateway() && r.payments[0].Reference == "" {
+				t.Error("reference of the completed payment is empty")
+			}
+
+			status, err := u.GetOrderPayments(ctx, testTenantID, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.Balance != tt.wantBalance {
+				t.Errorf("Balance = %d, want %d", status.Balance, tt.wantBalance)
+			}
+		})
+	}
+}
+
+func TestCreateRefund(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		gateway    func(g *testGateway)
+		wantErr    error
+		wantStatus model.RefundStatus
+		// ReconcilePaymentsで確かめた後の状態
+		wantReconciled model.RefundStatus
+		wantRefundable int
+	}{
+		{
+			name:           "accepted refund",
+			wantStatus:     model.RefundCompleted,
+			wantReconciled: model.RefundCompleted,
+			wantRefundable: 2000,
+		},
+		{
+			name:           "rejected refund fails and can be refunded again",
+			gateway:        func(g *testGateway) { g.err = payment.ErrRefundExceedsCharge },
+			wantErr:        usecase.ErrRefundFailed,
+			wantReconciled: model.RefundFailed,
+			wantRefundable: 6000,
+		},
+		{
+			name:           "timeout stays pending and is retried with the same key",
+			gateway:        func(g *testGateway) { g.lost = true },
+			wantStatus:     model.RefundPending,
+			wantReconciled: model.RefundCompleted,
+			wantRefundable: 2000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPaymentRepository(testOrder())
+			g := newTestGateway(t, r)
+			u := newPaymentUsecase(r, g)
+
+			if _, err := u.CreatePayment(ctx, request.CreatePaymentRequest{
+				TenantID: testTenantID,
+				OrderID:  1,
+				Method:   "CREDIT_CARD",
+				Amount:   6000,
+				Token:    "tok_visa",
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.gateway != nil {
+				tt.gateway(g)
+			}
+
+			amount := 4000
+			created, err := u.CreateRefund(ctx, request.CreateRefundRequest{
+				TenantID:  testTenantID,
+				OrderID:   1,
+				PaymentID: 1,
+				Amount:    &amount,
+				Cause:     string(model.RefundOther),
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateRefund() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && created.Status != tt.wantStatus {
+				t.Errorf("CreateRefund() status = %s, want %s", created.Status, tt.wantStatus)
+			}
+
+			g.err, g.lost = nil, false
+			if _, err := u.ReconcilePayments(ctx); err != nil {
+				t.Fatalf("ReconcilePayments() error = %v", err)
+			}
+			if got := r.refunds[0].Status; got != tt.wantReconciled {
+				t.Errorf("status after ReconcilePayments() = %s, want %s", got, tt.wantReconciled)
+			}
+			for _, req := range g.refunds {
+				if req.IdempotencyKey != "refund-1" || req.Reference != r.payments[0].Reference {
+					t.Errorf("Refund() request = %+v", req)
+				}
+			}
+
+			if got := r.payments[0].RefundableAmount(r.refunds); got != tt.wantRefundable {
+				t.Errorf("RefundableAmount() = %d, want %d", got, tt.wantRefundable)
+			}
+			// 同じ冪等キーで依頼し直しても二重に返金しない
+			if _, err := g.Fake.Refund(ctx, payment.RefundRequest{Reference: r.payments[0].Reference, Amount: tt.wantRefundable + 1}); !errors.Is(err, payment.ErrRefundExceedsCharge) {
+				t.Errorf("refund over the remaining charge error = %v, want %v", err, payment.ErrRefundExceedsCharge)
+			}
+		})
+	}
+}
+
+func TestCreateRefundOfPendingPayment(t *testing.T) {
+	ctx := context.Background()
+	r := newPaymentRepository(testOrder())
+	g := newTestGateway(t, r)
+	g.err = context.DeadlineExceeded
+	u := newPaymentUsecase(r, g)
+
+	created, err := u.CreatePayment(ctx, request.CreatePaymentRequest{
+		TenantID: testTenantID,
+		OrderID:  1,
+		Method:   "CREDIT_CARD",
+		Amount:   6000,
+		Token:    "tok_visa",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 決済の結果が分かるまでは返金できない
+	if _, err := u.CreateRefund(ctx, request.CreateRefundRequest{
+		TenantID:  testTenantID,
+		OrderID:   1,
+		PaymentID: created.ID,
+		Cause:     string(model.RefundOther),
+	}); !errors.Is(err, usecase.ErrRefundExceedsPayment) {
+		t.Errorf("CreateRefund() error = %v, want %v", err, usecase.ErrRefundExceedsPayment)
+	}
+	if len(g.refunds) != 0 {
+		t.Errorf("Refund() is called %d times", len(g.refunds))
+	}
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+)
+
+const (
+	// pointHistoryDefaultLimit はポイント履歴の1ページの既定の件数
+	pointHistoryDefaultLimit = 20
+	// pointHistoryMaxLimit はポイント履歴の1ページの件数の上限
+	pointHistoryMaxLimit = 100
+	// pointExpiryBatchSize はポイントの失効を1回に処理する顧客の件数
+	pointExpiryBatchSize = 500
+)
+
+// GetPointBalance は顧客のポイント残高と失効予定のポイントを取得する
+func (u *usecase) GetPointBalance(ctx context.Context, tenantID, customerID string) (*model.PointBalance, error) {
+	balance, err := u.Repository.GetPointBalance(ctx, tenantID, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiries, err := u.Repository.GetPointExpiries(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PointBalance{
+		CustomerID: customerID,
+		Balance:    balance,
+		Expiring:   expiries,
+	}, nil
+}
+
+// GetPointHistory は顧客のポイントの増減履歴を新しい順にページ単位で取得する
+func (u *usecase) GetPointHistory(ctx context.Context, input request.GetPointHistoryRequest) (*model.PointHistory, error) {
+	limit := pointHistoryDefaultLimit
+	if input.Limit != nil {
+		limit = min(*input.Limit, pointHistoryMaxLimit)
+	}
+	offset := 0
+	if input.Offset != nil {
+		offset = *input.Offset
+	}
+
+	if err := u.Repository.CheckCustomerExists(ctx, input.TenantID, input.CustomerID); err != nil {
+		return nil, err
+	}
+
+	total, err := u.Repository.CountPointTransactions(ctx, input.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := u.Repository.GetPointTransactions(ctx, input.CustomerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PointHistory{
+		Total:        total,
+		Limit:        limit,
+		Offset:       offset,
+		Transactions: transactions,
+	}, nil
+}
+
+// ExpirePoints は有効期限を過ぎた未使用のポイントを失効させ、失効させたポイントの合計を返す
+func (u *usecase) ExpirePoints(ctx context.Context) (int, error) {
+	expired := 0
+	now := time.Now()
+
+	for {
+		customers, err := u.Repository.GetCustomersWithExpiredPoints(ctx, now, pointExpiryBatchSize)
+		if err != nil {
+			return expired, err
+		}
+
+		for _, c := range customers {
+			err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+				customer, err := tx.LockPointAccount(ctx, c.TenantID, c.ID)
+				if err != nil {
+					return err
+				}
+
+				points, err := tx.ExpirePointLots(ctx, customer.ID, now)
+				if err != nil || points == 0 {
+					return err
+				}
+
+				balance, err := tx.AdjustPointBalance(ctx, customer.ID, -points)
+				if err != nil {
+					return err
+				}
+				expired += points
+
+				_, err = tx.CreatePointTransaction(ctx, model.PointTransaction{
+					TenantID:   customer.TenantID,
+					CustomerID: customer.ID,
+					Type:       model.PointExpire,
+					Points:     -points,
+					Balance:    balance,
+					OccurredAt: now,
+				})
+
+				return err
+			})
+			if err != nil {
+				return expired, err
+			}
+		}
+
+		// 失効させた顧客は次の取得の対象にならないため、件数が上限未満になるまで繰り返す
+		if len(customers) < pointExpiryBatchSize {
+			break
+		}
+	}
+
+	return expired, nil
+}
+
+// RefreshPointExpiry は定期実行用に有効期限を過ぎたポイントを失効させる
+func (u *usecase) RefreshPointExpiry(ctx context.Context) error {
+	_, err := u.ExpirePoints(ctx)
+
+	return err
+}
+
+// applyOrderPoints は発注の変化をポイントに反映する
+// 利用したポイントはキャンセルで返還し、付与するポイントは納品済みになった時点で付与して納品済みでなくなれば取り消す
+func (u *usecase) applyOrderPoints(ctx context.Context, tx repository.RepositoryInterface, tenantID string, before, after *model.Order) error {
+	redeemed := func(o *model.Order) int {
+		if o == nil || o.Status == model.StatusCancelled {
+			return 0
+		}
+
+		return o.PointsUsed
+	}
+	delivered := func(o *model.Order) bool {
+		return o != nil && o.Status == model.StatusDelivered
+	}
+
+	redeemDelta := redeemed(after) - redeemed(before)
+	if redeemDelta == 0 && delivered(before) == delivered(after) {
+		return nil
+	}
+
+	// 同じ顧客のポイントの増減を直列化し、同時に利用しても残高を超えないようにする
+	customer, err := tx.LockPointAccount(ctx, tenantID, after.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	setting, err := tx.GetTenantSetting(ctx, customer.TenantID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	orderID := after.ID
+
+	if redeemDelta > 0 {
+		balance, err := tx.AdjustPointBalance(ctx, customer.ID, -redeemDelta)
+		if err != nil {
+			return err
+		}
+		if err := tx.ConsumePointLots(ctx, customer.ID, redeemDelta); err != nil {
+			return err
+		}
+		if _, err := tx.CreatePointTransaction(ctx, model.PointTransaction{
+			TenantID:   customer.TenantID,
+			CustomerID: customer.ID,
+			OrderID:    &orderID,
+			Type:       model.PointRedeem,
+			Points:     -redeemDelta,
+			Balance:    balance,
+			OccurredAt: now,
+		}); err != nil {
+			return err
+		}
+	}
+	if redeemDelta < 0 {
+		if err := grantPoints(ctx, tx, customer, setting, &orderID, model.PointRedeemRefund, -redeemDelta, now); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case !delivered(before) && delivered(after):
+		if setting.PointEarnUnit <= 0 {
+			return nil
+		}
+		points := (after.TotalAmount - after.DiscountAmount - after.PointsUsed) / setting.PointEarnUnit
+		if points <= 0 {
+			return nil
+		}
+
+		return grantPoints(ctx, tx, customer, setting, &orderID, model.PointEarn, points, now)
+	case delivered(before) && !delivered(after):
+		earned, _, err := tx.GetOrderPointTotals(ctx, orderID)
+		if err != nil || earned <= 0 {
+			return err
+		}
+
+		return revokePoints(ctx, tx, customer, &orderID, earned, now)
+	}
+
+	return nil
+}
+
+// transferPoints は統合元の顧客のポイント残高を統合先に移す
+// ポイントの記録は未使用の残りや有効期限とともにReassignCustomerReferencesで統合先に付け替わるため、
+// 移行の記録は作らずに残高だけを移す。統合先の記録の合計は移行後の残高と一致する
+func transferPoints(ctx context.Context, tx repository.RepositoryInterface, from, to *model.Customer) error {
+	if from.PointBalance == 0 {
+		return nil
+	}
+
+	if _, err := tx.AdjustPointBalance(ctx, from.ID, -from.PointBalance); err != nil {
+		return err
+	}
+	_, err := tx.AdjustPointBalance(ctx, to.ID, from.PointBalance)
+
+	return err
+}
+
+// grantPoints は顧客にポイントを付与する。テナントの設定に有効期限があれば期限付きで付与する
+func grantPoints(
+	ctx context.Context,
+	tx repository.RepositoryInterface,
+	customer *model.Customer,
+	setting *model.TenantSetting,
+	orderID *int,
+	transactionType model.PointTransactionType,
+	points int,
+	now time.Time,
+) error {
+	balance, err := tx.AdjustPointBalance(ctx, customer.ID, points)
+	if err != nil {
+		return err
+	}
+
+	var expiresAt *time.Time
+	if setting.PointExpiryMonths > 0 {
+		t := now.AddDate(0, setting.PointExpiryMonths, 0)
+		expiresAt = &t
+	}
+
+	_, err = tx.CreatePointTransaction(ctx, model.PointTransaction{
+		TenantID:   customer.TenantID,
+		CustomerID: customer.ID,
+		OrderID:    orderID,
+		Type:       transactionType,
+		Points:     points,
+		Remaining:  points,
+		ExpiresAt:  expiresAt,
+		Balance:    balance,
+		OccurredAt: now,
+	})
+
+	return err
+}
+
+// revokePoints は発注で付与したポイントを取り消す
+// 付与したポイントを既に利用・失効している場合は残高の範囲で取り消し、取り消せなかったポイント数を備考に残す
+func revokePoints(ctx context.Context, tx repository.RepositoryInterface, customer *model.Customer, orderID *int, points int, now time.Time) error {
+	revoked := min(points, customer.PointBalance)
+
+	note := ""
+	if revoked < points {
+		note = fmt.Sprintf("残高不足のため%dポイントを取り消せませんでした", points-revoked)
+	}
+
+	balance, err := tx.AdjustPointBalance(ctx, customer.ID, -revoked)
+	if err != nil {
+		return err
+	}
+	if err := tx.ConsumePointLots(ctx, customer.ID, revoked); err != nil {
+		return err
+	}
+
+	_, err = tx.CreatePointTransaction(ctx, model.PointTransaction{
+		TenantID:   customer.TenantID,
+		CustomerID: customer.ID,
+		OrderID:    orderID,
+		Type:       model.PointEarnReversal,
+		Points:     -revoked,
+		Balance:    balance,
+		OccurredAt: now,
+		Note:       note,
+	})
+
+	return err
+}
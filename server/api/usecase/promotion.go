@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"gorm.io/gorm"
+)
+
+func (u *usecase) GetPromotions(ctx context.Context, tenantID string) ([]*model.Promotion, error) {
+	return u.Repository.GetPromotions(ctx, tenantID)
+}
+
+func (u *usecase) GetPromotion(ctx context.Context, tenantID string, promotionID int) (*model.Promotion, error) {
+	return u.Repository.GetPromotion(ctx, tenantID, promotionID)
+}
+
+func (u *usecase) CreatePromotion(ctx context.Context, input request.CreatePromotionRequest) (*model.Promotion, error) {
+	promotion := promotionModel(input.PromotionInput)
+	promotion.TenantID = input.TenantID
+	if err := validatePromotion(promotion); err != nil {
+		return nil, err
+	}
+
+	return u.Repository.CreatePromotion(ctx, promotion)
+}
+
+func (u *usecase) UpdatePromotion(ctx context.Context, input request.UpdatePromotionRequest) (*model.Promotion, error) {
+	promotion := promotionModel(input.PromotionInput)
+	promotion.ID = input.PromotionID
+	promotion.TenantID = input.TenantID
+	if err := validatePromotion(promotion); err != nil {
+		return nil, err
+	}
+
+	return u.Repository.UpdatePromotion(ctx, promotion)
+}
+
+func (u *usecase) DeletePromotion(ctx context.Context, tenantID string, promotionID int) error {
+	return u.Repository.DeletePromotion(ctx, tenantID, promotionID)
+}
+
+func (u *usecase) GetCoupons(ctx context.Context, tenantID string, promotionID int) ([]*model.Coupon, error) {
+	if _, err := u.Repository.GetPromotion(ctx, tenantID, promotionID); err != nil {
+		return nil, err
+	}
+
+	return u.Repository.GetCoupons(ctx, tenantID, promotionID)
+}
+
+func (u *usecase) CreateCoupon(ctx context.Context, input request.CreateCouponRequest) (*model.Coupon, error) {
+	if _, err := u.Repository.GetPromotion(ctx, input.TenantID, input.PromotionID); err != nil {
+		return nil, err
+	}
+
+	return u.Repository.CreateCoupon(ctx, model.Coupon{
+		TenantID:    input.TenantID,
+		PromotionID: input.PromotionID,
+		Code:        normalizeCouponCode(input.Code),
+		UsageLimit:  input.UsageLimit,
+		Active:      input.Active,
+	})
+}
+
+func (u *usecase) DeleteCoupon(ctx context.Context, tenantID string, promotionID, couponID int) error {
+	return u.Repository.DeleteCoupon(ctx, tenantID, promotionID, couponID)
+}
+
+func promotionModel(input request.PromotionInput) model.Promotion {
+	return model.Promotion{
+		Name:                  input.Name,
+		Description:           input.Description,
+		DiscountType:          model.DiscountType(input.DiscountType),
+		DiscountValue:         input.DiscountValue,
+		StockID:               input.StockID,
+		Category:              input.Category,
+		StoreID:               input.StoreID,
+		StartsAt:              input.StartsAt,
+		EndsAt:                input.EndsAt,
+		MinSpend:              input.MinSpend,
+		UsageLimitPerCustomer: input.UsageLimitPerCustomer,
+		UsageLimit:            input.UsageLimit,
+		RequiresCoupon:        input.RequiresCoupon,
+		Active:                input.Active,
+	}
+}
+
+// validatePromotion は入力検証では確認できない項目の組み合わせを検証する
+func validatePromotion(promotion model.Promotion) error {
+	if promotion.DiscountType == model.DiscountPercent && promotion.DiscountValue > 100 {
+		return fmt.Errorf("%w: percent discount must be 100 or less", ErrInvalidPromotion)
+	}
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && !promotion.EndsAt.After(*promotion.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidPromotion)
+	}
+
+	return nil
+}
+
+// normalizeCouponCode はクーポンコードを照合用に前後の空白を除いて大文字にする
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// discountEvaluator は発注明細に適用するキャンペーンを判定する
+// 一括作成では先に判定した明細の利用回数がまだ記録されていないため、同じトランザクションで適用した回数を加えて上限を判定する
+type discountEvaluator struct {
+	tx      repository.RepositoryInterface
+	now     time.Time
+	pending map[usageKey]int64
+}
+
+// usageKey は利用回数を数える単位。キャンペーン全体・顧客ごと・クーポンごとに数える
+type usageKey struct {
+	promotionID int
+	couponID    int
+	customerID  string
+}
+
+func newDiscountEvaluator(tx repository.RepositoryInterface) *discountEvaluator {
+	return &discountEvaluator{tx: tx, now: time.Now(), pending: map[usageKey]int64{}}
+}
+
+// apply は発注明細に適用するキャンペーンを判定して発注の値引き額を設定し、発注の作成後に記録する値引きを返す
+// 自動で適用するキャンペーンは値引き額が最も大きいもの1件と、クーポンのキャンペーンを併用する
+func (e *discountEvaluator) apply(ctx context.Context, order *model.Order, couponCode *string) ([]*model.OrderDiscount, error) {
+	target, err := e.tx.GetPromotionTarget(ctx, order.StockID)
+	if err != nil {
+		return nil, err
+	}
+
+	var discounts []*model.OrderDiscount
+	remaining := order.TotalAmount
+
+	if couponCode != nil && *couponCode != "" {
+		coupon, promotion, err := e.lockCoupon(ctx, *target, order, *couponCode)
+		if err != nil {
+			return nil, err
+		}
+		if err := e.checkUsage(ctx, promotion, order.CustomerID, coupon); err != nil {
+			return nil, err
+		}
+
+		amount := promotion.Discount(remaining)
+		remaining -= amount
+		discounts = append(discounts, &model.OrderDiscount{
+			StockID:     order.StockID,
+			PromotionID: promotion.ID,
+			CouponID:    &coupon.ID,
+			Name:        promotion.Name,
+			Amount:      amount,
+		})
+		e.use(promotion, order.CustomerID, coupon)
+	}
+
+	promotions, err := e.tx.GetAutomaticPromotions(ctx, *target, order.TotalAmount, e.now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 値引き額が大きい順に、利用回数の上限に達していない最初のキャンペーンを適用する。同額の場合はIDの小さいものを優先する
+	slices.SortStableFunc(promotions, func(a, b *model.Promotion) int {
+		return cmp.Compare(b.Discount(remaining), a.Discount(remaining))
+	})
+	for _, promotion := range promotions {
+		amount := promotion.Discount(remaining)
+		if amount <= 0 {
+			break
+		}
+
+		available, err := e.available(ctx, promotion, order.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if !available {
+			continue
+		}
+
+		remaining -= amount
+		discounts = append(discounts, &model.OrderDiscount{
+			StockID:     order.StockID,
+			PromotionID: promotion.ID,
+			Name:        promotion.Name,
+			Amount:      amount,
+		})
+		e.use(promotion, order.CustomerID, nil)
+
+		break
+	}
+
+	order.DiscountAmount = order.TotalAmount - remaining
+
+	return discounts, nil
+}
+
+// lockCoupon はクーポンと、発注明細が条件を満たすクーポンのキャンペーンを行ロックして取得する
+func (e *discountEvaluator) lockCoupon(ctx context.Context, target model.PromotionTarget, order *model.Order, code string) (*model.Coupon, *model.Promotion, error) {
+	coupon, err := e.tx.LockCoupon(ctx, target.TenantID, normalizeCouponCode(code))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidCoupon, code)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	promotion, err := e.tx.LockCouponPromotion(ctx, target, order.TotalAmount, e.now, coupon.PromotionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, fmt.Errorf("%w: %s does not apply to this order", ErrPromotionNotApplicable, code)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return coupon, promotion, nil
+}
+
+// available は自動で適用するキャンペーンの利用回数が上限に達していないか確認する
+// 上限のあるキャンペーンは、上限に達していなければ行ロックして確認し直し、同時に適用して上限を超えないようにする
+func (e *discountEvaluator) available(ctx context.Context, promotion *model.Promotion, customerID string) (bool, error) {
+	if !promotion.Limited() {
+		return true, nil
+	}
+	if err := e.checkUsage(ctx, promotion, customerID, nil); errors.Is(err, ErrPromotionNotApplicable) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	locked, err := e.tx.LockPromotion(ctx, promotion.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := e.checkUsage(ctx, locked, customerID, nil); errors.Is(err, ErrPromotionNotApplicable) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// checkUsage はキャンペーンとクーポンの利用回数が上限に達していないか確認する
+func (e *discountEvaluator) checkUsage(ctx context.Context, promotion *model.Promotion, customerID string, coupon *model.Coupon) error {
+	if promotion.UsageLimit > 0 {
+		count, err := e.tx.CountPromotionUsage(ctx, promotion.ID, nil)
+		if err != nil {
+			return err
+		}
+		if count+e.pending[usageKey{promotionID: promotion.ID}] >= int64(promotion.UsageLimit) {
+			return fmt.Errorf("%w: usage limit of %s reached", ErrPromotionNotApplicable, promotion.Name)
+		}
+	}
+	if promotion.UsageLimitPerCustomer > 0 {
+		count, err := e.tx.CountPromotionUsage(ctx, promotion.ID, &customerID)
+		if err != nil {
+			return err
+		}
+		if count+e.pending[usageKey{promotionID: promotion.ID, customerID: customerID}] >= int64(promotion.UsageLimitPerCustomer) {
+			return fmt.Errorf("%w: usage limit per customer of %s reached", ErrPromotionNotApplicable, promotion.Name)
+		}
+	}
+	if coupon != nil && coupon.UsageLimit > 0 {
+		count, err := e.tx.CountCouponUsage(ctx, coupon.ID)
+		if err != nil {
+			return err
+		}
+		if count+e.pending[usageKey{couponID: coupon.ID}] >= int64(coupon.UsageLimit) {
+			return fmt.Errorf("%w: usage limit of coupon %s reached", ErrPromotionNotApplicable, coupon.Code)
+		}
+	}
+
+	return nil
+}
+
+// use は同じトランザクションで適用した回数を記録する
+func (e *discountEvaluator) use(promotion *model.Promotion, customerID string, coupon *model.Coupon) {
+	e.pending[usageKey{promotionID: promotion.ID}]++
+	e.pending[usageKey{promotionID: promotion.ID, customerID: customerID}]++
+	if coupon != nil {
+		e.pending[usageKey{couponID: coupon.ID}]++
+	}
+}
+
+// recordDiscounts は作成した発注に適用した値引きを記録する
+func recordDiscounts(ctx context.Context, tx repository.RepositoryInterface, orderID int, discounts []*model.OrderDiscount) error {
+	for _, discount := range discounts {
+		discount.OrderID = orderID
+	}
+
+	return tx.CreateOrderDiscounts(ctx, discounts)
+}
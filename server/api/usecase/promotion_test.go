@@ -0,0 +1,129 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/config"
+)
+
+// promotionRepository は発注に加えてキャンペーンと記録した値引きをメモリに持つリポジトリ
+type promotionRepository struct {
+	*rollupRepository
+
+	promotions []*model.Promotion
+	discounts  []*model.OrderDiscount
+	locked     []int
+}
+
+func (r *promotionRepository) Transaction(ctx context.Context, fn func(tx repository.RepositoryInterface) error) error {
+	return fn(r)
+}
+
+func (r *promotionRepository) GetAutomaticPromotions(ctx context.Context, target model.PromotionTarget, amount int, now time.Time) ([]*model.Promotion, error) {
+	promotions := make([]*model.Promotion, 0, len(r.promotions))
+	for _, p := range r.promotions {
+		copied := *p
+		promotions = append(promotions, &copied)
+	}
+
+	return promotions, nil
+}
+
+func (r *promotionRepository) LockPromotion(ctx context.Context, promotionID int) (*model.Promotion, error) {
+	r.locked = append(r.locked, promotionID)
+	for _, p := range r.promotions {
+		if p.ID == promotionID {
+			copied := *p
+
+			return &copied, nil
+		}
+	}
+
+	return nil, errors.New("promotion not found")
+}
+
+func (r *promotionRepository) CountPromotionUsage(ctx context.Context, promotionID int, customerID *string) (int64, error) {
+	var count int64
+	for _, d := range r.discounts {
+		if d.PromotionID == promotionID && (customerID == nil || r.orders[d.OrderID].CustomerID == *customerID) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *promotionRepository) CreateOrderDiscounts(ctx context.Context, discounts []*model.OrderDiscount) error {
+	r.discounts = append(r.discounts, discounts...)
+
+	return nil
+}
+
+// TestCreateOrderDiscounts は自動で適用するキャンペーンのうち、利用回数の上限に達していない値引き額が最も大きいものを適用し、
+// 行ロックは適用する上限のあるキャンペーンだけにかけることを確かめる
+func TestCreateOrderDiscounts(t *testing.T) {
+	ctx := context.Background()
+	r := &promotionRepository{
+		rollupRepository: newRollupRepository(),
+		promotions: []*model.Promotion{
+			{ID: 1, Name: "10%引き", DiscountType: model.DiscountPercent, DiscountValue: 10, Active: true},
+			{ID: 2, Name: "先着1名2,000円引き", DiscountType: model.DiscountFixed, DiscountValue: 2000, UsageLimit: 1, Active: true},
+			{ID: 3, Name: "500円引き", DiscountType: model.DiscountFixed, DiscountValue: 500, UsageLimitPerCustomer: 5, Active: true},
+		},
+	}
+	u := usecase.NewUsecase(&usecase.UsecaseBundle{Config: &config.Config{}, Repository: r})
+
+	tests := []struct {
+		name         string
+		wantDiscount int
+		wantLocked   []int
+	}{
+		{"上限のあるキャンペーンを行ロックして適用", 2000, []int{2}},
+		{"上限に達したキャンペーンは行ロックせずに次に大きいものを適用", 1000, []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderID, err := u.CreateOrder(ctx, request.CreateOrderRequest{
+				TenantID:     testTenantID,
+				TotalAmount:  10000,
+				Quantity:     1,
+				DeliveryDate: "2025-10-01",
+				Status:       "PENDING",
+				StockID:      1,
+				CustomerID:   testCustomerID,
+			})
+			if err != nil {
+				t.Fatalf("CreateOrder() error = %v", err)
+			}
+			if got := r.orders[*orderID].DiscountAmount; got != tt.wantDiscount {
+				t.Errorf("DiscountAmount = %d, want %d", got, tt.wantDiscount)
+			}
+			if !slices.Equal(r.locked, tt.wantLocked) {
+				t.Errorf("locked = %v, want %v", r.locked, tt.wantLocked)
+			}
+		})
+	}
+
+	// 値引き額は作成時の金額で判定しているため、値引きを適用した発注の金額は変更できない
+	update := request.UpdateOrderRequest{ID: 1, TenantID: testTenantID, TotalAmount: 12000, Quantity: 1, DeliveryDate: "2025-10-01", Status: "PENDING"}
+	if _, err := u.UpdateOrder(ctx, update); !errors.Is(err, usecase.ErrOrderHasDiscounts) {
+		t.Errorf("UpdateOrder() of the total error = %v, want %v", err, usecase.ErrOrderHasDiscounts)
+	}
+	update.TotalAmount = 10000
+	update.Status = "SHIPPED"
+	order, err := u.UpdateOrder(ctx, update)
+	if err != nil {
+		t.Fatalf("UpdateOrder() of the status error = %v", err)
+	}
+	if order.DiscountAmount != 2000 || order.Status != model.StatusShipped {
+		t.Errorf("order = %+v", order)
+	}
+}
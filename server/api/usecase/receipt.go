@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/receipt"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"gorm.io/gorm"
+)
+
+func (u *usecase) GetReceiptTemplate(ctx context.Context, tenantID string) (*model.ReceiptTemplate, error) {
+	return u.Repository.GetReceiptTemplate(ctx, tenantID)
+}
+
+func (u *usecase) UpdateReceiptTemplate(ctx context.Context, input request.UpdateReceiptTemplateRequest) (*model.ReceiptTemplate, error) {
+	tmpl, err := u.Repository.GetReceiptTemplate(ctx, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.PaperWidth != nil {
+		tmpl.PaperWidth = *input.PaperWidth
+	}
+	if input.Header != nil {
+		tmpl.Header = *input.Header
+	}
+	if input.Footer != nil {
+		tmpl.Footer = *input.Footer
+	}
+	if input.ShowStoreAddress != nil {
+		tmpl.ShowStoreAddress = *input.ShowStoreAddress
+	}
+	if input.ShowBarcode != nil {
+		tmpl.ShowBarcode = *input.ShowBarcode
+	}
+
+	return u.Repository.SaveReceiptTemplate(ctx, *tmpl)
+}
+
+// GetOrderReceipt は発注のレシートをテナントの印字設定で描画する
+// 明細・税率ごとの内訳は請求書と同じ計算で、請求書の発行者情報が登録されていれば登録番号も記載する
+func (u *usecase) GetOrderReceipt(ctx context.Context, input request.GetOrderReceiptRequest) ([]byte, error) {
+	loc, err := time.LoadLocation(u.Config.ReportTimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := u.Repository.GetOrder(ctx, input.TenantID, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status == model.StatusCancelled {
+		return nil, ErrOrderCancelled
+	}
+
+	setting, err := u.Repository.GetReceiptTemplate(ctx, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	tmpl := receipt.Template{
+		PaperWidth:       setting.PaperWidth,
+		Header:           splitLines(setting.Header),
+		Footer:           splitLines(setting.Footer),
+		ShowStoreAddress: setting.ShowStoreAddress,
+		ShowBarcode:      setting.ShowBarcode,
+	}
+	if input.PaperWidth != nil {
+		tmpl.PaperWidth = *input.PaperWidth
+	}
+
+	owner, err := u.Repository.GetStockOwner(ctx, input.TenantID, order.StockID)
+	if err != nil {
+		return nil, err
+	}
+	stock, err := u.Repository.GetStock(ctx, owner.StoreID, strconv.Itoa(order.StockID))
+	if err != nil {
+		return nil, err
+	}
+	store, err := u.Repository.GetStore(ctx, input.TenantID, owner.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := u.Repository.GetPayments(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	// 決済代行会社の結果待ちの支払い・承認されなかった支払いは印字しない
+	payments = slices.DeleteFunc(payments, func(p *model.Payment) bool {
+		return p.Status != model.ChargeCompleted
+	})
+	refunds, err := u.Repository.GetRefunds(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	// 決済代行会社が受け付けなかった返金は印字しない
+	refunds = slices.DeleteFunc(refunds, func(refund *model.Refund) bool {
+		return !refund.Effective()
+	})
+
+	r := &receipt.Receipt{
+		StoreName:        store.Name,
+		StoreAddress:     store.Address,
+		StorePhoneNumber: store.PhoneNumber,
+		OrderID:          order.ID,
+		SoldAt:           order.CreatedAt.In(loc),
+		Lines:            orderLines(order, stock),
+		Payments:         payments,
+		Refunds:          refunds,
+		Barcode:          fmt.Sprintf("%08d", order.ID),
+	}
+	r.Taxes = lineTaxes(r.Lines)
+	for _, tax := range r.Taxes {
+		r.Total += tax.Amount
+	}
+
+	issuer, err := u.Repository.GetInvoiceIssuer(ctx, input.TenantID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if issuer != nil {
+		r.RegistrationNumber = issuer.RegistrationNumber
+	}
+
+	var buf bytes.Buffer
+	if err := receipt.Render(&buf, input.Format, tmpl, r); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// splitLines は改行区切りの文言を行に分ける。空の場合は行なしとする
+func splitLines(s string) []string {
+	s = strings.TrimRight(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/valuation"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+)
+
+func (u *usecase) GetInventoryValuation(ctx context.Context, input request.GetInventoryValuationRequest) (*model.InventoryValuationReport, error) {
+	asOf := time.Now()
+	if input.AsOf != nil {
+		asOf = *input.AsOf
+	}
+
+	// 評価方法はテナント設定に従う。指定があれば試算として上書きする
+	setting, err := u.Repository.GetTenantSetting(ctx, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	method := setting.ValuationMethod
+	if input.Method != nil {
+		method = model.ValuationMethod(*input.Method)
+	}
+
+	stores, err := u.Repository.GetStores(ctx, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.InventoryValuationReport{
+		TenantID: input.TenantID,
+		AsOf:     asOf,
+		Method:   method,
+		Stores:   make([]*model.StoreValuation, 0, len(stores)),
+	}
+	storeMap := make(map[string]*model.StoreValuation, len(stores))
+	for _, store := range stores {
+		sv := &model.StoreValuation{
+			StoreID:   store.ID,
+			StoreName: store.Name,
+			Stocks:    []*model.StockValuation{},
+		}
+		storeMap[store.ID] = sv
+		report.Stores = append(report.Stores, sv)
+	}
+
+	// 履歴は在庫ごとにまとまって届くため、在庫が切り替わった時点で集計する
+	var current *model.StockLedgerEntry
+	var calc *valuation.Calculator
+	flush := func() {
+		if current == nil {
+			return
+		}
+		sv, ok := storeMap[current.StoreID]
+		if !ok {
+			return
+		}
+
+		result := calc.Result()
+		if result.Quantity == 0 {
+			return
+		}
+		sv.Stocks = append(sv.Stocks, &model.StockValuation{
+			StockID:             current.StockID,
+			Name:                current.StockName,
+			Quantity:            result.Quantity,
+			UnitCost:            result.UnitCost,
+			Value:               result.Value,
+			UnknownCostQuantity: result.UnknownCostQuantity,
+		})
+		sv.Quantity += result.Quantity
+		sv.Value += result.Value
+		sv.UnknownCostQuantity += result.UnknownCostQuantity
+	}
+
+	err = u.Repository.EachStockLedgerEntry(ctx, input.TenantID, asOf, func(entry *model.StockLedgerEntry) error {
+		if current == nil || current.StockID != entry.StockID {
+			flush()
+			current = entry
+			calc = valuation.NewCalculator(method)
+		}
+		calc.Add(&entry.StockMovement)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	flush()
+
+	for _, sv := range report.Stores {
+		report.Quantity += sv.Quantity
+		report.Value += sv.Value
+		report.UnknownCostQuantity += sv.UnknownCostQuantity
+	}
+
+	return report, nil
+}
+
+// GetSalesReport は売上の日次集計から売上を集計する
+// ステータスの指定がない場合、キャンセルされた発注は売上に含めない
+func (u *usecase) GetSalesReport(ctx context.Context, input request.GetSalesReportRequest) (*model.SalesReport, error) {
+	loc, err := time.LoadLocation(u.Config.ReportTimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	query := model.SalesReportQuery{
+		TenantID: input.TenantID,
+		TimeZone: loc.String(),
+		StoreID:  input.StoreID,
+	}
+	if input.From != "" {
+		from, err := time.ParseInLocation("2006-01-02", input.From, loc)
+		if err != nil {
+			return nil, err
+		}
+		query.From = &from
+	}
+	if input.To != "" {
+		to, err := time.ParseInLocation("2006-01-02", input.To, loc)
+		if err != nil {
+			return nil, err
+		}
+		to = to.AddDate(0, 0, 1)
+		query.To = &to
+	}
+	if input.Period != nil {
+		period := model.SalesPeriod(*input.Period)
+		query.Period = &period
+	}
+	query.GroupBy = []model.SalesDimension{}
+	seen := map[model.SalesDimension]bool{}
+	for _, d := range input.GroupBy {
+		dimension := model.SalesDimension(d)
+		if seen[dimension] {
+			continue
+		}
+		seen[dimension] = true
+		query.GroupBy = append(query.GroupBy, dimension)
+	}
+	if len(input.Statuses) == 0 {
+		query.Statuses = []model.OrderStatus{model.StatusPending, model.StatusShipped, model.StatusDelivered}
+	}
+	for _, status := range input.Statuses {
+		query.Statuses = append(query.Statuses, model.OrderStatus(status))
+	}
+
+	// 集計軸なしで全体の合計を求める
+	totals, err := u.Repository.GetSalesReport(ctx, model.SalesReportQuery{
+		TenantID: query.TenantID,
+		From:     query.From,
+		To:       query.To,
+		Statuses: query.Statuses,
+		StoreID:  query.StoreID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.SalesReport{
+		TenantID: input.TenantID,
+		From:     query.From,
+		To:       query.To,
+		TimeZone: query.TimeZone,
+		Period:   query.Period,
+		GroupBy:  query.GroupBy,
+		Statuses: query.Statuses,
+		Rows:     []*model.SalesReportRow{},
+	}
+	if len(totals) > 0 {
+		report.Total = totals[0].SalesSummary
+	}
+
+	if query.Period == nil && len(query.GroupBy) == 0 {
+		return report, nil
+	}
+
+	report.Rows, err = u.Repository.GetSalesReport(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -11,7 +11,15 @@ type CreateCustomerRequest struct {
 	Name        string
 	Email       string
 	PhoneNumber string
-	Address     string
+	// 国内表記の電話番号の国。空の場合は日本
+	PhoneCountry string
+	// 都道府県を指定しない場合は Address を分割する
+	Address    string
+	PostalCode string
+	Prefecture string
+	City       string
+	Street     string
+	Building   string
 }
 
 type UpdateCustomerRequest struct {
@@ -20,5 +28,35 @@ type UpdateCustomerRequest struct {
 	Name        string
 	Email       string
 	PhoneNumber string
-	Address     string
+	// 国内表記の電話番号の国。空の場合は日本
+	PhoneCountry string
+	// 都道府県を指定しない場合は Address を分割する
+	Address    string
+	PostalCode string
+	Prefecture string
+	City       string
+	Street     string
+	Building   string
+}
+
+type GetCustomerDuplicatesRequest struct {
+	TenantID string
+	// 指定した顧客の重複候補のみを返す
+	CustomerID *string
+	// 氏名のみが類似する組を候補とする類似度の下限
+	MinNameSimilarity *float64
+}
+
+type MergeCustomersRequest struct {
+	TenantID  string
+	TargetID  string
+	SourceIDs []string
+	Note      string
+}
+
+type GetCustomerOrdersRequest struct {
+	TenantID   string
+	CustomerID string
+	Limit      *int
+	Offset     *int
 }
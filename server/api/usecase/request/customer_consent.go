@@ -0,0 +1,18 @@
+package request
+
+import (
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+)
+
+type RecordCustomerConsentRequest struct {
+	TenantID   string
+	CustomerID string
+	Purpose    model.ConsentPurpose
+	Granted    bool
+	Source     string
+	Note       string
+	// 同意・撤回の日時。未指定の場合は記録した日時
+	RecordedAt *time.Time
+}
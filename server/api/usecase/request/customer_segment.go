@@ -0,0 +1,25 @@
+package request
+
+import "github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+
+type CreateCustomerSegmentRequest struct {
+	TenantID    string
+	Name        string
+	Description string
+	Filter      model.SegmentFilter
+}
+
+type UpdateCustomerSegmentRequest struct {
+	TenantID    string
+	SegmentID   int
+	Name        string
+	Description string
+	Filter      model.SegmentFilter
+}
+
+type GetSegmentCustomersRequest struct {
+	TenantID  string
+	SegmentID int
+	Limit     *int
+	Offset    *int
+}
@@ -0,0 +1,51 @@
+package request
+
+import (
+	"io"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+)
+
+type ImportRequest struct {
+	TenantID string
+	StoreID  string
+	FileName string
+	Size     int64
+	Body     io.Reader
+	// CSVの文字コード。BOM付きの場合はUTF-8として読み込む
+	Encoding string
+	Mapping  model.ImportMapping
+	MatchBy  string
+	// 担当者の列がない在庫に設定する従業員
+	DefaultUserID *string
+	DryRun        bool
+}
+
+type GetImportJobsRequest struct {
+	TenantID string
+	Limit    *int
+	Offset   *int
+}
+
+// ImportStockRow は在庫の取り込み1行分。検証ルールは在庫登録APIと揃える
+type ImportStockRow struct {
+	Name         string  `json:"name" validate:"required,min=1,max=255"`
+	Quantity     int     `json:"quantity" validate:"required,numeric,gte=0"`
+	Price        int     `json:"price" validate:"required,numeric,gte=0"`
+	UserID       string  `json:"user_id" validate:"required,uuid4"`
+	Barcode      *string `json:"barcode" validate:"omitempty,min=1,max=128,printascii"`
+	JAN          *string `json:"jan" validate:"omitempty,jan"`
+	SerialNumber *string `json:"serial_number" validate:"omitempty,min=1,max=255,printascii"`
+	Category     *string `json:"category" validate:"omitempty,min=1,max=255,printascii"`
+	UnitCost     *int    `json:"unit_cost" validate:"omitempty,gte=0"`
+}
+
+// ImportCustomerRow は顧客の取り込み1行分。検証ルールは顧客登録APIと揃える
+type ImportCustomerRow struct {
+	Name         string `json:"name" validate:"required,min=1,max=255"`
+	Email        string `json:"email" validate:"required,email"`
+	PhoneNumber  string `json:"phone_number" validate:"required,phone_number=PhoneCountry"`
+	PhoneCountry string `json:"phone_country" validate:"omitempty,phone_country"`
+	Address      string `json:"address" validate:"required,min=1,max=255"`
+	PostalCode   string `json:"postal_code" validate:"omitempty,jp_postal_code"`
+}
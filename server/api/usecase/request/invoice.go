@@ -0,0 +1,16 @@
+package request
+
+type UpdateInvoiceIssuerRequest struct {
+	TenantID           string
+	RegistrationNumber string
+	Name               string
+	PostalCode         string
+	Address            string
+	PhoneNumber        string
+}
+
+type CreateCreditNoteRequest struct {
+	TenantID  string
+	InvoiceID int
+	Reason    string
+}
@@ -0,0 +1,13 @@
+package request
+
+type MarkdownRuleInput struct {
+	AgeDays         int
+	DiscountPercent int
+}
+
+type UpdateMarkdownRulesRequest struct {
+	TenantID string
+	// nilの場合は変更しない
+	FloorAtCost *bool
+	Rules       []MarkdownRuleInput
+}
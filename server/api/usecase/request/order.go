@@ -7,12 +7,23 @@ type GetOrdersRequest struct {
 }
 
 type CreateOrderRequest struct {
+	TenantID     string
 	TotalAmount  int
+	PointsUsed   int
 	Quantity     int
 	DeliveryDate string
 	Status       string
 	StockID      int
 	CustomerID   string
+	// 指定した場合はクーポンのキャンペーンを適用する
+	CouponCode *string
+}
+
+type CreateBulkOrderItemsRequest struct {
+	Orders []CreateOrderRequest
+	// ハンドラでの入力検証の結果。添字はOrdersと対応し、問題がない行はnil
+	Errors []error
+	Atomic bool
 }
 
 type UpdateOrderRequest struct {
@@ -0,0 +1,44 @@
+package request
+
+type GetOrderReturnsRequest struct {
+	TenantID string
+	Status   *string
+	OrderID  *int
+	Limit    *int
+	Offset   *int
+}
+
+type CreateOrderReturnRequest struct {
+	TenantID string
+	OrderID  int
+	Quantity int
+	Reason   string
+}
+
+type InspectOrderReturnRequest struct {
+	TenantID  string
+	ReturnID  int
+	Condition string
+	Note      string
+}
+
+type RejectOrderReturnRequest struct {
+	TenantID string
+	ReturnID int
+	Note     string
+}
+
+type CompleteOrderReturnRequest struct {
+	TenantID   string
+	ReturnID   int
+	Resolution string
+	// nilの場合はジャンク以外なら戻し入れる
+	Restock *bool
+	// nilの場合は返品された在庫に戻し入れる
+	RestockStockID *int
+	UnitCost       *int
+	// nilの場合は発注の支払いが必要な金額を数量で按分した額
+	Amount          *int
+	ExchangeOrderID *int
+	Note            string
+}
@@ -0,0 +1,26 @@
+package request
+
+type CreatePaymentRequest struct {
+	TenantID string
+	OrderID  int
+	Method   string
+	Amount   int
+	Tendered *int
+	// 決済代行会社を通す決済手段で使うトークン
+	Token     string
+	Reference string
+	Note      string
+}
+
+type CreateRefundRequest struct {
+	TenantID  string
+	OrderID   int
+	PaymentID int
+	// nilの場合は支払いのうち返金していない全額
+	Amount    *int
+	Cause     string
+	Reason    string
+	Reference string
+	// 返品による返金の場合の返品ID
+	ReturnID *int
+}
@@ -0,0 +1,8 @@
+package request
+
+type GetPointHistoryRequest struct {
+	TenantID   string
+	CustomerID string
+	Limit      *int
+	Offset     *int
+}
@@ -0,0 +1,42 @@
+package request
+
+import "time"
+
+// PromotionInput はキャンペーンの作成・更新で共通の項目
+type PromotionInput struct {
+	Name                  string
+	Description           string
+	DiscountType          string
+	DiscountValue         int
+	StockID               *int
+	Category              *string
+	StoreID               *string
+	StartsAt              *time.Time
+	EndsAt                *time.Time
+	MinSpend              int
+	UsageLimitPerCustomer int
+	UsageLimit            int
+	RequiresCoupon        bool
+	Active                bool
+}
+
+type CreatePromotionRequest struct {
+	PromotionInput
+
+	TenantID string
+}
+
+type UpdatePromotionRequest struct {
+	PromotionInput
+
+	TenantID    string
+	PromotionID int
+}
+
+type CreateCouponRequest struct {
+	TenantID    string
+	PromotionID int
+	Code        string
+	UsageLimit  int
+	Active      bool
+}
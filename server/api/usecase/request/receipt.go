@@ -0,0 +1,21 @@
+package request
+
+import "github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/receipt"
+
+type UpdateReceiptTemplateRequest struct {
+	TenantID string
+	// nilの場合は変更しない
+	PaperWidth       *int
+	Header           *string
+	Footer           *string
+	ShowStoreAddress *bool
+	ShowBarcode      *bool
+}
+
+type GetOrderReceiptRequest struct {
+	TenantID string
+	OrderID  int
+	// 用紙幅 (mm)。nilの場合は印字設定の用紙幅
+	PaperWidth *int
+	Format     receipt.Format
+}
@@ -0,0 +1,20 @@
+package request
+
+import "time"
+
+type GetInventoryValuationRequest struct {
+	TenantID string
+	AsOf     *time.Time
+	Method   *string
+}
+
+type GetSalesReportRequest struct {
+	TenantID string
+	// From・Toはレポートのタイムゾーンでの日付（YYYY-MM-DD）で、Toを含む
+	From     string
+	To       string
+	Period   *string
+	GroupBy  []string
+	Statuses []string
+	StoreID  *string
+}
@@ -1,5 +1,12 @@
 package request
 
+import (
+	"io"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/label"
+)
+
 type GetStocksRequest struct {
 	StoreID string
 	Limit   *int
@@ -12,6 +19,22 @@ type CreateStockRequest struct {
 	Price    int
 	StoreID  string
 	UserID   string
+	// 識別コード
+	Barcode      *string
+	JAN          *string
+	SerialNumber *string
+	Category     *string
+	// 消費税率 (%)。nilの場合は標準税率
+	TaxRate *int
+	// 取得原価(1点あたり)
+	UnitCost *int
+}
+
+type CreateBulkStockItemsRequest struct {
+	Stocks []CreateStockRequest
+	// ハンドラでの入力検証の結果。添字はStocksと対応し、問題がない行はnil
+	Errors []error
+	Atomic bool
 }
 
 type UpdateStockRequest struct {
@@ -21,4 +44,53 @@ type UpdateStockRequest struct {
 	Price    int
 	StoreID  string
 	UserID   string
+	// 識別コード
+	Barcode      *string
+	JAN          *string
+	SerialNumber *string
+	Category     *string
+	// 消費税率 (%)。nilの場合は変更しない
+	TaxRate *int
+}
+
+type GetStockBarcodeRequest struct {
+	StoreID     string
+	StockID     string
+	ModuleWidth int
+	Height      int
+}
+
+type GenerateStockLabelsRequest struct {
+	StoreID  string
+	StockIDs []int
+	Template label.Template
+}
+
+type UploadStockImageRequest struct {
+	StoreID string
+	StockID string
+	Size    int64
+	Body    io.Reader
+}
+
+type ReorderStockImagesRequest struct {
+	StoreID  string
+	StockID  string
+	ImageIDs []int
+}
+
+type GetStockMovementsRequest struct {
+	StoreID string
+	StockID string
+	Limit   *int
+	Offset  *int
+}
+
+type ReceiveStockRequest struct {
+	StoreID    string
+	StockID    string
+	Quantity   int
+	UnitCost   int
+	ReceivedAt *time.Time
+	Note       string
 }
@@ -0,0 +1,18 @@
+package request
+
+import "time"
+
+type GetStockPriceHistoryRequest struct {
+	StoreID string
+	StockID string
+	From    *time.Time
+	To      *time.Time
+}
+
+type SchedulePriceChangeRequest struct {
+	StoreID       string
+	StockID       string
+	Price         int
+	EffectiveFrom time.Time
+	Reason        string
+}
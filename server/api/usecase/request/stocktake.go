@@ -0,0 +1,46 @@
+package request
+
+import "github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+
+type GetStocktakesRequest struct {
+	StoreID string
+	Limit   *int
+	Offset  *int
+}
+
+type StartStocktakeRequest struct {
+	StoreID string
+	Note    string
+}
+
+type SubmitStocktakeCountsRequest struct {
+	StoreID     string
+	StocktakeID int
+	DeviceID    string
+	Counts      []StocktakeCount
+}
+
+// StocktakeCount は在庫IDまたは識別コードのどちらかで在庫を指定する
+type StocktakeCount struct {
+	StockID  *int
+	Code     *string
+	Quantity int
+}
+
+type GetStocktakeVariancesRequest struct {
+	StoreID         string
+	StocktakeID     int
+	DiscrepancyOnly bool
+}
+
+type ApproveStocktakeItemsRequest struct {
+	StoreID     string
+	StocktakeID int
+	Items       []ApproveStocktakeItem
+}
+
+type ApproveStocktakeItem struct {
+	StockID    int
+	ReasonCode model.StocktakeReasonCode
+	Note       string
+}
@@ -0,0 +1,9 @@
+package request
+
+type UpdateTenantSettingRequest struct {
+	TenantID        string
+	ValuationMethod string
+	// nilの場合は変更しない
+	PointEarnUnit     *int
+	PointExpiryMonths *int
+}
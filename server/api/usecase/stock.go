@@ -1,12 +1,24 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"image"
+	"time"
 
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/barcode"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/label"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"gorm.io/gorm"
 )
 
+// maxLabels は1回の出力で生成できるラベルの上限
+const maxLabels = 2000
+
 func (u *usecase) GetStocks(ctx context.Context, input request.GetStocksRequest) ([]*model.Stock, error) {
 	var validLimit, validOffset int
 	if input.Limit == nil || *input.Limit > 50000 {
@@ -39,12 +51,12 @@ func (u *usecase) GetStock(ctx context.Context, storeID, stockID string) (*model
 }
 
 func (u *usecase) CreateStock(ctx context.Context, stock request.CreateStockRequest) (*int, error) {
-	stockID, err := u.Repository.CreateStock(ctx, model.Stock{
-		Name:     stock.Name,
-		Quantity: stock.Quantity,
-		Price:    stock.Price,
-		StoreID:  stock.StoreID,
-		UserID:   stock.UserID,
+	var stockID *int
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		var err error
+		stockID, err = createStock(ctx, tx, stock)
+
+		return err
 	})
 	if err != nil {
 		return nil, err
@@ -57,6 +69,9 @@ func (u *usecase) CreateBulkStock(ctx context.Context, stocks []request.CreateSt
 	if len(stocks) == 0 {
 		return nil, nil
 	}
+	if err := u.checkBulkSize(len(stocks)); err != nil {
+		return nil, err
+	}
 
 	var stockModels []model.Stock
 	for _, stock := range stocks {
@@ -66,10 +81,34 @@ func (u *usecase) CreateBulkStock(ctx context.Context, stocks []request.CreateSt
 			Price:    stock.Price,
 			StoreID:  stock.StoreID,
 			UserID:   stock.UserID,
+			// 識別コード
+			Barcode:      stock.Barcode,
+			JAN:          stock.JAN,
+			SerialNumber: stock.SerialNumber,
+			Category:     stock.Category,
+			TaxRate:      taxRateOrDefault(stock.TaxRate),
 		})
 	}
 
-	stockIDs, err := u.Repository.CreateBulkStock(ctx, stockModels)
+	var stockIDs []*int
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		var err error
+		stockIDs, err = tx.CreateBulkStock(ctx, stockModels)
+		if err != nil {
+			return err
+		}
+
+		for i, stockID := range stockIDs {
+			if err := createInitialReceipt(ctx, tx, *stockID, stocks[i]); err != nil {
+				return err
+			}
+			if err := recordPriceChange(ctx, tx, *stockID, stocks[i].Price, nil, "initial registration"); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -77,19 +116,59 @@ func (u *usecase) CreateBulkStock(ctx context.Context, stocks []request.CreateSt
 	return stockIDs, nil
 }
 
+// CreateBulkStockItems は在庫を一括作成し、1件ごとの結果を返す
+func (u *usecase) CreateBulkStockItems(ctx context.Context, input request.CreateBulkStockItemsRequest) (*model.BulkResult, error) {
+	return u.createBulkItems(ctx, len(input.Stocks), input.Errors, input.Atomic, func(tx repository.RepositoryInterface, i int) (*int, error) {
+		return createStock(ctx, tx, input.Stocks[i])
+	})
+}
+
 func (u *usecase) UpdateStock(ctx context.Context, stock request.UpdateStockRequest) (*model.Stock, error) {
-	stockModel, err := u.Repository.GetStock(ctx, stock.StoreID, stock.StockID)
-	if err != nil {
-		return nil, err
-	}
+	var updatedStock *model.Stock
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		stockModel, err := tx.LockStock(ctx, stock.StoreID, stock.StockID)
+		if err != nil {
+			return err
+		}
 
-	stockModel.Name = stock.Name
-	stockModel.Quantity = stock.Quantity
-	stockModel.Price = stock.Price
-	stockModel.StoreID = stock.StoreID
-	stockModel.UserID = stock.UserID
+		// 数量の変更は調整として入出庫履歴に残す
+		if delta := stock.Quantity - stockModel.Quantity; delta != 0 {
+			if _, err := tx.CreateStockMovement(ctx, model.StockMovement{
+				StockID:    stockModel.ID,
+				Type:       model.MovementAdjustment,
+				Quantity:   delta,
+				Reason:     "manual update",
+				OccurredAt: time.Now(),
+			}); err != nil {
+				return err
+			}
+		}
 
-	updatedStock, err := u.Repository.UpdateStock(ctx, *stockModel)
+		// 価格の変更は価格の履歴に残す
+		if stock.Price != stockModel.Price {
+			previous := stockModel.Price
+			if err := recordPriceChange(ctx, tx, stockModel.ID, stock.Price, &previous, "manual update"); err != nil {
+				return err
+			}
+		}
+
+		stockModel.Name = stock.Name
+		stockModel.Quantity = stock.Quantity
+		stockModel.Price = stock.Price
+		stockModel.StoreID = stock.StoreID
+		stockModel.UserID = stock.UserID
+		stockModel.Barcode = stock.Barcode
+		stockModel.JAN = stock.JAN
+		stockModel.SerialNumber = stock.SerialNumber
+		stockModel.Category = stock.Category
+		if stock.TaxRate != nil {
+			stockModel.TaxRate = *stock.TaxRate
+		}
+
+		updatedStock, err = tx.UpdateStock(ctx, *stockModel)
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -98,9 +177,154 @@ func (u *usecase) UpdateStock(ctx context.Context, stock request.UpdateStockRequ
 }
 
 func (u *usecase) DeleteStock(ctx context.Context, storeID, stockID string) error {
+	stock, err := u.Repository.GetStock(ctx, storeID, stockID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	images, err := u.Repository.GetStockImages(ctx, stock.ID)
+	if err != nil {
+		return err
+	}
+
 	if err := u.Repository.DeleteStock(ctx, storeID, stockID); err != nil {
 		return err
 	}
 
-	return nil
+	// 画像のレコードは外部キーで削除されるため、保存先のファイルのみ消す
+	return u.deleteImageObjects(ctx, images...)
+}
+
+func (u *usecase) LookupStock(ctx context.Context, storeID, code string) (*model.Stock, error) {
+	return u.Repository.GetStockByCode(ctx, storeID, code)
+}
+
+func (u *usecase) GetStockBarcode(ctx context.Context, input request.GetStockBarcodeRequest) (image.Image, error) {
+	stock, err := u.Repository.GetStock(ctx, input.StoreID, input.StockID)
+	if err != nil {
+		return nil, err
+	}
+
+	code, ok := stock.Code()
+	if !ok {
+		return nil, ErrStockCodeNotSet
+	}
+
+	moduleWidth := input.ModuleWidth
+	if moduleWidth == 0 {
+		moduleWidth = 2
+	}
+	height := input.Height
+	if height == 0 {
+		height = 80
+	}
+
+	img, err := barcode.Code128Image(code, moduleWidth, height)
+	if errors.Is(err, barcode.ErrUnencodable) {
+		return nil, fmt.Errorf("%w: %v", ErrStockCodeNotEncodable, err)
+	}
+
+	return img, err
+}
+
+func (u *usecase) GenerateStockLabels(ctx context.Context, input request.GenerateStockLabelsRequest) ([]byte, error) {
+	font, err := u.pdfFont()
+	if err != nil {
+		return nil, err
+	}
+
+	stocks, err := u.Repository.GetStocksByIDs(ctx, input.StoreID, input.StockIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 指定された順序でラベルを並べる
+	stockMap := make(map[int]*model.Stock, len(stocks))
+	for _, stock := range stocks {
+		stockMap[stock.ID] = stock
+	}
+	ordered := make([]*model.Stock, 0, len(input.StockIDs))
+	for _, id := range input.StockIDs {
+		stock, ok := stockMap[id]
+		if !ok {
+			return nil, fmt.Errorf("stock %d: %w", id, gorm.ErrRecordNotFound)
+		}
+		ordered = append(ordered, stock)
+	}
+
+	count := label.Count(ordered)
+	if count == 0 {
+		return nil, ErrNoLabels
+	}
+	if count > maxLabels {
+		return nil, fmt.Errorf("%w: %d labels (max %d)", ErrTooManyLabels, count, maxLabels)
+	}
+
+	var buf bytes.Buffer
+	if err := label.Render(&buf, font, input.Template, ordered); err != nil {
+		if errors.Is(err, barcode.ErrUnencodable) {
+			return nil, fmt.Errorf("%w: %v", ErrStockCodeNotEncodable, err)
+		}
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func createStock(ctx context.Context, tx repository.RepositoryInterface, stock request.CreateStockRequest) (*int, error) {
+	stockID, err := tx.CreateStock(ctx, model.Stock{
+		Name:     stock.Name,
+		Quantity: stock.Quantity,
+		Price:    stock.Price,
+		StoreID:  stock.StoreID,
+		UserID:   stock.UserID,
+		// 識別コード
+		Barcode:      stock.Barcode,
+		JAN:          stock.JAN,
+		SerialNumber: stock.SerialNumber,
+		Category:     stock.Category,
+		TaxRate:      taxRateOrDefault(stock.TaxRate),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := createInitialReceipt(ctx, tx, *stockID, stock); err != nil {
+		return nil, err
+	}
+	if err := recordPriceChange(ctx, tx, *stockID, stock.Price, nil, "initial registration"); err != nil {
+		return nil, err
+	}
+
+	return stockID, nil
+}
+
+// createInitialReceipt は在庫登録時の数量を取得原価付きの入庫として記録する
+func createInitialReceipt(ctx context.Context, tx repository.RepositoryInterface, stockID int, stock request.CreateStockRequest) error {
+	if stock.Quantity == 0 {
+		return nil
+	}
+
+	_, err := tx.CreateStockMovement(ctx, model.StockMovement{
+		StockID:    stockID,
+		Type:       model.MovementReceipt,
+		Quantity:   stock.Quantity,
+		UnitCost:   stock.UnitCost,
+		Reason:     "initial registration",
+		OccurredAt: time.Now(),
+	})
+
+	return err
+}
+
+// taxRateOrDefault は指定がない場合に標準税率を返す
+func taxRateOrDefault(rate *int) int {
+	if rate == nil {
+		return model.StandardTaxRate
+	}
+
+	return *rate
 }
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // GIFのデコードに必要
+	"image/jpeg"
+	_ "image/png" // PNGのデコードに必要
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/storage"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/google/uuid"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // WebPのデコードに必要
+)
+
+// thumbnailSize はサムネイルの長辺のピクセル数
+const thumbnailSize = 320
+
+// imageExtensions はアップロードを許可する画像形式と保存時の拡張子
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+func (u *usecase) GetStockImages(ctx context.Context, storeID, stockID string) ([]*model.StockImage, error) {
+	stock, err := u.Repository.GetStock(ctx, storeID, stockID)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := u.Repository.GetStockImages(ctx, stock.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.signStockImages(ctx, images...); err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (u *usecase) UploadStockImage(ctx context.Context, input request.UploadStockImageRequest) (*model.StockImage, error) {
+	limit := u.Config.MaxImageSize
+	if input.Size > limit {
+		return nil, fmt.Errorf("%w: %d bytes (max %d)", ErrImageTooLarge, input.Size, limit)
+	}
+
+	// 他の店舗の在庫や存在しない在庫への画像は読み込む前に断る
+	stock, err := u.Repository.GetStock(ctx, input.StoreID, input.StockID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(input.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: max %d bytes", ErrImageTooLarge, limit)
+	}
+
+	// クライアントの申告ではなく内容から形式を判定する
+	contentType := http.DetectContentType(body)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedImageType, contentType)
+	}
+
+	// 小さなファイルでも展開後に巨大になる画像があるため、デコードする前に大きさを確かめる
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedImageType, err)
+	}
+	if cfg.Width > u.Config.MaxImageDimension || cfg.Height > u.Config.MaxImageDimension ||
+		cfg.Width*cfg.Height > u.Config.MaxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels (max %d per side, %d in total)",
+			ErrImageTooLarge, cfg.Width, cfg.Height, u.Config.MaxImageDimension, u.Config.MaxImagePixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedImageType, err)
+	}
+
+	thumbnail, err := makeThumbnail(img)
+	if err != nil {
+		return nil, err
+	}
+
+	name := uuid.NewString()
+	key := path.Join("stocks", strconv.Itoa(stock.ID), name+ext)
+	thumbnailKey := path.Join("stocks", strconv.Itoa(stock.ID), name+"_thumb.jpg")
+
+	if err := u.Storage.Put(ctx, key, body, contentType); err != nil {
+		return nil, err
+	}
+	if err := u.Storage.Put(ctx, thumbnailKey, thumbnail, "image/jpeg"); err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	var created *model.StockImage
+	err = u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		// 同時にアップロードされた画像が同じ位置にならないよう、在庫の行をロックして末尾の位置を決める
+		if _, err := tx.LockStock(ctx, input.StoreID, input.StockID); err != nil {
+			return err
+		}
+
+		images, err := tx.GetStockImages(ctx, stock.ID)
+		if err != nil {
+			return err
+		}
+		position := 0
+		if len(images) > 0 {
+			position = images[len(images)-1].Position + 1
+		}
+
+		created, err = tx.CreateStockImage(ctx, model.StockImage{
+			StockID:      stock.ID,
+			Position:     position,
+			ContentType:  contentType,
+			Size:         int64(len(body)),
+			Width:        bounds.Dx(),
+			Height:       bounds.Dy(),
+			StorageKey:   key,
+			ThumbnailKey: thumbnailKey,
+		})
+		return err
+	})
+	if err != nil {
+		// 登録に失敗した場合は保存済みのファイルを消しておく
+		return nil, errors.Join(err, u.Storage.Delete(ctx, key), u.Storage.Delete(ctx, thumbnailKey))
+	}
+
+	if err := u.signStockImages(ctx, created); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (u *usecase) ReorderStockImages(ctx context.Context, input request.ReorderStockImagesRequest) ([]*model.StockImage, error) {
+	stock, err := u.Repository.GetStock(ctx, input.StoreID, input.StockID)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := u.Repository.GetStockImages(ctx, stock.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 登録済みの画像IDをちょうど1回ずつ含んでいる必要がある
+	remaining := make(map[int]bool, len(images))
+	for _, img := range images {
+		remaining[img.ID] = true
+	}
+	for _, id := range input.ImageIDs {
+		if !remaining[id] {
+			return nil, fmt.Errorf("%w: unknown or duplicated image id %d", ErrInvalidImageOrder, id)
+		}
+		delete(remaining, id)
+	}
+	if len(remaining) > 0 {
+		return nil, fmt.Errorf("%w: all %d images must be listed", ErrInvalidImageOrder, len(images))
+	}
+
+	if err := u.Repository.UpdateStockImagePositions(ctx, stock.ID, input.ImageIDs); err != nil {
+		return nil, err
+	}
+
+	return u.GetStockImages(ctx, input.StoreID, input.StockID)
+}
+
+func (u *usecase) DeleteStockImage(ctx context.Context, storeID, stockID string, imageID int) error {
+	stock, err := u.Repository.GetStock(ctx, storeID, stockID)
+	if err != nil {
+		return err
+	}
+
+	img, err := u.Repository.GetStockImage(ctx, stock.ID, imageID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.Repository.DeleteStockImage(ctx, stock.ID, img.ID); err != nil {
+		return err
+	}
+
+	return u.deleteImageObjects(ctx, img)
+}
+
+func (u *usecase) GetMedia(ctx context.Context, key string, expires int64, signature string) (io.ReadCloser, string, error) {
+	verifier, ok := u.Storage.(storage.Verifier)
+	if !ok {
+		// 外部ストレージの場合は署名付きURLで直接配信される
+		return nil, "", storage.ErrNotFound
+	}
+
+	if err := verifier.Verify(key, expires, signature); err != nil {
+		return nil, "", err
+	}
+
+	body, err := u.Storage.Get(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return body, mime.TypeByExtension(path.Ext(key)), nil
+}
+
+func (u *usecase) signStockImages(ctx context.Context, images ...*model.StockImage) error {
+	for _, img := range images {
+		url, err := u.Storage.SignedURL(ctx, img.StorageKey, u.Config.SignedURLTTL)
+		if err != nil {
+			return err
+		}
+		thumbnailURL, err := u.Storage.SignedURL(ctx, img.ThumbnailKey, u.Config.SignedURLTTL)
+		if err != nil {
+			return err
+		}
+		img.URL = url
+		img.ThumbnailURL = thumbnailURL
+	}
+
+	return nil
+}
+
+func (u *usecase) deleteImageObjects(ctx context.Context, images ...*model.StockImage) error {
+	var errs []error
+	for _, img := range images {
+		errs = append(errs, u.Storage.Delete(ctx, img.StorageKey), u.Storage.Delete(ctx, img.ThumbnailKey))
+	}
+
+	return errors.Join(errs...)
+}
+
+// makeThumbnail は長辺がthumbnailSizeに収まるよう縮小したJPEGを生成する
+func makeThumbnail(src image.Image) ([]byte, error) {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w > thumbnailSize || h > thumbnailSize {
+		if w >= h {
+			w, h = thumbnailSize, max(h*thumbnailSize/w, 1)
+		} else {
+			w, h = max(w*thumbnailSize/h, 1), thumbnailSize
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	// 透過部分はJPEGで黒くならないよう白で塗りつぶす
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
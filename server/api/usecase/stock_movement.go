@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+)
+
+func (u *usecase) GetStockMovements(ctx context.Context, input request.GetStockMovementsRequest) ([]*model.StockMovement, error) {
+	var validLimit, validOffset int
+	if input.Limit == nil || *input.Limit > 50000 {
+		validLimit = 50000
+	} else {
+		validLimit = *input.Limit
+	}
+
+	if input.Offset == nil {
+		validOffset = 0
+	} else {
+		validOffset = *input.Offset
+	}
+
+	stock, err := u.Repository.GetStock(ctx, input.StoreID, input.StockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.Repository.GetStockMovements(ctx, stock.ID, validLimit, validOffset)
+}
+
+func (u *usecase) ReceiveStock(ctx context.Context, input request.ReceiveStockRequest) (*model.StockMovement, error) {
+	occurredAt := time.Now()
+	if input.ReceivedAt != nil {
+		occurredAt = *input.ReceivedAt
+	}
+
+	var movement *model.StockMovement
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		stock, err := tx.GetStock(ctx, input.StoreID, input.StockID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.AdjustStockQuantity(ctx, stock.ID, input.Quantity); err != nil {
+			return err
+		}
+
+		movement, err = tx.CreateStockMovement(ctx, model.StockMovement{
+			StockID:    stock.ID,
+			Type:       model.MovementReceipt,
+			Quantity:   input.Quantity,
+			UnitCost:   &input.UnitCost,
+			Reason:     input.Note,
+			OccurredAt: occurredAt,
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+// moveStockForOrder は発注による在庫の消費量の変化を在庫数量と入出庫履歴に反映する
+// キャンセルされた発注は在庫を消費しない。在庫がテナントに属さない場合はgorm.ErrRecordNotFoundを返す
+// 在庫数量が足りない場合も発注は受け付け、在庫数量をマイナスにする
+func moveStockForOrder(ctx context.Context, tx repository.RepositoryInterface, tenantID string, before, after *model.Order) error {
+	if _, err := tx.GetStockOwner(ctx, tenantID, after.StockID); err != nil {
+		return err
+	}
+
+	consumed := func(o *model.Order) int {
+		if o == nil || o.Status == model.StatusCancelled {
+			return 0
+		}
+
+		return o.Quantity
+	}
+
+	delta := consumed(after) - consumed(before)
+	if delta == 0 {
+		return nil
+	}
+
+	if err := tx.ShiftStockQuantity(ctx, after.StockID, -delta); err != nil {
+		return err
+	}
+
+	reason := "sale"
+	if delta < 0 {
+		reason = "sale reversal"
+	}
+	_, err := tx.CreateStockMovement(ctx, model.StockMovement{
+		StockID:    after.StockID,
+		Type:       model.MovementSale,
+		Quantity:   -delta,
+		OrderID:    &after.ID,
+		Reason:     reason,
+		OccurredAt: time.Now(),
+	})
+
+	return err
+}
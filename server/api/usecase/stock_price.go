@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+)
+
+// priceChangeBatchSize は予約した価格の変更を1回のトランザクションで適用する件数
+const priceChangeBatchSize = 500
+
+// GetStockPriceHistory は在庫の販売価格の推移と適用待ちの変更を取得する
+func (u *usecase) GetStockPriceHistory(ctx context.Context, input request.GetStockPriceHistoryRequest) (*model.StockPriceHistory, error) {
+	stock, err := u.Repository.GetStock(ctx, input.StoreID, input.StockID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := u.Repository.GetStockPriceChanges(ctx, stock.ID, model.PriceChangeApplied, input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled, err := u.Repository.GetStockPriceChanges(ctx, stock.ID, model.PriceChangeScheduled, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.StockPriceHistory{
+		StockID:      stock.ID,
+		CurrentPrice: stock.Price,
+		Changes:      changes,
+		Scheduled:    scheduled,
+	}, nil
+}
+
+// SchedulePriceChange は在庫の販売価格の変更を予約する。有効日時を過ぎるとワーカーが適用する
+func (u *usecase) SchedulePriceChange(ctx context.Context, input request.SchedulePriceChangeRequest) (*model.StockPriceChange, error) {
+	if !input.EffectiveFrom.After(time.Now()) {
+		return nil, ErrPriceChangeNotInFuture
+	}
+
+	stock, err := u.Repository.GetStock(ctx, input.StoreID, input.StockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.Repository.CreateStockPriceChange(ctx, model.StockPriceChange{
+		StockID:       stock.ID,
+		Price:         input.Price,
+		EffectiveFrom: input.EffectiveFrom,
+		Status:        model.PriceChangeScheduled,
+		Reason:        input.Reason,
+	})
+}
+
+// CancelPriceChange は適用待ちの価格の変更を取り消す
+func (u *usecase) CancelPriceChange(ctx context.Context, storeID, stockID string, changeID int) (*model.StockPriceChange, error) {
+	stock, err := u.Repository.GetStock(ctx, storeID, stockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.Repository.CancelStockPriceChange(ctx, stock.ID, changeID)
+}
+
+// ApplyPriceChanges は有効日時を過ぎた予約済みの価格の変更を有効日時の順に適用し、適用した件数を返す
+func (u *usecase) ApplyPriceChanges(ctx context.Context) (int, error) {
+	applied := 0
+
+	for {
+		var changes []*model.StockPriceChange
+		err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+			now := time.Now()
+
+			var err error
+			changes, err = tx.LockDuePriceChanges(ctx, now, priceChangeBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, change := range changes {
+				previous, err := tx.SetStockPrice(ctx, change.StockID, change.Price)
+				if err != nil {
+					return err
+				}
+				if err := tx.MarkPriceChangeApplied(ctx, change.ID, previous, now); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return applied, err
+		}
+		applied += len(changes)
+
+		if len(changes) < priceChangeBatchSize {
+			break
+		}
+	}
+
+	return applied, nil
+}
+
+// ProcessPriceChanges は定期実行用に予約した価格の変更を適用する
+func (u *usecase) ProcessPriceChanges(ctx context.Context) error {
+	_, err := u.ApplyPriceChanges(ctx)
+
+	return err
+}
+
+// recordPriceChange は即時に行った販売価格の変更を適用済みとして記録する
+func recordPriceChange(ctx context.Context, tx repository.RepositoryInterface, stockID, price int, previousPrice *int, reason string) error {
+	now := time.Now()
+	_, err := tx.CreateStockPriceChange(ctx, model.StockPriceChange{
+		StockID:       stockID,
+		Price:         price,
+		PreviousPrice: previousPrice,
+		EffectiveFrom: now,
+		Status:        model.PriceChangeApplied,
+		AppliedAt:     &now,
+		Reason:        reason,
+	})
+
+	return err
+}
@@ -0,0 +1,391 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"gorm.io/gorm"
+)
+
+func (u *usecase) GetStocktakes(ctx context.Context, input request.GetStocktakesRequest) ([]*model.Stocktake, error) {
+	var validLimit, validOffset int
+	if input.Limit == nil || *input.Limit > 50000 {
+		validLimit = 50000
+	} else {
+		validLimit = *input.Limit
+	}
+
+	if input.Offset == nil {
+		validOffset = 0
+	} else {
+		validOffset = *input.Offset
+	}
+
+	return u.Repository.GetStocktakes(ctx, input.StoreID, validLimit, validOffset)
+}
+
+func (u *usecase) GetStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error) {
+	return u.Repository.GetStocktake(ctx, storeID, stocktakeID)
+}
+
+func (u *usecase) StartStocktake(ctx context.Context, input request.StartStocktakeRequest) (*model.Stocktake, error) {
+	var stocktake *model.Stocktake
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		var err error
+		stocktake, err = tx.CreateStocktake(ctx, model.Stocktake{
+			StoreID:    input.StoreID,
+			Status:     model.StocktakeCounting,
+			Note:       input.Note,
+			SnapshotAt: time.Now(),
+		})
+
+		return err
+	})
+	// 進行中の棚卸は店舗ごとに1件までとする一意制約に違反した
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, ErrStocktakeInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return stocktake, nil
+}
+
+// SubmitStocktakeCounts は端末のカウントを登録し、登録した在庫の差異を返す
+// 締め切りと同時に登録されないよう、棚卸を行ロックしてから状態を確かめる
+func (u *usecase) SubmitStocktakeCounts(ctx context.Context, input request.SubmitStocktakeCountsRequest) ([]*model.StocktakeVariance, error) {
+	var variances []*model.StocktakeVariance
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		stocktake, err := tx.LockStocktake(ctx, input.StoreID, input.StocktakeID)
+		if err != nil {
+			return err
+		}
+		if stocktake.Status != model.StocktakeCounting {
+			return ErrStocktakeStatus
+		}
+
+		items, err := tx.GetStocktakeItems(ctx, stocktake.ID)
+		if err != nil {
+			return err
+		}
+		itemMap := make(map[int]*model.StocktakeItem, len(items))
+		for _, item := range items {
+			itemMap[item.StockID] = item
+		}
+
+		now := time.Now()
+		counts := make([]model.StocktakeCount, 0, len(input.Counts))
+		// 同じリクエスト内で同じ在庫が複数回指定された場合は合算する
+		index := make(map[int]int, len(input.Counts))
+		for _, c := range input.Counts {
+			stockID, err := resolveStocktakeStock(ctx, tx, input.StoreID, c)
+			if err != nil {
+				return err
+			}
+			if _, ok := itemMap[stockID]; !ok {
+				return fmt.Errorf("stock %d: %w", stockID, ErrStockNotInStocktake)
+			}
+
+			if i, ok := index[stockID]; ok {
+				counts[i].Quantity += c.Quantity
+				continue
+			}
+			index[stockID] = len(counts)
+			counts = append(counts, model.StocktakeCount{
+				StocktakeID: stocktake.ID,
+				StockID:     stockID,
+				DeviceID:    input.DeviceID,
+				Quantity:    c.Quantity,
+				CountedAt:   now,
+			})
+		}
+
+		if err := tx.SaveStocktakeCounts(ctx, stocktake.ID, counts); err != nil {
+			return err
+		}
+
+		items, err = tx.GetStocktakeItems(ctx, stocktake.ID)
+		if err != nil {
+			return err
+		}
+
+		variances = make([]*model.StocktakeVariance, 0, len(counts))
+		for _, item := range items {
+			if _, ok := index[item.StockID]; ok {
+				variances = append(variances, newStocktakeVariance(item))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return variances, nil
+}
+
+func (u *usecase) GetStocktakeVariances(ctx context.Context, input request.GetStocktakeVariancesRequest) ([]*model.StocktakeVariance, error) {
+	stocktake, err := u.Repository.GetStocktake(ctx, input.StoreID, input.StocktakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.Repository.GetStocktakeItems(ctx, stocktake.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	variances := make([]*model.StocktakeVariance, 0, len(items))
+	for _, item := range items {
+		v := newStocktakeVariance(item)
+		// 差異のみの場合、未カウントの在庫も確認が必要なため含める
+		if input.DiscrepancyOnly && v.Variance != nil && *v.Variance == 0 {
+			continue
+		}
+		variances = append(variances, v)
+	}
+
+	return variances, nil
+}
+
+// CloseStocktake はカウントを締め切り、差異確認に進める
+func (u *usecase) CloseStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error) {
+	return u.changeStocktakeStatus(ctx, storeID, stocktakeID, model.StocktakeReviewing, model.StocktakeCounting)
+}
+
+// CompleteStocktake は差異確認を終えて棚卸を完了する
+func (u *usecase) CompleteStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error) {
+	return u.changeStocktakeStatus(ctx, storeID, stocktakeID, model.StocktakeCompleted, model.StocktakeReviewing)
+}
+
+// CancelStocktake は棚卸を中止する。承認済みの調整は取り消さない
+func (u *usecase) CancelStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error) {
+	return u.changeStocktakeStatus(ctx, storeID, stocktakeID, model.StocktakeCancelled, model.StocktakeCounting, model.StocktakeReviewing)
+}
+
+// ApproveStocktakeItems は差異を理由コード付きで承認し、在庫数量に反映する
+// 実数は承認時点の在庫を数えたものとみなし、帳簿数量にスナップショット以降の入出庫を加えた数量との差を調整する
+func (u *usecase) ApproveStocktakeItems(ctx context.Context, input request.ApproveStocktakeItemsRequest) ([]*model.StocktakeVariance, error) {
+	var approved []*model.StocktakeVariance
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		stocktake, err := tx.LockStocktake(ctx, input.StoreID, input.StocktakeID)
+		if err != nil {
+			return err
+		}
+		if stocktake.Status != model.StocktakeReviewing {
+			return ErrStocktakeStatus
+		}
+
+		items, err := tx.GetStocktakeItems(ctx, stocktake.ID)
+		if err != nil {
+			return err
+		}
+		itemMap := make(map[int]*model.StocktakeItem, len(items))
+		for _, item := range items {
+			itemMap[item.StockID] = item
+		}
+
+		now := time.Now()
+		for _, in := range input.Items {
+			item, ok := itemMap[in.StockID]
+			if !ok {
+				return fmt.Errorf("stock %d: %w", in.StockID, ErrStockNotInStocktake)
+			}
+			variance := item.Variance()
+			if variance == nil {
+				return fmt.Errorf("stock %d: %w", in.StockID, ErrStocktakeItemNotCounted)
+			}
+			// 承認済みの差異は二重に反映しない
+			if item.ApprovedAt != nil {
+				approved = append(approved, newStocktakeVariance(item))
+				continue
+			}
+
+			// 入出庫の合計から調整までの間に販売などで数量が変わらないよう、在庫を行ロックしておく
+			if _, err := tx.LockStock(ctx, input.StoreID, strconv.Itoa(item.StockID)); err != nil {
+				return fmt.Errorf("stock %d: %w", item.StockID, err)
+			}
+			moved, err := tx.SumStockMovementsSince(ctx, item.StockID, stocktake.SnapshotAt)
+			if err != nil {
+				return err
+			}
+			adjustment := *item.CountedQuantity - (item.SystemQuantity + moved)
+
+			if adjustment != 0 {
+				if err := tx.AdjustStockQuantity(ctx, item.StockID, adjustment); err != nil {
+					return fmt.Errorf("stock %d: %w", item.StockID, err)
+				}
+
+				reason := string(in.ReasonCode)
+				if in.Note != "" {
+					reason += ": " + in.Note
+				}
+				movement, err := tx.CreateStockMovement(ctx, model.StockMovement{
+					StockID:    item.StockID,
+					Type:       model.MovementStocktake,
+					Quantity:   adjustment,
+					Reason:     reason,
+					OccurredAt: now,
+				})
+				if err != nil {
+					return err
+				}
+				item.MovementID = &movement.ID
+			}
+
+			reasonCode := in.ReasonCode
+			item.ReasonCode = &reasonCode
+			item.Note = in.Note
+			item.ApprovedAt = &now
+			item.AdjustedQuantity = &adjustment
+			if err := tx.UpdateStocktakeItem(ctx, *item); err != nil {
+				return err
+			}
+
+			approved = append(approved, newStocktakeVariance(item))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return approved, nil
+}
+
+func (u *usecase) GetStocktakeReport(ctx context.Context, storeID string, stocktakeID int) (*model.StocktakeReport, error) {
+	stocktake, err := u.Repository.GetStocktake(ctx, storeID, stocktakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.Repository.GetStocktakeItems(ctx, stocktake.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.StocktakeReport{
+		Stocktake:  stocktake,
+		TotalItems: len(items),
+		ByReason:   []*model.StocktakeReasonTotal{},
+		Items:      make([]*model.StocktakeVariance, 0, len(items)),
+	}
+	reasons := map[model.StocktakeReasonCode]*model.StocktakeReasonTotal{}
+	for _, item := range items {
+		v := newStocktakeVariance(item)
+		report.Items = append(report.Items, v)
+		report.SystemQuantity += item.SystemQuantity
+
+		if v.Variance == nil {
+			report.UncountedItems++
+			continue
+		}
+		report.CountedItems++
+		report.CountedQuantity += *item.CountedQuantity
+		report.VarianceQuantity += *v.Variance
+		report.VarianceValue += *v.VarianceValue
+		if *v.Variance != 0 {
+			report.DiscrepancyItems++
+		}
+
+		if item.ApprovedAt == nil {
+			continue
+		}
+		report.ApprovedItems++
+		if item.ReasonCode == nil || *v.Variance == 0 {
+			continue
+		}
+		total, ok := reasons[*item.ReasonCode]
+		if !ok {
+			total = &model.StocktakeReasonTotal{ReasonCode: *item.ReasonCode}
+			reasons[*item.ReasonCode] = total
+			report.ByReason = append(report.ByReason, total)
+		}
+		total.Items++
+		total.Quantity += *v.Variance
+		total.Value += *v.VarianceValue
+	}
+
+	return report, nil
+}
+
+func (u *usecase) changeStocktakeStatus(ctx context.Context, storeID string, stocktakeID int, to model.StocktakeStatus, from ...model.StocktakeStatus) (*model.Stocktake, error) {
+	var updated *model.Stocktake
+	err := u.Repository.Transaction(ctx, func(tx repository.RepositoryInterface) error {
+		stocktake, err := tx.LockStocktake(ctx, storeID, stocktakeID)
+		if err != nil {
+			return err
+		}
+
+		allowed := false
+		for _, status := range from {
+			if stocktake.Status == status {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return ErrStocktakeStatus
+		}
+
+		now := time.Now()
+		stocktake.Status = to
+		switch to {
+		case model.StocktakeReviewing:
+			stocktake.ClosedAt = &now
+		case model.StocktakeCompleted:
+			stocktake.CompletedAt = &now
+		}
+
+		updated, err = tx.UpdateStocktake(ctx, *stocktake)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// resolveStocktakeStock はカウントの在庫IDを返す。識別コードで指定された場合は在庫を検索する
+func resolveStocktakeStock(ctx context.Context, r repository.RepositoryInterface, storeID string, count request.StocktakeCount) (int, error) {
+	if count.StockID != nil {
+		return *count.StockID, nil
+	}
+	if count.Code == nil {
+		return 0, ErrStockNotInStocktake
+	}
+
+	stock, err := r.GetStockByCode(ctx, storeID, *count.Code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("code %s: %w", strconv.Quote(*count.Code), ErrStockNotInStocktake)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return stock.ID, nil
+}
+
+// newStocktakeVariance は差異と、販売価格で換算した差異金額を計算する
+func newStocktakeVariance(item *model.StocktakeItem) *model.StocktakeVariance {
+	v := &model.StocktakeVariance{
+		StocktakeItem: *item,
+		Variance:      item.Variance(),
+	}
+	if v.Variance != nil {
+		value := *v.Variance * item.Price
+		v.VarianceValue = &value
+	}
+
+	return v
+}
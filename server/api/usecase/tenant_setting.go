@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+)
+
+func (u *usecase) GetTenantSetting(ctx context.Context, tenantID string) (*model.TenantSetting, error) {
+	return u.Repository.GetTenantSetting(ctx, tenantID)
+}
+
+func (u *usecase) UpdateTenantSetting(ctx context.Context, input request.UpdateTenantSettingRequest) (*model.TenantSetting, error) {
+	setting, err := u.Repository.GetTenantSetting(ctx, input.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	setting.ValuationMethod = model.ValuationMethod(input.ValuationMethod)
+	if input.PointEarnUnit != nil {
+		setting.PointEarnUnit = *input.PointEarnUnit
+	}
+	if input.PointExpiryMonths != nil {
+		setting.PointExpiryMonths = *input.PointExpiryMonths
+	}
+
+	return u.Repository.SaveTenantSetting(ctx, *setting)
+}
@@ -2,10 +2,17 @@ package usecase
 
 import (
 	"context"
+	"image"
+	"io"
 
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/payment"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/storage"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/domain/model"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/pdf"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/sheet"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase/request"
+	"github.com/buysell-technologies/summer-internship-2024-backend/config"
 )
 
 type usecase struct {
@@ -13,10 +20,25 @@ type usecase struct {
 }
 
 type UsecaseBundle struct {
+	Config     *config.Config
 	Repository repository.RepositoryInterface
+	Storage    storage.Storage
+	// クレジットカード・QRコード決済を処理する決済代行会社
+	PaymentGateway payment.Gateway
+	// 帳票に埋め込む日本語フォント。読み込めなかった場合はnil
+	Font      *pdf.Font
+	Validator Validator
+}
+
+// Validator は取り込みの各行をハンドラと同じ検証ルールで検証する
+type Validator interface {
+	Validate(i interface{}) error
 }
 
 type UsecaseInterface interface {
+	/* tenant setting */
+	GetTenantSetting(ctx context.Context, tenantID string) (*model.TenantSetting, error)
+	UpdateTenantSetting(ctx context.Context, input request.UpdateTenantSettingRequest) (*model.TenantSetting, error)
 	/* user */
 	GetUsers(ctx context.Context, input request.GetUsersRequest) ([]*model.User, error)
 	GetUser(ctx context.Context, tenantID, userID string) (*model.User, error)
@@ -28,22 +50,156 @@ type UsecaseInterface interface {
 	GetStock(ctx context.Context, storeID, stockID string) (*model.Stock, error)
 	CreateStock(ctx context.Context, stock request.CreateStockRequest) (*int, error)
 	CreateBulkStock(ctx context.Context, stocks []request.CreateStockRequest) ([]*int, error)
+	CreateBulkStockItems(ctx context.Context, input request.CreateBulkStockItemsRequest) (*model.BulkResult, error)
 	UpdateStock(ctx context.Context, stock request.UpdateStockRequest) (*model.Stock, error)
 	DeleteStock(ctx context.Context, storeID, stockID string) error
+	LookupStock(ctx context.Context, storeID, code string) (*model.Stock, error)
+	GetStockBarcode(ctx context.Context, input request.GetStockBarcodeRequest) (image.Image, error)
+	GenerateStockLabels(ctx context.Context, input request.GenerateStockLabelsRequest) ([]byte, error)
+	/* stock movement */
+	GetStockMovements(ctx context.Context, input request.GetStockMovementsRequest) ([]*model.StockMovement, error)
+	ReceiveStock(ctx context.Context, input request.ReceiveStockRequest) (*model.StockMovement, error)
+	/* stock price */
+	GetStockPriceHistory(ctx context.Context, input request.GetStockPriceHistoryRequest) (*model.StockPriceHistory, error)
+	SchedulePriceChange(ctx context.Context, input request.SchedulePriceChangeRequest) (*model.StockPriceChange, error)
+	CancelPriceChange(ctx context.Context, storeID, stockID string, changeID int) (*model.StockPriceChange, error)
+	ApplyPriceChanges(ctx context.Context) (int, error)
+	ProcessPriceChanges(ctx context.Context) error
+	/* markdown */
+	GetMarkdownRules(ctx context.Context, tenantID string) (*model.MarkdownRuleSet, error)
+	UpdateMarkdownRules(ctx context.Context, input request.UpdateMarkdownRulesRequest) (*model.MarkdownRuleSet, error)
+	PreviewMarkdowns(ctx context.Context, tenantID string) (*model.MarkdownPlan, error)
+	ApplyMarkdowns(ctx context.Context, tenantID *string) (int, error)
+	ProcessMarkdowns(ctx context.Context) error
+	/* stocktake */
+	GetStocktakes(ctx context.Context, input request.GetStocktakesRequest) ([]*model.Stocktake, error)
+	GetStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error)
+	StartStocktake(ctx context.Context, input request.StartStocktakeRequest) (*model.Stocktake, error)
+	SubmitStocktakeCounts(ctx context.Context, input request.SubmitStocktakeCountsRequest) ([]*model.StocktakeVariance, error)
+	GetStocktakeVariances(ctx context.Context, input request.GetStocktakeVariancesRequest) ([]*model.StocktakeVariance, error)
+	CloseStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error)
+	ApproveStocktakeItems(ctx context.Context, input request.ApproveStocktakeItemsRequest) ([]*model.StocktakeVariance, error)
+	CompleteStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error)
+	CancelStocktake(ctx context.Context, storeID string, stocktakeID int) (*model.Stocktake, error)
+	GetStocktakeReport(ctx context.Context, storeID string, stocktakeID int) (*model.StocktakeReport, error)
+	/* stock image */
+	GetStockImages(ctx context.Context, storeID, stockID string) ([]*model.StockImage, error)
+	UploadStockImage(ctx context.Context, input request.UploadStockImageRequest) (*model.StockImage, error)
+	ReorderStockImages(ctx context.Context, input request.ReorderStockImagesRequest) ([]*model.StockImage, error)
+	DeleteStockImage(ctx context.Context, storeID, stockID string, imageID int) error
+	GetMedia(ctx context.Context, key string, expires int64, signature string) (io.ReadCloser, string, error)
 	/* customer */
 	GetCustomers(ctx context.Context, input request.GetCustomersRequest) ([]*model.Customer, error)
 	GetCustomer(ctx context.Context, tenantID, customerID string) (*model.Customer, error)
 	CreateCustomer(ctx context.Context, customer request.CreateCustomerRequest) (*string, error)
 	UpdateCustomer(ctx context.Context, customer request.UpdateCustomerRequest) (*model.Customer, error)
 	DeleteCustomer(ctx context.Context, tenantID, customerID string) error
+	/* customer consent */
+	GetCustomerConsents(ctx context.Context, tenantID, customerID string) (*model.CustomerConsents, error)
+	RecordCustomerConsent(ctx context.Context, input request.RecordCustomerConsentRequest) (*model.CustomerConsent, error)
+	GetCustomerPersonalData(ctx context.Context, tenantID, customerID string) (*model.CustomerPersonalData, error)
+	AnonymizeCustomer(ctx context.Context, tenantID, customerID string) (*model.CustomerAnonymization, error)
+	/* encryption */
+	RotateEncryptionKey(ctx context.Context) (*model.KeyRotation, error)
+	ReencryptCustomers(ctx context.Context) (int, error)
+	/* customer summary */
+	GetCustomerSummary(ctx context.Context, tenantID, customerID string) (*model.CustomerSummary, error)
+	GetCustomerOrders(ctx context.Context, input request.GetCustomerOrdersRequest) (*model.CustomerOrderHistory, error)
+	/* customer segment */
+	GetCustomerSegments(ctx context.Context, tenantID string) ([]*model.CustomerSegment, error)
+	GetCustomerSegment(ctx context.Context, tenantID string, segmentID int) (*model.CustomerSegment, error)
+	CreateCustomerSegment(ctx context.Context, input request.CreateCustomerSegmentRequest) (*model.CustomerSegment, error)
+	UpdateCustomerSegment(ctx context.Context, input request.UpdateCustomerSegmentRequest) (*model.CustomerSegment, error)
+	DeleteCustomerSegment(ctx context.Context, tenantID string, segmentID int) error
+	CountCustomerSegment(ctx context.Context, tenantID string, segmentID int) (*model.SegmentCount, error)
+	GetSegmentCustomers(ctx context.Context, input request.GetSegmentCustomersRequest) ([]*model.Customer, error)
+	ExportSegmentCustomers(ctx context.Context, input request.GetSegmentCustomersRequest, w sheet.Writer) error
+	ScoreCustomers(ctx context.Context, tenantID *string) (int64, error)
+	RefreshCustomerScores(ctx context.Context) error
+	/* customer merge */
+	GetCustomerDuplicates(ctx context.Context, input request.GetCustomerDuplicatesRequest) ([]*model.CustomerDuplicate, error)
+	MergeCustomers(ctx context.Context, input request.MergeCustomersRequest) ([]*model.CustomerMerge, error)
+	GetCustomerMerges(ctx context.Context, tenantID, customerID string) ([]*model.CustomerMerge, error)
+	/* address */
+	LookupAddress(ctx context.Context, postalCode string) ([]*model.AddressCandidate, error)
+	NormalizeAddresses(ctx context.Context, tenantID *string) (*model.AddressNormalization, error)
 	/* order */
 	GetOrders(ctx context.Context, input request.GetOrdersRequest) ([]*model.Order, error)
 	GetOrder(ctx context.Context, tenantID string, orderID int) (*model.Order, error)
 	CreateOrder(ctx context.Context, order request.CreateOrderRequest) (*int, error)
 	CreateBulkOrder(ctx context.Context, orders []request.CreateOrderRequest) ([]*int, error)
+	CreateBulkOrderItems(ctx context.Context, input request.CreateBulkOrderItemsRequest) (*model.BulkResult, error)
 	UpdateOrder(ctx context.Context, order request.UpdateOrderRequest) (*model.Order, error)
+	/* payment */
+	GetOrderPayments(ctx context.Context, tenantID string, orderID int) (*model.OrderPayments, error)
+	CreatePayment(ctx context.Context, input request.CreatePaymentRequest) (*model.Payment, error)
+	CreateRefund(ctx context.Context, input request.CreateRefundRequest) (*model.Refund, error)
+	ReconcilePayments(ctx context.Context) (int, error)
+	ProcessPendingPayments(ctx context.Context) error
+	/* order return */
+	GetOrderReturns(ctx context.Context, input request.GetOrderReturnsRequest) ([]*model.OrderReturn, error)
+	GetOrderReturn(ctx context.Context, tenantID string, returnID int) (*model.OrderReturn, error)
+	CreateOrderReturn(ctx context.Context, input request.CreateOrderReturnRequest) (*model.OrderReturn, error)
+	InspectOrderReturn(ctx context.Context, input request.InspectOrderReturnRequest) (*model.OrderReturn, error)
+	RejectOrderReturn(ctx context.Context, input request.RejectOrderReturnRequest) (*model.OrderReturn, error)
+	CompleteOrderReturn(ctx context.Context, input request.CompleteOrderReturnRequest) (*model.OrderReturn, error)
+	/* invoice */
+	GetInvoiceIssuer(ctx context.Context, tenantID string) (*model.InvoiceIssuer, error)
+	UpdateInvoiceIssuer(ctx context.Context, input request.UpdateInvoiceIssuerRequest) (*model.InvoiceIssuer, error)
+	GetOrderInvoicePDF(ctx context.Context, tenantID string, orderID int) ([]byte, error)
+	GetOrderInvoices(ctx context.Context, tenantID string, orderID int) ([]*model.Invoice, error)
+	GetInvoice(ctx context.Context, tenantID string, invoiceID int) (*model.Invoice, error)
+	GetInvoicePDF(ctx context.Context, tenantID string, invoiceID int) ([]byte, error)
+	CreateCreditNote(ctx context.Context, input request.CreateCreditNoteRequest) (*model.Invoice, error)
+	/* receipt */
+	GetReceiptTemplate(ctx context.Context, tenantID string) (*model.ReceiptTemplate, error)
+	UpdateReceiptTemplate(ctx context.Context, input request.UpdateReceiptTemplateRequest) (*model.ReceiptTemplate, error)
+	GetOrderReceipt(ctx context.Context, input request.GetOrderReceiptRequest) ([]byte, error)
+	/* promotion */
+	GetPromotions(ctx context.Context, tenantID string) ([]*model.Promotion, error)
+	GetPromotion(ctx context.Context, tenantID string, promotionID int) (*model.Promotion, error)
+	CreatePromotion(ctx context.Context, input request.CreatePromotionRequest) (*model.Promotion, error)
+	UpdatePromotion(ctx context.Context, input request.UpdatePromotionRequest) (*model.Promotion, error)
+	DeletePromotion(ctx context.Context, tenantID string, promotionID int) error
+	GetCoupons(ctx context.Context, tenantID string, promotionID int) ([]*model.Coupon, error)
+	CreateCoupon(ctx context.Context, input request.CreateCouponRequest) (*model.Coupon, error)
+	DeleteCoupon(ctx context.Context, tenantID string, promotionID, couponID int) error
+	/* point */
+	GetPointBalance(ctx context.Context, tenantID, customerID string) (*model.PointBalance, error)
+	GetPointHistory(ctx context.Context, input request.GetPointHistoryRequest) (*model.PointHistory, error)
+	ExpirePoints(ctx context.Context) (int, error)
+	RefreshPointExpiry(ctx context.Context) error
+	/* export */
+	ExportUsers(ctx context.Context, input request.GetUsersRequest, w sheet.Writer) error
+	ExportStocks(ctx context.Context, input request.GetStocksRequest, w sheet.Writer) error
+	ExportCustomers(ctx context.Context, input request.GetCustomersRequest, w sheet.Writer) error
+	ExportOrders(ctx context.Context, input request.GetOrdersRequest, w sheet.Writer) error
+	ExportStockMovements(ctx context.Context, input request.GetStockMovementsRequest, w sheet.Writer) error
+	ExportStocktakes(ctx context.Context, input request.GetStocktakesRequest, w sheet.Writer) error
+	/* import */
+	ImportStocks(ctx context.Context, input request.ImportRequest) (*model.ImportJob, error)
+	ImportCustomers(ctx context.Context, input request.ImportRequest) (*model.ImportJob, error)
+	GetImportJobs(ctx context.Context, input request.GetImportJobsRequest) ([]*model.ImportJob, error)
+	GetImportJob(ctx context.Context, tenantID string, jobID int) (*model.ImportJob, error)
+	ProcessImportJobs(ctx context.Context) error
+	/* daily sales rollup */
+	RebuildDailySales(ctx context.Context, tenantID *string) (int64, error)
+	VerifyDailySales(ctx context.Context, tenantID *string) ([]*model.DailySalesDiscrepancy, error)
+	/* report */
+	GetInventoryValuation(ctx context.Context, input request.GetInventoryValuationRequest) (*model.InventoryValuationReport, error)
+	GetSalesReport(ctx context.Context, input request.GetSalesReportRequest) (*model.SalesReport, error)
 }
 
 func NewUsecase(ub *UsecaseBundle) UsecaseInterface {
 	return &usecase{ub}
 }
+
+// pdfFont は帳票に埋め込むフォントを返す
+// ローカル環境に限り、フォントがなければ埋め込まずにビューアの標準フォントで描画する
+func (u *usecase) pdfFont() (*pdf.Font, error) {
+	if u.Font == nil && u.Config.Env != config.Local {
+		return nil, ErrFontUnavailable
+	}
+
+	return u.Font, nil
+}
@@ -0,0 +1,65 @@
+// Package worker はAPIサーバー内で定期的に実行するバックグラウンド処理
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Task は一定間隔で実行する処理
+type Task struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type Worker struct {
+	logger *slog.Logger
+	tasks  []Task
+}
+
+func New(logger *slog.Logger, tasks ...Task) *Worker {
+	return &Worker{
+		logger: logger,
+		tasks:  tasks,
+	}
+}
+
+// Start はタスクごとにgoroutineを起動する。ctxがキャンセルされると停止する
+// 前回の実行が終わるまで次の実行は始めない。間隔が0以下のタスクは起動しない
+func (w *Worker) Start(ctx context.Context) {
+	for _, task := range w.tasks {
+		if task.Interval <= 0 {
+			continue
+		}
+		go w.loop(ctx, task)
+	}
+}
+
+func (w *Worker) loop(ctx context.Context, task Task) {
+	ticker := time.NewTicker(task.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.run(ctx, task)
+		}
+	}
+}
+
+func (w *Worker) run(ctx context.Context, task Task) {
+	// 1つのタスクのpanicでサーバー全体を止めない
+	defer func() {
+		if r := recover(); r != nil {
+			w.logger.Error("worker task panicked", "task", task.Name, "panic", r)
+		}
+	}()
+
+	if err := task.Run(ctx); err != nil && ctx.Err() == nil {
+		w.logger.Error("worker task failed", "task", task.Name, "error", err)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+)
+
+// normalizeAddresses は既存の顧客・店舗の1行の住所を分割する。分割済みの住所は変更しない
+func normalizeAddresses(ctx context.Context, u usecase.UsecaseInterface, args []string) error {
+	fs := flag.NewFlagSet("address-normalize", flag.ExitOnError)
+	tenantID := tenantFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := u.NormalizeAddresses(ctx, tenantID())
+	if err != nil {
+		return err
+	}
+
+	slog.Info("addresses normalized",
+		"tenant_id", tenantID(),
+		"customers_parsed", result.CustomersParsed,
+		"customers_unparsed", result.CustomersUnparsed,
+		"stores_parsed", result.StoresParsed,
+		"stores_unparsed", result.StoresUnparsed,
+	)
+
+	return nil
+}
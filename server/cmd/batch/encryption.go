@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+)
+
+// reencryptCustomers は暗号化の導入前に登録された顧客や、ローテーション中に古い鍵で保存された顧客を暗号化し直す
+func reencryptCustomers(ctx context.Context, u usecase.UsecaseInterface, args []string) error {
+	fs := flag.NewFlagSet("pii-encrypt", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	reencrypted, err := u.ReencryptCustomers(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("customers encrypted", "customers", reencrypted)
+
+	return nil
+}
+
+// rotateEncryptionKey はデータ鍵をローテーションする。マスター鍵を切り替えた後に実行すると
+// 全てのデータ鍵を新しいマスター鍵で包み直すため、以前のマスター鍵を廃棄できる
+func rotateEncryptionKey(ctx context.Context, u usecase.UsecaseInterface, args []string) error {
+	fs := flag.NewFlagSet("pii-rotate", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := u.RotateEncryptionKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("encryption key rotated",
+		"data_key_id", result.DataKeyID,
+		"rewrapped", result.Rewrapped,
+		"reencrypted", result.Reencrypted,
+	)
+
+	return nil
+}
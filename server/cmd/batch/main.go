@@ -0,0 +1,107 @@
+// batch はAPIサーバーとは別に実行する運用コマンド
+//
+//	go run ./cmd/batch <command> [flags]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	_ "time/tzdata"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/validator"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	"github.com/buysell-technologies/summer-internship-2024-backend/config"
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(ctx context.Context, u usecase.UsecaseInterface, args []string) error
+}
+
+var commands = []command{
+	{"rollup-rebuild", "売上の日次集計を発注から作り直す", rebuildDailySales},
+	{"rollup-verify", "売上の日次集計と発注からの集計を突き合わせる", verifyDailySales},
+	{"address-normalize", "顧客・店舗の住所を都道府県・市区町村などに分割する", normalizeAddresses},
+	{"rfm-score", "顧客のRFMスコアを発注から算出し直す", scoreCustomers},
+	{"pii-encrypt", "平文または古いデータ鍵で暗号化された顧客の個人情報を有効なデータ鍵で暗号化する", reencryptCustomers},
+	{"price-apply", "有効日時を過ぎた予約済みの販売価格の変更を適用する", applyPriceChanges},
+	{"markdown-apply", "在庫の経過日数に応じて自動値下げの段階を適用する", applyMarkdowns},
+	{"point-expire", "有効期限を過ぎた未使用のポイントを失効させる", expirePoints},
+	{"pii-rotate", "新しいデータ鍵を作成し、顧客の個人情報を暗号化し直す", rotateEncryptionKey},
+}
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	slog.SetDefault(logger)
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == os.Args[1] {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		usage()
+		os.Exit(2)
+	}
+
+	u, err := newUsecase()
+	if err != nil {
+		logger.Error("failed to initialize", "error", err)
+		os.Exit(1)
+	}
+
+	if err := cmd.run(context.Background(), u, os.Args[2:]); err != nil {
+		logger.Error("command failed", "command", cmd.name, "error", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: batch <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-20s %s\n", cmd.name, cmd.description)
+	}
+}
+
+func newUsecase() (usecase.UsecaseInterface, error) {
+	cfg, err := config.New()
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repository.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecase.NewUsecase(&usecase.UsecaseBundle{
+		Config:     cfg,
+		Repository: r,
+		Validator:  validator.NewValidator(),
+	}), nil
+}
+
+// tenantFlag は対象テナントを絞り込む -tenant フラグを登録する。未指定の場合は全テナント
+func tenantFlag(fs *flag.FlagSet) func() *string {
+	tenantID := fs.String("tenant", "", "対象のテナントID（未指定の場合は全テナント）")
+
+	return func() *string {
+		if *tenantID == "" {
+			return nil
+		}
+
+		return tenantID
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+)
+
+// applyMarkdowns は在庫の経過日数に応じて自動値下げの段階を適用する
+func applyMarkdowns(ctx context.Context, u usecase.UsecaseInterface, args []string) error {
+	fs := flag.NewFlagSet("markdown-apply", flag.ExitOnError)
+	tenantID := tenantFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	applied, err := u.ApplyMarkdowns(ctx, tenantID())
+	if err != nil {
+		return err
+	}
+
+	slog.Info("markdowns applied", "tenant_id", tenantID(), "stocks", applied)
+
+	return nil
+}
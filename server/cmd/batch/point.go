@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+)
+
+// expirePoints は有効期限を過ぎた未使用のポイントを失効させる
+func expirePoints(ctx context.Context, u usecase.UsecaseInterface, args []string) error {
+	fs := flag.NewFlagSet("point-expire", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	expired, err := u.ExpirePoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("points expired", "points", expired)
+
+	return nil
+}
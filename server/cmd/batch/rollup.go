@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+)
+
+func rebuildDailySales(ctx context.Context, u usecase.UsecaseInterface, args []string) error {
+	fs := flag.NewFlagSet("rollup-rebuild", flag.ExitOnError)
+	tenantID := tenantFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	rows, err := u.RebuildDailySales(ctx, tenantID())
+	if err != nil {
+		return err
+	}
+
+	slog.Info("daily sales rebuilt", "tenant_id", tenantID(), "rows", rows)
+
+	return nil
+}
+
+// verifyDailySales は不一致があれば内容を出力して失敗する
+func verifyDailySales(ctx context.Context, u usecase.UsecaseInterface, args []string) error {
+	fs := flag.NewFlagSet("rollup-verify", flag.ExitOnError)
+	tenantID := tenantFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	discrepancies, err := u.VerifyDailySales(ctx, tenantID())
+	if err != nil {
+		return err
+	}
+
+	for _, d := range discrepancies {
+		slog.Warn("daily sales mismatch",
+			"tenant_id", d.TenantID,
+			"store_id", d.StoreID,
+			"stock_id", d.StockID,
+			"sales_date", d.SalesDate.Format("2006-01-02"),
+			"status", d.Status,
+			"expected_revenue", d.ExpectedRevenue,
+			"actual_revenue", d.ActualRevenue,
+			"expected_order_count", d.ExpectedOrderCount,
+			"actual_order_count", d.ActualOrderCount,
+			"expected_units", d.ExpectedUnits,
+			"actual_units", d.ActualUnits,
+		)
+	}
+	if len(discrepancies) > 0 {
+		return fmt.Errorf("%d daily sales rows do not match orders; run rollup-rebuild to fix", len(discrepancies))
+	}
+
+	slog.Info("daily sales verified", "tenant_id", tenantID())
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+)
+
+// scoreCustomers は顧客のRFMスコアを発注から算出し直す
+func scoreCustomers(ctx context.Context, u usecase.UsecaseInterface, args []string) error {
+	fs := flag.NewFlagSet("rfm-score", flag.ExitOnError)
+	tenantID := tenantFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	scored, err := u.ScoreCustomers(ctx, tenantID())
+	if err != nil {
+		return err
+	}
+
+	slog.Info("customers scored", "tenant_id", tenantID(), "customers", scored)
+
+	return nil
+}
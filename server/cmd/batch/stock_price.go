@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+)
+
+// applyPriceChanges は有効日時を過ぎた予約済みの販売価格の変更を適用する
+func applyPriceChanges(ctx context.Context, u usecase.UsecaseInterface, args []string) error {
+	fs := flag.NewFlagSet("price-apply", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	applied, err := u.ApplyPriceChanges(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("price changes applied", "changes", applied)
+
+	return nil
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
@@ -19,6 +20,16 @@ type Config struct {
 	Env  Platform `split_words:"true" default:"local"`
 	Port string   `split_words:"true" default:"1234"`
 	Database
+	Storage
+	Report
+	Import
+	Bulk
+	Segment
+	Point
+	Price
+	Payment
+	Encryption
+	PDF
 }
 
 type Database struct {
@@ -29,15 +40,154 @@ type Database struct {
 	DBUser     string `envconfig:"DB_USER" default:"postgres"`
 }
 
+type StorageDriver string
+
+const (
+	StorageFileSystem StorageDriver = "filesystem"
+	StorageS3         StorageDriver = "s3"
+)
+
+type Storage struct {
+	StorageDriver  StorageDriver `envconfig:"STORAGE_DRIVER" default:"filesystem"`
+	StorageDir     string        `envconfig:"STORAGE_DIR" default:"./storage"`
+	StorageBaseURL string        `envconfig:"STORAGE_BASE_URL" default:"http://localhost:1234"`
+	// 署名付きURLの署名に使う鍵。ローカル環境以外では必須
+	StorageSigningKey string        `envconfig:"STORAGE_SIGNING_KEY" default:""`
+	SignedURLTTL      time.Duration `envconfig:"SIGNED_URL_TTL" default:"15m"`
+	MaxImageSize      int64         `envconfig:"MAX_IMAGE_SIZE" default:"10485760"`
+	// デコード前に画像の縦横それぞれのピクセル数と総ピクセル数を確かめる上限
+	MaxImageDimension int    `envconfig:"MAX_IMAGE_DIMENSION" default:"8192"`
+	MaxImagePixels    int    `envconfig:"MAX_IMAGE_PIXELS" default:"40000000"`
+	S3Endpoint        string `envconfig:"S3_ENDPOINT" default:"http://minio:9000"`
+	S3PublicEndpoint  string `envconfig:"S3_PUBLIC_ENDPOINT" default:"http://localhost:9000"`
+	S3Region          string `envconfig:"S3_REGION" default:"ap-northeast-1"`
+	S3Bucket          string `envconfig:"S3_BUCKET" default:"stock-images"`
+	// ローカル環境以外でS3を使う場合は必須
+	S3AccessKey string `envconfig:"S3_ACCESS_KEY" default:""`
+	S3SecretKey string `envconfig:"S3_SECRET_KEY" default:""`
+	S3PathStyle bool   `envconfig:"S3_PATH_STYLE" default:"true"`
+}
+
+type PDF struct {
+	// 請求書・ラベルのPDFに使用した文字だけを埋め込む日本語のTrueTypeフォント(.ttf/.ttc)
+	// 読み込めない場合、ローカル環境では埋め込まずに描画し、それ以外の環境ではPDFのAPIをエラーにする
+	PDFFont string `envconfig:"PDF_FONT" default:"./fonts/ipaexg.ttf"`
+}
+
+type Report struct {
+	// 日・週・月の集計の区切りに使うタイムゾーン
+	ReportTimeZone string `envconfig:"REPORT_TIMEZONE" default:"Asia/Tokyo"`
+}
+
+type Import struct {
+	MaxImportSize int64 `envconfig:"MAX_IMPORT_SIZE" default:"52428800"`
+	// このサイズ以下のファイルはリクエスト内で取り込み、超える場合はワーカーで処理する
+	ImportSyncSize     int64         `envconfig:"IMPORT_SYNC_SIZE" default:"262144"`
+	ImportPollInterval time.Duration `envconfig:"IMPORT_POLL_INTERVAL" default:"5s"`
+	// 進捗がこの時間更新されない処理中のジョブは停止したとみなす
+	ImportStaleAfter time.Duration `envconfig:"IMPORT_STALE_AFTER" default:"10m"`
+}
+
+type Bulk struct {
+	// 一括作成で1回に受け付ける件数の上限
+	MaxBulkItems int `envconfig:"MAX_BULK_ITEMS" default:"1000"`
+}
+
+type Segment struct {
+	// 顧客のRFMスコアを算出し直す間隔。0以下の場合はサーバーでは算出しない
+	RFMScoreInterval time.Duration `envconfig:"RFM_SCORE_INTERVAL" default:"24h"`
+}
+
+type Point struct {
+	// 有効期限を過ぎたポイントを失効させる間隔。0以下の場合はサーバーでは失効させない
+	PointExpiryInterval time.Duration `envconfig:"POINT_EXPIRY_INTERVAL" default:"1h"`
+}
+
+type Price struct {
+	// 予約した販売価格の変更を適用する間隔。0以下の場合はサーバーでは適用しない
+	PriceChangeInterval time.Duration `envconfig:"PRICE_CHANGE_INTERVAL" default:"1m"`
+	// 在庫の経過日数に応じて自動値下げを行う間隔。0以下の場合はサーバーでは値下げしない
+	MarkdownInterval time.Duration `envconfig:"MARKDOWN_INTERVAL" default:"24h"`
+}
+
+type PaymentGateway string
+
+const (
+	PaymentGatewayFake     PaymentGateway = "fake"
+	PaymentGatewayDisabled PaymentGateway = "disabled"
+)
+
+type Payment struct {
+	// クレジットカード・QRコード決済を処理する決済代行会社。fakeは接続せずに決済を承認する開発用の実装で、ローカル環境でのみ使える
+	// disabledはクレジットカード・QRコード決済を受け付けず、現金・ポイントなどの支払いだけを記録する
+	PaymentGateway PaymentGateway `envconfig:"PAYMENT_GATEWAY" default:"fake"`
+	// 決済代行会社の結果が分からないまま残った支払い・返金を確かめる間隔。0以下の場合はサーバーでは確かめない
+	PaymentReconcileInterval time.Duration `envconfig:"PAYMENT_RECONCILE_INTERVAL" default:"1m"`
+	// 記録からこの時間が経った結果待ちの支払い・返金を確かめる。決済代行会社への依頼のタイムアウトより長くする
+	PaymentReconcileAfter time.Duration `envconfig:"PAYMENT_RECONCILE_AFTER" default:"10m"`
+}
+
+type KeyProvider string
+
+const (
+	KeyProviderKeyfile KeyProvider = "keyfile"
+	KeyProviderAWSKMS  KeyProvider = "aws_kms"
+)
+
+type Encryption struct {
+	// 顧客の個人情報を暗号化するデータ鍵を包むマスター鍵の管理方法
+	KeyProvider KeyProvider `envconfig:"KEY_PROVIDER" default:"keyfile"`
+	// ローカル環境ではファイルがなければ鍵を生成する
+	Keyfile      string `envconfig:"KEYFILE" default:"./secrets/master.key"`
+	KMSEndpoint  string `envconfig:"KMS_ENDPOINT" default:"https://kms.ap-northeast-1.amazonaws.com"`
+	KMSRegion    string `envconfig:"KMS_REGION" default:"ap-northeast-1"`
+	KMSKeyID     string `envconfig:"KMS_KEY_ID" default:""`
+	KMSAccessKey string `envconfig:"KMS_ACCESS_KEY" default:""`
+	KMSSecretKey string `envconfig:"KMS_SECRET_KEY" default:""`
+}
+
 func New() (*Config, error) {
 	c := &Config{}
 	if err := envconfig.Process("", c); err != nil {
 		return nil, err
 	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
 
 	return c, nil
 }
 
+// validate は秘密情報の設定漏れと開発用の実装の指定を確かめる
+// ローカル環境では未設定の項目に開発用の値を入れ、それ以外の環境では起動させない
+func (c *Config) validate() error {
+	if c.Env == Local {
+		if c.StorageSigningKey == "" {
+			c.StorageSigningKey = "local-signing-key"
+		}
+		if c.S3AccessKey == "" {
+			c.S3AccessKey = "minioadmin"
+		}
+		if c.S3SecretKey == "" {
+			c.S3SecretKey = "minioadmin"
+		}
+
+		return nil
+	}
+
+	if c.StorageSigningKey == "" {
+		return fmt.Errorf("STORAGE_SIGNING_KEY is required in %s", c.Env)
+	}
+	if c.StorageDriver == StorageS3 && (c.S3AccessKey == "" || c.S3SecretKey == "") {
+		return fmt.Errorf("S3_ACCESS_KEY and S3_SECRET_KEY are required in %s", c.Env)
+	}
+	if c.PaymentGateway == PaymentGatewayFake {
+		return fmt.Errorf("PAYMENT_GATEWAY=%s is only allowed in %s; use %s to run without gateway payments", PaymentGatewayFake, Local, PaymentGatewayDisabled)
+	}
+
+	return nil
+}
+
 func (d Database) DSN() string {
 	return fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
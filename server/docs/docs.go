@@ -15,34 +15,25 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/customers": {
+        "/addresses/lookup": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "顧客一覧の取得",
+                "description": "郵便番号に対応する都道府県・市区町村・町域を取得する。入力フォームの自動補完用\n1つの郵便番号に複数の町域が対応する場合は全件を返す",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "顧客一覧の取得",
+                "summary": "郵便番号からの住所の検索",
                 "parameters": [
                     {
-                        "minimum": 0,
-                        "type": "integer",
-                        "example": 10,
-                        "description": "取得件数",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 0,
-                        "type": "integer",
-                        "example": 0,
-                        "description": "取得開始位置",
-                        "name": "offset",
-                        "in": "query"
+                        "type": "string",
+                        "description": "郵便番号（ハイフンの有無は問わない）",
+                        "name": "zip",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -51,7 +42,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Customer"
+                                "$ref": "#/definitions/model.AddressCandidate"
                             }
                         }
                     },
@@ -59,6 +50,39 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/customer-segments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "保存した顧客の絞り込み条件を名前順に取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "顧客セグメント一覧の取得",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CustomerSegment"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
@@ -71,14 +95,14 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "顧客の作成",
+                "description": "顧客の絞り込み条件を名前を付けて保存する。RFMスコアは定期的に算出し直される",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "顧客の作成",
+                "summary": "顧客セグメントの作成",
                 "parameters": [
                     {
                         "description": "作成条件",
@@ -86,7 +110,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateCustomerRequest"
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateCustomerSegmentRequest"
                         }
                     }
                 ],
@@ -94,13 +118,17 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.CustomerSegment"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
@@ -108,23 +136,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/customers/{id}": {
+        "/customer-segments/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "顧客の取得",
+                "description": "顧客セグメントの取得",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "顧客の取得",
+                "summary": "顧客セグメントの取得",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "顧客ID",
+                        "type": "integer",
+                        "description": "顧客セグメントID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -134,13 +161,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Customer"
+                            "$ref": "#/definitions/model.CustomerSegment"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
@@ -153,19 +184,18 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "顧客の更新",
+                "description": "顧客セグメントの更新",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "顧客の更新",
+                "summary": "顧客セグメントの更新",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "顧客ID",
+                        "type": "integer",
+                        "description": "顧客セグメントID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -176,7 +206,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateCustomerRequest"
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateCustomerSegmentRequest"
                         }
                     }
                 ],
@@ -184,13 +214,21 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Customer"
+                            "$ref": "#/definitions/model.CustomerSegment"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
@@ -203,16 +241,15 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "顧客の削除",
+                "description": "顧客セグメントの削除",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "顧客の削除",
+                "summary": "顧客セグメントの削除",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "顧客ID",
+                        "type": "integer",
+                        "description": "顧客セグメントID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -229,6 +266,10 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
@@ -236,36 +277,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/customer-segments/{id}/count": {
             "get": {
-                "description": "ヘルスチェック",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "顧客セグメントに該当する削除されていない顧客の件数を取得する",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "ヘルスチェック",
+                "summary": "顧客セグメントの件数の取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "顧客セグメントID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.SegmentCount"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
                     }
                 }
             }
         },
-        "/orders": {
+        "/customer-segments/{id}/customers": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "発注一覧の取得",
+                "description": "顧客セグメントに該当する削除されていない顧客を累計購入額の多い順に取得する",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
-                "summary": "発注一覧の取得",
+                "summary": "顧客セグメントの顧客一覧の取得",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "顧客セグメントID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "minimum": 0,
                         "type": "integer",
@@ -281,6 +357,26 @@ const docTemplate = `{
                         "description": "取得開始位置",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "出力形式（指定時は件数の指定がなければ全件をファイルで出力）",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "utf-8",
+                            "shift_jis"
+                        ],
+                        "type": "string",
+                        "description": "CSVの文字コード（既定はBOM付きUTF-8）",
+                        "name": "encoding",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -289,7 +385,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Order"
+                                "$ref": "#/definitions/model.Customer"
                             }
                         }
                     },
@@ -297,42 +393,77 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/customers": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "発注の作成",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "顧客一覧の取得",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
-                "summary": "発注の作成",
+                "summary": "顧客一覧の取得",
                 "parameters": [
                     {
-                        "description": "作成条件",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateOrderRequest"
-                        }
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 10,
+                        "description": "取得件数",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 0,
+                        "description": "取得開始位置",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "出力形式（指定時は件数の指定がなければ全件をファイルで出力）",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "utf-8",
+                            "shift_jis"
+                        ],
+                        "type": "string",
+                        "description": "CSVの文字コード（既定はBOM付きUTF-8）",
+                        "name": "encoding",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "integer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Customer"
+                            }
                         }
                     },
                     "400": {
@@ -344,23 +475,21 @@ const docTemplate = `{
                         "schema": {}
                     }
                 }
-            }
-        },
-        "/orders/bulk": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "発注の一括作成",
+                "description": "顧客の作成\n都道府県を指定した場合は分割した住所から address を作り、指定しない場合は address を分割して保存する\n郵便番号は郵便番号データにある場合、住所と一致する必要がある",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "発注の一括作成",
+                "summary": "顧客の作成",
                 "parameters": [
                     {
                         "description": "作成条件",
@@ -368,7 +497,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CreateBulkOrderRequest"
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateCustomerRequest"
                         }
                     }
                 ],
@@ -376,10 +505,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "integer"
-                            }
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -393,89 +519,138 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}": {
+        "/customers/duplicates": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "発注の取得",
+                "description": "重複の可能性がある顧客の組を取得する\n電話番号（ハイフン・全角・+81の表記ゆれを除く）とメールアドレス（大文字・小文字を区別しない）は一致、氏名は類似度で判定する",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "発注の取得",
+                "summary": "重複顧客の候補の取得",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "発注ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "指定した顧客の重複候補のみを取得",
+                        "name": "customer_id",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1,
+                        "minimum": 0,
+                        "type": "number",
+                        "description": "氏名の類似度の下限（既定は0.8）",
+                        "name": "min_name_similarity",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Order"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CustomerDuplicate"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/customers/import": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "発注の更新",
+                "description": "CSV・Excelファイルから顧客を登録する。メールアドレスまたは電話番号が一致する顧客は更新する\n列の対応付けを省略した場合は、項目名または顧客一覧の出力と同じ見出しの列を使う\n小さいファイルはその場で取り込んで結果を返し、大きいファイルは202を返してバックグラウンドで取り込む",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "発注の更新",
+                "summary": "顧客の取り込み",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "発注ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "取り込むファイル（CSVまたはxlsx）",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     },
                     {
-                        "description": "更新条件",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateOrderRequest"
-                        }
+                        "type": "boolean",
+                        "description": "検証のみ行い、登録・更新しない",
+                        "name": "dry_run",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "utf-8",
+                            "shift_jis"
+                        ],
+                        "type": "string",
+                        "description": "CSVの文字コード（BOM付きの場合はUTF-8）",
+                        "name": "encoding",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "{\"氏名\":\"name\"}",
+                        "description": "列見出しから項目名への対応（JSON）",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "email",
+                            "phone_number"
+                        ],
+                        "type": "string",
+                        "description": "既存の顧客と照合する項目（既定はemail）",
+                        "name": "match_by",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Order"
+                            "$ref": "#/definitions/model.ImportJob"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportJob"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
@@ -483,44 +658,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/stocks": {
+        "/customers/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "在庫一覧の取得",
+                "description": "顧客の取得",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "在庫一覧の取得",
+                "summary": "顧客の取得",
                 "parameters": [
                     {
-                        "minimum": 0,
-                        "type": "integer",
-                        "example": 10,
-                        "description": "取得件数",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 0,
-                        "type": "integer",
-                        "example": 0,
-                        "description": "取得開始位置",
-                        "name": "offset",
-                        "in": "query"
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "顧客ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Stock"
-                            }
+                            "$ref": "#/definitions/model.Customer"
                         }
                     },
                     "400": {
@@ -533,36 +697,44 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "在庫の作成",
+                "description": "顧客の更新\n住所の扱いは顧客の作成と同じ",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "在庫の作成",
+                "summary": "顧客の更新",
                 "parameters": [
                     {
-                        "description": "在庫情報",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "顧客ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新条件",
                         "name": "req",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateStockRequest"
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateCustomerRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/model.Customer"
                         }
                     },
                     "400": {
@@ -574,42 +746,33 @@ const docTemplate = `{
                         "schema": {}
                     }
                 }
-            }
-        },
-        "/stocks/bulk": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "在庫の一括作成",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "顧客の削除",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "在庫の一括作成",
+                "summary": "顧客の削除",
                 "parameters": [
                     {
-                        "description": "在庫情報",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateBulkStockRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "顧客ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "integer"
-                            }
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -623,23 +786,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/stocks/{id}": {
-            "get": {
+        "/customers/{id}/anonymize": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "在庫の取得",
+                "description": "本人からの削除請求に応じて、氏名・連絡先・住所を消去して顧客を削除済みにする。元に戻すことはできない\n発注は会計のために残す。統合された顧客と統合履歴の顧客情報も消去し、有効な同意は撤回として記録する",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "在庫の取得",
+                "summary": "顧客の個人情報の匿名化",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "在庫ID",
+                        "type": "string",
+                        "description": "顧客ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -649,101 +811,121 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Stock"
+                            "$ref": "#/definitions/model.CustomerAnonymization"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/customers/{id}/consents": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "在庫の更新",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "利用目的ごとの最新の同意状況と、同意・撤回の全ての記録を取得する",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "在庫の更新",
+                "summary": "顧客の同意状況の取得",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "在庫ID",
+                        "type": "string",
+                        "description": "顧客ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "在庫情報",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateStockRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Stock"
+                            "$ref": "#/definitions/model.CustomerConsents"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "在庫の削除",
+                "description": "利用目的ごとの同意・撤回を記録する。記録は追記のみで、同じ目的の最新の記録が現在の同意状況になる",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "在庫の削除",
+                "summary": "顧客の同意・撤回の記録",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "在庫ID",
+                        "type": "string",
+                        "description": "顧客ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "同意・撤回の内容",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.RecordCustomerConsentRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.CustomerConsent"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
@@ -751,34 +933,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
-            "get": {
+        "/customers/{id}/merge": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "従業員一覧の取得",
+                "description": "統合元の顧客の発注を指定した顧客に付け替え、統合元の顧客を削除する\n統合元の顧客情報と付け替えた発注は統合履歴として残る",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "従業員一覧の取得",
+                "summary": "顧客の統合",
                 "parameters": [
                     {
-                        "minimum": 0,
-                        "type": "integer",
-                        "example": 10,
-                        "description": "取得件数",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "統合先の顧客ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "minimum": 0,
-                        "type": "integer",
-                        "example": 0,
-                        "description": "取得開始位置",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "統合元",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.MergeCustomersRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -787,7 +973,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.User"
+                                "$ref": "#/definitions/model.CustomerMerge"
                             }
                         }
                     },
@@ -795,45 +981,57 @@ const docTemplate = `{
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/customers/{id}/merges": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "従業員の作成",
+                "description": "顧客が統合先または統合元になった履歴を新しい順に取得する",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "従業員の作成",
+                "summary": "顧客の統合履歴の取得",
                 "parameters": [
                     {
-                        "description": "作成条件",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateUserRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "顧客ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CustomerMerge"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
@@ -841,471 +1039,7741 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/customers/{id}/orders": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "従業員の取得",
+                "description": "顧客の発注を新しい順に取得する。件数の既定は20件、上限は100件",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "従業員の取得",
+                "summary": "顧客の発注履歴の取得",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "従業員ID",
+                        "description": "顧客ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "取得件数",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "取得開始位置",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.CustomerOrderHistory"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/customers/{id}/personal-data": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "従業員の更新",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "本人からの開示請求に応じて、顧客情報・発注・同意の記録・統合履歴など顧客に紐づく全ての情報を出力する\n削除済みの顧客や、統合により削除された顧客の情報も含む",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "従業員の更新",
+                "summary": "顧客の保有個人データの開示",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "従業員ID",
+                        "description": "顧客ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "更新条件",
-                        "name": "req",
-                        "in": "body",
-                        "required": true,
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateUserRequest"
+                            "$ref": "#/definitions/model.CustomerPersonalData"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/customers/{id}/points": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ポイント残高と、失効予定のポイントを失効日時の近い順に取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "顧客のポイント残高の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "顧客ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.PointBalance"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/customers/{id}/points/history": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "従業員の削除",
+                "description": "ポイントの付与・利用・失効・取り消しを新しい順に取得する。件数の既定は20件、上限は100件",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "従業員の削除",
+                "summary": "顧客のポイント履歴の取得",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "従業員ID",
+                        "description": "顧客ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "取得件数",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "description": "取得開始位置",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/model.PointHistory"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {}
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {}
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateCustomerRequest": {
-            "type": "object",
-            "required": [
-                "address",
-                "email",
-                "name",
-                "phone_number",
-                "tenant_id"
-            ],
-            "properties": {
-                "address": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1,
-                    "example": "東京都千代田区丸の内1-1-1"
-                },
-                "email": {
-                    "type": "string",
-                    "example": "taro_tanaka@example.com"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1,
-                    "example": "田中 太郎"
-                },
-                "phone_number": {
-                    "type": "string",
-                    "example": "09012345678"
-                },
-                "tenant_id": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+        },
+        "/customers/{id}/summary": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "累計購入額・発注件数・初回と最終の購入日時・平均購入額・よく購入する分類を取得する\nキャンセルされた発注は含めない",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "顧客の購入実績の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "顧客ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
                 }
             }
         },
-        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateOrderRequest": {
-            "type": "object",
-            "required": [
-                "customer_id",
-                "delivery_date",
-                "stock_id"
-            ],
-            "properties": {
-                "customer_id": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
-                },
-                "delivery_date": {
-                    "type": "string",
-                    "example": "2022-01-01"
-                },
-                "quantity": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 1
-                },
-                "status": {
-                    "description": "nolint:lll",
-                    "type": "string",
-                    "enum": [
-                        "PENDING",
-                        "SHIPPED",
-                        "DELIVERED",
-                        "CANCELLED"
-                    ],
-                    "example": "PENDING"
-                },
-                "stock_id": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "total_amount": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 100000
+        "/health": {
+            "get": {
+                "description": "ヘルスチェック",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "ヘルスチェック",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 }
             }
         },
-        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateStockRequest": {
-            "type": "object",
-            "required": [
-                "name",
-                "price",
-                "quantity",
-                "store_id",
-                "user_id"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1,
-                    "example": "LOUIS VUITTON M41524 ブラウン モノグラム ハンドバッグ"
+        "/import-jobs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "テナントの取り込みを新しい順に取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "取り込み一覧の取得",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 10,
+                        "description": "取得件数",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 0,
+                        "description": "取得開始位置",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ImportJob"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/import-jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "取り込みの状態・進捗・行ごとのエラーを取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "取り込みの取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "取り込みID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/invoices/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発行済みの請求書・返還請求書を取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "請求書の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "請求書ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Invoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/invoices/{id}/credit-note": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発行済みの請求書を取り消す返還請求書を発行する。取り消せるのは発注の有効な請求書のみ\n取り消した後に発注の請求書PDFを取得すると、その時点の発注の内容で請求書を発行し直す",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "返還請求書の発行",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "請求書ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "発行内容",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateCreditNoteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Invoice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/invoices/{id}/pdf": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発行済みの請求書・返還請求書を発行時点の記載内容でPDFにする",
+                "produces": [
+                    "application/pdf"
+                ],
+                "summary": "請求書PDFの取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "請求書ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/markdown-rules": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "在庫の経過日数に応じた自動値下げの段階を経過日数の順に取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "自動値下げの段階の取得",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MarkdownRuleSet"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "自動値下げの段階を指定した内容で置き換える。空の配列を指定すると自動値下げを行わない\n在庫の登録からの経過日数が到達した最も大きい段階の値下げ率を、自動値下げ以外で最後に設定した販売価格に対して適用する\n経過日数が大きい段階ほど値下げ率を大きくする必要がある。値下げは日次で実行する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "自動値下げの段階の更新",
+                "parameters": [
+                    {
+                        "description": "自動値下げの段階",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateMarkdownRulesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MarkdownRuleSet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/markdown-rules/preview": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "現在の段階で自動値下げを実行した場合に変更される販売価格を試算する。販売価格は変更しない\n原価を下限とする設定で取得原価が不明な在庫は値下げせず、在庫IDのみを返す",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "自動値下げの試算",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MarkdownPlan"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注一覧の取得",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "summary": "発注一覧の取得",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 10,
+                        "description": "取得件数",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 0,
+                        "description": "取得開始位置",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "出力形式（指定時は件数の指定がなければ全件をファイルで出力）",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "utf-8",
+                            "shift_jis"
+                        ],
+                        "type": "string",
+                        "description": "CSVの文字コード（既定はBOM付きUTF-8）",
+                        "name": "encoding",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Order"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注の作成",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "発注の作成",
+                "parameters": [
+                    {
+                        "description": "作成条件",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/orders/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注の一括作成\natomicを指定すると1件ごとの結果（添字・状態・作成したID・エラー）を返す\natomic=trueは全件を1つのトランザクションで作成し、1件でも失敗すれば全件を取り消す\natomic=falseは作成できた行を確定し、一部が失敗した場合は207を返す",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "発注の一括作成",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "1件ごとの結果を返す。trueは全件成功した場合のみ作成する",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "description": "作成条件",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateBulkOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "atomic未指定の場合",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "207": {
+                        "description": "atomic=falseで一部の行が失敗した場合",
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkResult"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注の取得",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "発注の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "発注ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注を更新する。値引きを適用した発注の金額は変更できない",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "発注の更新",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "発注ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新条件",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/orders/{id}/invoice.pdf": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注の適格請求書をPDFで取得する。登録番号・税率ごとの対象金額と消費税額を記載する\n初回の取得時にその時点の発注の内容で請求書番号を採番して発行し、以降は同じ請求書を返す\n記載内容を訂正する場合は返還請求書で取り消すと、次回の取得時に発行し直す",
+                "produces": [
+                    "application/pdf"
+                ],
+                "summary": "発注の請求書PDFの取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "発注ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/orders/{id}/invoices": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注の請求書・返還請求書を発行順に取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "発注の請求書一覧の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "発注ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Invoice"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/orders/{id}/payments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注の支払いと返金を日時の順に取得する\n支払いが必要な金額は値引き額と値引きに利用したポイント、完了した返品で返金・ポイント返還した額を差し引いた金額\n返品によらない返金の分は未払いに戻る",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "発注の支払い状況の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "発注ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderPayments"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注に支払いを記録する。複数の決済手段に分けて支払う場合は決済手段ごとに記録する\nクレジットカード・QRコード決済は決済代行会社で決済し、ポイントは顧客の残高から差し引く\n未払いの残額を超える支払いは受け付けない。現金の釣り銭は預かり金額で記録する\n決済代行会社を使わない設定の場合、クレジットカード・QRコード決済は400を返す\nクレジットカード・QRコード決済はPENDINGで記録してから決済し、承認されなかった場合は402を返して支払いをFAILEDとして残す\n決済代行会社の結果が分からない場合は202を返す。支払いはPENDINGのまま残り、後から結果を確かめて反映する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "発注の支払いの記録",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "発注ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "支払い",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreatePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "402": {
+                        "description": "Payment Required",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/orders/{id}/receipt": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注のレシートをレシートプリンタ向けのESC/POSのコマンド列 (Shift_JIS) で取得する\n店舗名・明細・税率ごとの内訳・支払い・発注番号のバーコードを、テナントの印字設定に従って印字する",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "summary": "発注のレシートの取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "発注ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            58,
+                            80
+                        ],
+                        "type": "integer",
+                        "description": "用紙幅 (mm)。未指定の場合は印字設定の用紙幅",
+                        "name": "paper_width",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/orders/{id}/receipt/preview": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注のレシートをプレーンテキストで取得する。拡大文字は通常の大きさ、バーコードは発注番号で表示する",
+                "produces": [
+                    "text/plain"
+                ],
+                "summary": "発注のレシートのプレビュー",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "発注ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            58,
+                            80
+                        ],
+                        "type": "integer",
+                        "description": "用紙幅 (mm)。未指定の場合は印字設定の用紙幅",
+                        "name": "paper_width",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/orders/{id}/refunds": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "支払いの全部または一部を支払った決済手段で返金する。ポイントで支払った分は顧客の残高に戻す\nキャンセルによる返金はキャンセルした発注に限る\nクレジットカード・QRコード決済の返金は記録してから決済代行会社に依頼し、受け付けられなかった場合は502を返して返金をFAILEDとして残す\n決済代行会社の結果が分からない場合は202を返す。返金はPENDINGのまま残り、後から同じ依頼をし直して結果を反映する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "発注の支払いの返金",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "発注ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "返金",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateRefundRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Refund"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.Refund"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/orders/{id}/returns": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "発注の返品を受付の新しい順に取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "発注の返品一覧の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "発注ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.OrderReturn"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "納品済みの発注の返品を受け付ける。返品不可としたものを除き、発注の数量を超えて返品できない",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "返品の受付",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "発注ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "返品",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateOrderReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "キャンペーンを新しい順に取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "キャンペーン一覧の取得",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Promotion"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "値引きのキャンペーンを作成する。値引きは定率 (%) または定額 (円)\nクーポンが不要なキャンペーンは条件を満たす発注に自動で適用し、複数該当する場合は値引き額が最も大きいものを適用する\nクーポンのキャンペーンは発注の作成時にクーポンコードを指定した場合に適用し、自動で適用するキャンペーンと併用できる",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "キャンペーンの作成",
+                "parameters": [
+                    {
+                        "description": "作成条件",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreatePromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/promotions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "キャンペーンの取得",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "キャンペーンの取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "キャンペーンID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "キャンペーンの更新。適用済みの発注の値引きは変更しない",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "キャンペーンの更新",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "キャンペーンID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新条件",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdatePromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "キャンペーンとクーポンを削除する。発注に適用済みのキャンペーンは削除できないため、無効にする",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "キャンペーンの削除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "キャンペーンID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/promotions/{id}/coupons": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "キャンペーンのクーポンをコード順に取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "クーポン一覧の取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "キャンペーンID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Coupon"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "キャンペーンを適用するクーポンコードを作成する。コードは大文字小文字を区別せず、テナント内で重複できない",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "クーポンの作成",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "キャンペーンID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "作成条件",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateCouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Coupon"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/promotions/{id}/coupons/{coupon_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "クーポンを削除する。発注に適用済みのクーポンは削除できない",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "クーポンの削除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "キャンペーンID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "クーポンID",
+                        "name": "coupon_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/reports/inventory-valuation": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "基準日の終了時点における在庫の評価額を店舗別・テナント合計で取得する\n評価方法はテナント設定に従い、methodを指定した場合はその方法で試算する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "棚卸資産評価額の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "example": "2025-09-30",
+                        "description": "基準日(省略時は現在)",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "MOVING_AVERAGE",
+                            "FIFO"
+                        ],
+                        "type": "string",
+                        "description": "評価方法",
+                        "name": "method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InventoryValuationReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/reports/sales": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "売上金額・発注件数・数量・平均発注額を集計する。期間は発注日時で絞り込む\nperiod と group_by を指定すると、期間・店舗・担当者・在庫・ステータスごとの内訳を返す\nstatus を指定しない場合、キャンセルされた発注は集計に含めない",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "売上集計の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "example": "2025-10-01",
+                        "description": "開始日",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "example": "2025-10-31",
+                        "description": "終了日（当日を含む）",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "description": "期間の単位",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "store",
+                                "user",
+                                "stock",
+                                "status"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "集計軸",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "PENDING",
+                                "SHIPPED",
+                                "DELIVERED",
+                                "CANCELLED"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "ステータス",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "店舗ID",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SalesReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/returns": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返品を受付の新しい順に取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "返品一覧の取得",
+                "parameters": [
+                    {
+                        "enum": [
+                            "REQUESTED",
+                            "INSPECTED",
+                            "COMPLETED",
+                            "REJECTED"
+                        ],
+                        "type": "string",
+                        "description": "状態",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 10,
+                        "description": "取得件数",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 0,
+                        "description": "取得開始位置",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.OrderReturn"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/returns/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返品の取得",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "返品の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "返品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/returns/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "検品済みの返品を在庫に戻し入れ、返金・交換・ポイント返還のいずれかで完了する\n戻し入れは返品の入出庫として記録し、状態ランクに応じて元とは別の在庫に戻せる\n返金は発注の新しい支払いから順に、支払った決済手段で返金する。交換の場合は交換品の発注を別に作成して指定する\n決済代行会社が返金を受け付けなかった場合は、返品を完了したまま502を返し、返金をFAILEDとして残す\n納品で付与したポイントは、返品した数量の按分額に相当する分を取り消す。売上集計は発注の内容を集計するため返品では変わらない",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "返品の完了",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "返品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "対応",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CompleteOrderReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/returns/{id}/inspect": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "返品の状態ランクを記録する。完了前であれば検品し直せる",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "返品の検品",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "返品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "検品結果",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.InspectOrderReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/returns/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "完了前の返品を返品不可とする。返品不可とした数量は再び返品を受け付けられる",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "返品の不可",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "返品ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "理由",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.RejectOrderReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/settings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "テナント設定の取得",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "テナント設定の取得",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TenantSetting"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "テナント設定の更新",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "テナント設定の更新",
+                "parameters": [
+                    {
+                        "description": "更新条件",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateTenantSettingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TenantSetting"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/settings/invoice": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "適格請求書に記載する登録番号・名称・所在地を取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "請求書の発行者情報の取得",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvoiceIssuer"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "適格請求書に記載する登録番号・名称・所在地を登録する。発行済みの請求書の記載内容は変わらない",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "請求書の発行者情報の登録",
+                "parameters": [
+                    {
+                        "description": "登録内容",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateInvoiceIssuerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvoiceIssuer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/settings/receipt": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "レシートの用紙幅・ヘッダー・フッターの文言と、店舗住所・バーコードを印字するかを取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "レシートの印字設定の取得",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReceiptTemplate"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "レシートの印字設定を更新する。ヘッダー・フッターは改行で複数行にできる",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "レシートの印字設定の更新",
+                "parameters": [
+                    {
+                        "description": "更新条件",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateReceiptTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReceiptTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "在庫一覧の取得",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "summary": "在庫一覧の取得",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 10,
+                        "description": "取得件数",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 0,
+                        "description": "取得開始位置",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "出力形式（指定時は件数の指定がなければ全件をファイルで出力）",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "utf-8",
+                            "shift_jis"
+                        ],
+                        "type": "string",
+                        "description": "CSVの文字コード（既定はBOM付きUTF-8）",
+                        "name": "encoding",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Stock"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "在庫の作成",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫の作成",
+                "parameters": [
+                    {
+                        "description": "在庫情報",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "在庫の一括作成\natomicを指定すると1件ごとの結果（添字・状態・作成したID・エラー）を返す\natomic=trueは全件を1つのトランザクションで作成し、1件でも失敗すれば全件を取り消す\natomic=falseは作成できた行を確定し、一部が失敗した場合は207を返す",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫の一括作成",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "1件ごとの結果を返す。trueは全件成功した場合のみ作成する",
+                        "name": "atomic",
+                        "in": "query"
+                    },
+                    {
+                        "description": "在庫情報",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateBulkStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "atomic未指定の場合",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "207": {
+                        "description": "atomic=falseで一部の行が失敗した場合",
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkResult"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/model.BulkResult"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "CSV・Excelファイルから在庫を登録する。バーコードが一致する在庫は更新する\n列の対応付けを省略した場合は、項目名または在庫一覧の出力と同じ見出しの列を使う\n小さいファイルはその場で取り込んで結果を返し、大きいファイルは202を返してバックグラウンドで取り込む",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫の取り込み",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "取り込むファイル（CSVまたはxlsx）",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "検証のみ行い、登録・更新しない",
+                        "name": "dry_run",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "utf-8",
+                            "shift_jis"
+                        ],
+                        "type": "string",
+                        "description": "CSVの文字コード（BOM付きの場合はUTF-8）",
+                        "name": "encoding",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "{\"商品名\":\"name\"}",
+                        "description": "列見出しから項目名への対応（JSON）",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "担当者の列がない場合に設定する従業員ID",
+                        "name": "default_user_id",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportJob"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/labels": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "指定した在庫の値札・ラベルを在庫数量1単位につき1枚のPDFとして生成する\n1ページが1ラベルで、ページサイズはテンプレートのラベルサイズになる",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "summary": "在庫ラベルPDFの生成",
+                "parameters": [
+                    {
+                        "description": "出力条件",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.GenerateStockLabelsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/lookup": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "バーコード、JAN、シリアル番号のいずれかに一致する在庫を取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "識別コードによる在庫の検索",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "4901234567894",
+                        "description": "バーコード / JAN / シリアル番号",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Stock"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "在庫の取得",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Stock"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "在庫の更新",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫の更新",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "在庫情報",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Stock"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "在庫の削除",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫の削除",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/{id}/barcode": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ラベル印刷用のCode128バーコード画像(PNG)を取得する\nバーコード、JAN、シリアル番号の順に登録済みのコードを使用する",
+                "produces": [
+                    "image/png"
+                ],
+                "summary": "在庫のバーコード画像の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 10,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 2,
+                        "description": "1モジュールの幅(px)",
+                        "name": "module_width",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 10,
+                        "type": "integer",
+                        "example": 80,
+                        "description": "高さ(px)",
+                        "name": "height",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/{id}/images": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "在庫画像を表示順に取得する。URLは有効期限付きの署名付きURL",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫画像一覧の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StockImage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "在庫画像をアップロードし、サムネイルを生成する\n対応形式はJPEG/PNG/GIF/WebPで、形式はファイルの内容から判定する",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫画像のアップロード",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "画像ファイル",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockImage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {}
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/{id}/images/order": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "登録済みの画像IDをすべて表示順に指定する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫画像の並び替え",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "表示順",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.ReorderStockImagesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StockImage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/{id}/images/{image_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "在庫画像の削除",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫画像の削除",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "画像ID",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/{id}/movements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "入庫・販売・数量調整などの履歴を新しい順に取得する",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "summary": "在庫の入出庫履歴の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 10,
+                        "description": "取得件数",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 0,
+                        "description": "取得開始位置",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "出力形式（指定時は件数の指定がなければ全件をファイルで出力）",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "utf-8",
+                            "shift_jis"
+                        ],
+                        "type": "string",
+                        "description": "CSVの文字コード（既定はBOM付きUTF-8）",
+                        "name": "encoding",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StockMovement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/{id}/price-changes": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "有効日時に販売価格を変更する。有効日時を過ぎるとワーカーが適用する",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫の販売価格の変更の予約",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "予約内容",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.SchedulePriceChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockPriceChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/{id}/price-changes/{change_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "適用待ちの価格の変更を取り消す。適用済みの変更は取り消せない",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "予約した販売価格の変更の取り消し",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "価格の変更ID",
+                        "name": "change_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockPriceChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/{id}/prices": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "適用済みの価格の変更を有効日時の古い順に、適用待ちの変更を有効日時の近い順に取得する\n期間は適用済みの変更の有効日時で絞り込む",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫の販売価格の推移の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-10-01T00:00:00+09:00",
+                        "description": "期間の開始日時 (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-11-01T00:00:00+09:00",
+                        "description": "期間の終了日時 (RFC3339、この日時を含まない)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockPriceHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocks/{id}/receipts": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "取得原価を付けて在庫を入庫し、在庫数量を増やす",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "在庫の入庫",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "在庫ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "入庫情報",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.ReceiveStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocktakes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "店舗の棚卸を新しい順に取得する",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "summary": "棚卸一覧の取得",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 10,
+                        "description": "取得件数",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 0,
+                        "description": "取得開始位置",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "出力形式（指定時は件数の指定がなければ全件をファイルで出力）",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "utf-8",
+                            "shift_jis"
+                        ],
+                        "type": "string",
+                        "description": "CSVの文字コード（既定はBOM付きUTF-8）",
+                        "name": "encoding",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Stocktake"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "店舗の全在庫の現在数量をスナップショットとして保存し、カウントを開始する\n進行中の棚卸がある店舗では開始できない",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "棚卸の開始",
+                "parameters": [
+                    {
+                        "description": "棚卸情報",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.StartStocktakeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Stocktake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "棚卸の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "棚卸ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Stocktake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "差異を理由コード付きで承認し、在庫数量を実数に合わせる\n帳簿数量にスナップショット以降の入出庫を加えた数量と実数との差を調整として記録する\n調整は入出庫履歴に STOCKTAKE として記録される",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "棚卸の差異の承認",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "棚卸ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "承認内容",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.ApproveStocktakeItemsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StocktakeVariance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "承認済みの差異による在庫数量の調整は取り消されない",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "棚卸の中止",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "棚卸ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Stocktake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/close": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "カウントを締め切り、差異確認に進める",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "棚卸のカウント締め切り",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "棚卸ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Stocktake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "棚卸の完了",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "棚卸ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Stocktake"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/counts": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "端末ごとのカウント結果を登録する。在庫は在庫IDまたは識別コードで指定する\n同じ端末から再送された在庫は上書きし、複数端末のカウントは合計して実数とする",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "棚卸の実数の登録",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "棚卸ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "カウント結果",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.SubmitStocktakeCountsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StocktakeVariance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/report": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "カウント状況、差異数量・金額の合計、理由コード別の集計を取得する",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "棚卸報告書の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "棚卸ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StocktakeReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/stocktakes/{id}/variances": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "帳簿数量と実数の差異、販売価格で換算した差異金額を取得する\ndiscrepancy_only を指定すると差異のある在庫と未カウントの在庫のみを返す",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "棚卸の差異一覧の取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "棚卸ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "差異のみ",
+                        "name": "discrepancy_only",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StocktakeVariance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "従業員一覧の取得",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "summary": "従業員一覧の取得",
+                "parameters": [
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 10,
+                        "description": "取得件数",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 0,
+                        "description": "取得開始位置",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "出力形式（指定時は件数の指定がなければ全件をファイルで出力）",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "utf-8",
+                            "shift_jis"
+                        ],
+                        "type": "string",
+                        "description": "CSVの文字コード（既定はBOM付きUTF-8）",
+                        "name": "encoding",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "従業員の作成",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "従業員の作成",
+                "parameters": [
+                    {
+                        "description": "作成条件",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "従業員の取得",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "従業員の取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "従業員ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "従業員の更新",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "従業員の更新",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "従業員ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新条件",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "従業員の削除",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "従業員の削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "従業員ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {}
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {}
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.ApproveStocktakeItemsRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request.ApproveStocktakeItemRequest"
+                    }
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CompleteOrderReturnRequest": {
+            "type": "object",
+            "required": [
+                "resolution"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "返金額、またはポイントで返還する額。未指定の場合は発注の支払いが必要な金額を数量で按分した額\n按分した額と、発注の支払いが必要な金額から完了した返品で返した額を差し引いた額を超えられない",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 8000
+                },
+                "exchange_order_id": {
+                    "description": "交換品の発注。交換の場合は必須で、返品の受付後に作成した同じ顧客の発注のうち、他の返品の交換品でないものに限る",
+                    "type": "integer",
+                    "example": 3
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": ""
+                },
+                "resolution": {
+                    "type": "string",
+                    "enum": [
+                        "REFUND",
+                        "EXCHANGE",
+                        "STORE_CREDIT"
+                    ],
+                    "example": "REFUND"
+                },
+                "restock": {
+                    "description": "在庫に戻し入れるか。未指定の場合はジャンク以外なら戻し入れる",
+                    "type": "boolean",
+                    "example": true
+                },
+                "restock_stock_id": {
+                    "description": "戻し入れる在庫。未指定の場合は返品された在庫に戻し入れる",
+                    "type": "integer",
+                    "example": 2
+                },
+                "unit_cost": {
+                    "description": "戻し入れる返品の取得原価 (1点あたり)。未指定の場合は取得原価不明として扱う",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50000
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateCouponRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "active": {
+                    "description": "未指定の場合は有効",
+                    "type": "boolean",
+                    "example": true
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 4,
+                    "example": "AUTUMN2025"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateCreditNoteRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "宛名の誤り"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateCustomerRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "phone_number",
+                "tenant_id"
+            ],
+            "properties": {
+                "address": {
+                    "description": "都道府県を指定しない場合は必須。都道府県を指定した場合は分割した住所から作る",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "東京都千代田区丸の内1-1-1"
+                },
+                "building": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "丸の内ビル5F"
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "千代田区"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "taro_tanaka@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "田中 太郎"
+                },
+                "phone_country": {
+                    "description": "国内表記の電話番号の国 (ISO 3166-1 alpha-2)。未指定の場合は日本",
+                    "type": "string",
+                    "example": "JP"
+                },
+                "phone_number": {
+                    "description": "+から始まる国際表記、または phone_country の国内表記。E.164形式で保存する",
+                    "type": "string",
+                    "example": "090-1234-5678"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "100-0005"
+                },
+                "prefecture": {
+                    "type": "string",
+                    "maxLength": 4,
+                    "example": "東京都"
+                },
+                "street": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "丸の内1-1-1"
+                },
+                "tenant_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateCustomerSegmentRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "半年以上購入がなく累計購入額が上位の顧客"
+                },
+                "filter": {
+                    "$ref": "#/definitions/request.SegmentFilterRequest"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "休眠中の優良顧客"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateOrderRequest": {
+            "type": "object",
+            "required": [
+                "customer_id",
+                "delivery_date",
+                "stock_id"
+            ],
+            "properties": {
+                "coupon_code": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "AUTUMN2025"
+                },
+                "customer_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "delivery_date": {
+                    "type": "string",
+                    "example": "2022-01-01"
+                },
+                "points_used": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "status": {
+                    "description": "nolint:lll",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "SHIPPED",
+                        "DELIVERED",
+                        "CANCELLED"
+                    ],
+                    "example": "PENDING"
+                },
+                "stock_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_amount": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100000
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateOrderReturnRequest": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "reason"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "サイズが合わない"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreatePaymentRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "method"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 8000
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "CASH",
+                        "CREDIT_CARD",
+                        "QR",
+                        "BANK_TRANSFER",
+                        "POINTS"
+                    ],
+                    "example": "CASH"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": ""
+                },
+                "reference": {
+                    "description": "振込の照合番号など。クレジットカード・QRコード決済では決済代行会社の参照番号で置き換える",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": ""
+                },
+                "tendered": {
+                    "description": "現金で預かった金額。現金の場合のみ指定できる",
+                    "type": "integer",
+                    "example": 10000
+                },
+                "token": {
+                    "description": "クレジットカード・QRコード決済で使うトークン",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "tok_visa"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreatePromotionRequest": {
+            "type": "object",
+            "required": [
+                "discount_type",
+                "discount_value",
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "description": "未指定の場合は有効",
+                    "type": "boolean",
+                    "example": true
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "バッグ"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "対象のバッグが10%オフ"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "PERCENT",
+                        "FIXED"
+                    ],
+                    "example": "PERCENT"
+                },
+                "discount_value": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 10
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-12-01T00:00:00+09:00"
+                },
+                "min_spend": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "秋のブランドセール"
+                },
+                "requires_coupon": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00+09:00"
+                },
+                "stock_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "store_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "usage_limit_per_customer": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateRefundRequest": {
+            "type": "object",
+            "required": [
+                "cause",
+                "payment_id"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "未指定の場合は支払いのうち返金していない全額",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 8000
+                },
+                "cause": {
+                    "type": "string",
+                    "enum": [
+                        "CANCELLATION",
+                        "RETURN",
+                        "OTHER"
+                    ],
+                    "example": "CANCELLATION"
+                },
+                "payment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "お客様都合のキャンセル"
+                },
+                "reference": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": ""
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateStockRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "price",
+                "quantity",
+                "store_id",
+                "user_id"
+            ],
+            "properties": {
+                "barcode": {
+                    "description": "識別コード",
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 1,
+                    "example": "BS-000001"
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "バッグ"
+                },
+                "jan": {
+                    "type": "string",
+                    "example": "4901234567894"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "LOUIS VUITTON M41524 ブラウン モノグラム ハンドバッグ"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100000
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "serial_number": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "SN12345678"
+                },
+                "store_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "tax_rate": {
+                    "description": "消費税率 (%)。未指定の場合は標準税率",
+                    "type": "integer",
+                    "enum": [
+                        8,
+                        10
+                    ],
+                    "example": 10
+                },
+                "unit_cost": {
+                    "description": "取得原価(1点あたり)",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 80000
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "employee_number",
+                "name",
+                "store_id"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "taro_tanaka@example.com"
+                },
+                "employee_number": {
+                    "type": "string",
+                    "maxLength": 10,
+                    "minLength": 1,
+                    "example": "0000000000"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female"
+                    ],
+                    "example": "male"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "田中 太郎"
+                },
+                "store_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.GenerateStockLabelsRequest": {
+            "type": "object",
+            "required": [
+                "stock_ids",
+                "template"
+            ],
+            "properties": {
+                "stock_ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "template": {
+                    "$ref": "#/definitions/request.LabelTemplateRequest"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.InspectOrderReturnRequest": {
+            "type": "object",
+            "required": [
+                "condition"
+            ],
+            "properties": {
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "NEW",
+                        "S",
+                        "A",
+                        "B",
+                        "C",
+                        "JUNK"
+                    ],
+                    "example": "B"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "持ち手に擦れあり"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.MergeCustomersRequest": {
+            "type": "object",
+            "required": [
+                "source_ids"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "電話番号の表記ゆれによる重複登録"
+                },
+                "source_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "00000000-0000-0000-0000-000000000001"
+                    ]
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.ReceiveStockRequest": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "買取"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "received_at": {
+                    "type": "string",
+                    "example": "2025-10-01T10:00:00+09:00"
+                },
+                "unit_cost": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 80000
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.RecordCustomerConsentRequest": {
+            "type": "object",
+            "required": [
+                "granted",
+                "purpose"
+            ],
+            "properties": {
+                "granted": {
+                    "description": "trueで同意、falseで撤回",
+                    "type": "boolean",
+                    "example": true
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "会員登録時の申込書で同意"
+                },
+                "purpose": {
+                    "type": "string",
+                    "enum": [
+                        "marketing_email",
+                        "marketing_sms",
+                        "marketing_post",
+                        "third_party"
+                    ],
+                    "example": "marketing_email"
+                },
+                "recorded_at": {
+                    "description": "同意・撤回の日時。未指定の場合は記録した日時",
+                    "type": "string",
+                    "example": "2025-10-31T10:00:00+09:00"
+                },
+                "source": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "店頭"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.RejectOrderReturnRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "返品期限切れ"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.ReorderStockImagesRequest": {
+            "type": "object",
+            "required": [
+                "image_ids"
+            ],
+            "properties": {
+                "image_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        1,
+                        2
+                    ]
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.SchedulePriceChangeRequest": {
+            "type": "object",
+            "required": [
+                "effective_from"
+            ],
+            "properties": {
+                "effective_from": {
+                    "type": "string",
+                    "example": "2025-11-03T09:00:00+09:00"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 90000
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "週明けの値下げ"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.StartStocktakeRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "2025年10月 月末棚卸"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.SubmitStocktakeCountsRequest": {
+            "type": "object",
+            "required": [
+                "counts",
+                "device_id"
+            ],
+            "properties": {
+                "counts": {
+                    "type": "array",
+                    "maxItems": 10000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request.StocktakeCountRequest"
+                    }
+                },
+                "device_id": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "handy-01"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateCustomerRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "phone_number",
+                "tenant_id"
+            ],
+            "properties": {
+                "address": {
+                    "description": "都道府県を指定しない場合は必須。都道府県を指定した場合は分割した住所から作る",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "東京都千代田区丸の内1-1-1"
+                },
+                "building": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "丸の内ビル5F"
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "千代田区"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "taro_tanaka@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "田中 太郎"
+                },
+                "phone_country": {
+                    "description": "国内表記の電話番号の国 (ISO 3166-1 alpha-2)。未指定の場合は日本",
+                    "type": "string",
+                    "example": "JP"
+                },
+                "phone_number": {
+                    "description": "+から始まる国際表記、または phone_country の国内表記。E.164形式で保存する",
+                    "type": "string",
+                    "example": "090-1234-5678"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "100-0005"
+                },
+                "prefecture": {
+                    "type": "string",
+                    "maxLength": 4,
+                    "example": "東京都"
+                },
+                "street": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "丸の内1-1-1"
+                },
+                "tenant_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateCustomerSegmentRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "半年以上購入がなく累計購入額が上位の顧客"
+                },
+                "filter": {
+                    "$ref": "#/definitions/request.SegmentFilterRequest"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "休眠中の優良顧客"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateInvoiceIssuerRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "registration_number"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "東京都新宿区西新宿6-8-1"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "株式会社バイセル"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "03-1234-5678"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "160-0023"
+                },
+                "registration_number": {
+                    "description": "適格請求書発行事業者の登録番号 (T+13桁)",
+                    "type": "string",
+                    "example": "T1234567890123"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateMarkdownRulesRequest": {
+            "type": "object",
+            "required": [
+                "rules"
+            ],
+            "properties": {
+                "floor_at_cost": {
+                    "description": "未指定の場合は変更しない",
+                    "type": "boolean",
+                    "example": true
+                },
+                "rules": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/request.MarkdownRuleRequest"
+                    }
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateOrderRequest": {
+            "type": "object",
+            "required": [
+                "delivery_date",
+                "tenant_id"
+            ],
+            "properties": {
+                "delivery_date": {
+                    "type": "string",
+                    "example": "2022-01-01"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "status": {
+                    "description": "nolint:lll",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "SHIPPED",
+                        "DELIVERED",
+                        "CANCELLED"
+                    ],
+                    "example": "PENDING"
+                },
+                "tenant_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "total_amount": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100000
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdatePromotionRequest": {
+            "type": "object",
+            "required": [
+                "discount_type",
+                "discount_value",
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "description": "未指定の場合は有効",
+                    "type": "boolean",
+                    "example": true
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "バッグ"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "対象のバッグが10%オフ"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "PERCENT",
+                        "FIXED"
+                    ],
+                    "example": "PERCENT"
+                },
+                "discount_value": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 10
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-12-01T00:00:00+09:00"
+                },
+                "min_spend": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "秋のブランドセール"
+                },
+                "requires_coupon": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00+09:00"
+                },
+                "stock_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "store_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "usage_limit_per_customer": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateReceiptTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "footer": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "返品・交換はレシートをお持ちのうえ14日以内にお申し付けください"
+                },
+                "header": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "いつもご利用ありがとうございます"
+                },
+                "paper_width": {
+                    "description": "未指定の項目は変更しない",
+                    "type": "integer",
+                    "enum": [
+                        58,
+                        80
+                    ],
+                    "example": 80
+                },
+                "show_barcode": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "show_store_address": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateStockRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "price",
+                "quantity",
+                "store_id",
+                "user_id"
+            ],
+            "properties": {
+                "barcode": {
+                    "description": "識別コード",
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 1,
+                    "example": "BS-000001"
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "バッグ"
+                },
+                "jan": {
+                    "type": "string",
+                    "example": "4901234567894"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "LOUIS VUITTON M41524 ブラウン モノグラム ハンドバッグ"
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100000
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "serial_number": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "SN12345678"
+                },
+                "store_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                },
+                "tax_rate": {
+                    "description": "消費税率 (%)。未指定の場合は変更しない",
+                    "type": "integer",
+                    "enum": [
+                        8,
+                        10
+                    ],
+                    "example": 10
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateTenantSettingRequest": {
+            "type": "object",
+            "required": [
+                "valuation_method"
+            ],
+            "properties": {
+                "point_earn_unit": {
+                    "description": "未指定の場合は変更しない",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "point_expiry_months": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0,
+                    "example": 12
+                },
+                "valuation_method": {
+                    "type": "string",
+                    "enum": [
+                        "MOVING_AVERAGE",
+                        "FIFO"
+                    ],
+                    "example": "MOVING_AVERAGE"
+                }
+            }
+        },
+        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "employee_number",
+                "name",
+                "store_id"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "taro_tanaka@example.com"
+                },
+                "employee_number": {
+                    "type": "string",
+                    "maxLength": 10,
+                    "minLength": 1,
+                    "example": "0000000000"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female"
+                    ],
+                    "example": "male"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "田中 太郎"
+                },
+                "store_id": {
+                    "type": "string",
+                    "example": "00000000-0000-0000-0000-000000000000"
+                }
+            }
+        },
+        "model.AddressCandidate": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "千代田区"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "100-0005"
+                },
+                "prefecture": {
+                    "type": "string",
+                    "example": "東京都"
+                },
+                "town": {
+                    "description": "町域。郵便番号が市区町村全体に対応する場合は空",
+                    "type": "string",
+                    "example": "丸の内"
+                }
+            }
+        },
+        "model.BulkItemResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.BulkItemStatus"
+                }
+            }
+        },
+        "model.BulkItemStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "failed",
+                "skipped"
+            ],
+            "x-enum-comments": {
+                "BulkItemCreated": "作成済み",
+                "BulkItemFailed": "検証・登録に失敗",
+                "BulkItemSkipped": "他の行の失敗により取り消し・未実行"
+            },
+            "x-enum-varnames": [
+                "BulkItemCreated",
+                "BulkItemFailed",
+                "BulkItemSkipped"
+            ]
+        },
+        "model.BulkResult": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BulkItemResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CategorySpend": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "order_count": {
+                    "type": "integer"
+                },
+                "spend": {
+                    "type": "integer"
+                },
+                "units": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ChargeStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "COMPLETED",
+                "FAILED"
+            ],
+            "x-enum-comments": {
+                "ChargeCompleted": "支払い済み",
+                "ChargeFailed": "決済代行会社が決済を承認しなかった",
+                "ChargePending": "決済代行会社の結果待ち"
+            },
+            "x-enum-varnames": [
+                "ChargePending",
+                "ChargeCompleted",
+                "ChargeFailed"
+            ]
+        },
+        "model.ConsentPurpose": {
+            "type": "string",
+            "enum": [
+                "marketing_email",
+                "marketing_sms",
+                "marketing_post",
+                "third_party"
+            ],
+            "x-enum-comments": {
+                "ConsentMarketingEmail": "メールでの販促",
+                "ConsentMarketingPost": "ダイレクトメールの郵送",
+                "ConsentMarketingSMS": "SMSでの販促",
+                "ConsentThirdParty": "第三者への提供"
+            },
+            "x-enum-varnames": [
+                "ConsentMarketingEmail",
+                "ConsentMarketingSMS",
+                "ConsentMarketingPost",
+                "ConsentThirdParty"
+            ]
+        },
+        "model.Coupon": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "AUTUMN2025"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "promotion_id": {
+                    "type": "integer"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "description": "このコードの利用回数の上限。0の場合は無制限",
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "model.Customer": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "anonymized_at": {
+                    "description": "個人情報を匿名化した日時。匿名化した顧客は削除済みとして扱う",
+                    "type": "string"
+                },
+                "building": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "format": "date-time",
+                    "example": "2023-01-01T00:00:00Z"
+                },
+                "email": {
+                    "description": "メールアドレス・電話番号・住所 (番地・建物名を含む) はDBに暗号化して保存する",
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "integer"
+                },
+                "frequency_score": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "monetary": {
+                    "type": "integer"
+                },
+                "monetary_score": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orders": {
+                    "description": "リレーション (hasMany)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Order"
+                    }
+                },
+                "phone_country": {
+                    "description": "電話番号の国 (ISO 3166-1 alpha-2)。国番号から特定できない場合は空",
+                    "type": "string",
+                    "example": "JP"
+                },
+                "phone_number": {
+                    "description": "E.164形式 (例: +819012345678)",
+                    "type": "string",
+                    "example": "+819012345678"
+                },
+                "phone_number_display": {
+                    "description": "表示用の電話番号。日本の番号は国内表記 (例: 090-1234-5678)",
+                    "type": "string",
+                    "example": "090-1234-5678"
+                },
+                "point_balance": {
+                    "description": "ポイント残高",
+                    "type": "integer"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "prefecture": {
+                    "type": "string"
+                },
+                "recency_days": {
+                    "description": "スコア算出時点の最終購入からの日数・発注件数・累計購入額",
+                    "type": "integer"
+                },
+                "recency_score": {
+                    "description": "RFM分析のスコア (1〜5、5が最良)。テナント内の順位から算出し、発注のない顧客は空",
+                    "type": "integer"
+                },
+                "rfm_scored_at": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerAnonymization": {
+            "type": "object",
+            "properties": {
+                "anonymized_at": {
+                    "type": "string"
+                },
+                "anonymized_customer_ids": {
+                    "description": "匿名化した顧客。統合元の顧客を含む",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "customer_id": {
+                    "type": "string"
+                },
+                "retained_orders": {
+                    "description": "会計のために保持した発注の件数",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CustomerConsent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "string"
+                },
+                "granted": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "purpose": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ConsentPurpose"
+                        }
+                    ],
+                    "example": "marketing_email"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "同意を取得した経路 (例: 店頭、Webフォーム)",
+                    "type": "string",
+                    "example": "店頭"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerConsents": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "目的ごとの最新の記録",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerConsent"
+                    }
+                },
+                "history": {
+                    "description": "全ての記録 (新しい順)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerConsent"
+                    }
+                }
+            }
+        },
+        "model.CustomerDuplicate": {
+            "type": "object",
+            "properties": {
+                "candidate": {
+                    "$ref": "#/definitions/model.Customer"
+                },
+                "customer": {
+                    "$ref": "#/definitions/model.Customer"
+                },
+                "name_similarity": {
+                    "type": "number"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DuplicateReason"
+                    }
+                }
+            }
+        },
+        "model.CustomerMerge": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merged_at": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "order_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "source": {
+                    "$ref": "#/definitions/model.CustomerSnapshot"
+                },
+                "source_customer_id": {
+                    "type": "string"
+                },
+                "target_customer_id": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerOrderHistory": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Order"
+                    }
+                },
+                "total": {
+                    "description": "発注の総件数 (キャンセルを含む)",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CustomerPersonalData": {
+            "type": "object",
+            "properties": {
+                "consents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerConsent"
+                    }
+                },
+                "customer": {
+                    "description": "発注を含む顧客情報。削除済みの顧客も対象",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Customer"
+                        }
+                    ]
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "merged_customers": {
+                    "description": "この顧客に統合された顧客 (統合元) の情報",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Customer"
+                    }
+                },
+                "merges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerMerge"
+                    }
+                }
+            }
+        },
+        "model.CustomerSegment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "filter": {
+                    "$ref": "#/definitions/model.SegmentFilter"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerSnapshot": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerSummary": {
+            "type": "object",
+            "properties": {
+                "average_basket": {
+                    "description": "1回の発注あたりの平均購入額 (円未満は四捨五入)",
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "type": "string"
+                },
+                "favorite_categories": {
+                    "description": "購入額の多い分類。分類が未設定の在庫は含めない",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CategorySpend"
+                    }
+                },
+                "first_purchase_at": {
+                    "type": "string"
+                },
+                "last_purchase_at": {
+                    "type": "string"
+                },
+                "lifetime_spend": {
+                    "description": "累計購入額。値引き・ポイント利用・返品で返した額を差し引く",
+                    "type": "integer"
+                },
+                "order_count": {
+                    "type": "integer"
+                },
+                "units": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DiscountType": {
+            "type": "string",
+            "enum": [
+                "PERCENT",
+                "FIXED"
+            ],
+            "x-enum-comments": {
+                "DiscountFixed": "定額 (円)",
+                "DiscountPercent": "定率 (%)"
+            },
+            "x-enum-varnames": [
+                "DiscountPercent",
+                "DiscountFixed"
+            ]
+        },
+        "model.DuplicateReason": {
+            "type": "string",
+            "enum": [
+                "phone_number",
+                "email",
+                "name"
+            ],
+            "x-enum-comments": {
+                "DuplicateEmail": "メールアドレスが一致",
+                "DuplicateName": "氏名が類似",
+                "DuplicatePhoneNumber": "電話番号が一致"
+            },
+            "x-enum-varnames": [
+                "DuplicatePhoneNumber",
+                "DuplicateEmail",
+                "DuplicateName"
+            ]
+        },
+        "model.ImportJob": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_rows": {
+                    "type": "integer"
+                },
+                "default_user_id": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "encoding": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportRowError"
+                    }
+                },
+                "failed_rows": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mapping": {
+                    "$ref": "#/definitions/model.ImportMapping"
+                },
+                "match_by": {
+                    "type": "string"
+                },
+                "processed_rows": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.ImportJobStatus"
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "target": {
+                    "$ref": "#/definitions/model.ImportTarget"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImportJobStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "RUNNING",
+                "COMPLETED",
+                "FAILED"
+            ],
+            "x-enum-comments": {
+                "ImportCompleted": "完了",
+                "ImportFailed": "失敗",
+                "ImportPending": "処理待ち",
+                "ImportRunning": "処理中"
+            },
+            "x-enum-varnames": [
+                "ImportPending",
+                "ImportRunning",
+                "ImportCompleted",
+                "ImportFailed"
+            ]
+        },
+        "model.ImportMapping": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "model.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImportTarget": {
+            "type": "string",
+            "enum": [
+                "stocks",
+                "customers"
+            ],
+            "x-enum-comments": {
+                "ImportCustomers": "顧客",
+                "ImportStocks": "在庫"
+            },
+            "x-enum-varnames": [
+                "ImportStocks",
+                "ImportCustomers"
+            ]
+        },
+        "model.InventoryValuationReport": {
+            "type": "object",
+            "properties": {
+                "as_of": {
+                    "type": "string"
+                },
+                "method": {
+                    "$ref": "#/definitions/model.ValuationMethod"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "stores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StoreValuation"
+                    }
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "unknown_cost_quantity": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Invoice": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "issuer_address": {
+                    "type": "string"
+                },
+                "issuer_name": {
+                    "type": "string"
+                },
+                "issuer_phone_number": {
+                    "type": "string"
+                },
+                "issuer_postal_code": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InvoiceLine"
+                    }
+                },
+                "number": {
+                    "description": "テナント・種別ごとの連番 (例: INV-00000001)",
+                    "type": "string",
+                    "example": "INV-00000001"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "original_invoice_id": {
+                    "description": "返還請求書が取り消す請求書",
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "recipient_name": {
+                    "description": "宛名 (顧客名)",
+                    "type": "string"
+                },
+                "registration_number": {
+                    "description": "発行者",
+                    "type": "string",
+                    "example": "T1234567890123"
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "taxes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TaxBreakdown"
+                    }
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "税込の合計金額と消費税額の合計。返還請求書では負の値",
+                    "type": "integer"
+                },
+                "transaction_date": {
+                    "description": "取引年月日 (発注日)",
+                    "type": "string"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.InvoiceType"
+                        }
+                    ],
+                    "example": "INVOICE"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.InvoiceIssuer": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "東京都新宿区西新宿6-8-1"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "株式会社バイセル"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "03-1234-5678"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "1600023"
+                },
+                "registration_number": {
+                    "description": "適格請求書発行事業者の登録番号 (T+13桁)",
+                    "type": "string",
+                    "example": "T1234567890123"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.InvoiceLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 110000
+                },
+                "description": {
+                    "type": "string",
+                    "example": "LOUIS VUITTON M41524 ブラウン モノグラム ハンドバッグ"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "tax_rate": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "unit_price": {
+                    "description": "単価は数量で割り切れる場合のみ記載する",
+                    "type": "integer",
+                    "example": 110000
+                }
+            }
+        },
+        "model.InvoiceType": {
+            "type": "string",
+            "enum": [
+                "INVOICE",
+                "CREDIT_NOTE"
+            ],
+            "x-enum-comments": {
+                "InvoiceTypeCreditNote": "適格返還請求書 (発行済みの請求書の取消)",
+                "InvoiceTypeInvoice": "適格請求書"
+            },
+            "x-enum-varnames": [
+                "InvoiceTypeInvoice",
+                "InvoiceTypeCreditNote"
+            ]
+        },
+        "model.MarkdownItem": {
+            "type": "object",
+            "properties": {
+                "age_days": {
+                    "type": "integer",
+                    "example": 63
+                },
+                "base_price": {
+                    "type": "integer",
+                    "example": 100000
+                },
+                "current_price": {
+                    "type": "integer",
+                    "example": 100000
+                },
+                "discount_percent": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "floor_applied": {
+                    "description": "原価の下限で値下げ額を抑えたか",
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ヴィンテージ ショルダーバッグ"
+                },
+                "new_price": {
+                    "type": "integer",
+                    "example": 90000
+                },
+                "rule_age_days": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "stock_id": {
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "unit_cost": {
+                    "description": "評価方法に従った取得原価の単価。取得原価が不明な場合はnull",
+                    "type": "integer",
+                    "example": 70000
+                }
+            }
+        },
+        "model.MarkdownPlan": {
+            "type": "object",
+            "properties": {
+                "cost_unknown_stock_ids": {
+                    "description": "原価の下限を適用する設定で取得原価が不明なため値下げしない在庫",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "evaluated_at": {
+                    "type": "string"
+                },
+                "floor_at_cost": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MarkdownItem"
+                    }
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MarkdownRule": {
+            "type": "object",
+            "properties": {
+                "age_days": {
+                    "description": "在庫の登録からの経過日数",
+                    "type": "integer",
+                    "example": 60
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "discount_percent": {
+                    "description": "基準価格からの値下げ率 (%)",
+                    "type": "integer",
+                    "example": 10
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.MarkdownRuleSet": {
+            "type": "object",
+            "properties": {
+                "floor_at_cost": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MarkdownRule"
+                    }
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Order": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "string"
+                },
+                "delivery_date": {
+                    "type": "string"
+                },
+                "discount_amount": {
+                    "description": "キャンペーンによる値引き額の合計。TotalAmountは値引き前の金額",
+                    "type": "integer"
+                },
+                "discounts": {
+                    "description": "リレーション (hasMany)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderDiscount"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "points_used": {
+                    "description": "値引きに利用したポイント (1ポイント1円)",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.OrderStatus"
+                },
+                "stock_id": {
+                    "type": "integer"
+                },
+                "total_amount": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderDiscount": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "coupon_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "秋のブランドセール"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "promotion_id": {
+                    "type": "integer"
+                },
+                "stock_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderPayments": {
+            "type": "object",
+            "properties": {
+                "amount_due": {
+                    "description": "支払いが必要な金額。値引き額と値引きに利用したポイント、返品で返した額を差し引いた金額",
+                    "type": "integer",
+                    "example": 8000
+                },
+                "balance": {
+                    "description": "未払いの残額。返品によらない返金の分は未払いに戻る",
+                    "type": "integer",
+                    "example": 0
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "paid": {
+                    "description": "支払額と返金額。決済代行会社の結果待ちのものを含み、失敗したものは含まない",
+                    "type": "integer",
+                    "example": 8000
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Payment"
+                    }
+                },
+                "refunded": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "refunds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Refund"
+                    }
+                },
+                "returned": {
+                    "description": "完了した返品で返金、またはポイントで返還した額",
+                    "type": "integer",
+                    "example": 0
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PaymentStatus"
+                        }
+                    ],
+                    "example": "PAID"
+                }
+            }
+        },
+        "model.OrderReturn": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "返金額、またはポイントで返還した額",
+                    "type": "integer",
+                    "example": 8000
+                },
+                "closed_at": {
+                    "description": "完了、または返品不可とした日時",
+                    "type": "string"
+                },
+                "condition": {
+                    "description": "検品した状態ランク。検品前は空",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ReturnCondition"
+                        }
+                    ],
+                    "example": "B"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "exchange_order_id": {
+                    "description": "交換品の発注",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inspected_at": {
+                    "type": "string"
+                },
+                "note": {
+                    "description": "検品・対応の記録",
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "サイズが合わない"
+                },
+                "resolution": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ReturnResolution"
+                        }
+                    ],
+                    "example": "REFUND"
+                },
+                "restock_stock_id": {
+                    "description": "戻し入れた在庫。状態ランクに応じて元とは別の在庫に戻せる。戻し入れない場合は空",
+                    "type": "integer"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ReturnStatus"
+                        }
+                    ],
+                    "example": "REQUESTED"
+                },
+                "stock_id": {
+                    "description": "返品された在庫 (発注の在庫)",
+                    "type": "integer"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "SHIPPED",
+                "DELIVERED",
+                "CANCELLED"
+            ],
+            "x-enum-comments": {
+                "StatusCancelled": "キャンセル",
+                "StatusDelivered": "納品済み",
+                "StatusPending": "保留中",
+                "StatusShipped": "出荷済み"
+            },
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusShipped",
+                "StatusDelivered",
+                "StatusCancelled"
+            ]
+        },
+        "model.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 8000
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PaymentMethod"
+                        }
+                    ],
+                    "example": "CASH"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "reference": {
+                    "description": "決済代行会社の取引の参照番号、または振込の照合番号など",
+                    "type": "string",
+                    "example": "fake_ch_000001"
+                },
+                "status": {
+                    "description": "決済代行会社を通す支払いは記録を確定してから決済するため、結果が分かるまでPENDINGになる",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ChargeStatus"
+                        }
+                    ],
+                    "example": "COMPLETED"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "tendered": {
+                    "description": "現金で預かった金額。釣り銭は預かり金額と支払額の差",
+                    "type": "integer",
+                    "example": 10000
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PaymentMethod": {
+            "type": "string",
+            "enum": [
+                "CASH",
+                "CREDIT_CARD",
+                "QR",
+                "BANK_TRANSFER",
+                "POINTS"
+            ],
+            "x-enum-comments": {
+                "PaymentBankTransfer": "銀行振込",
+                "PaymentCash": "現金",
+                "PaymentCreditCard": "クレジットカード",
+                "PaymentPoints": "ポイント (1ポイント1円)",
+                "PaymentQR": "QRコード決済"
+            },
+            "x-enum-varnames": [
+                "PaymentCash",
+                "PaymentCreditCard",
+                "PaymentQR",
+                "PaymentBankTransfer",
+                "PaymentPoints"
+            ]
+        },
+        "model.PaymentStatus": {
+            "type": "string",
+            "enum": [
+                "UNPAID",
+                "PARTIALLY_PAID",
+                "PAID",
+                "REFUNDED"
+            ],
+            "x-enum-comments": {
+                "PaymentPaid": "支払い済み",
+                "PaymentPartiallyPaid": "一部入金",
+                "PaymentRefunded": "全額返金済み",
+                "PaymentUnpaid": "未払い"
+            },
+            "x-enum-varnames": [
+                "PaymentUnpaid",
+                "PaymentPartiallyPaid",
+                "PaymentPaid",
+                "PaymentRefunded"
+            ]
+        },
+        "model.PointBalance": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "customer_id": {
+                    "type": "string"
+                },
+                "expiring": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PointExpiry"
+                    }
+                }
+            }
+        },
+        "model.PointExpiry": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "model.PointHistory": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PointTransaction"
+                    }
+                }
+            }
+        },
+        "model.PointTransaction": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "記録後の残高",
+                    "type": "integer",
+                    "example": 1200
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "増加したポイントの有効期限。空の場合は無期限",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "points": {
+                    "description": "増減したポイント (減少は負)",
+                    "type": "integer",
+                    "example": 100
+                },
+                "remaining": {
+                    "description": "増加したポイントのうち未使用・未失効の残り",
+                    "type": "integer",
+                    "example": 100
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PointTransactionType"
+                        }
+                    ],
+                    "example": "EARN"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PointTransactionType": {
+            "type": "string",
+            "enum": [
+                "EARN",
+                "REDEEM",
+                "EXPIRE",
+                "EARN_REVERSAL",
+                "REDEEM_REFUND",
+                "PAYMENT",
+                "PAYMENT_REFUND",
+                "RETURN_CREDIT"
+            ],
+            "x-enum-comments": {
+                "PointEarn": "発注の納品による付与",
+                "PointEarnReversal": "納品の取り消し・返品による付与の取り消し",
+                "PointExpire": "有効期限切れによる失効",
+                "PointPayment": "発注の支払いに利用",
+                "PointPaymentRefund": "支払いの返金による返還",
+                "PointRedeem": "発注の値引きに利用",
+                "PointRedeemRefund": "発注のキャンセルによる利用の取り消し",
+                "PointReturnCredit": "返品のポイントでの返還"
+            },
+            "x-enum-varnames": [
+                "PointEarn",
+                "PointRedeem",
+                "PointExpire",
+                "PointEarnReversal",
+                "PointRedeemRefund",
+                "PointPayment",
+                "PointPaymentRefund",
+                "PointReturnCredit"
+            ]
+        },
+        "model.PriceChangeStatus": {
+            "type": "string",
+            "enum": [
+                "SCHEDULED",
+                "APPLIED",
+                "CANCELLED"
+            ],
+            "x-enum-comments": {
+                "PriceChangeApplied": "適用済み",
+                "PriceChangeCancelled": "取消",
+                "PriceChangeScheduled": "適用待ち"
+            },
+            "x-enum-varnames": [
+                "PriceChangeScheduled",
+                "PriceChangeApplied",
+                "PriceChangeCancelled"
+            ]
+        },
+        "model.Promotion": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "バッグ"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.DiscountType"
+                        }
+                    ],
+                    "example": "PERCENT"
+                },
+                "discount_value": {
+                    "description": "定率の場合は%、定額の場合は円",
+                    "type": "integer",
+                    "example": 10
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "description": "適用に必要な発注金額の下限",
+                    "type": "integer",
+                    "example": 10000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "秋のブランドセール"
+                },
+                "requires_coupon": {
+                    "type": "boolean"
+                },
+                "starts_at": {
+                    "description": "適用期間。未指定の場合は期限を設けない",
+                    "type": "string"
+                },
+                "stock_id": {
+                    "description": "対象の在庫・分類・店舗。未指定の条件では絞り込まない",
+                    "type": "integer"
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "description": "全体の利用回数の上限。0の場合は無制限",
+                    "type": "integer",
+                    "example": 0
+                },
+                "usage_limit_per_customer": {
+                    "description": "顧客1人あたりの利用回数の上限。0の場合は無制限",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "model.ReceiptTemplate": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "footer": {
+                    "type": "string",
+                    "example": "返品・交換はレシートをお持ちのうえ14日以内にお申し付けください"
+                },
+                "header": {
+                    "description": "店舗名の下・レシートの末尾に印字する文言。改行で複数行にできる",
+                    "type": "string",
+                    "example": "いつもご利用ありがとうございます"
+                },
+                "paper_width": {
+                    "description": "既定の用紙幅 (mm)",
+                    "type": "integer",
+                    "example": 80
+                },
+                "show_barcode": {
+                    "description": "発注番号のバーコードを印字するか",
+                    "type": "boolean",
+                    "example": true
+                },
+                "show_store_address": {
+                    "description": "店舗の住所・電話番号を印字するか",
+                    "type": "boolean",
+                    "example": true
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Refund": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 8000
+                },
+                "cause": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.RefundCause"
+                        }
+                    ],
+                    "example": "CANCELLATION"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reference": {
+                    "description": "決済代行会社の返金の参照番号など",
+                    "type": "string",
+                    "example": "fake_re_000002"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "return_id": {
+                    "description": "返品による返金の場合の返品ID",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "決済代行会社を通す返金は記録を確定してから依頼するため、結果が分かるまでPENDINGになる",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.RefundStatus"
+                        }
+                    ],
+                    "example": "COMPLETED"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.RefundCause": {
+            "type": "string",
+            "enum": [
+                "CANCELLATION",
+                "RETURN",
+                "OTHER"
+            ],
+            "x-enum-comments": {
+                "RefundCancellation": "発注のキャンセル",
+                "RefundOther": "その他の調整",
+                "RefundReturn": "返品"
+            },
+            "x-enum-varnames": [
+                "RefundCancellation",
+                "RefundReturn",
+                "RefundOther"
+            ]
+        },
+        "model.RefundStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "COMPLETED",
+                "FAILED"
+            ],
+            "x-enum-comments": {
+                "RefundCompleted": "返金済み",
+                "RefundFailed": "決済代行会社が返金を受け付けなかった",
+                "RefundPending": "決済代行会社の結果待ち"
+            },
+            "x-enum-varnames": [
+                "RefundPending",
+                "RefundCompleted",
+                "RefundFailed"
+            ]
+        },
+        "model.ReturnCondition": {
+            "type": "string",
+            "enum": [
+                "NEW",
+                "S",
+                "A",
+                "B",
+                "C",
+                "JUNK"
+            ],
+            "x-enum-comments": {
+                "ConditionA": "目立った傷や汚れなし",
+                "ConditionB": "やや傷や汚れあり",
+                "ConditionC": "傷や汚れあり",
+                "ConditionJunk": "ジャンク (再販不可)",
+                "ConditionNew": "新品・未使用",
+                "ConditionS": "未使用に近い"
+            },
+            "x-enum-varnames": [
+                "ConditionNew",
+                "ConditionS",
+                "ConditionA",
+                "ConditionB",
+                "ConditionC",
+                "ConditionJunk"
+            ]
+        },
+        "model.ReturnResolution": {
+            "type": "string",
+            "enum": [
+                "REFUND",
+                "EXCHANGE",
+                "STORE_CREDIT"
+            ],
+            "x-enum-comments": {
+                "ResolutionExchange": "別の発注で交換品を渡す",
+                "ResolutionRefund": "支払った決済手段で返金",
+                "ResolutionStoreCredit": "ポイントで返還"
+            },
+            "x-enum-varnames": [
+                "ResolutionRefund",
+                "ResolutionExchange",
+                "ResolutionStoreCredit"
+            ]
+        },
+        "model.ReturnStatus": {
+            "type": "string",
+            "enum": [
+                "REQUESTED",
+                "INSPECTED",
+                "COMPLETED",
+                "REJECTED"
+            ],
+            "x-enum-comments": {
+                "ReturnCompleted": "完了",
+                "ReturnInspected": "検品済み",
+                "ReturnRejected": "返品不可",
+                "ReturnRequested": "受付済み"
+            },
+            "x-enum-varnames": [
+                "ReturnRequested",
+                "ReturnInspected",
+                "ReturnCompleted",
+                "ReturnRejected"
+            ]
+        },
+        "model.SalesDimension": {
+            "type": "string",
+            "enum": [
+                "store",
+                "user",
+                "stock",
+                "status"
+            ],
+            "x-enum-comments": {
+                "DimensionStatus": "ステータス別",
+                "DimensionStock": "在庫別",
+                "DimensionStore": "店舗別",
+                "DimensionUser": "担当者別"
+            },
+            "x-enum-varnames": [
+                "DimensionStore",
+                "DimensionUser",
+                "DimensionStock",
+                "DimensionStatus"
+            ]
+        },
+        "model.SalesPeriod": {
+            "type": "string",
+            "enum": [
+                "day",
+                "week",
+                "month"
+            ],
+            "x-enum-comments": {
+                "PeriodDay": "日別",
+                "PeriodMonth": "月別",
+                "PeriodWeek": "週別（月曜始まり）"
+            },
+            "x-enum-varnames": [
+                "PeriodDay",
+                "PeriodWeek",
+                "PeriodMonth"
+            ]
+        },
+        "model.SalesReport": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SalesDimension"
+                    }
+                },
+                "period": {
+                    "$ref": "#/definitions/model.SalesPeriod"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SalesReportRow"
+                    }
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderStatus"
+                    }
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "time_zone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/model.SalesSummary"
+                }
+            }
+        },
+        "model.SalesReportRow": {
+            "type": "object",
+            "properties": {
+                "average_order_value": {
+                    "type": "integer"
+                },
+                "order_count": {
+                    "type": "integer"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "revenue": {
+                    "description": "売上金額。値引き・ポイント利用・返品で返した額を差し引く",
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.OrderStatus"
+                },
+                "stock_id": {
+                    "type": "integer"
+                },
+                "stock_name": {
+                    "type": "string"
+                },
+                "store_id": {
+                    "type": "string"
+                },
+                "store_name": {
+                    "type": "string"
+                },
+                "units": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SalesSummary": {
+            "type": "object",
+            "properties": {
+                "average_order_value": {
+                    "type": "integer"
+                },
+                "order_count": {
+                    "type": "integer"
+                },
+                "revenue": {
+                    "description": "売上金額。値引き・ポイント利用・返品で返した額を差し引く",
+                    "type": "integer"
+                },
+                "units": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SegmentCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "segment_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SegmentFilter": {
+            "type": "object",
+            "properties": {
+                "max_frequency_score": {
+                    "type": "integer"
+                },
+                "max_monetary_score": {
+                    "type": "integer"
+                },
+                "max_recency_days": {
+                    "type": "integer"
+                },
+                "max_recency_score": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "min_frequency": {
+                    "description": "発注件数・累計購入額の下限",
+                    "type": "integer"
+                },
+                "min_frequency_score": {
+                    "type": "integer"
+                },
+                "min_monetary": {
+                    "type": "integer",
+                    "example": 100000
+                },
+                "min_monetary_score": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "min_recency_days": {
+                    "description": "最終購入からの日数の範囲",
+                    "type": "integer",
+                    "example": 180
+                },
+                "min_recency_score": {
+                    "description": "RFMスコアの範囲 (1〜5)",
+                    "type": "integer",
+                    "example": 1
+                },
+                "prefectures": {
+                    "description": "いずれかの都道府県に住む顧客",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.Stock": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "category": {
+                    "description": "分類 (例: バッグ、時計)。顧客の購入傾向の集計に使う",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockImage"
+                    }
+                },
+                "jan": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orders": {
+                    "description": "リレーション (hasMany)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Order"
+                    }
                 },
                 "price": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 100000
+                    "type": "integer"
                 },
                 "quantity": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 1
+                    "type": "integer"
+                },
+                "serial_number": {
+                    "type": "string"
                 },
                 "store_id": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "type": "string"
+                },
+                "tax_rate": {
+                    "description": "消費税率 (%)。販売価格は税込で、食品など軽減税率の対象は8",
+                    "type": "integer",
+                    "example": 10
+                },
+                "updated_at": {
+                    "type": "string"
                 },
                 "user_id": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                    "type": "string"
                 }
             }
         },
-        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.CreateUserRequest": {
+        "model.StockImage": {
             "type": "object",
-            "required": [
-                "email",
-                "employee_number",
-                "name",
-                "store_id"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "taro_tanaka@example.com"
+                "content_type": {
+                    "type": "string"
                 },
-                "employee_number": {
-                    "type": "string",
-                    "maxLength": 10,
-                    "minLength": 1,
-                    "example": "0000000000"
+                "created_at": {
+                    "type": "string"
                 },
-                "gender": {
-                    "type": "string",
-                    "enum": [
-                        "male",
-                        "female"
-                    ],
-                    "example": "male"
+                "height": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1,
-                    "example": "田中 太郎"
+                "id": {
+                    "type": "integer"
                 },
-                "store_id": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                "position": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "stock_id": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "署名付きURL (DBには保存しない)",
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateCustomerRequest": {
+        "model.StockMovement": {
             "type": "object",
-            "required": [
-                "address",
-                "email",
-                "name",
-                "phone_number",
-                "tenant_id"
-            ],
             "properties": {
-                "address": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1,
-                    "example": "東京都千代田区丸の内1-1-1"
+                "created_at": {
+                    "type": "string"
                 },
-                "email": {
-                    "type": "string",
-                    "example": "taro_tanaka@example.com"
+                "id": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1,
-                    "example": "田中 太郎"
+                "occurred_at": {
+                    "description": "発生日時。評価額の基準日判定に使う",
+                    "type": "string"
                 },
-                "phone_number": {
-                    "type": "string",
-                    "example": "09012345678"
+                "order_id": {
+                    "type": "integer"
                 },
-                "tenant_id": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                "quantity": {
+                    "description": "増加は正、減少は負",
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "stock_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/model.StockMovementType"
+                },
+                "unit_cost": {
+                    "description": "入庫時の取得原価(1点あたり)",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateOrderRequest": {
-            "type": "object",
-            "required": [
-                "delivery_date",
-                "tenant_id"
+        "model.StockMovementType": {
+            "type": "string",
+            "enum": [
+                "RECEIPT",
+                "SALE",
+                "ADJUSTMENT",
+                "STOCKTAKE",
+                "RETURN"
             ],
+            "x-enum-comments": {
+                "MovementAdjustment": "数量調整",
+                "MovementReceipt": "入庫(仕入)",
+                "MovementReturn": "返品の戻し入れ",
+                "MovementSale": "販売・販売取消",
+                "MovementStocktake": "棚卸差異"
+            },
+            "x-enum-varnames": [
+                "MovementReceipt",
+                "MovementSale",
+                "MovementAdjustment",
+                "MovementStocktake",
+                "MovementReturn"
+            ]
+        },
+        "model.StockPriceChange": {
+            "type": "object",
             "properties": {
-                "delivery_date": {
-                    "type": "string",
-                    "example": "2022-01-01"
+                "applied_at": {
+                    "type": "string"
                 },
-                "quantity": {
+                "created_at": {
+                    "type": "string"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "markdown_age_days": {
+                    "description": "自動値下げによる変更の場合、適用した段階の経過日数",
                     "type": "integer",
-                    "minimum": 0,
-                    "example": 1
+                    "example": 60
                 },
-                "status": {
-                    "description": "nolint:lll",
+                "previous_price": {
+                    "description": "変更前の価格。適用時に記録する",
+                    "type": "integer",
+                    "example": 100000
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 90000
+                },
+                "reason": {
                     "type": "string",
-                    "enum": [
-                        "PENDING",
-                        "SHIPPED",
-                        "DELIVERED",
-                        "CANCELLED"
+                    "example": "週明けの値下げ"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.PriceChangeStatus"
+                        }
                     ],
-                    "example": "PENDING"
+                    "example": "APPLIED"
                 },
-                "tenant_id": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                "stock_id": {
+                    "type": "integer"
                 },
-                "total_amount": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 100000
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateStockRequest": {
+        "model.StockPriceHistory": {
             "type": "object",
-            "required": [
-                "name",
-                "price",
-                "quantity",
-                "store_id",
-                "user_id"
-            ],
             "properties": {
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1,
-                    "example": "LOUIS VUITTON M41524 ブラウン モノグラム ハンドバッグ"
-                },
-                "price": {
-                    "type": "integer",
-                    "example": 100000
+                "changes": {
+                    "description": "適用済みの変更を有効日時の古い順に並べたもの",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockPriceChange"
+                    }
                 },
-                "quantity": {
+                "current_price": {
                     "type": "integer",
-                    "example": 1
+                    "example": 90000
                 },
-                "store_id": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                "scheduled": {
+                    "description": "適用待ちの変更を有効日時の近い順に並べたもの",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockPriceChange"
+                    }
                 },
-                "user_id": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                "stock_id": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_buysell-technologies_summer-internship-2024-backend_api_handler_request.UpdateUserRequest": {
+        "model.StockValuation": {
             "type": "object",
-            "required": [
-                "email",
-                "employee_number",
-                "name",
-                "store_id"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "taro_tanaka@example.com"
+                "name": {
+                    "type": "string"
                 },
-                "employee_number": {
-                    "type": "string",
-                    "maxLength": 10,
-                    "minLength": 1,
-                    "example": "0000000000"
+                "quantity": {
+                    "type": "integer"
                 },
-                "gender": {
-                    "type": "string",
-                    "enum": [
-                        "male",
-                        "female"
-                    ],
-                    "example": "male"
+                "stock_id": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1,
-                    "example": "田中 太郎"
+                "unit_cost": {
+                    "type": "integer"
                 },
-                "store_id": {
-                    "type": "string",
-                    "example": "00000000-0000-0000-0000-000000000000"
+                "unknown_cost_quantity": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "integer"
                 }
             }
         },
-        "model.Customer": {
+        "model.Stocktake": {
             "type": "object",
             "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "created_at": {
+                "closed_at": {
                     "type": "string"
                 },
-                "deleted_at": {
-                    "type": "string",
-                    "format": "date-time",
-                    "example": "2023-01-01T00:00:00Z"
+                "completed_at": {
+                    "type": "string"
                 },
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
-                    "type": "string"
+                    "type": "integer"
                 },
-                "name": {
+                "note": {
                     "type": "string"
                 },
-                "orders": {
-                    "description": "リレーション (hasMany)",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Order"
-                    }
-                },
-                "phone_number": {
+                "snapshot_at": {
                     "type": "string"
                 },
-                "tenant_id": {
+                "status": {
+                    "$ref": "#/definitions/model.StocktakeStatus"
+                },
+                "store_id": {
                     "type": "string"
                 },
                 "updated_at": {
@@ -1313,92 +8781,248 @@ const docTemplate = `{
                 }
             }
         },
-        "model.Order": {
+        "model.StocktakeReasonCode": {
+            "type": "string",
+            "enum": [
+                "DAMAGE",
+                "THEFT",
+                "LOST",
+                "FOUND",
+                "MISCOUNT",
+                "OTHER"
+            ],
+            "x-enum-comments": {
+                "ReasonDamage": "破損",
+                "ReasonFound": "発見",
+                "ReasonLost": "紛失",
+                "ReasonMiscount": "計上誤り",
+                "ReasonOther": "その他",
+                "ReasonTheft": "盗難"
+            },
+            "x-enum-varnames": [
+                "ReasonDamage",
+                "ReasonTheft",
+                "ReasonLost",
+                "ReasonFound",
+                "ReasonMiscount",
+                "ReasonOther"
+            ]
+        },
+        "model.StocktakeReasonTotal": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "items": {
+                    "type": "integer"
                 },
-                "customer_id": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer"
                 },
-                "delivery_date": {
-                    "type": "string"
+                "reason_code": {
+                    "$ref": "#/definitions/model.StocktakeReasonCode"
                 },
-                "id": {
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.StocktakeReport": {
+            "type": "object",
+            "properties": {
+                "approved_items": {
                     "type": "integer"
                 },
-                "quantity": {
+                "by_reason": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StocktakeReasonTotal"
+                    }
+                },
+                "counted_items": {
                     "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/model.OrderStatus"
+                "counted_quantity": {
+                    "type": "integer"
                 },
-                "stock_id": {
+                "discrepancy_items": {
                     "type": "integer"
                 },
-                "total_amount": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StocktakeVariance"
+                    }
+                },
+                "stocktake": {
+                    "$ref": "#/definitions/model.Stocktake"
+                },
+                "system_quantity": {
                     "type": "integer"
                 },
-                "updated_at": {
-                    "type": "string"
+                "total_items": {
+                    "type": "integer"
+                },
+                "uncounted_items": {
+                    "type": "integer"
+                },
+                "variance_quantity": {
+                    "type": "integer"
+                },
+                "variance_value": {
+                    "type": "integer"
                 }
             }
         },
-        "model.OrderStatus": {
+        "model.StocktakeStatus": {
             "type": "string",
             "enum": [
-                "PENDING",
-                "SHIPPED",
-                "DELIVERED",
+                "COUNTING",
+                "REVIEWING",
+                "COMPLETED",
                 "CANCELLED"
             ],
             "x-enum-comments": {
-                "StatusCancelled": "キャンセル",
-                "StatusDelivered": "納品済み",
-                "StatusPending": "保留中",
-                "StatusShipped": "出荷済み"
+                "StocktakeCancelled": "中止",
+                "StocktakeCompleted": "完了",
+                "StocktakeCounting": "実数カウント中",
+                "StocktakeReviewing": "差異確認中"
             },
             "x-enum-varnames": [
-                "StatusPending",
-                "StatusShipped",
-                "StatusDelivered",
-                "StatusCancelled"
+                "StocktakeCounting",
+                "StocktakeReviewing",
+                "StocktakeCompleted",
+                "StocktakeCancelled"
             ]
         },
-        "model.Stock": {
+        "model.StocktakeVariance": {
             "type": "object",
             "properties": {
+                "adjusted_quantity": {
+                    "description": "承認時に在庫数量に反映した調整量。スナップショット以降の入出庫を考慮するため、実数と帳簿数量の差と異なる場合がある",
+                    "type": "integer"
+                },
+                "approved_at": {
+                    "type": "string"
+                },
+                "counted_quantity": {
+                    "type": "integer"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "movement_id": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
-                "orders": {
-                    "description": "リレーション (hasMany)",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Order"
-                    }
+                "note": {
+                    "type": "string"
                 },
                 "price": {
                     "type": "integer"
                 },
+                "reason_code": {
+                    "$ref": "#/definitions/model.StocktakeReasonCode"
+                },
+                "stock_id": {
+                    "type": "integer"
+                },
+                "stocktake_id": {
+                    "type": "integer"
+                },
+                "system_quantity": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variance": {
+                    "type": "integer"
+                },
+                "variance_value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.StoreValuation": {
+            "type": "object",
+            "properties": {
                 "quantity": {
                     "type": "integer"
                 },
+                "stocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StockValuation"
+                    }
+                },
                 "store_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "store_name": {
                     "type": "string"
                 },
-                "user_id": {
+                "unknown_cost_quantity": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TaxBreakdown": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 110000
+                },
+                "rate": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "reduced": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "tax": {
+                    "type": "integer",
+                    "example": 10000
+                }
+            }
+        },
+        "model.TenantSetting": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "markdown_floor_at_cost": {
+                    "description": "自動値下げで販売価格を取得原価より下げないか",
+                    "type": "boolean",
+                    "example": true
+                },
+                "point_earn_unit": {
+                    "description": "納品済みの発注で何円ごとに1ポイント付与するか。0の場合は付与しない",
+                    "type": "integer",
+                    "example": 100
+                },
+                "point_expiry_months": {
+                    "description": "付与したポイントの有効期限 (月数)。0の場合は無期限",
+                    "type": "integer",
+                    "example": 12
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
+                },
+                "valuation_method": {
+                    "$ref": "#/definitions/model.ValuationMethod"
                 }
             }
         },
@@ -1443,6 +9067,52 @@ const docTemplate = `{
                 }
             }
         },
+        "model.ValuationMethod": {
+            "type": "string",
+            "enum": [
+                "MOVING_AVERAGE",
+                "FIFO"
+            ],
+            "x-enum-comments": {
+                "ValuationFIFO": "先入先出法",
+                "ValuationMovingAverage": "移動平均法"
+            },
+            "x-enum-varnames": [
+                "ValuationMovingAverage",
+                "ValuationFIFO"
+            ]
+        },
+        "request.ApproveStocktakeItemRequest": {
+            "type": "object",
+            "required": [
+                "reason_code",
+                "stock_id"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "落下による破損"
+                },
+                "reason_code": {
+                    "description": "nolint:lll",
+                    "type": "string",
+                    "enum": [
+                        "DAMAGE",
+                        "THEFT",
+                        "LOST",
+                        "FOUND",
+                        "MISCOUNT",
+                        "OTHER"
+                    ],
+                    "example": "DAMAGE"
+                },
+                "stock_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
         "request.CreateBulkOrderRequest": {
             "type": "object",
             "required": [
@@ -1470,6 +9140,157 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "request.LabelTemplateRequest": {
+            "type": "object",
+            "required": [
+                "fields",
+                "height_mm",
+                "width_mm"
+            ],
+            "properties": {
+                "barcode": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "fields": {
+                    "description": "nolint:lll",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "name",
+                            "price",
+                            "code",
+                            "serial_number",
+                            "stock_id"
+                        ]
+                    },
+                    "example": [
+                        "name",
+                        "price"
+                    ]
+                },
+                "height_mm": {
+                    "type": "number",
+                    "maximum": 297,
+                    "minimum": 10,
+                    "example": 30
+                },
+                "width_mm": {
+                    "type": "number",
+                    "maximum": 210,
+                    "minimum": 20,
+                    "example": 40
+                }
+            }
+        },
+        "request.MarkdownRuleRequest": {
+            "type": "object",
+            "required": [
+                "age_days",
+                "discount_percent"
+            ],
+            "properties": {
+                "age_days": {
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 1,
+                    "example": 60
+                },
+                "discount_percent": {
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 1,
+                    "example": 10
+                }
+            }
+        },
+        "request.SegmentFilterRequest": {
+            "type": "object",
+            "properties": {
+                "max_frequency_score": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "max_monetary_score": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "max_recency_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "max_recency_score": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 2
+                },
+                "min_frequency": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "min_frequency_score": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "min_monetary": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100000
+                },
+                "min_monetary_score": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 4
+                },
+                "min_recency_days": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 180
+                },
+                "min_recency_score": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "prefectures": {
+                    "type": "array",
+                    "maxItems": 47,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "東京都"
+                    ]
+                }
+            }
+        },
+        "request.StocktakeCountRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "4901234567894"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "stock_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -1,17 +1,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	_ "time/tzdata"
 
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/payment"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/client/storage"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/handler/validator"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/middleware/auth"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/middleware/cors"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/renderer/pdf"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/repository"
 	"github.com/buysell-technologies/summer-internship-2024-backend/api/usecase"
+	"github.com/buysell-technologies/summer-internship-2024-backend/api/worker"
 	"github.com/buysell-technologies/summer-internship-2024-backend/config"
 	_ "github.com/buysell-technologies/summer-internship-2024-backend/docs"
 	"github.com/labstack/echo/v4"
@@ -44,7 +50,7 @@ func main() {
 	e.Use(cors.Check)
 	e.Use(auth.Complex())
 
-	if err := di(e, cfg); err != nil {
+	if err := di(e, cfg, logger); err != nil {
 		e.Logger.Fatal(err)
 		panic(err)
 	}
@@ -56,16 +62,36 @@ func main() {
 	}
 }
 
-func di(e *echo.Echo, cfg *config.Config) error {
+func di(e *echo.Echo, cfg *config.Config, logger *slog.Logger) error {
 	// Repository層
 	r, err := repository.New(cfg)
 	if err != nil {
 		return err
 	}
 
+	// ストレージ
+	s, err := newStorage(cfg)
+	if err != nil {
+		return err
+	}
+
+	// 決済代行会社
+	pg, err := newPaymentGateway(cfg)
+	if err != nil {
+		return err
+	}
+
+	// 帳票に埋め込むフォント
+	font := newFont(cfg, logger)
+
 	// Usecase層
 	ub := &usecase.UsecaseBundle{
-		Repository: r,
+		Config:         cfg,
+		Repository:     r,
+		Storage:        s,
+		PaymentGateway: pg,
+		Font:           font,
+		Validator:      validator.NewValidator(),
 	}
 	u := usecase.NewUsecase(ub)
 
@@ -73,5 +99,61 @@ func di(e *echo.Echo, cfg *config.Config) error {
 	h := handler.NewHandler(u)
 	h.AssignRoutes(e)
 
+	// バックグラウンド処理
+	worker.New(logger,
+		worker.Task{Name: "import", Interval: cfg.ImportPollInterval, Run: u.ProcessImportJobs},
+		worker.Task{Name: "rfm-score", Interval: cfg.RFMScoreInterval, Run: u.RefreshCustomerScores},
+		worker.Task{Name: "point-expiry", Interval: cfg.PointExpiryInterval, Run: u.RefreshPointExpiry},
+		worker.Task{Name: "price-change", Interval: cfg.PriceChangeInterval, Run: u.ProcessPriceChanges},
+		worker.Task{Name: "markdown", Interval: cfg.MarkdownInterval, Run: u.ProcessMarkdowns},
+		worker.Task{Name: "payment-reconcile", Interval: cfg.PaymentReconcileInterval, Run: u.ProcessPendingPayments},
+	).Start(context.Background())
+
 	return nil
 }
+
+func newStorage(cfg *config.Config) (storage.Storage, error) {
+	switch cfg.StorageDriver {
+	case config.StorageFileSystem:
+		return storage.NewFileSystem(cfg.StorageDir, cfg.StorageBaseURL, cfg.StorageSigningKey)
+	case config.StorageS3:
+		return storage.NewS3(storage.S3Config{
+			Endpoint:       cfg.S3Endpoint,
+			PublicEndpoint: cfg.S3PublicEndpoint,
+			Region:         cfg.S3Region,
+			Bucket:         cfg.S3Bucket,
+			AccessKey:      cfg.S3AccessKey,
+			SecretKey:      cfg.S3SecretKey,
+			PathStyle:      cfg.S3PathStyle,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", cfg.StorageDriver)
+	}
+}
+
+func newPaymentGateway(cfg *config.Config) (payment.Gateway, error) {
+	switch cfg.PaymentGateway {
+	case config.PaymentGatewayFake:
+		return payment.NewFake(), nil
+	case config.PaymentGatewayDisabled:
+		return payment.NewDisabled(), nil
+	default:
+		return nil, fmt.Errorf("unknown payment gateway: %s", cfg.PaymentGateway)
+	}
+}
+
+// newFont は帳票に埋め込むフォントを読み込む
+// 読み込めなくても帳票以外のAPIは使えるよう起動を続け、帳票のAPIだけをエラーにする
+func newFont(cfg *config.Config, logger *slog.Logger) *pdf.Font {
+	font, err := pdf.LoadFont(cfg.PDFFont)
+	if err != nil && cfg.Env == config.Local {
+		logger.Warn("failed to load PDF_FONT; fonts are not embedded in PDFs", "path", cfg.PDFFont, "error", err)
+		return nil
+	}
+	if err != nil {
+		logger.Error("failed to load PDF_FONT; PDF endpoints are unavailable", "path", cfg.PDFFont, "error", err)
+		return nil
+	}
+
+	return font
+}
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "idx_stocks_store_id_serial_number";
+DROP INDEX IF EXISTS "idx_stocks_store_id_jan";
+DROP INDEX IF EXISTS "idx_stocks_store_id_barcode";
+
+ALTER TABLE "stocks"
+  DROP COLUMN IF EXISTS "serial_number",
+  DROP COLUMN IF EXISTS "jan",
+  DROP COLUMN IF EXISTS "barcode";
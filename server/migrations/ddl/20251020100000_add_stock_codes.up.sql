@@ -0,0 +1,10 @@
+-- Add barcode / JAN / serial number columns to "stocks"
+ALTER TABLE "stocks"
+  ADD COLUMN "barcode" text NULL,
+  ADD COLUMN "jan" text NULL,
+  ADD COLUMN "serial_number" text NULL;
+
+-- Codes must be unique within a store
+CREATE UNIQUE INDEX "idx_stocks_store_id_barcode" ON "stocks" ("store_id", "barcode") WHERE "barcode" IS NOT NULL;
+CREATE UNIQUE INDEX "idx_stocks_store_id_jan" ON "stocks" ("store_id", "jan") WHERE "jan" IS NOT NULL;
+CREATE UNIQUE INDEX "idx_stocks_store_id_serial_number" ON "stocks" ("store_id", "serial_number") WHERE "serial_number" IS NOT NULL;
@@ -0,0 +1,18 @@
+-- Create "stock_images" table
+CREATE TABLE "stock_images" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "stock_id" bigint NOT NULL,
+  "position" bigint NOT NULL DEFAULT 0,
+  "content_type" text NOT NULL,
+  "size" bigint NOT NULL,
+  "width" bigint NOT NULL,
+  "height" bigint NOT NULL,
+  "storage_key" text NOT NULL,
+  "thumbnail_key" text NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_stocks_images" FOREIGN KEY ("stock_id") REFERENCES "stocks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+
+CREATE INDEX "idx_stock_images_stock_id_position" ON "stock_images" ("stock_id", "position");
@@ -0,0 +1,34 @@
+-- Create "stock_movements" table: ledger of every change to stocks.quantity
+CREATE TABLE "stock_movements" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "stock_id" bigint NOT NULL,
+  "type" text NOT NULL,
+  "quantity" bigint NOT NULL,
+  "unit_cost" bigint NULL,
+  "order_id" bigint NULL,
+  "reason" text NOT NULL DEFAULT '',
+  "occurred_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_stocks_movements" FOREIGN KEY ("stock_id") REFERENCES "stocks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "fk_orders_movements" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE NO ACTION ON DELETE SET NULL
+);
+
+CREATE INDEX "idx_stock_movements_stock_id_occurred_at" ON "stock_movements" ("stock_id", "occurred_at");
+
+-- Opening balance for existing stocks (acquisition cost unknown)
+INSERT INTO "stock_movements" ("created_at", "updated_at", "stock_id", "type", "quantity", "unit_cost", "reason", "occurred_at")
+SELECT now(), now(), "id", 'RECEIPT', "quantity", NULL, 'opening balance', COALESCE("created_at", now())
+FROM "stocks"
+WHERE "quantity" <> 0;
+
+-- Create "tenant_settings" table
+CREATE TABLE "tenant_settings" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "tenant_id" uuid NOT NULL,
+  "valuation_method" text NOT NULL DEFAULT 'MOVING_AVERAGE',
+  PRIMARY KEY ("tenant_id"),
+  CONSTRAINT "fk_tenants_settings" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "stocktake_counts";
+DROP TABLE IF EXISTS "stocktake_items";
+DROP TABLE IF EXISTS "stocktakes";
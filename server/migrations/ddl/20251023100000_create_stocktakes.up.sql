@@ -0,0 +1,59 @@
+-- Create "stocktakes" table
+CREATE TABLE "stocktakes" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "store_id" uuid NOT NULL,
+  "status" text NOT NULL,
+  "note" text NOT NULL DEFAULT '',
+  "snapshot_at" timestamptz NOT NULL,
+  "closed_at" timestamptz NULL,
+  "completed_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_stores_stocktakes" FOREIGN KEY ("store_id") REFERENCES "stores" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+
+-- Only one stocktake can be in progress per store
+CREATE UNIQUE INDEX "idx_stocktakes_store_id_active" ON "stocktakes" ("store_id") WHERE "status" IN ('COUNTING', 'REVIEWING');
+
+-- Create "stocktake_items" table: snapshot of system quantities
+-- adjusted_quantity is the adjustment posted on approval, which accounts for movements since the snapshot
+CREATE TABLE "stocktake_items" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "stocktake_id" bigint NOT NULL,
+  "stock_id" bigint NOT NULL,
+  "name" text NOT NULL,
+  "price" bigint NOT NULL,
+  "system_quantity" bigint NOT NULL,
+  "counted_quantity" bigint NULL,
+  "reason_code" text NULL,
+  "note" text NOT NULL DEFAULT '',
+  "approved_at" timestamptz NULL,
+  "movement_id" bigint NULL,
+  "adjusted_quantity" bigint NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_stocktakes_items" FOREIGN KEY ("stocktake_id") REFERENCES "stocktakes" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "fk_stocks_stocktake_items" FOREIGN KEY ("stock_id") REFERENCES "stocks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "fk_stock_movements_stocktake_items" FOREIGN KEY ("movement_id") REFERENCES "stock_movements" ("id") ON UPDATE NO ACTION ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX "idx_stocktake_items_stocktake_id_stock_id" ON "stocktake_items" ("stocktake_id", "stock_id");
+
+-- Create "stocktake_counts" table: counts submitted per device
+CREATE TABLE "stocktake_counts" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "stocktake_id" bigint NOT NULL,
+  "stock_id" bigint NOT NULL,
+  "device_id" text NOT NULL,
+  "quantity" bigint NOT NULL,
+  "counted_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_stocktakes_counts" FOREIGN KEY ("stocktake_id") REFERENCES "stocktakes" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "fk_stocks_stocktake_counts" FOREIGN KEY ("stock_id") REFERENCES "stocks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX "idx_stocktake_counts_stocktake_id_stock_id_device_id" ON "stocktake_counts" ("stocktake_id", "stock_id", "device_id");
@@ -0,0 +1,27 @@
+-- Create "daily_sales_rollups" table: orders aggregated per tenant/store/stock/day/status
+CREATE TABLE "daily_sales_rollups" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "tenant_id" uuid NOT NULL,
+  "store_id" uuid NOT NULL,
+  "stock_id" bigint NOT NULL,
+  "sales_date" date NOT NULL,
+  "status" order_status NOT NULL,
+  "revenue" bigint NOT NULL DEFAULT 0,
+  "order_count" bigint NOT NULL DEFAULT 0,
+  "units" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("tenant_id", "store_id", "stock_id", "sales_date", "status")
+);
+
+CREATE INDEX "idx_daily_sales_rollups_tenant_id_sales_date" ON "daily_sales_rollups" ("tenant_id", "sales_date");
+
+-- Backfill from existing orders so that sales reports have data right after deploy.
+-- Sales dates use the REPORT_TIMEZONE default (Asia/Tokyo); run `make rollup-rebuild` if it is set otherwise.
+INSERT INTO "daily_sales_rollups" ("created_at", "updated_at", "tenant_id", "store_id", "stock_id", "sales_date", "status", "revenue", "order_count", "units")
+SELECT now(), now(), c."tenant_id", s."store_id", o."stock_id", (o."created_at" AT TIME ZONE 'Asia/Tokyo')::date, o."status",
+  COALESCE(SUM(o."total_amount"), 0), COUNT(*), COALESCE(SUM(o."quantity"), 0)
+FROM "orders" AS o
+JOIN "customers" AS c ON o."customer_id" = c."id"
+JOIN "stocks" AS s ON o."stock_id" = s."id"
+WHERE c."tenant_id" IS NOT NULL AND s."store_id" IS NOT NULL AND o."status" IS NOT NULL AND o."created_at" IS NOT NULL
+GROUP BY 3, 4, 5, 6, 7;
@@ -0,0 +1,33 @@
+-- Create "import_jobs" table
+CREATE TABLE "import_jobs" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "store_id" uuid NOT NULL,
+  "target" text NOT NULL,
+  "status" text NOT NULL,
+  "dry_run" boolean NOT NULL DEFAULT false,
+  "file_name" text NOT NULL DEFAULT '',
+  "file_key" text NOT NULL DEFAULT '',
+  "encoding" text NOT NULL DEFAULT '',
+  "mapping" jsonb NOT NULL DEFAULT '{}',
+  "match_by" text NOT NULL DEFAULT '',
+  "default_user_id" uuid NULL,
+  "total_rows" bigint NOT NULL DEFAULT 0,
+  "processed_rows" bigint NOT NULL DEFAULT 0,
+  "created_rows" bigint NOT NULL DEFAULT 0,
+  "updated_rows" bigint NOT NULL DEFAULT 0,
+  "failed_rows" bigint NOT NULL DEFAULT 0,
+  "errors" jsonb NOT NULL DEFAULT '[]',
+  "error" text NOT NULL DEFAULT '',
+  "started_at" timestamptz NULL,
+  "finished_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_tenants_import_jobs" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_stores_import_jobs" FOREIGN KEY ("store_id") REFERENCES "stores" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+
+CREATE INDEX "idx_import_jobs_tenant_id" ON "import_jobs" ("tenant_id");
+-- Workers pick up pending jobs in order
+CREATE INDEX "idx_import_jobs_status_id" ON "import_jobs" ("status", "id");
@@ -0,0 +1,20 @@
+-- Create "customer_merges" table: audit trail of merged duplicate customers
+CREATE TABLE "customer_merges" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "target_customer_id" uuid NOT NULL,
+  "source_customer_id" uuid NOT NULL,
+  "source" jsonb NOT NULL,
+  "order_ids" jsonb NOT NULL DEFAULT '[]',
+  "note" text NOT NULL DEFAULT '',
+  "merged_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_tenants_customer_merges" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_customers_customer_merges_target" FOREIGN KEY ("target_customer_id") REFERENCES "customers" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_customers_customer_merges_source" FOREIGN KEY ("source_customer_id") REFERENCES "customers" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+
+CREATE INDEX "idx_customer_merges_target_customer_id" ON "customer_merges" ("target_customer_id");
+CREATE INDEX "idx_customer_merges_source_customer_id" ON "customer_merges" ("source_customer_id");
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS "idx_customers_tenant_id_prefecture_city";
+
+ALTER TABLE "stores"
+  DROP COLUMN IF EXISTS "building",
+  DROP COLUMN IF EXISTS "street",
+  DROP COLUMN IF EXISTS "city",
+  DROP COLUMN IF EXISTS "prefecture";
+
+ALTER TABLE "customers"
+  DROP COLUMN IF EXISTS "building",
+  DROP COLUMN IF EXISTS "street",
+  DROP COLUMN IF EXISTS "city",
+  DROP COLUMN IF EXISTS "prefecture",
+  DROP COLUMN IF EXISTS "postal_code";
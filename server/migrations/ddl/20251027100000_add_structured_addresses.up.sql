@@ -0,0 +1,16 @@
+-- Split free-text addresses into structured columns. "address" keeps the one-line form
+ALTER TABLE "customers"
+  ADD COLUMN "postal_code" text NULL,
+  ADD COLUMN "prefecture" text NULL,
+  ADD COLUMN "city" text NULL,
+  ADD COLUMN "street" text NULL,
+  ADD COLUMN "building" text NULL;
+
+-- "stores" already has "zip_code"
+ALTER TABLE "stores"
+  ADD COLUMN "prefecture" text NULL,
+  ADD COLUMN "city" text NULL,
+  ADD COLUMN "street" text NULL,
+  ADD COLUMN "building" text NULL;
+
+CREATE INDEX "idx_customers_tenant_id_prefecture_city" ON "customers" ("tenant_id", "prefecture", "city");
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "idx_customers_tenant_id_phone_number";
+
+UPDATE "customers"
+SET "phone_number" = "phone_number_display"
+WHERE "phone_number_display" IS NOT NULL;
+
+ALTER TABLE "customers"
+  DROP COLUMN IF EXISTS "phone_country",
+  DROP COLUMN IF EXISTS "phone_number_display";
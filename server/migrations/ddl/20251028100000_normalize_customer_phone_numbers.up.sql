@@ -0,0 +1,26 @@
+-- Store customer phone numbers in E.164 and keep the entered form for display
+ALTER TABLE "customers"
+  ADD COLUMN "phone_number_display" text NULL,
+  ADD COLUMN "phone_country" text NULL;
+
+-- Japanese numbers entered with or without hyphens: 0X... -> +81X...
+UPDATE "customers"
+SET
+  "phone_number_display" = "phone_number",
+  "phone_country" = 'JP',
+  "phone_number" = '+81' || substr(regexp_replace("phone_number", '[^0-9]', '', 'g'), 2)
+WHERE "phone_number" !~ '^\s*\+'
+  AND regexp_replace("phone_number", '[^0-9]', '', 'g') ~ '^0[1-9][0-9]{8,9}$';
+
+-- Numbers already entered in international form. "+81 090..." drops the redundant trunk prefix
+UPDATE "customers"
+SET
+  "phone_number_display" = "phone_number",
+  "phone_country" = CASE WHEN regexp_replace("phone_number", '[^0-9]', '', 'g') LIKE '81%' THEN 'JP' END,
+  "phone_number" = '+' || regexp_replace(regexp_replace("phone_number", '[^0-9]', '', 'g'), '^810', '81')
+WHERE "phone_number" ~ '^\s*\+'
+  AND "phone_number_display" IS NULL;
+
+-- Other rows cannot be converted safely and are left as entered (phone_number_display IS NULL)
+
+CREATE INDEX "idx_customers_tenant_id_phone_number" ON "customers" ("tenant_id", "phone_number");
@@ -0,0 +1,6 @@
+-- Free-text category on stocks, used for customers' favorite categories
+ALTER TABLE "stocks"
+  ADD COLUMN "category" text NULL;
+
+-- Customer order history is paged newest first
+CREATE INDEX "idx_orders_customer_id_created_at" ON "orders" ("customer_id", "created_at" DESC, "id" DESC);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS "customer_segments";
+
+ALTER TABLE "customers"
+  DROP COLUMN IF EXISTS "rfm_scored_at",
+  DROP COLUMN IF EXISTS "monetary",
+  DROP COLUMN IF EXISTS "frequency",
+  DROP COLUMN IF EXISTS "recency_days",
+  DROP COLUMN IF EXISTS "monetary_score",
+  DROP COLUMN IF EXISTS "frequency_score",
+  DROP COLUMN IF EXISTS "recency_score";
@@ -0,0 +1,24 @@
+-- RFM (recency / frequency / monetary) scores, recalculated by the scoring job
+ALTER TABLE "customers"
+  ADD COLUMN "recency_score" smallint NULL,
+  ADD COLUMN "frequency_score" smallint NULL,
+  ADD COLUMN "monetary_score" smallint NULL,
+  ADD COLUMN "recency_days" integer NULL,
+  ADD COLUMN "frequency" integer NOT NULL DEFAULT 0,
+  ADD COLUMN "monetary" bigint NOT NULL DEFAULT 0,
+  ADD COLUMN "rfm_scored_at" timestamptz NULL;
+
+-- Saved customer filters used for marketing
+CREATE TABLE "customer_segments" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "name" text NOT NULL,
+  "description" text NULL,
+  "filter" jsonb NOT NULL DEFAULT '{}',
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_tenants_customer_segments" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX "idx_customer_segments_tenant_id_name" ON "customer_segments" ("tenant_id", "name");
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "customer_consents";
+
+ALTER TABLE "customers"
+  DROP COLUMN IF EXISTS "anonymized_at";
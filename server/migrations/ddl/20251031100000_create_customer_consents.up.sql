@@ -0,0 +1,22 @@
+-- Marks customers whose personal information was scrubbed on request
+ALTER TABLE "customers"
+  ADD COLUMN "anonymized_at" timestamptz NULL;
+
+-- Append-only log of consent grants and withdrawals per purpose
+CREATE TABLE "customer_consents" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "customer_id" uuid NOT NULL,
+  "purpose" text NOT NULL,
+  "granted" boolean NOT NULL,
+  "source" text NOT NULL DEFAULT '',
+  "note" text NOT NULL DEFAULT '',
+  "recorded_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_tenants_customer_consents" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_customers_customer_consents" FOREIGN KEY ("customer_id") REFERENCES "customers" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+
+CREATE INDEX "idx_customer_consents_customer_id_purpose" ON "customer_consents" ("customer_id", "purpose", "recorded_at" DESC, "id" DESC);
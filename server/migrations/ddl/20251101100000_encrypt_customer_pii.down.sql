@@ -0,0 +1,32 @@
+-- Encrypted values cannot be read once the data keys are dropped, so refuse to roll back while any remain.
+-- Decrypt them first (restore plaintext from a backup taken before `make pii-encrypt`) and run this again.
+DO $$
+BEGIN
+  IF EXISTS (
+    SELECT 1 FROM "customers"
+    WHERE "email" LIKE 'enc:%'
+      OR "phone_number" LIKE 'enc:%'
+      OR "phone_number_display" LIKE 'enc:%'
+      OR "address" LIKE 'enc:%'
+      OR "street" LIKE 'enc:%'
+      OR "building" LIKE 'enc:%'
+  ) OR EXISTS (
+    SELECT 1 FROM "customer_merges"
+    WHERE "source"->>'email' LIKE 'enc:%'
+      OR "source"->>'phone_number' LIKE 'enc:%'
+      OR "source"->>'address' LIKE 'enc:%'
+  ) THEN
+    RAISE EXCEPTION 'customer PII is still encrypted; decrypt it before dropping data_keys';
+  END IF;
+END
+$$;
+
+DROP INDEX IF EXISTS "idx_customers_tenant_id_phone_number_bidx";
+DROP INDEX IF EXISTS "idx_customers_tenant_id_email_bidx";
+CREATE INDEX IF NOT EXISTS "idx_customers_tenant_id_phone_number" ON "customers" ("tenant_id", "phone_number");
+
+ALTER TABLE "customers"
+  DROP COLUMN IF EXISTS "phone_number_bidx",
+  DROP COLUMN IF EXISTS "email_bidx";
+
+DROP TABLE IF EXISTS "data_keys";
@@ -0,0 +1,23 @@
+-- Data keys for field-level encryption, stored wrapped by the master key (keyfile or KMS)
+CREATE TABLE "data_keys" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "purpose" text NOT NULL,
+  "wrapped_key" bytea NOT NULL,
+  "master_key_id" text NOT NULL,
+  "active" boolean NOT NULL DEFAULT false,
+  PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX "idx_data_keys_purpose_active" ON "data_keys" ("purpose") WHERE "active";
+
+-- Blind indexes (HMAC of the normalized value) for exact-match lookup of encrypted columns.
+-- Existing rows are encrypted and indexed by `make pii-encrypt`; plaintext values stay readable until then.
+ALTER TABLE "customers"
+  ADD COLUMN "email_bidx" text NULL,
+  ADD COLUMN "phone_number_bidx" text NULL;
+
+DROP INDEX IF EXISTS "idx_customers_tenant_id_phone_number";
+CREATE INDEX "idx_customers_tenant_id_email_bidx" ON "customers" ("tenant_id", "email_bidx");
+CREATE INDEX "idx_customers_tenant_id_phone_number_bidx" ON "customers" ("tenant_id", "phone_number_bidx");
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS "point_transactions";
+
+ALTER TABLE "orders"
+  DROP COLUMN IF EXISTS "points_used";
+
+ALTER TABLE "customers"
+  DROP CONSTRAINT IF EXISTS "chk_customers_point_balance",
+  DROP COLUMN IF EXISTS "point_balance";
+
+ALTER TABLE "tenant_settings"
+  DROP COLUMN IF EXISTS "point_expiry_months",
+  DROP COLUMN IF EXISTS "point_earn_unit";
@@ -0,0 +1,39 @@
+-- Loyalty point settings per tenant (0 yen per point disables earning)
+ALTER TABLE "tenant_settings"
+  ADD COLUMN "point_earn_unit" integer NOT NULL DEFAULT 0,
+  ADD COLUMN "point_expiry_months" integer NOT NULL DEFAULT 12;
+
+-- Current point balance, kept equal to the sum of remaining points in the ledger
+ALTER TABLE "customers"
+  ADD COLUMN "point_balance" integer NOT NULL DEFAULT 0,
+  ADD CONSTRAINT "chk_customers_point_balance" CHECK ("point_balance" >= 0);
+
+-- Points redeemed as a discount on the order
+ALTER TABLE "orders"
+  ADD COLUMN "points_used" integer NOT NULL DEFAULT 0;
+
+-- Point ledger; positive rows are lots consumed in expiry order by redemptions, expiry and reversals
+CREATE TABLE "point_transactions" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "customer_id" uuid NOT NULL,
+  "order_id" bigint NULL,
+  "type" text NOT NULL,
+  "points" integer NOT NULL,
+  "remaining" integer NOT NULL DEFAULT 0,
+  "expires_at" timestamptz NULL,
+  "balance" integer NOT NULL,
+  "occurred_at" timestamptz NOT NULL,
+  "note" text NOT NULL DEFAULT '',
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_tenants_point_transactions" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_customers_point_transactions" FOREIGN KEY ("customer_id") REFERENCES "customers" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_orders_point_transactions" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "chk_point_transactions_remaining" CHECK ("remaining" >= 0)
+);
+
+CREATE INDEX "idx_point_transactions_customer_id_occurred_at" ON "point_transactions" ("customer_id", "occurred_at" DESC, "id" DESC);
+CREATE INDEX "idx_point_transactions_order_id" ON "point_transactions" ("order_id");
+CREATE INDEX "idx_point_transactions_open_lots" ON "point_transactions" ("expires_at") WHERE "remaining" > 0;
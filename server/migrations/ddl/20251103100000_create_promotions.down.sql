@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "order_discounts";
+
+ALTER TABLE "orders"
+  DROP COLUMN IF EXISTS "discount_amount";
+
+DROP TABLE IF EXISTS "coupons";
+
+DROP TABLE IF EXISTS "promotions";
@@ -0,0 +1,72 @@
+-- Discount campaigns; a rule narrows targets by stock, category and store, all unset means every order
+CREATE TABLE "promotions" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "name" text NOT NULL,
+  "description" text NOT NULL DEFAULT '',
+  "discount_type" text NOT NULL,
+  "discount_value" integer NOT NULL,
+  "stock_id" bigint NULL,
+  "category" text NULL,
+  "store_id" uuid NULL,
+  "starts_at" timestamptz NULL,
+  "ends_at" timestamptz NULL,
+  "min_spend" integer NOT NULL DEFAULT 0,
+  "usage_limit_per_customer" integer NOT NULL DEFAULT 0,
+  "usage_limit" integer NOT NULL DEFAULT 0,
+  "requires_coupon" boolean NOT NULL DEFAULT false,
+  "active" boolean NOT NULL DEFAULT true,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_tenants_promotions" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_stocks_promotions" FOREIGN KEY ("stock_id") REFERENCES "stocks" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_stores_promotions" FOREIGN KEY ("store_id") REFERENCES "stores" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "chk_promotions_discount_value" CHECK ("discount_value" > 0)
+);
+
+CREATE INDEX "idx_promotions_tenant_id_active" ON "promotions" ("tenant_id") WHERE "active";
+
+-- Coupon codes that unlock a promotion; codes are stored upper-cased
+CREATE TABLE "coupons" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "promotion_id" bigint NOT NULL,
+  "code" text NOT NULL,
+  "usage_limit" integer NOT NULL DEFAULT 0,
+  "active" boolean NOT NULL DEFAULT true,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_tenants_coupons" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_promotions_coupons" FOREIGN KEY ("promotion_id") REFERENCES "promotions" ("id") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX "idx_coupons_tenant_id_code" ON "coupons" ("tenant_id", "code");
+CREATE INDEX "idx_coupons_promotion_id" ON "coupons" ("promotion_id");
+
+-- Total discount applied to the order; total_amount stays the amount before discounts
+ALTER TABLE "orders"
+  ADD COLUMN "discount_amount" integer NOT NULL DEFAULT 0;
+
+-- Discounts applied to each order line, with the promotion name at the time of sale
+CREATE TABLE "order_discounts" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "order_id" bigint NOT NULL,
+  "stock_id" bigint NOT NULL,
+  "promotion_id" bigint NOT NULL,
+  "coupon_id" bigint NULL,
+  "name" text NOT NULL,
+  "amount" integer NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_orders_order_discounts" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "fk_stocks_order_discounts" FOREIGN KEY ("stock_id") REFERENCES "stocks" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_promotions_order_discounts" FOREIGN KEY ("promotion_id") REFERENCES "promotions" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_coupons_order_discounts" FOREIGN KEY ("coupon_id") REFERENCES "coupons" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+
+CREATE INDEX "idx_order_discounts_order_id" ON "order_discounts" ("order_id");
+CREATE INDEX "idx_order_discounts_promotion_id" ON "order_discounts" ("promotion_id");
+CREATE INDEX "idx_order_discounts_coupon_id" ON "order_discounts" ("coupon_id");
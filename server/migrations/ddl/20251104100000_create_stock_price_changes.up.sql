@@ -0,0 +1,24 @@
+-- Selling price history per stock; future changes stay SCHEDULED until the worker applies them
+CREATE TABLE "stock_price_changes" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "stock_id" bigint NOT NULL,
+  "price" integer NOT NULL,
+  "previous_price" integer NULL,
+  "effective_from" timestamptz NOT NULL,
+  "status" text NOT NULL,
+  "applied_at" timestamptz NULL,
+  "reason" text NOT NULL DEFAULT '',
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_stocks_stock_price_changes" FOREIGN KEY ("stock_id") REFERENCES "stocks" ("id") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "chk_stock_price_changes_price" CHECK ("price" >= 0)
+);
+
+CREATE INDEX "idx_stock_price_changes_stock_id_effective_from" ON "stock_price_changes" ("stock_id", "effective_from");
+CREATE INDEX "idx_stock_price_changes_scheduled" ON "stock_price_changes" ("effective_from") WHERE "status" = 'SCHEDULED';
+
+-- Existing stocks start their history with the current price
+INSERT INTO "stock_price_changes" ("created_at", "updated_at", "stock_id", "price", "effective_from", "status", "applied_at", "reason")
+SELECT now(), now(), "id", "price", COALESCE("created_at", now()), 'APPLIED', COALESCE("created_at", now()), 'initial registration'
+FROM "stocks";
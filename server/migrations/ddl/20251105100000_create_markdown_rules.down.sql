@@ -0,0 +1,7 @@
+ALTER TABLE "stock_price_changes"
+  DROP COLUMN IF EXISTS "markdown_age_days";
+
+ALTER TABLE "tenant_settings"
+  DROP COLUMN IF EXISTS "markdown_floor_at_cost";
+
+DROP TABLE IF EXISTS "markdown_rules";
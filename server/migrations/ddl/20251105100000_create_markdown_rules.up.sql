@@ -0,0 +1,19 @@
+-- Tenant-level markdown steps for aging stock: the step with the largest age_days reached applies
+CREATE TABLE "markdown_rules" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "age_days" integer NOT NULL,
+  "discount_percent" integer NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "chk_markdown_rules_age_days" CHECK ("age_days" > 0),
+  CONSTRAINT "chk_markdown_rules_discount_percent" CHECK ("discount_percent" BETWEEN 1 AND 99)
+);
+
+CREATE UNIQUE INDEX "idx_markdown_rules_tenant_id_age_days" ON "markdown_rules" ("tenant_id", "age_days");
+
+ALTER TABLE "tenant_settings" ADD COLUMN "markdown_floor_at_cost" boolean NOT NULL DEFAULT true;
+
+-- Price changes made by a markdown step remember the step so it is applied only once per stock
+ALTER TABLE "stock_price_changes" ADD COLUMN "markdown_age_days" integer NULL;
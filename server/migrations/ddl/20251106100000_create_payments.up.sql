@@ -0,0 +1,50 @@
+-- Payments received against an order; split tender is recorded as several rows
+CREATE TABLE "payments" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "order_id" bigint NOT NULL,
+  "method" text NOT NULL,
+  "amount" integer NOT NULL,
+  "tendered" integer NULL,
+  "reference" text NOT NULL DEFAULT '',
+  "note" text NOT NULL DEFAULT '',
+  -- Gateway payments are recorded as PENDING and settled after the transaction commits
+  "status" text NOT NULL DEFAULT 'COMPLETED',
+  "paid_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_tenants_payments" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_orders_payments" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "chk_payments_amount" CHECK ("amount" > 0),
+  CONSTRAINT "chk_payments_tendered" CHECK ("tendered" IS NULL OR "tendered" >= "amount")
+);
+
+CREATE INDEX "idx_payments_order_id" ON "payments" ("order_id");
+CREATE INDEX "idx_payments_pending" ON "payments" ("id") WHERE "status" = 'PENDING';
+
+-- Refunds of a payment, full or partial, with the cause that triggered them
+CREATE TABLE "refunds" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "order_id" bigint NOT NULL,
+  "payment_id" bigint NOT NULL,
+  "amount" integer NOT NULL,
+  "cause" text NOT NULL,
+  "reason" text NOT NULL DEFAULT '',
+  "reference" text NOT NULL DEFAULT '',
+  -- Gateway refunds are recorded as PENDING and settled after the transaction commits
+  "status" text NOT NULL DEFAULT 'COMPLETED',
+  "refunded_at" timestamptz NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_tenants_refunds" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_orders_refunds" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_payments_refunds" FOREIGN KEY ("payment_id") REFERENCES "payments" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "chk_refunds_amount" CHECK ("amount" > 0)
+);
+
+CREATE INDEX "idx_refunds_order_id" ON "refunds" ("order_id");
+CREATE INDEX "idx_refunds_payment_id" ON "refunds" ("payment_id");
+CREATE INDEX "idx_refunds_pending" ON "refunds" ("id") WHERE "status" = 'PENDING';
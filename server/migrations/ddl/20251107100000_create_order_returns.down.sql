@@ -0,0 +1,5 @@
+ALTER TABLE "refunds"
+  DROP CONSTRAINT IF EXISTS "fk_order_returns_refunds",
+  DROP COLUMN IF EXISTS "return_id";
+
+DROP TABLE IF EXISTS "order_returns";
@@ -0,0 +1,36 @@
+-- Return requests (RMA) for delivered orders, from request through inspection to resolution
+CREATE TABLE "order_returns" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "order_id" bigint NOT NULL,
+  "stock_id" bigint NOT NULL,
+  "quantity" integer NOT NULL,
+  "reason" text NOT NULL DEFAULT '',
+  "status" text NOT NULL,
+  "condition" text NULL,
+  "note" text NOT NULL DEFAULT '',
+  "resolution" text NULL,
+  "restock_stock_id" bigint NULL,
+  "amount" integer NOT NULL DEFAULT 0,
+  "exchange_order_id" bigint NULL,
+  "inspected_at" timestamptz NULL,
+  "closed_at" timestamptz NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_tenants_order_returns" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_orders_order_returns" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_stocks_order_returns" FOREIGN KEY ("stock_id") REFERENCES "stocks" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_stocks_order_returns_restock" FOREIGN KEY ("restock_stock_id") REFERENCES "stocks" ("id") ON UPDATE NO ACTION ON DELETE SET NULL,
+  CONSTRAINT "fk_orders_order_returns_exchange" FOREIGN KEY ("exchange_order_id") REFERENCES "orders" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "chk_order_returns_quantity" CHECK ("quantity" > 0),
+  CONSTRAINT "chk_order_returns_amount" CHECK ("amount" >= 0)
+);
+
+CREATE INDEX "idx_order_returns_order_id" ON "order_returns" ("order_id");
+CREATE INDEX "idx_order_returns_tenant_id_status" ON "order_returns" ("tenant_id", "status", "created_at" DESC);
+
+-- Refunds issued to resolve a return
+ALTER TABLE "refunds"
+  ADD COLUMN "return_id" bigint NULL,
+  ADD CONSTRAINT "fk_order_returns_refunds" FOREIGN KEY ("return_id") REFERENCES "order_returns" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION;
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS "trg_invoices_immutable" ON "invoices";
+DROP FUNCTION IF EXISTS "reject_invoice_changes"();
+
+DROP TABLE IF EXISTS "invoices";
+DROP TABLE IF EXISTS "invoice_sequences";
+DROP TABLE IF EXISTS "invoice_issuers";
+
+ALTER TABLE "stocks"
+  DROP CONSTRAINT IF EXISTS "chk_stocks_tax_rate",
+  DROP COLUMN IF EXISTS "tax_rate";
@@ -0,0 +1,73 @@
+-- Tax rate of each stock in percent. Prices are tax-inclusive; reduced-rate items (e.g. food) use 8
+ALTER TABLE "stocks"
+  ADD COLUMN "tax_rate" smallint NOT NULL DEFAULT 10,
+  ADD CONSTRAINT "chk_stocks_tax_rate" CHECK ("tax_rate" IN (8, 10));
+
+-- Issuer details printed on qualified invoices, one row per tenant
+CREATE TABLE "invoice_issuers" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "tenant_id" uuid NOT NULL,
+  "registration_number" text NOT NULL,
+  "name" text NOT NULL,
+  "postal_code" text NOT NULL DEFAULT '',
+  "address" text NOT NULL DEFAULT '',
+  "phone_number" text NOT NULL DEFAULT '',
+  PRIMARY KEY ("tenant_id"),
+  CONSTRAINT "fk_tenants_invoice_issuers" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "chk_invoice_issuers_registration_number" CHECK ("registration_number" ~ '^T[0-9]{13}$')
+);
+
+-- Last number issued per tenant and document type. The row lock keeps numbers gapless
+CREATE TABLE "invoice_sequences" (
+  "tenant_id" uuid NOT NULL,
+  "type" text NOT NULL,
+  "last_number" bigint NOT NULL,
+  PRIMARY KEY ("tenant_id", "type"),
+  CONSTRAINT "fk_tenants_invoice_sequences" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+
+-- Issued invoices and credit notes. Contents are a snapshot taken at issue time
+CREATE TABLE "invoices" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "id" bigserial NOT NULL,
+  "tenant_id" uuid NOT NULL,
+  "order_id" bigint NOT NULL,
+  "type" text NOT NULL,
+  "number" text NOT NULL,
+  "original_invoice_id" bigint NULL,
+  "reason" text NOT NULL DEFAULT '',
+  "issued_at" timestamptz NOT NULL,
+  "transaction_date" date NOT NULL,
+  "registration_number" text NOT NULL,
+  "issuer_name" text NOT NULL,
+  "issuer_postal_code" text NOT NULL DEFAULT '',
+  "issuer_address" text NOT NULL DEFAULT '',
+  "issuer_phone_number" text NOT NULL DEFAULT '',
+  "recipient_name" text NOT NULL,
+  "lines" jsonb NOT NULL,
+  "taxes" jsonb NOT NULL,
+  "total" integer NOT NULL,
+  "tax" integer NOT NULL,
+  PRIMARY KEY ("id"),
+  CONSTRAINT "fk_tenants_invoices" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_orders_invoices" FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "fk_invoices_invoices" FOREIGN KEY ("original_invoice_id") REFERENCES "invoices" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "chk_invoices_original" CHECK (("type" = 'CREDIT_NOTE') = ("original_invoice_id" IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX "idx_invoices_tenant_id_number" ON "invoices" ("tenant_id", "number");
+CREATE UNIQUE INDEX "idx_invoices_original_invoice_id" ON "invoices" ("original_invoice_id");
+CREATE INDEX "idx_invoices_order_id" ON "invoices" ("order_id");
+
+-- Issued invoices are immutable: corrections are made by issuing a credit note
+CREATE FUNCTION "reject_invoice_changes"() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'invoices are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "trg_invoices_immutable"
+  BEFORE UPDATE OR DELETE ON "invoices"
+  FOR EACH ROW EXECUTE FUNCTION "reject_invoice_changes"();
@@ -0,0 +1,14 @@
+-- Per-tenant receipt layout for in-store receipt printers
+CREATE TABLE "receipt_templates" (
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "tenant_id" uuid NOT NULL,
+  "paper_width" smallint NOT NULL DEFAULT 80,
+  "header" text NOT NULL DEFAULT '',
+  "footer" text NOT NULL DEFAULT '',
+  "show_store_address" boolean NOT NULL DEFAULT true,
+  "show_barcode" boolean NOT NULL DEFAULT true,
+  PRIMARY KEY ("tenant_id"),
+  CONSTRAINT "fk_tenants_receipt_templates" FOREIGN KEY ("tenant_id") REFERENCES "tenants" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "chk_receipt_templates_paper_width" CHECK ("paper_width" IN (58, 80))
+);